@@ -0,0 +1,92 @@
+// Command discordbot answers Discord slash commands from the game's
+// database: /roll for dice, /check for a character's skill and ability
+// checks, and /character for quick stats. It reads the server's
+// configuration, so it shares the database and settings in dnd.toml.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/brady1408/dnd/internal/config"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/discord"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func main() {
+	cfg, _, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.DiscordPublicKey == "" {
+		log.Fatalf("No Discord public key; set discord_bot.public_key or DISCORD_PUBLIC_KEY")
+	}
+
+	ctx := context.Background()
+	poolConfig, err := pgxpool.ParseConfig(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("Invalid database URL: %v", err)
+	}
+	if cfg.QueryTimeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.QueryTimeout.Milliseconds(), 10)
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+	if err := pool.Ping(ctx); err != nil {
+		log.Fatalf("Failed to ping database: %v", err)
+	}
+
+	bot, err := discord.NewBot(db.New(pool), cfg.DiscordPublicKey)
+	if err != nil {
+		log.Fatalf("Invalid Discord settings: %v", err)
+	}
+
+	if cfg.DiscordBotToken != "" {
+		if cfg.DiscordApplicationID == "" {
+			log.Fatalf("Registering commands needs the application ID; set discord_bot.application_id or DISCORD_APPLICATION_ID")
+		}
+		if err := discord.RegisterCommands(ctx, cfg.DiscordApplicationID, cfg.DiscordBotToken); err != nil {
+			log.Fatalf("Failed to register slash commands: %v", err)
+		}
+		log.Println("Registered slash commands")
+	}
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf("%s:%s", cfg.Host, cfg.DiscordBotPort),
+		Handler:           bot.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Printf("Answering Discord interactions at %s:%s/interactions", cfg.Host, cfg.DiscordBotPort)
+
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Discord bot error: %v", err)
+		}
+	}()
+
+	<-done
+	log.Println("Shutting down...")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Failed to shut down: %v", err)
+	}
+}
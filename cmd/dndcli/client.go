@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/google/uuid"
+)
+
+// client calls the server's HTTP API with an API token
+type client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+func newClient(baseURL, token string) *client {
+	return &client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		http:    &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// do sends a request and returns the response body, turning error
+// responses into errors with the server's message
+func (c *client) do(method, path string, body any) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error != "" {
+			return nil, errors.New(apiErr.Error)
+		}
+		return nil, fmt.Errorf("%s %s: %s", method, path, resp.Status)
+	}
+	return data, nil
+}
+
+func (c *client) characters() ([]db.Character, error) {
+	data, err := c.do(http.MethodGet, "/characters", nil)
+	if err != nil {
+		return nil, err
+	}
+	var chars []db.Character
+	return chars, json.Unmarshal(data, &chars)
+}
+
+// find looks up one of the player's characters by ID, by name ignoring
+// case, or by the start of a name if only one character matches
+func (c *client) find(nameOrID string) (db.Character, error) {
+	chars, err := c.characters()
+	if err != nil {
+		return db.Character{}, err
+	}
+
+	var prefixed []db.Character
+	for _, char := range chars {
+		if id, err := uuid.Parse(nameOrID); err == nil && char.ID.Bytes == id {
+			return char, nil
+		}
+		if strings.EqualFold(char.Name, nameOrID) {
+			return char, nil
+		}
+		if strings.HasPrefix(strings.ToLower(char.Name), strings.ToLower(nameOrID)) {
+			prefixed = append(prefixed, char)
+		}
+	}
+
+	switch len(prefixed) {
+	case 0:
+		return db.Character{}, fmt.Errorf("no character named %q", nameOrID)
+	case 1:
+		return prefixed[0], nil
+	}
+	names := make([]string, len(prefixed))
+	for i, char := range prefixed {
+		names[i] = char.Name
+	}
+	return db.Character{}, fmt.Errorf("%q could be %s", nameOrID, strings.Join(names, ", "))
+}
+
+// character returns the full character, with inventory and spells, as the
+// API's JSON
+func (c *client) character(char db.Character) ([]byte, error) {
+	return c.do(http.MethodGet, "/characters/"+uuid.UUID(char.ID.Bytes).String(), nil)
+}
+
+// sheet returns the character's whole sheet as printed by the server, ext
+// being "md" for Markdown or "txt" for plain text
+func (c *client) sheet(char db.Character, ext string) ([]byte, error) {
+	return c.do(http.MethodGet, "/characters/"+uuid.UUID(char.ID.Bytes).String()+"/sheet."+ext, nil)
+}
+
+// hitPoints is the body for changing hit points; see the API server's
+// hitPointsRequest
+type hitPoints struct {
+	CurrentHitPoints   *int32 `json:"current_hit_points,omitempty"`
+	TemporaryHitPoints *int32 `json:"temporary_hit_points,omitempty"`
+	Damage             *int32 `json:"damage,omitempty"`
+	Heal               *int32 `json:"heal,omitempty"`
+}
+
+func (c *client) updateHitPoints(char db.Character, change hitPoints) (db.Character, error) {
+	data, err := c.do(http.MethodPatch, "/characters/"+uuid.UUID(char.ID.Bytes).String()+"/hp", change)
+	if err != nil {
+		return db.Character{}, err
+	}
+	var updated db.Character
+	return updated, json.Unmarshal(data, &updated)
+}
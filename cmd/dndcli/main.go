@@ -0,0 +1,231 @@
+// Command dndcli uses the server's HTTP API from the command line: list
+// characters, roll dice, change hit points, export a character as JSON, and
+// print a character's sheet. It signs in with an API token made on the home
+// screen (t).
+//
+//	dndcli roll 2d6
+//	dndcli hp -5 gandalf
+//	dndcli export gandalf > gandalf.json
+//	dndcli print gandalf | lpr
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+)
+
+const usage = `Usage: dndcli [flags] <command> [arguments]
+
+Commands:
+  list                    list your characters
+  show <character>        show a character's hit points and armor class
+  roll <dice>...          roll dice, e.g. d20, 2d6+3
+  hp <change> <character> -5 takes damage, +5 heals, 12 sets current HP
+  temp <n> <character>    set temporary hit points
+  export <character>      print a character as JSON
+  print <character>       print a character's sheet as plain text
+  markdown <character>    print a character's sheet as Markdown
+
+A character is a name, the start of one, or an ID.
+
+Flags:
+`
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("dndcli: ")
+
+	url := flag.String("url", envOr("DND_URL", "http://localhost:8080"), "API address (or DND_URL)")
+	token := flag.String("token", os.Getenv("DND_TOKEN"), "API token (or DND_TOKEN)")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Rolling dice doesn't need the server
+	if args[0] == "roll" {
+		roll(args[1:])
+		return
+	}
+
+	if *token == "" {
+		log.Fatalf("No API token; pass -token or set DND_TOKEN")
+	}
+	c := newClient(*url, *token)
+
+	switch args[0] {
+	case "list":
+		list(c)
+	case "show":
+		needArgs(args, 1, "show <character>")
+		show(c, args[1])
+	case "hp":
+		needArgs(args, 2, "hp <change> <character>")
+		hp(c, args[1], args[2])
+	case "temp":
+		needArgs(args, 2, "temp <n> <character>")
+		temp(c, args[1], args[2])
+	case "export":
+		needArgs(args, 1, "export <character>")
+		export(c, args[1])
+	case "print":
+		needArgs(args, 1, "print <character>")
+		printSheet(c, args[1], "txt")
+	case "markdown":
+		needArgs(args, 1, "markdown <character>")
+		printSheet(c, args[1], "md")
+	default:
+		log.Fatalf("Unknown command %q; run dndcli -h for help", args[0])
+	}
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// needArgs exits with the command's usage unless it was given n arguments
+func needArgs(args []string, n int, form string) {
+	if len(args)-1 != n {
+		log.Fatalf("Usage: dndcli %s", form)
+	}
+}
+
+func roll(exprs []string) {
+	if len(exprs) == 0 {
+		log.Fatalf("Usage: dndcli roll <dice>...")
+	}
+	for _, expr := range exprs {
+		dice, err := character.ParseDice(expr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rolls, total := dice.Roll()
+		faces := make([]string, len(rolls))
+		for i, r := range rolls {
+			faces[i] = strconv.Itoa(r)
+		}
+		fmt.Printf("%s: %d [%s]\n", dice, total, strings.Join(faces, ", "))
+	}
+}
+
+func list(c *client) {
+	chars, err := c.characters()
+	if err != nil {
+		log.Fatal(err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, char := range chars {
+		fmt.Fprintf(w, "%s\t%s %s %d\t%s\n", char.Name, char.Race, char.Class, char.Level, hitPointsText(char))
+	}
+	w.Flush()
+}
+
+func show(c *client, nameOrID string) {
+	char, err := c.find(nameOrID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%s, level %d %s %s\n", char.Name, char.Level, char.Race, char.Class)
+	fmt.Printf("HP  %s\n", hitPointsText(char))
+	fmt.Printf("AC  %d\n", char.ArmorClass)
+}
+
+// hp applies a change written the way players say it: a minus sign for
+// damage, a plus sign for healing, and a bare number to set current HP
+func hp(c *client, change, nameOrID string) {
+	n, err := strconv.Atoi(change)
+	if err != nil {
+		log.Fatalf("%q is not a number like -5, +5, or 12", change)
+	}
+	amount := int32(n)
+	var body hitPoints
+	switch {
+	case strings.HasPrefix(change, "-"):
+		amount = -amount
+		body.Damage = &amount
+	case strings.HasPrefix(change, "+"):
+		body.Heal = &amount
+	default:
+		body.CurrentHitPoints = &amount
+	}
+	updateHitPoints(c, nameOrID, body)
+}
+
+func temp(c *client, value, nameOrID string) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Fatalf("%q is not a number of hit points", value)
+	}
+	amount := int32(n)
+	updateHitPoints(c, nameOrID, hitPoints{TemporaryHitPoints: &amount})
+}
+
+func updateHitPoints(c *client, nameOrID string, body hitPoints) {
+	char, err := c.find(nameOrID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	updated, err := c.updateHitPoints(char, body)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%s: %s\n", updated.Name, hitPointsText(updated))
+}
+
+func export(c *client, nameOrID string) {
+	char, err := c.find(nameOrID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	data, err := c.character(char)
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Stdout.Write(data)
+	if !strings.HasSuffix(string(data), "\n") {
+		fmt.Println()
+	}
+}
+
+// printSheet writes out a character's whole sheet, ext being "md" for
+// Markdown or "txt" for plain text
+func printSheet(c *client, nameOrID, ext string) {
+	char, err := c.find(nameOrID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	data, err := c.sheet(char, ext)
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Stdout.Write(data)
+}
+
+// hitPointsText shows hit points the way the sheet does, e.g. "12/20 +5 temp"
+func hitPointsText(char db.Character) string {
+	maxHP := character.MaxHitPoints(character.RulesetFor(char.Ruleset),
+		int(char.MaxHitPoints), int(char.MaxHitPointsBonus), int(char.Exhaustion))
+	s := fmt.Sprintf("%d/%d", char.CurrentHitPoints, maxHP)
+	if char.TemporaryHitPoints > 0 {
+		s += fmt.Sprintf(" +%d temp", char.TemporaryHitPoints)
+	}
+	return s
+}
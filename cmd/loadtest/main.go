@@ -0,0 +1,218 @@
+// Command loadtest opens many simulated SSH sessions against a running
+// server, plays a short script on each, and reports how long the screen
+// took to respond. With a database URL it also samples the server's
+// database connections and removes the accounts it created.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/brady1408/dnd/internal/auth"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:2222", "server SSH address")
+	sessions := flag.Int("sessions", 10, "number of concurrent sessions")
+	iterations := flag.Int("iterations", 10, "times each session runs the script")
+	ramp := flag.Duration("ramp", 50*time.Millisecond, "delay between starting sessions")
+	settle := flag.Duration("settle", 50*time.Millisecond, "quiet time after which a screen counts as drawn")
+	timeout := flag.Duration("timeout", 10*time.Second, "how long to wait for a response")
+	databaseURL := flag.String("database-url", "", "server database, for connection sampling and cleanup (optional)")
+	poolSize := flag.Int("pool-size", 0, "server pool size, to report saturation against (optional)")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	var pool *pgxpool.Pool
+	if *databaseURL != "" {
+		var err error
+		pool, err = pgxpool.New(ctx, *databaseURL)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer pool.Close()
+	}
+
+	results := newRecorder()
+
+	var sampler *connSampler
+	stopSampling := func() {}
+	if pool != nil {
+		sampler = &connSampler{pool: pool}
+		sampleCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			sampler.run(sampleCtx)
+			close(done)
+		}()
+		stopSampling = func() {
+			cancel()
+			<-done
+		}
+	}
+
+	log.Printf("Running %d session(s) × %d iteration(s) against %s", *sessions, *iterations, *addr)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	keys := make(chan gossh.PublicKey, *sessions)
+	for i := 0; i < *sessions; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runSession(*addr, *iterations, *settle, *timeout, results, keys)
+		}()
+		time.Sleep(*ramp)
+	}
+	wg.Wait()
+	close(keys)
+
+	elapsed := time.Since(start)
+	stopSampling()
+
+	results.report(os.Stdout, elapsed)
+	if sampler != nil {
+		sampler.report(os.Stdout, *poolSize)
+	}
+
+	if pool != nil {
+		cleanup(ctx, db.New(pool), keys)
+	}
+}
+
+// runSession connects, registers, and plays the script. The session's key
+// is sent on keys once it has an account, so it can be cleaned up.
+func runSession(addr string, iterations int, settle, timeout time.Duration, results *recorder, keys chan<- gossh.PublicKey) {
+	connectStart := time.Now()
+	s, err := dial(addr, settle, timeout)
+	if err != nil {
+		results.fail("connect", err)
+		return
+	}
+	defer s.Close()
+
+	if err := s.register(); err != nil {
+		results.fail("connect", err)
+		return
+	}
+	keys <- s.key
+	results.add("connect", time.Since(connectStart))
+
+	for range iterations {
+		for _, st := range script {
+			d, err := s.press(st.key)
+			if err != nil {
+				results.fail(st.name, err)
+				return
+			}
+			results.add(st.name, d)
+		}
+	}
+}
+
+// cleanup deletes the accounts created by the sessions
+func cleanup(ctx context.Context, queries *db.Queries, keys <-chan gossh.PublicKey) {
+	deleted := 0
+	for key := range keys {
+		user, err := queries.GetUserByPublicKey(ctx, pgtype.Text{String: auth.NormalizePublicKey(key), Valid: true})
+		if err != nil {
+			continue
+		}
+		if err := queries.DeleteUser(ctx, user.ID); err != nil {
+			log.Printf("Failed to delete load test user: %v", err)
+			continue
+		}
+		deleted++
+	}
+	log.Printf("Removed %d load test account(s)", deleted)
+}
+
+// recorder collects latencies and errors by step name
+type recorder struct {
+	mu        sync.Mutex
+	order     []string
+	latencies map[string][]time.Duration
+	errors    map[string]int
+	lastErr   map[string]error
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		latencies: make(map[string][]time.Duration),
+		errors:    make(map[string]int),
+		lastErr:   make(map[string]error),
+	}
+}
+
+func (r *recorder) seen(name string) {
+	if _, ok := r.latencies[name]; !ok {
+		r.order = append(r.order, name)
+		r.latencies[name] = nil
+	}
+}
+
+func (r *recorder) add(name string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seen(name)
+	r.latencies[name] = append(r.latencies[name], d)
+}
+
+func (r *recorder) fail(name string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seen(name)
+	r.errors[name]++
+	r.lastErr[name] = err
+}
+
+func (r *recorder) report(w io.Writer, elapsed time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fmt.Fprintln(w)
+	total := 0
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "step\tcount\terrors\tp50\tp90\tp99\tmax\t")
+	for _, name := range r.order {
+		l := r.latencies[name]
+		sort.Slice(l, func(i, j int) bool { return l[i] < l[j] })
+		total += len(l)
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t\n", name, len(l), r.errors[name],
+			formatMS(percentile(l, 50)), formatMS(percentile(l, 90)), formatMS(percentile(l, 99)), formatMS(percentile(l, 100)))
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\n%d responses in %s (%.1f/s)\n", total, elapsed.Round(time.Millisecond), float64(total)/elapsed.Seconds())
+	for _, name := range r.order {
+		if err := r.lastErr[name]; err != nil {
+			fmt.Fprintf(w, "last %s error: %v\n", name, err)
+		}
+	}
+}
+
+// percentile returns the p-th percentile of sorted latencies, by nearest rank
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+func formatMS(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d.Microseconds())/1000)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// sampleInterval is how often database connections are counted
+const sampleInterval = 200 * time.Millisecond
+
+// connSampler counts the server's connections to the database while the
+// test runs. Its own sampling connection is excluded.
+type connSampler struct {
+	pool *pgxpool.Pool
+
+	samples     int
+	totalActive int
+	peakActive  int
+	peakOpen    int
+}
+
+func (c *connSampler) run(ctx context.Context) {
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.sample(ctx)
+		}
+	}
+}
+
+func (c *connSampler) sample(ctx context.Context) {
+	var open, active int
+	err := c.pool.QueryRow(ctx, `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE state = 'active')
+		FROM pg_stat_activity
+		WHERE datname = current_database() AND pid <> pg_backend_pid()`).Scan(&open, &active)
+	if err != nil {
+		return
+	}
+	c.samples++
+	c.totalActive += active
+	c.peakActive = max(c.peakActive, active)
+	c.peakOpen = max(c.peakOpen, open)
+}
+
+// report prints connection counts. With the server's pool size, the peak
+// is also shown as a share of it.
+func (c *connSampler) report(w io.Writer, poolSize int) {
+	if c.samples == 0 {
+		fmt.Fprintln(w, "\nNo database samples taken")
+		return
+	}
+	fmt.Fprintf(w, "\nDatabase connections (%d samples)\n", c.samples)
+	fmt.Fprintf(w, "  open:   peak %d\n", c.peakOpen)
+	fmt.Fprintf(w, "  active: peak %d, mean %.1f\n", c.peakActive, float64(c.totalActive)/float64(c.samples))
+	if poolSize > 0 {
+		fmt.Fprintf(w, "  saturation: peak %.0f%% of %d\n", 100*float64(c.peakActive)/float64(poolSize), poolSize)
+	}
+}
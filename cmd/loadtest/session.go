@@ -0,0 +1,209 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// Terminal keys sent by the script
+const (
+	keyEnter = "\r"
+	keyEsc   = "\x1b"
+	keyTab   = "\t"
+	keyDown  = "\x1b[B"
+)
+
+// step is one scripted keypress and the name its latency is reported under
+type step struct {
+	name string
+	key  string
+}
+
+// script is what each session does once logged in. Every step redraws the
+// screen and most of them query the database.
+var script = []step{
+	{"sort list", "s"},
+	{"show templates", keyTab},
+	{"show characters", keyTab},
+	{"open tokens", "t"},
+	{"back to list", keyEsc},
+}
+
+// session is one simulated player connected over SSH with its own key
+type session struct {
+	key    gossh.PublicKey
+	client *gossh.Client
+	ssh    *gossh.Session
+	stdin  io.WriteCloser
+	output chan struct{} // signalled when screen output arrives
+
+	settle  time.Duration // output is finished after this long without more
+	timeout time.Duration // how long to wait for a response to a key
+}
+
+// errNoOutput is returned when a key gets no response before the timeout
+var errNoOutput = errors.New("no response")
+
+// dial connects with a fresh key and starts the app in a pseudo-terminal
+func dial(addr string, settle, timeout time.Duration) (*session, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := gossh.NewSignerFromKey(private)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := gossh.Dial("tcp", addr, &gossh.ClientConfig{
+		User:            "loadtest",
+		Auth:            []gossh.AuthMethod{gossh.PublicKeys(signer)},
+		HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+		Timeout:         timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &session{
+		key:     signer.PublicKey(),
+		client:  client,
+		output:  make(chan struct{}, 1),
+		settle:  settle,
+		timeout: timeout,
+	}
+	if err := s.start(); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *session) start() error {
+	var err error
+	s.ssh, err = s.client.NewSession()
+	if err != nil {
+		return err
+	}
+	if err := s.ssh.RequestPty("xterm-256color", 40, 120, gossh.TerminalModes{}); err != nil {
+		return err
+	}
+	s.stdin, err = s.ssh.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := s.ssh.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := s.ssh.Shell(); err != nil {
+		return err
+	}
+
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			if _, err := stdout.Read(buf); err != nil {
+				close(s.output)
+				return
+			}
+			select {
+			case s.output <- struct{}{}:
+			default:
+				// A signal is already waiting; the reader only needs one
+			}
+		}
+	}()
+
+	// Wait for the first screen
+	_, err = s.wait()
+	return err
+}
+
+// register creates an account for the session's key from the welcome menu
+func (s *session) register() error {
+	// "Register with SSH Key" is the fourth menu entry when a key is present
+	for range 3 {
+		if _, err := s.press(keyDown); err != nil {
+			return err
+		}
+	}
+	if _, err := s.press(keyEnter); err != nil {
+		return err
+	}
+	_, err := s.press("y")
+	return err
+}
+
+// press sends a key and returns how long the screen took to finish
+// redrawing
+func (s *session) press(key string) (time.Duration, error) {
+	// Drop output left over from the previous step
+drain:
+	for {
+		select {
+		case _, ok := <-s.output:
+			if !ok {
+				return 0, io.EOF
+			}
+		default:
+			break drain
+		}
+	}
+
+	start := time.Now()
+	if _, err := io.WriteString(s.stdin, key); err != nil {
+		return 0, err
+	}
+	last, err := s.wait()
+	if err != nil {
+		return 0, err
+	}
+	return last.Sub(start), nil
+}
+
+// wait blocks until output arrives and then stops for the settle time. It
+// returns when the last output arrived.
+func (s *session) wait() (time.Time, error) {
+	select {
+	case _, ok := <-s.output:
+		if !ok {
+			return time.Time{}, io.EOF
+		}
+	case <-time.After(s.timeout):
+		return time.Time{}, errNoOutput
+	}
+	last := time.Now()
+
+	for {
+		select {
+		case _, ok := <-s.output:
+			if !ok {
+				return last, nil
+			}
+			last = time.Now()
+		case <-time.After(s.settle):
+			return last, nil
+		}
+	}
+}
+
+func (s *session) Close() error {
+	if s.stdin != nil {
+		// ctrl+c quits the app cleanly
+		_, _ = io.WriteString(s.stdin, "\x03")
+	}
+	if s.ssh != nil {
+		_ = s.ssh.Close()
+	}
+	if err := s.client.Close(); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("close: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/brady1408/dnd/internal/auth"
+)
+
+const adminUsage = "usage: dnd admin grant|revoke email"
+
+// runAdmin handles the admin subcommand, which gives an account the admin
+// role, or takes it away, so it can open the admin screen
+func runAdmin(ctx context.Context, authService *auth.Service, args []string) {
+	if len(args) != 2 || (args[0] != "grant" && args[0] != "revoke") {
+		fmt.Fprintln(os.Stderr, adminUsage)
+		os.Exit(2)
+	}
+
+	user, err := authService.SetAdmin(ctx, args[1], args[0] == "grant")
+	if err != nil {
+		log.Fatalf("Failed to update %s: %v", args[1], err)
+	}
+	if auth.IsAdmin(user) {
+		fmt.Printf("%s is now an admin\n", user.Email.String)
+	} else {
+		fmt.Printf("%s is no longer an admin\n", user.Email.String)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/announce"
+	"github.com/brady1408/dnd/internal/db"
+)
+
+const broadcastUsage = "usage: dnd broadcast [-for 10m] message"
+
+// runBroadcast handles the broadcast subcommand, which shows a banner in
+// every connected session until it expires
+func runBroadcast(ctx context.Context, queries *db.Queries, args []string) {
+	fs := flag.NewFlagSet("broadcast", flag.ExitOnError)
+	duration := fs.Duration("for", announce.DefaultDuration, "how long the announcement shows")
+	fs.Usage = func() { fmt.Fprintln(os.Stderr, broadcastUsage) }
+	_ = fs.Parse(args)
+
+	message := strings.Join(fs.Args(), " ")
+	if strings.TrimSpace(message) == "" {
+		fmt.Fprintln(os.Stderr, broadcastUsage)
+		os.Exit(2)
+	}
+
+	a, err := announce.Post(ctx, queries, message, *duration)
+	if err != nil {
+		log.Fatalf("Failed to broadcast: %v", err)
+	}
+	fmt.Printf("Broadcasting until %s: %s\n", a.ExpiresAt.Time.Local().Format("15:04"), a.Message)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/brady1408/dnd/internal/compendium"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/sanitize"
+)
+
+const importUsage = "usage: dnd import [-source NAME] [-dry-run] file.json..."
+
+// runImport handles the import subcommand, which loads spells from Open5e
+// or 5eTools JSON files. Running servers offer them after a restart.
+func runImport(ctx context.Context, queries *db.Queries, args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	source := fs.String("source", "", "name of the content pack, recorded with each spell (default: the file name)")
+	dryRun := fs.Bool("dry-run", false, "read the files and report what they contain, saving nothing")
+	fs.Usage = func() { fmt.Fprintln(os.Stderr, importUsage) }
+	_ = fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	for _, path := range fs.Args() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", path, err)
+		}
+		spells, err := compendium.ParseSpells(data)
+		if err != nil {
+			log.Fatalf("Failed to import %s: %v", path, err)
+		}
+		if *dryRun {
+			fmt.Printf("%s: %d spell(s), nothing saved\n", path, len(spells))
+			continue
+		}
+
+		name := *source
+		if name == "" {
+			name = filepath.Base(path)
+		}
+		name = sanitize.Line(name, sanitize.MaxName)
+		if err := compendium.Store(ctx, queries, spells, name); err != nil {
+			log.Fatalf("Failed to save spells from %s: %v", path, err)
+		}
+		fmt.Printf("Imported %d spell(s) from %s\n", len(spells), path)
+	}
+	if !*dryRun {
+		fmt.Println("Restart the server to offer them")
+	}
+}
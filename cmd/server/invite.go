@@ -0,0 +1,20 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/brady1408/dnd/internal/auth"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// runInvite handles the invite subcommand, which prints a new invite code
+// issued by the server operator
+func runInvite(ctx context.Context, authService *auth.Service) {
+	invite, err := authService.CreateInvite(ctx, pgtype.UUID{})
+	if err != nil {
+		log.Fatalf("Failed to create invite: %v", err)
+	}
+	fmt.Println(invite.Code)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/brady1408/dnd/internal/config"
+	"github.com/brady1408/dnd/internal/migrations"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const migrateUsage = `usage: dnd migrate up [-dry-run] [-no-backup]
+       dnd migrate down [-to VERSION] [-dry-run] [-no-backup]
+       dnd migrate status`
+
+// runMigrate handles the migrate subcommand
+func runMigrate(ctx context.Context, pool *pgxpool.Pool, cfg config.Config, args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "run the migrations in a transaction that is rolled back, changing nothing")
+	noBackup := fs.Bool("no-backup", false, "skip the backup set by database.backup_dir")
+	to := fs.Int("to", -1, "roll back every migration newer than this version (0 rolls back all)")
+	fs.Usage = func() { fmt.Fprintln(os.Stderr, migrateUsage) }
+	_ = fs.Parse(args[1:])
+	if fs.NArg() > 0 || (*to >= 0 && args[0] != "down") {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	migrator, err := migrations.New(pool)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+	if cfg.BackupDir != "" && !*noBackup {
+		migrator.SetBackup(migrations.PgDump(cfg.DatabaseURL, cfg.BackupDir))
+	}
+
+	switch args[0] {
+	case "up":
+		if *dryRun {
+			pending, err := migrator.DryRunUp(ctx)
+			reportDryRun("apply", pending, err)
+			return
+		}
+		count, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		fmt.Printf("Applied %d migration(s)\n", count)
+
+	case "down":
+		if *dryRun {
+			version := *to
+			if version < 0 {
+				version = previousVersion(ctx, migrator)
+			}
+			rollbacks, err := migrator.DryRunDownTo(ctx, version)
+			reportDryRun("roll back", rollbacks, err)
+			return
+		}
+		if *to >= 0 {
+			rolledBack, err := migrator.DownTo(ctx, *to)
+			for _, migration := range rolledBack {
+				fmt.Printf("Rolled back %04d_%s\n", migration.Version, migration.Name)
+			}
+			if err != nil {
+				log.Fatalf("Rollback failed: %v", err)
+			}
+			if len(rolledBack) == 0 {
+				fmt.Println("No migrations to roll back")
+			}
+			return
+		}
+		migration, err := migrator.Down(ctx)
+		if err != nil {
+			log.Fatalf("Rollback failed: %v", err)
+		}
+		if migration == nil {
+			fmt.Println("No migrations to roll back")
+			return
+		}
+		fmt.Printf("Rolled back %04d_%s\n", migration.Version, migration.Name)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied " + status.AppliedAt.Local().Format("2006-01-02 15:04")
+			}
+			fmt.Printf("%04d_%-30s %s\n", status.Version, status.Name, state)
+		}
+
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+}
+
+// reportDryRun lists what a dry run would have done and whether it worked
+func reportDryRun(action string, planned []migrations.Migration, err error) {
+	if len(planned) == 0 {
+		fmt.Printf("Nothing to %s\n", action)
+		return
+	}
+	for _, migration := range planned {
+		fmt.Printf("Would %s %04d_%s\n", action, migration.Version, migration.Name)
+	}
+	if err != nil {
+		log.Fatalf("Dry run failed: %v", err)
+	}
+	fmt.Println("Dry run succeeded; nothing was changed")
+}
+
+// previousVersion returns the version that rolling back the latest applied
+// migration returns the database to
+func previousVersion(ctx context.Context, migrator *migrations.Migrator) int {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		log.Fatalf("Failed to read migration status: %v", err)
+	}
+	var applied []int
+	for _, status := range statuses {
+		if status.Applied {
+			applied = append(applied, status.Version)
+		}
+	}
+	if len(applied) < 2 {
+		return 0
+	}
+	return applied[len(applied)-2]
+}
+
+// autoMigrate applies pending migrations at startup
+func autoMigrate(ctx context.Context, pool *pgxpool.Pool, cfg config.Config) {
+	migrator, err := migrations.New(pool)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+	if cfg.BackupDir != "" {
+		migrator.SetBackup(migrations.PgDump(cfg.DatabaseURL, cfg.BackupDir))
+	}
+	count, err := migrator.Up(ctx)
+	if err != nil {
+		log.Fatalf("Migration failed: %v", err)
+	}
+	if count > 0 {
+		log.Printf("Applied %d migration(s)", count)
+	}
+}
@@ -0,0 +1,123 @@
+// Package announce carries operator broadcasts ("server restarting in 5
+// minutes") to every connected session. Announcements are stored in the
+// database, so one posted from the command line reaches every server
+// sharing it. Sessions also learn here which accounts an admin has
+// disabled, so they can sign them out.
+package announce
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/sanitize"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// DefaultDuration is how long an announcement shows when not given
+const DefaultDuration = 10 * time.Minute
+
+// MaxMessage is the longest announcement, in characters
+const MaxMessage = 200
+
+// pollInterval is how often a Board checks for a new announcement
+const pollInterval = 15 * time.Second
+
+// Post stores an announcement for every session to show until duration
+// has passed
+func Post(ctx context.Context, queries *db.Queries, message string, duration time.Duration) (db.ServerAnnouncement, error) {
+	message = sanitize.Line(message, MaxMessage)
+	if message == "" {
+		return db.ServerAnnouncement{}, errors.New("the announcement is empty")
+	}
+	if duration <= 0 {
+		return db.ServerAnnouncement{}, errors.New("the announcement must last longer than zero")
+	}
+	return queries.CreateAnnouncement(ctx, db.CreateAnnouncementParams{
+		Message:   message,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(duration), Valid: true},
+	})
+}
+
+// Board keeps the current announcement and the disabled accounts for a
+// server's sessions, so they read them from memory instead of each querying
+// the database
+type Board struct {
+	queries *db.Queries
+
+	mu       sync.RWMutex
+	current  db.ServerAnnouncement
+	disabled map[pgtype.UUID]bool
+}
+
+// NewBoard creates a new board
+func NewBoard(queries *db.Queries) *Board {
+	return &Board{queries: queries}
+}
+
+// Run refreshes the current announcement until ctx is done
+func (b *Board) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		b.Refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh picks up changes right away, such as an announcement just posted
+// from this server, rather than at the next poll
+func (b *Board) Refresh(ctx context.Context) {
+	current, err := b.queries.GetCurrentAnnouncement(ctx)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		log.Printf("Failed to check for announcements: %v", err)
+		return
+	}
+	ids, err := b.queries.ListDisabledUserIDs(ctx)
+	if err != nil {
+		log.Printf("Failed to check for disabled accounts: %v", err)
+		return
+	}
+	disabled := make(map[pgtype.UUID]bool, len(ids))
+	for _, id := range ids {
+		disabled[id] = true
+	}
+
+	b.mu.Lock()
+	b.current = current
+	b.disabled = disabled
+	b.mu.Unlock()
+}
+
+// Current returns the announcement to show, if one hasn't expired
+func (b *Board) Current() (db.ServerAnnouncement, bool) {
+	if b == nil {
+		return db.ServerAnnouncement{}, false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.current.ID.Valid || !b.current.ExpiresAt.Time.After(time.Now()) {
+		return db.ServerAnnouncement{}, false
+	}
+	return b.current, true
+}
+
+// Disabled reports whether an admin has disabled the account
+func (b *Board) Disabled(userID pgtype.UUID) bool {
+	if b == nil {
+		return false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.disabled[userID]
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/audit"
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/sanitize"
+)
+
+// maxImportRows caps how many items one CSV import can add
+const maxImportRows = 500
+
+// inventoryColumns are the header names recognised for each field when the
+// request doesn't map them, compared case-insensitively
+var inventoryColumns = map[string][]string{
+	"name":     {"name", "item", "item name"},
+	"quantity": {"quantity", "qty", "count", "amount"},
+	"equipped": {"equipped", "equip", "worn"},
+	"location": {"location", "container", "stored in"},
+}
+
+// inventoryRow is one validated line of an imported CSV
+type inventoryRow struct {
+	Name     string
+	Quantity int32
+	Equipped bool
+	Location string
+}
+
+func (s *Server) exportInventory(w http.ResponseWriter, r *http.Request) {
+	char, err := s.loadOwnedCharacter(r)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	items, err := s.queries.GetCharacterInventory(r.Context(), char.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load inventory")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", char.Name+" inventory.csv"))
+	out := csv.NewWriter(w)
+	_ = out.Write([]string{"name", "quantity", "equipped", "location"})
+	for _, item := range items {
+		_ = out.Write([]string{item.Name, strconv.Itoa(int(item.Quantity)), strconv.FormatBool(item.Equipped), item.Location})
+	}
+	out.Flush()
+}
+
+// importInventory adds the items in a CSV body to a character's inventory.
+// With ?replace=true the current inventory is removed first. Query
+// parameters name, quantity, equipped, and location pick the header of the column
+// holding each field. Nothing is saved unless every row is valid.
+func (s *Server) importInventory(w http.ResponseWriter, r *http.Request) {
+	char, err := s.loadOwnedCharacter(r)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	mapping := map[string]string{}
+	for field := range inventoryColumns {
+		if header := r.URL.Query().Get(field); header != "" {
+			mapping[field] = header
+		}
+	}
+	rows, problems := parseInventoryCSV(r.Body, mapping)
+	if len(problems) > 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]any{"error": "invalid CSV", "problems": problems})
+		return
+	}
+
+	replace, _ := strconv.ParseBool(r.URL.Query().Get("replace"))
+	if replace {
+		if err := s.queries.DeleteCharacterInventory(r.Context(), char.ID); err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to clear inventory")
+			return
+		}
+	}
+	for _, row := range rows {
+		_, err := s.queries.AddInventoryItem(r.Context(), db.AddInventoryItemParams{
+			CharacterID: char.ID,
+			Name:        row.Name,
+			Quantity:    row.Quantity,
+			Equipped:    row.Equipped,
+			Location:    row.Location,
+		})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to add "+row.Name)
+			return
+		}
+	}
+
+	description := fmt.Sprintf("Imported %d items from CSV (API)", len(rows))
+	if replace {
+		description = fmt.Sprintf("Replaced inventory with %d items from CSV (API)", len(rows))
+	}
+	audit.Record(r.Context(), s.queries, char.ID, char.UserID, audit.KindInventory, description)
+
+	// Imported armor and shields change AC just as equipping them on the sheet does
+	items, err := s.queries.GetCharacterInventory(r.Context(), char.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load inventory")
+		return
+	}
+	updated, err := s.queries.UpdateCharacterArmorClass(r.Context(), db.UpdateCharacterArmorClassParams{
+		ID:                 char.ID,
+		ArmorClass:         int32(armorClass(char, items)),
+		ArmorClassOverride: char.ArmorClassOverride,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update armor class")
+		return
+	}
+
+	resp := newCharacterResponse(updated)
+	resp.Inventory = items
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// parseInventoryCSV reads items from CSV with a header row. mapping names
+// the header for a field, overriding the defaults in inventoryColumns. Every
+// problem found is returned, each naming its line.
+func parseInventoryCSV(r io.Reader, mapping map[string]string) ([]inventoryRow, []string) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, []string{"the CSV is empty"}
+	}
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	columns := map[string]int{}
+	for field, names := range inventoryColumns {
+		if name, ok := mapping[field]; ok {
+			names = []string{name}
+		}
+		for i, h := range header {
+			if containsFold(names, strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))) {
+				columns[field] = i
+				break
+			}
+		}
+	}
+	if _, ok := columns["name"]; !ok {
+		if name, ok := mapping["name"]; ok {
+			return nil, []string{fmt.Sprintf("line 1: no %q column", name)}
+		}
+		return nil, []string{"line 1: no name column (add a name header or map one with ?name=)"}
+	}
+
+	var rows []inventoryRow
+	var problems []string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			problems = append(problems, err.Error())
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		cell := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		if strings.Join(record, "") == "" {
+			continue
+		}
+		row := inventoryRow{
+			Name:     sanitize.Line(cell("name"), sanitize.MaxName),
+			Quantity: 1,
+			Location: sanitize.Line(cell("location"), sanitize.MaxName),
+		}
+		if row.Name == "" {
+			problems = append(problems, fmt.Sprintf("line %d: name is empty", line))
+		}
+		if q := cell("quantity"); q != "" {
+			n, err := strconv.Atoi(q)
+			if err != nil || n < 1 || n > 9999 {
+				problems = append(problems, fmt.Sprintf("line %d: quantity %q must be a whole number from 1 to 9999", line, q))
+			}
+			row.Quantity = int32(n)
+		}
+		if e := cell("equipped"); e != "" {
+			equipped, ok := parseEquipped(e)
+			if !ok {
+				problems = append(problems, fmt.Sprintf("line %d: equipped %q must be yes or no", line, e))
+			}
+			row.Equipped = equipped
+		}
+		rows = append(rows, row)
+
+		if len(rows) > maxImportRows {
+			problems = append(problems, fmt.Sprintf("more than %d items", maxImportRows))
+			break
+		}
+	}
+	if len(rows) == 0 && len(problems) == 0 {
+		problems = append(problems, "the CSV has no items")
+	}
+	return rows, problems
+}
+
+// parseEquipped accepts the ways spreadsheets usually write a checkbox
+func parseEquipped(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "true", "yes", "y", "1", "x", "✓":
+		return true, true
+	case "false", "no", "n", "0", "-":
+		return false, true
+	}
+	return false, false
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// armorClass calculates a character's AC from their equipped items
+func armorClass(char db.Character, items []db.CharacterInventory) int {
+	var equipped []string
+	for _, item := range items {
+		if item.Equipped {
+			equipped = append(equipped, item.Name)
+		}
+	}
+	override := 0
+	if char.ArmorClassOverride.Valid {
+		override = int(char.ArmorClassOverride.Int32)
+	}
+	return character.CalculateArmorClass(character.ArmorClassInput{
+		Class:        char.Class,
+		Strength:     int(char.Strength),
+		Dexterity:    int(char.Dexterity),
+		Constitution: int(char.Constitution),
+		Wisdom:       int(char.Wisdom),
+		Equipped:     equipped,
+		Override:     override,
+	}).Total
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/printout"
+)
+
+// printSheet serves the whole character sheet as Markdown or plain text,
+// for pasting into a wiki or printing
+func (s *Server) printSheet(format printout.Format) http.HandlerFunc {
+	contentType, ext := "text/markdown; charset=utf-8", "md"
+	if format == printout.Text {
+		contentType, ext = "text/plain; charset=utf-8", "txt"
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		char, err := s.loadOwnedCharacter(r)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		data, err := s.queries.GetSheetData(r.Context(), db.GetSheetDataParams{CharacterID: char.ID})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to load character sheet")
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", char.Name+"."+ext))
+		fmt.Fprint(w, printout.Render(printout.Sheet{
+			Character:    char,
+			Spellcasting: data.Spellcasting,
+			Spells:       data.Spells,
+			Inventory:    data.Inventory,
+			Resources:    data.Resources,
+			Journal:      data.Journal,
+		}, format))
+	}
+}
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/audit"
+	"github.com/brady1408/dnd/internal/auth"
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/printout"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Server exposes character data over a JSON HTTP API
+type Server struct {
+	queries *db.Queries
+	auth    *auth.Service
+}
+
+// NewServer creates a new API server
+func NewServer(queries *db.Queries) *Server {
+	return &Server{
+		queries: queries,
+		auth:    auth.NewService(queries),
+	}
+}
+
+type contextKey int
+
+const userContextKey contextKey = iota
+
+var errNotFound = errors.New("character not found")
+
+// Handler returns the HTTP handler with all routes registered
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /characters", s.requireToken(s.listCharacters))
+	mux.Handle("GET /characters/{id}", s.requireToken(s.getCharacter))
+	mux.Handle("PATCH /characters/{id}/hp", s.requireToken(s.updateHitPoints))
+	mux.Handle("GET /characters/{id}/inventory.csv", s.requireToken(s.exportInventory))
+	mux.Handle("POST /characters/{id}/inventory.csv", s.requireToken(s.importInventory))
+	mux.Handle("GET /characters/{id}/sheet.md", s.requireToken(s.printSheet(printout.Markdown)))
+	mux.Handle("GET /characters/{id}/sheet.txt", s.requireToken(s.printSheet(printout.Text)))
+	return mux
+}
+
+// requireToken authenticates requests with a bearer API token
+func (s *Server) requireToken(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+
+		user, err := s.auth.AuthenticateAPIToken(r.Context(), strings.TrimSpace(token))
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), userContextKey, user)
+		next(w, r.WithContext(ctx))
+	})
+}
+
+func userFromContext(ctx context.Context) *db.User {
+	user, _ := ctx.Value(userContextKey).(*db.User)
+	return user
+}
+
+// characterResponse is the JSON representation of a character
+type characterResponse struct {
+	db.Character
+	Inventory    []db.CharacterInventory   `json:"inventory,omitempty"`
+	Spellcasting *db.CharacterSpellcasting `json:"spellcasting,omitempty"`
+	Spells       []db.CharacterSpell       `json:"spells,omitempty"`
+}
+
+func newCharacterResponse(char db.Character) characterResponse {
+	return characterResponse{Character: char}
+}
+
+func (s *Server) listCharacters(w http.ResponseWriter, r *http.Request) {
+	user := userFromContext(r.Context())
+
+	chars, err := s.queries.GetCharactersByUserID(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load characters")
+		return
+	}
+
+	resp := make([]characterResponse, len(chars))
+	for i, char := range chars {
+		resp[i] = newCharacterResponse(char)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) getCharacter(w http.ResponseWriter, r *http.Request) {
+	char, err := s.loadOwnedCharacter(r)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	resp := newCharacterResponse(char)
+	if items, err := s.queries.GetCharacterInventory(r.Context(), char.ID); err == nil {
+		resp.Inventory = items
+	}
+	if sc, err := s.queries.GetCharacterSpellcasting(r.Context(), char.ID); err == nil {
+		resp.Spellcasting = &sc
+		if spells, err := s.queries.GetCharacterSpells(r.Context(), char.ID); err == nil {
+			resp.Spells = spells
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// hitPointsRequest is the body for PATCH /characters/{id}/hp. Omitted fields
+// keep their current value. Damage and healing apply after any values set,
+// with damage taken from temporary hit points first.
+type hitPointsRequest struct {
+	CurrentHitPoints   *int32 `json:"current_hit_points"`
+	TemporaryHitPoints *int32 `json:"temporary_hit_points"`
+	Damage             *int32 `json:"damage"`
+	Heal               *int32 `json:"heal"`
+}
+
+func (s *Server) updateHitPoints(w http.ResponseWriter, r *http.Request) {
+	char, err := s.loadOwnedCharacter(r)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	var req hitPointsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	hp := character.HitPoints{
+		Current: int(char.CurrentHitPoints),
+		Temp:    int(char.TemporaryHitPoints),
+		Max: character.MaxHitPoints(character.RulesetFor(char.Ruleset),
+			int(char.MaxHitPoints), int(char.MaxHitPointsBonus), int(char.Exhaustion)),
+	}
+	if req.CurrentHitPoints != nil {
+		hp = hp.Set(int(*req.CurrentHitPoints))
+	}
+	if req.TemporaryHitPoints != nil {
+		hp = hp.SetTemp(int(*req.TemporaryHitPoints))
+	}
+	if req.Damage != nil {
+		hp = hp.Damage(int(*req.Damage))
+	}
+	if req.Heal != nil {
+		hp = hp.Heal(int(*req.Heal))
+	}
+
+	updated, err := s.queries.UpdateCharacterHitPoints(r.Context(), db.UpdateCharacterHitPointsParams{
+		ID:                 char.ID,
+		CurrentHitPoints:   int32(hp.Current),
+		TemporaryHitPoints: int32(hp.Temp),
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update hit points")
+		return
+	}
+	_ = s.queries.AddHPHistory(r.Context(), db.AddHPHistoryParams{
+		CharacterID:        updated.ID,
+		CurrentHitPoints:   updated.CurrentHitPoints,
+		TemporaryHitPoints: updated.TemporaryHitPoints,
+		MaxHitPoints:       updated.MaxHitPoints,
+	})
+	audit.Record(r.Context(), s.queries, updated.ID, updated.UserID, audit.KindHP, audit.HPChange(char, updated)+" (API)")
+	writeJSON(w, http.StatusOK, newCharacterResponse(updated))
+}
+
+// loadOwnedCharacter loads the character in the path, only if it belongs to the caller
+func (s *Server) loadOwnedCharacter(r *http.Request) (db.Character, error) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return db.Character{}, errNotFound
+	}
+
+	char, err := s.queries.GetCharacterByID(r.Context(), pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		return db.Character{}, errNotFound
+	}
+
+	user := userFromContext(r.Context())
+	if user == nil || char.UserID != user.ID {
+		return db.Character{}, errNotFound
+	}
+	return char, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,58 @@
+// Package audit records changes made to characters, for the sheet's History
+// tab
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Event kinds
+const (
+	KindCreated       = "created"
+	KindCopied        = "copied"
+	KindHP            = "hp"
+	KindNotes         = "notes"
+	KindFeatures      = "features"
+	KindLevelUp       = "level_up"
+	KindConcentration = "concentration"
+	KindExhaustion    = "exhaustion"
+	KindRest          = "rest"
+	KindInspiration   = "inspiration"
+	KindProficiency   = "proficiency"
+	KindInventory     = "inventory"
+	KindArmorClass    = "armor_class"
+	KindRenamed       = "renamed"
+	KindXP            = "xp"
+	KindCast          = "cast"
+	KindRepaired      = "repaired"
+	KindSpellcasting  = "spellcasting"
+	KindResource      = "resource"
+	KindMovement      = "movement"
+	KindPortrait      = "portrait"
+	KindCrafting      = "crafting"
+)
+
+// Record adds an entry to a character's change log. Errors are ignored so a
+// failed log write never undoes the change it describes.
+func Record(ctx context.Context, queries *db.Queries, characterID, userID pgtype.UUID, kind, description string) {
+	_ = queries.AddCharacterEvent(ctx, db.AddCharacterEventParams{
+		CharacterID: characterID,
+		UserID:      userID,
+		Kind:        kind,
+		Description: description,
+	})
+}
+
+// HPChange describes a hit point change, e.g. "HP 20/31 → 12/31 (temp 5 → 0)"
+func HPChange(from, to db.Character) string {
+	desc := fmt.Sprintf("HP %d/%d → %d/%d",
+		from.CurrentHitPoints, from.MaxHitPoints, to.CurrentHitPoints, to.MaxHitPoints)
+	if from.TemporaryHitPoints != to.TemporaryHitPoints {
+		desc += fmt.Sprintf(" (temp %d → %d)", from.TemporaryHitPoints, to.TemporaryHitPoints)
+	}
+	return desc
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/brady1408/dnd/internal/announce"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrAccountDisabled = errors.New("this account has been disabled")
+	ErrNotAdmin        = errors.New("only admins can do that")
+	ErrDisableSelf     = errors.New("you can't disable your own account")
+)
+
+// Roles a user can have
+const (
+	RolePlayer = "player"
+	RoleAdmin  = "admin"
+)
+
+// IsAdmin reports whether a user can open the admin screen
+func IsAdmin(user *db.User) bool {
+	return user != nil && user.Role == RoleAdmin
+}
+
+// checkEnabled refuses sign-in to disabled accounts
+func checkEnabled(user *db.User) error {
+	if user.DisabledAt.Valid {
+		return ErrAccountDisabled
+	}
+	return nil
+}
+
+// SetAdmin grants or removes the admin role for the account with this
+// email. It's how the operator makes the first admin.
+func (s *Service) SetAdmin(ctx context.Context, email string, admin bool) (*db.User, error) {
+	user, err := s.queries.GetUserByEmail(ctx, pgtype.Text{String: email, Valid: true})
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	role := RolePlayer
+	if admin {
+		role = RoleAdmin
+	}
+	user, err = s.queries.SetUserRole(ctx, db.SetUserRoleParams{ID: user.ID, Role: role})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// requireAdmin checks that a user is still an admin, rather than trusting
+// the role their session started with
+func (s *Service) requireAdmin(ctx context.Context, userID pgtype.UUID) error {
+	user, err := s.GetUserByID(ctx, userID)
+	if err != nil || !IsAdmin(user) || checkEnabled(user) != nil {
+		return ErrNotAdmin
+	}
+	return nil
+}
+
+// ListUsers returns every account with how many characters it has, oldest
+// first
+func (s *Service) ListUsers(ctx context.Context, adminID pgtype.UUID) ([]db.ListUsersWithCharacterCountsRow, error) {
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+	return s.queries.ListUsersWithCharacterCounts(ctx)
+}
+
+// SetDisabled disables or re-enables an account. Disabled accounts can't
+// sign in or use API tokens, their open sessions are signed out, and any
+// password reset code already sent stops working.
+func (s *Service) SetDisabled(ctx context.Context, adminID, userID pgtype.UUID, disabled bool) (*db.User, error) {
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+	if disabled && adminID == userID {
+		return nil, ErrDisableSelf
+	}
+
+	var user db.User
+	var err error
+	if disabled {
+		user, err = s.queries.DisableUser(ctx, userID)
+		if err == nil {
+			err = s.queries.DeleteUserPasswordResets(ctx, userID)
+		}
+	} else {
+		user, err = s.queries.EnableUser(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Broadcast shows a message to every connected player until duration has
+// passed
+func (s *Service) Broadcast(ctx context.Context, adminID pgtype.UUID, message string, duration time.Duration) error {
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return err
+	}
+	_, err := announce.Post(ctx, s.queries, message, duration)
+	return err
+}
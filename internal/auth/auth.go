@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/mail"
+	"github.com/brady1408/dnd/internal/sanitize"
 	"github.com/jackc/pgx/v5/pgtype"
 	"golang.org/x/crypto/bcrypt"
 	"golang.org/x/crypto/ssh"
@@ -16,11 +18,16 @@ var (
 	ErrUserNotFound       = errors.New("user not found")
 	ErrEmailTaken         = errors.New("email already registered")
 	ErrKeyTaken           = errors.New("SSH key already registered")
+	ErrAccountHasKey      = errors.New("this account already has a different SSH key")
 )
 
 // Service handles authentication
 type Service struct {
-	queries *db.Queries
+	queries    *db.Queries
+	inviteOnly bool
+	signInURL  string
+	shareURL   string
+	mailer     mail.Mailer
 }
 
 // NewService creates a new auth service
@@ -53,14 +60,21 @@ func isValidUUID(id pgtype.UUID) bool {
 	return id.Valid
 }
 
-// RegisterWithPassword registers a new user with email and password
-func (s *Service) RegisterWithPassword(ctx context.Context, email, password string) (*db.User, error) {
+// RegisterWithPassword registers a new user with email and password. The
+// invite code is only checked in invite-only mode.
+func (s *Service) RegisterWithPassword(ctx context.Context, email, password, inviteCode string) (*db.User, error) {
+	email = sanitize.Line(email, sanitize.MaxEmail)
+
 	// Check if email already exists
 	existing, err := s.queries.GetUserByEmail(ctx, pgtype.Text{String: email, Valid: true})
 	if err == nil && isValidUUID(existing.ID) {
 		return nil, ErrEmailTaken
 	}
 
+	if err := s.checkInvite(ctx, inviteCode); err != nil {
+		return nil, err
+	}
+
 	hash, err := HashPassword(password)
 	if err != nil {
 		return nil, err
@@ -74,11 +88,16 @@ func (s *Service) RegisterWithPassword(ctx context.Context, email, password stri
 		return nil, err
 	}
 
+	if err := s.claimInvite(ctx, inviteCode, &user); err != nil {
+		return nil, err
+	}
+
 	return &user, nil
 }
 
-// RegisterWithPublicKey registers a new user with SSH public key
-func (s *Service) RegisterWithPublicKey(ctx context.Context, key ssh.PublicKey) (*db.User, error) {
+// RegisterWithPublicKey registers a new user with SSH public key. The invite
+// code is only checked in invite-only mode.
+func (s *Service) RegisterWithPublicKey(ctx context.Context, key ssh.PublicKey, inviteCode string) (*db.User, error) {
 	keyStr := NormalizePublicKey(key)
 
 	// Check if key already exists
@@ -87,16 +106,25 @@ func (s *Service) RegisterWithPublicKey(ctx context.Context, key ssh.PublicKey)
 		return nil, ErrKeyTaken
 	}
 
+	if err := s.checkInvite(ctx, inviteCode); err != nil {
+		return nil, err
+	}
+
 	user, err := s.queries.CreateUserWithPublicKey(ctx, pgtype.Text{String: keyStr, Valid: true})
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.claimInvite(ctx, inviteCode, &user); err != nil {
+		return nil, err
+	}
+
 	return &user, nil
 }
 
 // LoginWithPassword authenticates a user with email and password
 func (s *Service) LoginWithPassword(ctx context.Context, email, password string) (*db.User, error) {
+	email = sanitize.Line(email, sanitize.MaxEmail)
 	user, err := s.queries.GetUserByEmail(ctx, pgtype.Text{String: email, Valid: true})
 	if err != nil {
 		return nil, ErrUserNotFound
@@ -105,6 +133,9 @@ func (s *Service) LoginWithPassword(ctx context.Context, email, password string)
 	if !user.PasswordHash.Valid || !CheckPassword(password, user.PasswordHash.String) {
 		return nil, ErrInvalidCredentials
 	}
+	if err := checkEnabled(&user); err != nil {
+		return nil, err
+	}
 
 	return &user, nil
 }
@@ -116,6 +147,9 @@ func (s *Service) LoginWithPublicKey(ctx context.Context, key ssh.PublicKey) (*d
 	if err != nil {
 		return nil, ErrUserNotFound
 	}
+	if err := checkEnabled(&user); err != nil {
+		return nil, err
+	}
 	return &user, nil
 }
 
@@ -145,8 +179,28 @@ func (s *Service) LinkPublicKey(ctx context.Context, userID pgtype.UUID, key ssh
 	return err
 }
 
+// LinkPublicKeyWithPassword attaches an SSH key to an email account after
+// checking its password, so a player who registered by email can log in
+// with their key from then on. Accounts keep one key, so one that already
+// has a different key is refused rather than losing it.
+func (s *Service) LinkPublicKeyWithPassword(ctx context.Context, email, password string, key ssh.PublicKey) (*db.User, error) {
+	user, err := s.LoginWithPassword(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+	if user.PublicKey.Valid && user.PublicKey.String != NormalizePublicKey(key) {
+		return nil, ErrAccountHasKey
+	}
+	if err := s.LinkPublicKey(ctx, user.ID, key); err != nil {
+		return nil, err
+	}
+	return s.GetUserByID(ctx, user.ID)
+}
+
 // UpdateEmail updates a user's email
 func (s *Service) UpdateEmail(ctx context.Context, userID pgtype.UUID, email string) error {
+	email = sanitize.Line(email, sanitize.MaxEmail)
+
 	// Check if email is taken
 	existing, err := s.queries.GetUserByEmail(ctx, pgtype.Text{String: email, Valid: true})
 	if err == nil && isValidUUID(existing.ID) && existing.ID != userID {
@@ -160,6 +214,43 @@ func (s *Service) UpdateEmail(ctx context.Context, userID pgtype.UUID, email str
 	return err
 }
 
+// SetUsageStats turns a user's opt-in to anonymous usage statistics on or off
+func (s *Service) SetUsageStats(ctx context.Context, userID pgtype.UUID, enabled bool) (*db.User, error) {
+	user, err := s.queries.UpdateUserUsageStats(ctx, db.UpdateUserUsageStatsParams{
+		ID:         userID,
+		UsageStats: enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SetTheme stores the name of a user's color scheme
+func (s *Service) SetTheme(ctx context.Context, userID pgtype.UUID, theme string) (*db.User, error) {
+	user, err := s.queries.UpdateUserTheme(ctx, db.UpdateUserThemeParams{
+		ID:    userID,
+		Theme: theme,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SetNotifications saves how a user wants to be alerted: bell, flash, or
+// silent
+func (s *Service) SetNotifications(ctx context.Context, userID pgtype.UUID, mode string) (*db.User, error) {
+	user, err := s.queries.UpdateUserNotifications(ctx, db.UpdateUserNotificationsParams{
+		ID:            userID,
+		Notifications: mode,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 // UpdatePassword updates a user's password
 func (s *Service) UpdatePassword(ctx context.Context, userID pgtype.UUID, password string) error {
 	hash, err := HashPassword(password)
@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/sanitize"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	ErrInvalidLinkCode = errors.New("invalid or expired link code")
+	ErrIdentityTaken   = errors.New("that account is already linked to another user")
+	ErrLinkNeedsKey    = errors.New("connect with an SSH key to create an account from a link code")
+)
+
+// LinkCodeTTL is how long a link code from the web sign-in flow stays valid
+const LinkCodeTTL = 10 * time.Minute
+
+// SetSignInURL sets the web sign-in page that issues link codes. Linking is
+// disabled while it is empty.
+func (s *Service) SetSignInURL(url string) {
+	s.signInURL = url
+}
+
+// SignInURL returns the web sign-in page, or "" when linking is disabled
+func (s *Service) SignInURL() string {
+	return s.signInURL
+}
+
+// IdentityLinking reports whether web sign-in is configured
+func (s *Service) IdentityLinking() bool {
+	return s.signInURL != ""
+}
+
+// CreateLinkCode issues a short-lived code for a verified external identity
+func (s *Service) CreateLinkCode(ctx context.Context, provider, subject, email string) (string, error) {
+	email = sanitize.Line(email, sanitize.MaxEmail)
+	_ = s.queries.DeleteExpiredLinkCodes(ctx)
+
+	code, err := generateCode()
+	if err != nil {
+		return "", err
+	}
+
+	err = s.queries.CreateLinkCode(ctx, db.CreateLinkCodeParams{
+		Code:      code,
+		Provider:  provider,
+		Subject:   subject,
+		Email:     pgtype.Text{String: email, Valid: email != ""},
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(LinkCodeTTL), Valid: true},
+	})
+	if err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// LoginWithLinkCode signs in the user linked to a code's identity. An
+// identity that isn't linked yet gets a new account registered with the
+// session's SSH key.
+//
+// It all happens in one transaction: a sign-in that fails, such as one
+// needing a key or an invite, leaves the code to be used again, and a new
+// account is never left without its identity.
+func (s *Service) LoginWithLinkCode(ctx context.Context, code string, key ssh.PublicKey) (*db.User, error) {
+	var user *db.User
+	err := s.queries.InTx(ctx, func(q *db.Queries) error {
+		tx := *s
+		tx.queries = q
+		var err error
+		user, err = tx.loginWithLinkCode(ctx, code, key)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *Service) loginWithLinkCode(ctx context.Context, code string, key ssh.PublicKey) (*db.User, error) {
+	link, err := s.queries.ConsumeLinkCode(ctx, NormalizeCode(code))
+	if err != nil {
+		return nil, ErrInvalidLinkCode
+	}
+
+	identity, err := s.queries.GetUserIdentity(ctx, db.GetUserIdentityParams{
+		Provider: link.Provider,
+		Subject:  link.Subject,
+	})
+	if err == nil {
+		user, err := s.GetUserByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkEnabled(user); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	if key == nil {
+		return nil, ErrLinkNeedsKey
+	}
+	if s.inviteOnly {
+		return nil, ErrInviteRequired
+	}
+
+	user, err := s.RegisterWithPublicKey(ctx, key, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.createIdentity(ctx, user.ID, link); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// LinkIdentity attaches a code's identity to an existing user
+func (s *Service) LinkIdentity(ctx context.Context, userID pgtype.UUID, code string) error {
+	link, err := s.queries.ConsumeLinkCode(ctx, NormalizeCode(code))
+	if err != nil {
+		return ErrInvalidLinkCode
+	}
+
+	identity, err := s.queries.GetUserIdentity(ctx, db.GetUserIdentityParams{
+		Provider: link.Provider,
+		Subject:  link.Subject,
+	})
+	if err == nil {
+		if identity.UserID == userID {
+			return nil
+		}
+		return ErrIdentityTaken
+	}
+
+	return s.createIdentity(ctx, userID, link)
+}
+
+// ListIdentities returns the external identities linked to a user
+func (s *Service) ListIdentities(ctx context.Context, userID pgtype.UUID) ([]db.UserIdentity, error) {
+	return s.queries.GetUserIdentitiesByUserID(ctx, userID)
+}
+
+func (s *Service) createIdentity(ctx context.Context, userID pgtype.UUID, link db.IdentityLinkCode) error {
+	_, err := s.queries.CreateUserIdentity(ctx, db.CreateUserIdentityParams{
+		UserID:   userID,
+		Provider: link.Provider,
+		Subject:  link.Subject,
+		Email:    link.Email,
+	})
+	return err
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrInviteRequired = errors.New("an invite code is required to register")
+	ErrInvalidInvite  = errors.New("invalid or already used invite code")
+)
+
+// codeAlphabet leaves out characters that are easy to misread
+const codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// SetInviteOnly controls whether registration requires an invite code
+func (s *Service) SetInviteOnly(enabled bool) {
+	s.inviteOnly = enabled
+}
+
+// InviteOnly reports whether registration requires an invite code
+func (s *Service) InviteOnly() bool {
+	return s.inviteOnly
+}
+
+// NormalizeCode uppercases an invite or link code and trims surrounding space
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// generateCode creates a code like ABCD-EFGH for invites, link codes, and
+// password resets
+func generateCode() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for i, c := range buf {
+		if i == 4 {
+			b.WriteByte('-')
+		}
+		b.WriteByte(codeAlphabet[int(c)%len(codeAlphabet)])
+	}
+	return b.String(), nil
+}
+
+// CreateInvite issues a new invite code. Pass an invalid createdBy for
+// codes issued by the server operator.
+func (s *Service) CreateInvite(ctx context.Context, createdBy pgtype.UUID) (*db.Invite, error) {
+	code, err := generateCode()
+	if err != nil {
+		return nil, err
+	}
+
+	invite, err := s.queries.CreateInvite(ctx, db.CreateInviteParams{
+		Code:      code,
+		CreatedBy: createdBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// ListInvites returns the invites a user has created
+func (s *Service) ListInvites(ctx context.Context, userID pgtype.UUID) ([]db.Invite, error) {
+	return s.queries.GetInvitesByCreator(ctx, userID)
+}
+
+// checkInvite verifies an invite code can be used, when invites are required
+func (s *Service) checkInvite(ctx context.Context, code string) error {
+	if !s.inviteOnly {
+		return nil
+	}
+	code = NormalizeCode(code)
+	if code == "" {
+		return ErrInviteRequired
+	}
+	if _, err := s.queries.GetUnusedInviteByCode(ctx, code); err != nil {
+		return ErrInvalidInvite
+	}
+	return nil
+}
+
+// claimInvite marks an invite used by a newly registered user. If another
+// registration claimed it first, the new user is removed again.
+func (s *Service) claimInvite(ctx context.Context, code string, user *db.User) error {
+	if !s.inviteOnly {
+		return nil
+	}
+	_, err := s.queries.ClaimInvite(ctx, db.ClaimInviteParams{
+		Code:   NormalizeCode(code),
+		UsedBy: user.ID,
+	})
+	if err != nil {
+		_ = s.queries.DeleteUser(ctx, user.ID)
+		return ErrInvalidInvite
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/mail"
+	"github.com/brady1408/dnd/internal/sanitize"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrInvalidResetCode = errors.New("invalid or expired reset code")
+
+// PasswordResetTTL is how long an emailed reset code stays valid
+const PasswordResetTTL = 30 * time.Minute
+
+// passwordResetCooldown is how soon after one code another can be sent to
+// the same account, so the form can't be used to flood someone's inbox
+const passwordResetCooldown = time.Minute
+
+// SetMailer sets how password reset codes are emailed. Resets are disabled
+// while there's no mailer.
+func (s *Service) SetMailer(m mail.Mailer) {
+	s.mailer = m
+}
+
+// PasswordResets reports whether players can reset a forgotten password
+func (s *Service) PasswordResets() bool {
+	return s.mailer != nil
+}
+
+// RequestPasswordReset emails a one-time reset code to the account with
+// this email. It succeeds whether or not there is one, so the form doesn't
+// reveal who has an account; failures to send are only logged.
+func (s *Service) RequestPasswordReset(ctx context.Context, email string) error {
+	if s.mailer == nil {
+		return errors.New("password resets are not available on this server")
+	}
+	email = sanitize.Line(email, sanitize.MaxEmail)
+	_ = s.queries.DeleteExpiredPasswordResets(ctx)
+
+	user, err := s.queries.GetUserByEmail(ctx, pgtype.Text{String: email, Valid: true})
+	if err != nil || !user.PasswordHash.Valid || checkEnabled(&user) != nil {
+		return nil
+	}
+	if last, err := s.queries.GetPasswordResetByUserID(ctx, user.ID); err == nil && time.Since(last.CreatedAt.Time) < passwordResetCooldown {
+		return nil
+	}
+
+	code, err := generateCode()
+	if err != nil {
+		return err
+	}
+	// The code is hashed like an API token; a new code replaces any earlier one
+	err = s.queries.UpsertPasswordReset(ctx, db.UpsertPasswordResetParams{
+		TokenHash: HashAPIToken(code),
+		UserID:    user.ID,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(PasswordResetTTL), Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Someone asked to reset the password for your D&D character sheet account.\n\n"+
+		"Your reset code is: %s\n\n"+
+		"Connect over SSH, choose Forgot Password, and enter this code with a new password. "+
+		"It works once and expires in %d minutes.\n\n"+
+		"If you didn't ask for this, you can ignore this email; your password hasn't changed.\n",
+		code, int(PasswordResetTTL.Minutes()))
+	if err := s.mailer.Send(ctx, email, "Your password reset code", body); err != nil {
+		log.Printf("Failed to send password reset email: %v", err)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password with an emailed reset code, returning
+// the user it belongs to. The code can't be used again, and doesn't work
+// for an account disabled since it was sent.
+func (s *Service) ResetPassword(ctx context.Context, code, password string) (*db.User, error) {
+	reset, err := s.queries.ConsumePasswordReset(ctx, HashAPIToken(NormalizeCode(code)))
+	if err != nil {
+		return nil, ErrInvalidResetCode
+	}
+	user, err := s.GetUserByID(ctx, reset.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEnabled(user); err != nil {
+		return nil, err
+	}
+	if err := s.UpdatePassword(ctx, reset.UserID, password); err != nil {
+		return nil, err
+	}
+	return s.GetUserByID(ctx, reset.UserID)
+}
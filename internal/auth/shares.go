@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrInvalidShareLink = errors.New("invalid or expired share link")
+
+// ShareLinkTTL is how long a read-only sheet link stays valid, long enough
+// for a session at the table
+const ShareLinkTTL = 6 * time.Hour
+
+// SetShareURL sets the base URL of the read-only sheet pages. Sharing is
+// disabled while it is empty.
+func (s *Service) SetShareURL(url string) {
+	s.shareURL = url
+}
+
+// Sharing reports whether read-only sheet links can be created
+func (s *Service) Sharing() bool {
+	return s.shareURL != ""
+}
+
+// CreateShareLink issues a read-only link to one of a user's characters and
+// returns its URL. Only a hash of the token is stored.
+func (s *Service) CreateShareLink(ctx context.Context, userID, characterID pgtype.UUID) (string, time.Time, error) {
+	if !s.Sharing() {
+		return "", time.Time{}, errors.New("sharing is not configured")
+	}
+	_ = s.queries.DeleteExpiredShareLinks(ctx)
+
+	// Short tokens keep the QR code small enough to scan from a terminal
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	expires := time.Now().Add(ShareLinkTTL)
+
+	err := s.queries.CreateShareLink(ctx, db.CreateShareLinkParams{
+		TokenHash:   HashAPIToken(token),
+		CharacterID: characterID,
+		UserID:      userID,
+		ExpiresAt:   pgtype.Timestamptz{Time: expires, Valid: true},
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return s.shareURL + "/" + token, expires, nil
+}
+
+// SharedCharacter returns the character a share link points to
+func (s *Service) SharedCharacter(ctx context.Context, token string) (db.Character, error) {
+	link, err := s.queries.GetShareLink(ctx, HashAPIToken(token))
+	if err != nil {
+		return db.Character{}, ErrInvalidShareLink
+	}
+	char, err := s.queries.GetCharacterByID(ctx, link.CharacterID)
+	if err != nil || char.UserID != link.UserID {
+		return db.Character{}, ErrInvalidShareLink
+	}
+	return char, nil
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/sanitize"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// APITokenPrefix marks strings that are API tokens
+const APITokenPrefix = "dnd_"
+
+var ErrInvalidToken = errors.New("invalid API token")
+
+// HashAPIToken returns the stored hash for an API token
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateAPIToken creates a new random API token
+func generateAPIToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return APITokenPrefix + hex.EncodeToString(buf), nil
+}
+
+// CreateAPIToken issues a new API token for a user. The plaintext token is
+// returned only once; only its hash is stored.
+func (s *Service) CreateAPIToken(ctx context.Context, userID pgtype.UUID, name string) (string, *db.ApiToken, error) {
+	name = sanitize.Line(name, sanitize.MaxName)
+	token, err := generateAPIToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	record, err := s.queries.CreateAPIToken(ctx, db.CreateAPITokenParams{
+		UserID:    userID,
+		Name:      name,
+		TokenHash: HashAPIToken(token),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, &record, nil
+}
+
+// AuthenticateAPIToken returns the user that owns a token
+func (s *Service) AuthenticateAPIToken(ctx context.Context, token string) (*db.User, error) {
+	if !strings.HasPrefix(token, APITokenPrefix) {
+		return nil, ErrInvalidToken
+	}
+
+	record, err := s.queries.GetAPITokenByHash(ctx, HashAPIToken(token))
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.queries.GetUserByID(ctx, record.UserID)
+	if err != nil || checkEnabled(&user) != nil {
+		return nil, ErrInvalidToken
+	}
+
+	_ = s.queries.TouchAPIToken(ctx, record.ID)
+	return &user, nil
+}
+
+// RevokeAPIToken deletes one of a user's API tokens
+func (s *Service) RevokeAPIToken(ctx context.Context, userID, tokenID pgtype.UUID) error {
+	return s.queries.DeleteAPIToken(ctx, db.DeleteAPITokenParams{
+		ID:     tokenID,
+		UserID: userID,
+	})
+}
+
+// ListAPITokens returns a user's API tokens
+func (s *Service) ListAPITokens(ctx context.Context, userID pgtype.UUID) ([]db.ApiToken, error) {
+	return s.queries.GetAPITokensByUserID(ctx, userID)
+}
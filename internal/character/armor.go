@@ -0,0 +1,168 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ArmorType determines how much Dexterity adds to armor
+type ArmorType int
+
+const (
+	LightArmor ArmorType = iota
+	MediumArmor
+	HeavyArmor
+	ShieldArmor
+)
+
+// mediumArmorDexCap is the most Dexterity can add while wearing medium armor
+const mediumArmorDexCap = 2
+
+// Armor is a suit of armor or a shield. For shields, BaseAC is the bonus.
+type Armor struct {
+	Name                string
+	Type                ArmorType
+	BaseAC              int
+	Strength            int // Minimum Strength to avoid the speed penalty
+	StealthDisadvantage bool
+}
+
+// ArmorTable lists the SRD armor and shield
+var ArmorTable = []Armor{
+	{Name: "Padded", Type: LightArmor, BaseAC: 11, StealthDisadvantage: true},
+	{Name: "Leather", Type: LightArmor, BaseAC: 11},
+	{Name: "Studded Leather", Type: LightArmor, BaseAC: 12},
+	{Name: "Hide", Type: MediumArmor, BaseAC: 12},
+	{Name: "Chain Shirt", Type: MediumArmor, BaseAC: 13},
+	{Name: "Scale Mail", Type: MediumArmor, BaseAC: 14, StealthDisadvantage: true},
+	{Name: "Breastplate", Type: MediumArmor, BaseAC: 14},
+	{Name: "Half Plate", Type: MediumArmor, BaseAC: 15, StealthDisadvantage: true},
+	{Name: "Ring Mail", Type: HeavyArmor, BaseAC: 14, StealthDisadvantage: true},
+	{Name: "Chain Mail", Type: HeavyArmor, BaseAC: 16, Strength: 13, StealthDisadvantage: true},
+	{Name: "Splint", Type: HeavyArmor, BaseAC: 17, Strength: 15, StealthDisadvantage: true},
+	{Name: "Plate", Type: HeavyArmor, BaseAC: 18, Strength: 15, StealthDisadvantage: true},
+	{Name: "Shield", Type: ShieldArmor, BaseAC: 2},
+}
+
+// FindArmor looks up armor by item name, ignoring case. Names like
+// "Leather Armor" and "Plate Armor" match their table entries.
+func FindArmor(name string) (Armor, bool) {
+	name = strings.TrimSpace(name)
+	for _, a := range ArmorTable {
+		if strings.EqualFold(name, a.Name) || strings.EqualFold(name, a.Name+" Armor") {
+			return a, true
+		}
+	}
+	return Armor{}, false
+}
+
+// ArmorClassInput is what a character's AC depends on
+type ArmorClassInput struct {
+	Class        string
+	Strength     int
+	Dexterity    int
+	Constitution int
+	Wisdom       int
+	Equipped     []string // Names of equipped items; anything that isn't armor is ignored
+	Override     int      // Manually set AC, or zero to calculate it
+}
+
+// ArmorClass is a calculated AC with the pieces that make it up
+type ArmorClass struct {
+	Total      int
+	Parts      []string // e.g. "Chain Mail 16", "Shield +2"
+	Notes      []string // Side effects of the armor worn, such as stealth disadvantage
+	Overridden bool
+}
+
+// Breakdown joins the parts, e.g. "Chain Mail 16 + Shield +2"
+func (ac ArmorClass) Breakdown() string {
+	if ac.Overridden {
+		return "set manually"
+	}
+	return strings.Join(ac.Parts, " + ")
+}
+
+// CalculateArmorClass works out AC from equipped armor and shield. Without
+// armor, barbarians and monks use Unarmored Defense. A manual override
+// replaces the calculation but keeps the armor notes.
+func CalculateArmorClass(in ArmorClassInput) ArmorClass {
+	var armor, shield *Armor
+	for _, name := range in.Equipped {
+		a, ok := FindArmor(name)
+		if !ok {
+			continue
+		}
+		if a.Type == ShieldArmor {
+			shield = &a
+		} else if armor == nil || a.BaseAC > armor.BaseAC {
+			armor = &a
+		}
+	}
+
+	dex := AbilityModifier(in.Dexterity)
+	var ac ArmorClass
+	switch {
+	case armor != nil:
+		ac.Total = armor.BaseAC
+		ac.Parts = append(ac.Parts, fmt.Sprintf("%s %d", armor.Name, armor.BaseAC))
+		switch armor.Type {
+		case LightArmor:
+			ac.addModifier(dex, "DEX")
+		case MediumArmor:
+			ac.addModifier(min(dex, mediumArmorDexCap), fmt.Sprintf("DEX (max %d)", mediumArmorDexCap))
+		}
+		if armor.StealthDisadvantage {
+			ac.Notes = append(ac.Notes, "Disadvantage on Stealth checks")
+		}
+		if armor.Strength > in.Strength {
+			ac.Notes = append(ac.Notes, fmt.Sprintf("Speed -10 ft (needs Strength %d)", armor.Strength))
+		}
+	case in.Class == "Barbarian":
+		ac.Total = 10
+		ac.Parts = append(ac.Parts, "Unarmored 10")
+		ac.addModifier(dex, "DEX")
+		ac.addModifier(AbilityModifier(in.Constitution), "CON")
+	case in.Class == "Monk" && shield == nil:
+		ac.Total = 10
+		ac.Parts = append(ac.Parts, "Unarmored 10")
+		ac.addModifier(dex, "DEX")
+		ac.addModifier(AbilityModifier(in.Wisdom), "WIS")
+	default:
+		ac.Total = 10
+		ac.Parts = append(ac.Parts, "Base 10")
+		ac.addModifier(dex, "DEX")
+	}
+
+	if shield != nil {
+		ac.Total += shield.BaseAC
+		ac.Parts = append(ac.Parts, fmt.Sprintf("%s +%d", shield.Name, shield.BaseAC))
+	}
+
+	if in.Override > 0 {
+		ac.Total = in.Override
+		ac.Overridden = true
+	}
+	return ac
+}
+
+// InitializeArmorClass sets AC from the character's equipment
+func (c *Character) InitializeArmorClass() {
+	c.ArmorClass = CalculateArmorClass(ArmorClassInput{
+		Class:        c.Class,
+		Strength:     c.Strength,
+		Dexterity:    c.Dexterity,
+		Constitution: c.Constitution,
+		Wisdom:       c.Wisdom,
+		Equipped:     c.Equipment,
+	}).Total
+}
+
+// addModifier adds an ability modifier to the total, skipping zero modifiers
+func (ac *ArmorClass) addModifier(mod int, label string) {
+	if mod == 0 {
+		return
+	}
+	ac.Total += mod
+	ac.Parts = append(ac.Parts, fmt.Sprintf("%s %s", label, FormatModifierInt(mod)))
+}
@@ -166,6 +166,16 @@ var RaceSpeed = map[string]int{
 	"Tiefling":   30,
 }
 
+// RaceDarkvision maps races that see in the dark to how far, in feet
+var RaceDarkvision = map[string]int{
+	"Dwarf":    60,
+	"Elf":      60,
+	"Gnome":    60,
+	"Half-Elf": 60,
+	"Half-Orc": 60,
+	"Tiefling": 60,
+}
+
 // Character represents a D&D 5e character
 type Character struct {
 	// Basic Info
@@ -191,6 +201,7 @@ type Character struct {
 	TemporaryHitPoints int
 	ArmorClass         int
 	Speed              int
+	Darkvision         int
 
 	// Proficiencies
 	SavingThrowProficiencies []string
@@ -230,6 +241,7 @@ func (c *Character) SetRace(race string) {
 	if speed, ok := RaceSpeed[race]; ok {
 		c.Speed = speed
 	}
+	c.Darkvision = RaceDarkvision[race]
 }
 
 // CalculateMaxHP calculates max HP for level 1
@@ -0,0 +1,381 @@
+package character
+
+// ClassLevel is one row of a class progression table
+type ClassLevel struct {
+	Features []string
+	Columns  []string // values for the class's extra columns
+}
+
+// ClassTable is a class's level 1-20 progression from the SRD
+type ClassTable struct {
+	Columns []string // class-specific columns, such as Rages or Ki Points
+	Levels  []ClassLevel
+}
+
+// asi is the feature name used on ability score improvement levels
+const asi = "Ability Score Improvement"
+
+// ClassTables maps class to its progression table
+var ClassTables = map[string]ClassTable{
+	"Barbarian": {
+		Columns: []string{"Rages", "Rage Damage"},
+		Levels: []ClassLevel{
+			{[]string{"Rage", "Unarmored Defense"}, []string{"2", "+2"}},
+			{[]string{"Reckless Attack", "Danger Sense"}, []string{"2", "+2"}},
+			{[]string{"Primal Path"}, []string{"3", "+2"}},
+			{[]string{asi}, []string{"3", "+2"}},
+			{[]string{"Extra Attack", "Fast Movement"}, []string{"3", "+2"}},
+			{[]string{"Path feature"}, []string{"4", "+2"}},
+			{[]string{"Feral Instinct"}, []string{"4", "+2"}},
+			{[]string{asi}, []string{"4", "+2"}},
+			{[]string{"Brutal Critical (1 die)"}, []string{"4", "+3"}},
+			{[]string{"Path feature"}, []string{"4", "+3"}},
+			{[]string{"Relentless Rage"}, []string{"4", "+3"}},
+			{[]string{asi}, []string{"5", "+3"}},
+			{[]string{"Brutal Critical (2 dice)"}, []string{"5", "+3"}},
+			{[]string{"Path feature"}, []string{"5", "+3"}},
+			{[]string{"Persistent Rage"}, []string{"5", "+3"}},
+			{[]string{asi}, []string{"5", "+4"}},
+			{[]string{"Brutal Critical (3 dice)"}, []string{"6", "+4"}},
+			{[]string{"Indomitable Might"}, []string{"6", "+4"}},
+			{[]string{asi}, []string{"6", "+4"}},
+			{[]string{"Primal Champion"}, []string{"Unlimited", "+4"}},
+		},
+	},
+	"Bard": {
+		Columns: []string{"Inspiration", "Cantrips", "Spells Known"},
+		Levels: []ClassLevel{
+			{[]string{"Spellcasting", "Bardic Inspiration"}, []string{"d6", "2", "4"}},
+			{[]string{"Jack of All Trades", "Song of Rest (d6)"}, []string{"d6", "2", "5"}},
+			{[]string{"Bard College", "Expertise"}, []string{"d6", "2", "6"}},
+			{[]string{asi}, []string{"d6", "3", "7"}},
+			{[]string{"Bardic Inspiration (d8)", "Font of Inspiration"}, []string{"d8", "3", "8"}},
+			{[]string{"Countercharm", "Bard College feature"}, []string{"d8", "3", "9"}},
+			{nil, []string{"d8", "3", "10"}},
+			{[]string{asi}, []string{"d8", "3", "11"}},
+			{[]string{"Song of Rest (d8)"}, []string{"d8", "3", "12"}},
+			{[]string{"Bardic Inspiration (d10)", "Expertise", "Magical Secrets"}, []string{"d10", "4", "14"}},
+			{nil, []string{"d10", "4", "15"}},
+			{[]string{asi}, []string{"d10", "4", "15"}},
+			{[]string{"Song of Rest (d10)"}, []string{"d10", "4", "16"}},
+			{[]string{"Magical Secrets", "Bard College feature"}, []string{"d10", "4", "18"}},
+			{[]string{"Bardic Inspiration (d12)"}, []string{"d12", "4", "19"}},
+			{[]string{asi}, []string{"d12", "4", "19"}},
+			{[]string{"Song of Rest (d12)"}, []string{"d12", "4", "20"}},
+			{[]string{"Magical Secrets"}, []string{"d12", "4", "22"}},
+			{[]string{asi}, []string{"d12", "4", "22"}},
+			{[]string{"Superior Inspiration"}, []string{"d12", "4", "22"}},
+		},
+	},
+	"Cleric": {
+		Columns: []string{"Cantrips", "Channel Divinity"},
+		Levels: []ClassLevel{
+			{[]string{"Spellcasting", "Divine Domain"}, []string{"3", "-"}},
+			{[]string{"Channel Divinity", "Divine Domain feature"}, []string{"3", "1/rest"}},
+			{nil, []string{"3", "1/rest"}},
+			{[]string{asi}, []string{"4", "1/rest"}},
+			{[]string{"Destroy Undead (CR 1/2)"}, []string{"4", "1/rest"}},
+			{[]string{"Channel Divinity (2/rest)", "Divine Domain feature"}, []string{"4", "2/rest"}},
+			{nil, []string{"4", "2/rest"}},
+			{[]string{asi, "Destroy Undead (CR 1)", "Divine Domain feature"}, []string{"4", "2/rest"}},
+			{nil, []string{"4", "2/rest"}},
+			{[]string{"Divine Intervention"}, []string{"5", "2/rest"}},
+			{[]string{"Destroy Undead (CR 2)"}, []string{"5", "2/rest"}},
+			{[]string{asi}, []string{"5", "2/rest"}},
+			{nil, []string{"5", "2/rest"}},
+			{[]string{"Destroy Undead (CR 3)"}, []string{"5", "2/rest"}},
+			{nil, []string{"5", "2/rest"}},
+			{[]string{asi}, []string{"5", "2/rest"}},
+			{[]string{"Destroy Undead (CR 4)", "Divine Domain feature"}, []string{"5", "2/rest"}},
+			{[]string{"Channel Divinity (3/rest)"}, []string{"5", "3/rest"}},
+			{[]string{asi}, []string{"5", "3/rest"}},
+			{[]string{"Divine Intervention improvement"}, []string{"5", "3/rest"}},
+		},
+	},
+	"Druid": {
+		Columns: []string{"Cantrips"},
+		Levels: []ClassLevel{
+			{[]string{"Druidic", "Spellcasting"}, []string{"2"}},
+			{[]string{"Wild Shape", "Druid Circle"}, []string{"2"}},
+			{nil, []string{"2"}},
+			{[]string{"Wild Shape improvement", asi}, []string{"3"}},
+			{nil, []string{"3"}},
+			{[]string{"Druid Circle feature"}, []string{"3"}},
+			{nil, []string{"3"}},
+			{[]string{"Wild Shape improvement", asi}, []string{"3"}},
+			{nil, []string{"3"}},
+			{[]string{"Druid Circle feature"}, []string{"4"}},
+			{nil, []string{"4"}},
+			{[]string{asi}, []string{"4"}},
+			{nil, []string{"4"}},
+			{[]string{"Druid Circle feature"}, []string{"4"}},
+			{nil, []string{"4"}},
+			{[]string{asi}, []string{"4"}},
+			{nil, []string{"4"}},
+			{[]string{"Timeless Body", "Beast Spells"}, []string{"4"}},
+			{[]string{asi}, []string{"4"}},
+			{[]string{"Archdruid"}, []string{"4"}},
+		},
+	},
+	"Fighter": {
+		Levels: []ClassLevel{
+			{[]string{"Fighting Style", "Second Wind"}, nil},
+			{[]string{"Action Surge (one use)"}, nil},
+			{[]string{"Martial Archetype"}, nil},
+			{[]string{asi}, nil},
+			{[]string{"Extra Attack"}, nil},
+			{[]string{asi}, nil},
+			{[]string{"Martial Archetype feature"}, nil},
+			{[]string{asi}, nil},
+			{[]string{"Indomitable (one use)"}, nil},
+			{[]string{"Martial Archetype feature"}, nil},
+			{[]string{"Extra Attack (2)"}, nil},
+			{[]string{asi}, nil},
+			{[]string{"Indomitable (two uses)"}, nil},
+			{[]string{asi}, nil},
+			{[]string{"Martial Archetype feature"}, nil},
+			{[]string{asi}, nil},
+			{[]string{"Action Surge (two uses)", "Indomitable (three uses)"}, nil},
+			{[]string{"Martial Archetype feature"}, nil},
+			{[]string{asi}, nil},
+			{[]string{"Extra Attack (3)"}, nil},
+		},
+	},
+	"Monk": {
+		Columns: []string{"Martial Arts", "Ki", "Movement"},
+		Levels: []ClassLevel{
+			{[]string{"Unarmored Defense", "Martial Arts"}, []string{"1d4", "-", "-"}},
+			{[]string{"Ki", "Unarmored Movement"}, []string{"1d4", "2", "+10 ft."}},
+			{[]string{"Monastic Tradition", "Deflect Missiles"}, []string{"1d4", "3", "+10 ft."}},
+			{[]string{asi, "Slow Fall"}, []string{"1d4", "4", "+10 ft."}},
+			{[]string{"Extra Attack", "Stunning Strike"}, []string{"1d6", "5", "+10 ft."}},
+			{[]string{"Ki-Empowered Strikes", "Monastic Tradition feature"}, []string{"1d6", "6", "+15 ft."}},
+			{[]string{"Evasion", "Stillness of Mind"}, []string{"1d6", "7", "+15 ft."}},
+			{[]string{asi}, []string{"1d6", "8", "+15 ft."}},
+			{[]string{"Unarmored Movement improvement"}, []string{"1d6", "9", "+15 ft."}},
+			{[]string{"Purity of Body"}, []string{"1d6", "10", "+20 ft."}},
+			{[]string{"Monastic Tradition feature"}, []string{"1d8", "11", "+20 ft."}},
+			{[]string{asi}, []string{"1d8", "12", "+20 ft."}},
+			{[]string{"Tongue of the Sun and Moon"}, []string{"1d8", "13", "+20 ft."}},
+			{[]string{"Diamond Soul"}, []string{"1d8", "14", "+25 ft."}},
+			{[]string{"Timeless Body"}, []string{"1d8", "15", "+25 ft."}},
+			{[]string{asi}, []string{"1d8", "16", "+25 ft."}},
+			{[]string{"Monastic Tradition feature"}, []string{"1d10", "17", "+25 ft."}},
+			{[]string{"Empty Body"}, []string{"1d10", "18", "+30 ft."}},
+			{[]string{asi}, []string{"1d10", "19", "+30 ft."}},
+			{[]string{"Perfect Self"}, []string{"1d10", "20", "+30 ft."}},
+		},
+	},
+	"Paladin": {
+		Levels: []ClassLevel{
+			{[]string{"Divine Sense", "Lay on Hands"}, nil},
+			{[]string{"Fighting Style", "Spellcasting", "Divine Smite"}, nil},
+			{[]string{"Divine Health", "Sacred Oath"}, nil},
+			{[]string{asi}, nil},
+			{[]string{"Extra Attack"}, nil},
+			{[]string{"Aura of Protection"}, nil},
+			{[]string{"Sacred Oath feature"}, nil},
+			{[]string{asi}, nil},
+			{nil, nil},
+			{[]string{"Aura of Courage"}, nil},
+			{[]string{"Improved Divine Smite"}, nil},
+			{[]string{asi}, nil},
+			{nil, nil},
+			{[]string{"Cleansing Touch"}, nil},
+			{[]string{"Sacred Oath feature"}, nil},
+			{[]string{asi}, nil},
+			{nil, nil},
+			{[]string{"Aura improvements"}, nil},
+			{[]string{asi}, nil},
+			{[]string{"Sacred Oath feature"}, nil},
+		},
+	},
+	"Ranger": {
+		Columns: []string{"Spells Known"},
+		Levels: []ClassLevel{
+			{[]string{"Favored Enemy", "Natural Explorer"}, []string{"-"}},
+			{[]string{"Fighting Style", "Spellcasting"}, []string{"2"}},
+			{[]string{"Ranger Archetype", "Primeval Awareness"}, []string{"3"}},
+			{[]string{asi}, []string{"3"}},
+			{[]string{"Extra Attack"}, []string{"4"}},
+			{[]string{"Favored Enemy and Natural Explorer improvements"}, []string{"4"}},
+			{[]string{"Ranger Archetype feature"}, []string{"5"}},
+			{[]string{asi, "Land's Stride"}, []string{"5"}},
+			{nil, []string{"6"}},
+			{[]string{"Natural Explorer improvement", "Hide in Plain Sight"}, []string{"6"}},
+			{[]string{"Ranger Archetype feature"}, []string{"7"}},
+			{[]string{asi}, []string{"7"}},
+			{nil, []string{"8"}},
+			{[]string{"Favored Enemy improvement", "Vanish"}, []string{"8"}},
+			{[]string{"Ranger Archetype feature"}, []string{"9"}},
+			{[]string{asi}, []string{"9"}},
+			{nil, []string{"10"}},
+			{[]string{"Feral Senses"}, []string{"10"}},
+			{[]string{asi}, []string{"11"}},
+			{[]string{"Foe Slayer"}, []string{"11"}},
+		},
+	},
+	"Rogue": {
+		Columns: []string{"Sneak Attack"},
+		Levels: []ClassLevel{
+			{[]string{"Expertise", "Sneak Attack", "Thieves' Cant"}, []string{"1d6"}},
+			{[]string{"Cunning Action"}, []string{"1d6"}},
+			{[]string{"Roguish Archetype"}, []string{"2d6"}},
+			{[]string{asi}, []string{"2d6"}},
+			{[]string{"Uncanny Dodge"}, []string{"3d6"}},
+			{[]string{"Expertise"}, []string{"3d6"}},
+			{[]string{"Evasion"}, []string{"4d6"}},
+			{[]string{asi}, []string{"4d6"}},
+			{[]string{"Roguish Archetype feature"}, []string{"5d6"}},
+			{[]string{asi}, []string{"5d6"}},
+			{[]string{"Reliable Talent"}, []string{"6d6"}},
+			{[]string{asi}, []string{"6d6"}},
+			{[]string{"Roguish Archetype feature"}, []string{"7d6"}},
+			{[]string{"Blindsense"}, []string{"7d6"}},
+			{[]string{"Slippery Mind"}, []string{"8d6"}},
+			{[]string{asi}, []string{"8d6"}},
+			{[]string{"Roguish Archetype feature"}, []string{"9d6"}},
+			{[]string{"Elusive"}, []string{"9d6"}},
+			{[]string{asi}, []string{"10d6"}},
+			{[]string{"Stroke of Luck"}, []string{"10d6"}},
+		},
+	},
+	"Sorcerer": {
+		Columns: []string{"Sorcery Points", "Cantrips", "Spells Known"},
+		Levels: []ClassLevel{
+			{[]string{"Spellcasting", "Sorcerous Origin"}, []string{"-", "4", "2"}},
+			{[]string{"Font of Magic"}, []string{"2", "4", "3"}},
+			{[]string{"Metamagic"}, []string{"3", "4", "4"}},
+			{[]string{asi}, []string{"4", "5", "5"}},
+			{nil, []string{"5", "5", "6"}},
+			{[]string{"Sorcerous Origin feature"}, []string{"6", "5", "7"}},
+			{nil, []string{"7", "5", "8"}},
+			{[]string{asi}, []string{"8", "5", "9"}},
+			{nil, []string{"9", "5", "10"}},
+			{[]string{"Metamagic"}, []string{"10", "6", "11"}},
+			{nil, []string{"11", "6", "12"}},
+			{[]string{asi}, []string{"12", "6", "12"}},
+			{nil, []string{"13", "6", "13"}},
+			{[]string{"Sorcerous Origin feature"}, []string{"14", "6", "13"}},
+			{nil, []string{"15", "6", "14"}},
+			{[]string{asi}, []string{"16", "6", "14"}},
+			{[]string{"Metamagic"}, []string{"17", "6", "15"}},
+			{[]string{"Sorcerous Origin feature"}, []string{"18", "6", "15"}},
+			{[]string{asi}, []string{"19", "6", "15"}},
+			{[]string{"Sorcerous Restoration"}, []string{"20", "6", "15"}},
+		},
+	},
+	"Warlock": {
+		Columns: []string{"Cantrips", "Spells Known", "Invocations"},
+		Levels: []ClassLevel{
+			{[]string{"Otherworldly Patron", "Pact Magic"}, []string{"2", "2", "-"}},
+			{[]string{"Eldritch Invocations"}, []string{"2", "3", "2"}},
+			{[]string{"Pact Boon"}, []string{"2", "4", "2"}},
+			{[]string{asi}, []string{"3", "5", "2"}},
+			{nil, []string{"3", "6", "3"}},
+			{[]string{"Otherworldly Patron feature"}, []string{"3", "7", "3"}},
+			{nil, []string{"3", "8", "4"}},
+			{[]string{asi}, []string{"3", "9", "4"}},
+			{nil, []string{"3", "10", "5"}},
+			{[]string{"Otherworldly Patron feature"}, []string{"4", "10", "5"}},
+			{[]string{"Mystic Arcanum (6th level)"}, []string{"4", "11", "5"}},
+			{[]string{asi}, []string{"4", "11", "6"}},
+			{[]string{"Mystic Arcanum (7th level)"}, []string{"4", "12", "6"}},
+			{[]string{"Otherworldly Patron feature"}, []string{"4", "12", "6"}},
+			{[]string{"Mystic Arcanum (8th level)"}, []string{"4", "13", "7"}},
+			{[]string{asi}, []string{"4", "13", "7"}},
+			{[]string{"Mystic Arcanum (9th level)"}, []string{"4", "14", "7"}},
+			{nil, []string{"4", "14", "8"}},
+			{[]string{asi}, []string{"4", "15", "8"}},
+			{[]string{"Eldritch Master"}, []string{"4", "15", "8"}},
+		},
+	},
+	"Wizard": {
+		Columns: []string{"Cantrips"},
+		Levels: []ClassLevel{
+			{[]string{"Spellcasting", "Arcane Recovery"}, []string{"3"}},
+			{[]string{"Arcane Tradition"}, []string{"3"}},
+			{nil, []string{"3"}},
+			{[]string{asi}, []string{"4"}},
+			{nil, []string{"4"}},
+			{[]string{"Arcane Tradition feature"}, []string{"4"}},
+			{nil, []string{"4"}},
+			{[]string{asi}, []string{"4"}},
+			{nil, []string{"4"}},
+			{[]string{"Arcane Tradition feature"}, []string{"5"}},
+			{nil, []string{"5"}},
+			{[]string{asi}, []string{"5"}},
+			{nil, []string{"5"}},
+			{[]string{"Arcane Tradition feature"}, []string{"5"}},
+			{nil, []string{"5"}},
+			{[]string{asi}, []string{"5"}},
+			{nil, []string{"5"}},
+			{[]string{"Spell Mastery"}, []string{"5"}},
+			{[]string{asi}, []string{"5"}},
+			{[]string{"Signature Spells"}, []string{"5"}},
+		},
+	},
+}
+
+// fullCasterSlots are spell slots per spell level (index 0 = 1st) for each
+// full caster level (index 0 = level 1)
+var fullCasterSlots = [MaxLevel][9]int{
+	{2},
+	{3},
+	{4, 2},
+	{4, 3},
+	{4, 3, 2},
+	{4, 3, 3},
+	{4, 3, 3, 1},
+	{4, 3, 3, 2},
+	{4, 3, 3, 3, 1},
+	{4, 3, 3, 3, 2},
+	{4, 3, 3, 3, 2, 1},
+	{4, 3, 3, 3, 2, 1},
+	{4, 3, 3, 3, 2, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1, 1},
+	{4, 3, 3, 3, 3, 1, 1, 1, 1},
+	{4, 3, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 3, 2, 2, 1, 1},
+}
+
+// warlockSlots are Pact Magic slot counts and slot levels per warlock level
+var warlockSlots = [MaxLevel]struct{ count, level int }{
+	{1, 1}, {2, 1}, {2, 2}, {2, 2}, {2, 3},
+	{2, 3}, {2, 4}, {2, 4}, {2, 5}, {2, 5},
+	{3, 5}, {3, 5}, {3, 5}, {3, 5}, {3, 5},
+	{3, 5}, {4, 5}, {4, 5}, {4, 5}, {4, 5},
+}
+
+// SpellSlots returns the spell slot maxima (index 0 = 1st level) for a
+// single-class character of the given level. Eldritch Knights and Arcane
+// Tricksters pass their subclass as the class.
+func SpellSlots(class string, level int) []int {
+	slots := make([]int, 9)
+	if level < 1 || level > MaxLevel {
+		return slots
+	}
+	switch class {
+	case "Bard", "Cleric", "Druid", "Sorcerer", "Wizard":
+		copy(slots, fullCasterSlots[level-1][:])
+	case "Paladin", "Ranger":
+		// Half casters use the full caster slots of half their level,
+		// rounded up, starting at 2nd level
+		if level >= 2 {
+			copy(slots, fullCasterSlots[(level+1)/2-1][:])
+		}
+	case "Arcane Trickster", "Eldritch Knight":
+		// Third casters use the full caster slots of a third of their
+		// class level, rounded up, starting at 3rd level
+		if level >= 3 {
+			copy(slots, fullCasterSlots[(level+2)/3-1][:])
+		}
+	case "Warlock":
+		pact := warlockSlots[level-1]
+		slots[pact.level-1] = pact.count
+	}
+	return slots
+}
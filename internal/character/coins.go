@@ -0,0 +1,72 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CoinValues are what each coin is worth in copper pieces, in CoinNames
+// order
+var CoinValues = [5]int{1, 10, 50, 100, 1000}
+
+// Purse counts a character's coins in CoinNames order
+type Purse [5]int
+
+// Total is what the coins are worth in copper pieces
+func (p Purse) Total() int {
+	total := 0
+	for i, n := range p {
+		total += n * CoinValues[i]
+	}
+	return total
+}
+
+// Pay takes cost copper pieces' worth of coins out of the purse, the way a
+// player at the table would: the biggest coins that fit first, then one
+// coin broken for change, given back in gold, silver, and copper. It
+// reports false, leaving the purse alone, when there isn't enough.
+func (p Purse) Pay(cost int) (Purse, bool) {
+	if cost > p.Total() {
+		return p, false
+	}
+	left := cost
+	for i := len(p) - 1; i >= 0 && left > 0; i-- {
+		n := min(p[i], left/CoinValues[i])
+		p[i] -= n
+		left -= n * CoinValues[i]
+	}
+	if left == 0 {
+		return p, true
+	}
+
+	// Every coin left is worth more than what's still owed, so break the
+	// smallest of them
+	for i := range p {
+		if p[i] > 0 {
+			p[i]--
+			change := CoinValues[i] - left
+			for _, coin := range []int{gp, sp, cp} {
+				p[coin] += change / CoinValues[coin]
+				change %= CoinValues[coin]
+			}
+			break
+		}
+	}
+	return p, true
+}
+
+// FormatCoins writes an amount in copper pieces with the fewest gold,
+// silver, and copper coins, like "2 gp 5 sp"
+func FormatCoins(copper int) string {
+	var parts []string
+	for _, coin := range []int{gp, sp, cp} {
+		if n := copper / CoinValues[coin]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, coinAbbreviations[coin]))
+			copper %= CoinValues[coin]
+		}
+	}
+	if len(parts) == 0 {
+		return "0 gp"
+	}
+	return strings.Join(parts, " ")
+}
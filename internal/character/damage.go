@@ -0,0 +1,26 @@
+package character
+
+import "slices"
+
+// DamageTypes are the kinds of damage in 5e
+var DamageTypes = []string{
+	"acid", "bludgeoning", "cold", "fire", "force", "lightning", "necrotic",
+	"piercing", "poison", "psychic", "radiant", "slashing", "thunder",
+}
+
+// RaceResistances are the damage types each race resists. Dragonborn
+// resistance depends on their ancestry, which isn't recorded.
+var RaceResistances = map[string][]string{
+	"Dwarf":    {"poison"},
+	"Tiefling": {"fire"},
+}
+
+// DamageTaken is how much of some damage a character of race takes,
+// halved and rounded down when they resist its type, and whether they did.
+// Untyped damage, with an empty type, is never resisted.
+func DamageTaken(race, damageType string, amount int) (int, bool) {
+	if damageType == "" || !slices.Contains(RaceResistances[race], damageType) {
+		return amount, false
+	}
+	return amount / 2, true
+}
@@ -0,0 +1,98 @@
+package character
+
+import (
+	"sort"
+	"strings"
+)
+
+// ClassAbilityPriority orders each class's abilities from most to least
+// important, following the PHB quick build advice
+var ClassAbilityPriority = map[string][]string{
+	"Barbarian": {"Strength", "Constitution", "Dexterity", "Wisdom", "Charisma", "Intelligence"},
+	"Bard":      {"Charisma", "Dexterity", "Constitution", "Wisdom", "Intelligence", "Strength"},
+	"Cleric":    {"Wisdom", "Constitution", "Strength", "Dexterity", "Charisma", "Intelligence"},
+	"Druid":     {"Wisdom", "Constitution", "Dexterity", "Intelligence", "Charisma", "Strength"},
+	"Fighter":   {"Strength", "Constitution", "Dexterity", "Wisdom", "Charisma", "Intelligence"},
+	"Monk":      {"Dexterity", "Wisdom", "Constitution", "Strength", "Intelligence", "Charisma"},
+	"Paladin":   {"Strength", "Charisma", "Constitution", "Wisdom", "Dexterity", "Intelligence"},
+	"Ranger":    {"Dexterity", "Wisdom", "Constitution", "Strength", "Intelligence", "Charisma"},
+	"Rogue":     {"Dexterity", "Constitution", "Wisdom", "Intelligence", "Charisma", "Strength"},
+	"Sorcerer":  {"Charisma", "Constitution", "Dexterity", "Wisdom", "Intelligence", "Strength"},
+	"Warlock":   {"Charisma", "Constitution", "Dexterity", "Wisdom", "Intelligence", "Strength"},
+	"Wizard":    {"Intelligence", "Constitution", "Dexterity", "Wisdom", "Charisma", "Strength"},
+}
+
+// AbilityPriority returns the class's ability order, or Abilities for an
+// unknown class
+func AbilityPriority(class string) []string {
+	if order, ok := ClassAbilityPriority[class]; ok {
+		return order
+	}
+	return Abilities
+}
+
+// DefaultAbilityAssignment maps each ability to the index of the standard
+// array score it gets, highest scores going to the class's key abilities
+func DefaultAbilityAssignment(class string) map[string]int {
+	assigned := make(map[string]int, len(Abilities))
+	for i, ability := range AbilityPriority(class) {
+		assigned[ability] = i
+	}
+	return assigned
+}
+
+// Recommend spends the points on the standard array, which costs exactly
+// the points available, with the highest scores going to the class's key
+// abilities
+func (p *PointBuyState) Recommend(class string) {
+	p.PointsRemaining = PointBuyTotal
+	for i, ability := range AbilityPriority(class) {
+		score := StandardArrayValues[i]
+		p.Scores[ability] = score
+		p.PointsRemaining -= PointBuyCosts[score]
+	}
+}
+
+// DefaultSkills picks count skills from the options, preferring those that
+// use the character's best abilities. Ties keep the options' order.
+func DefaultSkills(options []string, count int, scores map[string]int) []string {
+	ranked := make([]string, len(options))
+	copy(ranked, options)
+	score := func(skill string) int {
+		for ability, s := range scores {
+			if strings.EqualFold(ability, Skills[skill]) {
+				return s
+			}
+		}
+		return 0
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return score(ranked[i]) > score(ranked[j]) })
+	return ranked[:min(count, len(ranked))]
+}
+
+// DefaultSpells picks the first count spells from a list
+func DefaultSpells(spells []Spell, count int) []string {
+	var picked []string
+	for _, spell := range spells[:min(count, len(spells))] {
+		picked = append(picked, spell.Name)
+	}
+	return picked
+}
+
+// RandomSpells picks count different spells from a list at random
+func RandomSpells(spells []Spell, count int) []string {
+	names := make([]string, len(spells))
+	for i, spell := range spells {
+		names[i] = spell.Name
+	}
+	// Shuffle just the front of the list
+	count = min(count, len(names))
+	for i := range count {
+		j := i + rollDie(len(names)-i) - 1
+		names[i], names[j] = names[j], names[i]
+	}
+	if count == 0 {
+		return nil
+	}
+	return names[:count]
+}
@@ -0,0 +1,71 @@
+package character
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxDice caps how many dice one expression can roll, and how many sides
+// they can have
+const maxDice = 100
+
+// Dice is a dice expression such as "2d6+3"
+type Dice struct {
+	Count    int
+	Sides    int
+	Modifier int
+}
+
+// ParseDice reads a dice expression like "d20", "2d6", or "1d8-1"
+func ParseDice(expr string) (Dice, error) {
+	s := strings.ToLower(strings.ReplaceAll(expr, " ", ""))
+	count, rest, ok := strings.Cut(s, "d")
+	if !ok {
+		return Dice{}, fmt.Errorf("%q is not a dice expression like 2d6+3", expr)
+	}
+
+	d := Dice{Count: 1}
+	if count != "" {
+		n, err := strconv.Atoi(count)
+		if err != nil || n < 1 || n > maxDice {
+			return Dice{}, fmt.Errorf("%q: roll between 1 and %d dice", expr, maxDice)
+		}
+		d.Count = n
+	}
+
+	sides := rest
+	if i := strings.IndexAny(rest, "+-"); i >= 0 {
+		sides = rest[:i]
+		mod, err := strconv.Atoi(rest[i:])
+		if err != nil {
+			return Dice{}, fmt.Errorf("%q: the modifier must be a number", expr)
+		}
+		d.Modifier = mod
+	}
+	n, err := strconv.Atoi(sides)
+	if err != nil || n < 2 || n > maxDice {
+		return Dice{}, fmt.Errorf("%q: dice need between 2 and %d sides", expr, maxDice)
+	}
+	d.Sides = n
+	return d, nil
+}
+
+// Roll rolls the dice, returning each die and the total with the modifier
+func (d Dice) Roll() ([]int, int) {
+	rolls := RollDice(d.Count, d.Sides)
+	total := d.Modifier
+	for _, r := range rolls {
+		total += r
+	}
+	return rolls, total
+}
+
+// String formats the expression, e.g. "2d6+3"
+func (d Dice) String() string {
+	s := fmt.Sprintf("%dd%d", d.Count, d.Sides)
+	if d.Modifier != 0 {
+		s += FormatModifierInt(d.Modifier)
+	}
+	return s
+}
@@ -0,0 +1,166 @@
+package character
+
+import "strings"
+
+// Equipment is an SRD item with its price and the tools used to craft it
+type Equipment struct {
+	Name  string
+	Price int    // copper pieces
+	Count int32  // how many come at the price, for ammunition and the like
+	Tool  string // "" for things that can't be crafted from raw materials
+}
+
+// Artisan's tools that craft equipment
+const (
+	smiths         = "Smith's tools"
+	leatherworkers = "Leatherworker's tools"
+	woodcarvers    = "Woodcarver's tools"
+	weavers        = "Weaver's tools"
+	carpenters     = "Carpenter's tools"
+	tinkers        = "Tinker's tools"
+	alchemists     = "Alchemist's supplies"
+	herbalism      = "Herbalism kit"
+	cooks          = "Cook's utensils"
+)
+
+// EquipmentTable lists SRD weapons, armor, and adventuring gear with their
+// prices, weapons and armor first in the order of their own tables
+var EquipmentTable = []Equipment{
+	// Simple weapons
+	{Name: "Club", Price: 10, Tool: woodcarvers},
+	{Name: "Dagger", Price: 200, Tool: smiths},
+	{Name: "Greatclub", Price: 20, Tool: woodcarvers},
+	{Name: "Handaxe", Price: 500, Tool: smiths},
+	{Name: "Javelin", Price: 50, Tool: woodcarvers},
+	{Name: "Light Hammer", Price: 200, Tool: smiths},
+	{Name: "Mace", Price: 500, Tool: smiths},
+	{Name: "Quarterstaff", Price: 20, Tool: woodcarvers},
+	{Name: "Sickle", Price: 100, Tool: smiths},
+	{Name: "Spear", Price: 100, Tool: smiths},
+	{Name: "Light Crossbow", Price: 2500, Tool: woodcarvers},
+	{Name: "Dart", Price: 5, Tool: smiths},
+	{Name: "Shortbow", Price: 2500, Tool: woodcarvers},
+	{Name: "Sling", Price: 10, Tool: leatherworkers},
+
+	// Martial weapons
+	{Name: "Battleaxe", Price: 1000, Tool: smiths},
+	{Name: "Flail", Price: 1000, Tool: smiths},
+	{Name: "Glaive", Price: 2000, Tool: smiths},
+	{Name: "Greataxe", Price: 3000, Tool: smiths},
+	{Name: "Greatsword", Price: 5000, Tool: smiths},
+	{Name: "Halberd", Price: 2000, Tool: smiths},
+	{Name: "Lance", Price: 1000, Tool: smiths},
+	{Name: "Longsword", Price: 1500, Tool: smiths},
+	{Name: "Maul", Price: 1000, Tool: smiths},
+	{Name: "Morningstar", Price: 1500, Tool: smiths},
+	{Name: "Pike", Price: 500, Tool: smiths},
+	{Name: "Rapier", Price: 2500, Tool: smiths},
+	{Name: "Scimitar", Price: 2500, Tool: smiths},
+	{Name: "Shortsword", Price: 1000, Tool: smiths},
+	{Name: "Trident", Price: 500, Tool: smiths},
+	{Name: "War Pick", Price: 500, Tool: smiths},
+	{Name: "Warhammer", Price: 1500, Tool: smiths},
+	{Name: "Whip", Price: 200, Tool: leatherworkers},
+	{Name: "Blowgun", Price: 1000, Tool: woodcarvers},
+	{Name: "Hand Crossbow", Price: 7500, Tool: woodcarvers},
+	{Name: "Heavy Crossbow", Price: 5000, Tool: woodcarvers},
+	{Name: "Longbow", Price: 5000, Tool: woodcarvers},
+	{Name: "Net", Price: 100, Tool: weavers},
+
+	// Armor
+	{Name: "Padded", Price: 500, Tool: weavers},
+	{Name: "Leather", Price: 1000, Tool: leatherworkers},
+	{Name: "Studded Leather", Price: 4500, Tool: leatherworkers},
+	{Name: "Hide", Price: 1000, Tool: leatherworkers},
+	{Name: "Chain Shirt", Price: 5000, Tool: smiths},
+	{Name: "Scale Mail", Price: 5000, Tool: smiths},
+	{Name: "Breastplate", Price: 40000, Tool: smiths},
+	{Name: "Half Plate", Price: 75000, Tool: smiths},
+	{Name: "Ring Mail", Price: 3000, Tool: smiths},
+	{Name: "Chain Mail", Price: 7500, Tool: smiths},
+	{Name: "Splint", Price: 20000, Tool: smiths},
+	{Name: "Plate", Price: 150000, Tool: smiths},
+	{Name: "Shield", Price: 1000, Tool: smiths},
+
+	// Ammunition
+	{Name: "Arrows", Price: 100, Count: 20, Tool: woodcarvers},
+	{Name: "Bolts", Price: 100, Count: 20, Tool: woodcarvers},
+
+	// Adventuring gear
+	{Name: "Acid", Price: 2500, Tool: alchemists},
+	{Name: "Alchemist's Fire", Price: 5000, Tool: alchemists},
+	{Name: "Antitoxin", Price: 5000, Tool: alchemists},
+	{Name: "Backpack", Price: 200, Tool: leatherworkers},
+	{Name: "Basket", Price: 40, Tool: weavers},
+	{Name: "Bedroll", Price: 100, Tool: weavers},
+	{Name: "Blanket", Price: 50, Tool: weavers},
+	{Name: "Chest", Price: 500, Tool: carpenters},
+	{Name: "Component Pouch", Price: 2500},
+	{Name: "Crowbar", Price: 200, Tool: smiths},
+	{Name: "Flask of Oil", Price: 10},
+	{Name: "Grappling Hook", Price: 200, Tool: smiths},
+	{Name: "Hammer", Price: 100, Tool: smiths},
+	{Name: "Healer's Kit", Price: 500, Tool: herbalism},
+	{Name: "Holy Symbol", Price: 500},
+	{Name: "Hooded Lantern", Price: 500, Tool: tinkers},
+	{Name: "Hempen Rope", Price: 100, Tool: weavers},
+	{Name: "Piton", Price: 5, Tool: smiths},
+	{Name: "Potion of Healing", Price: 5000, Tool: herbalism},
+	{Name: "Pouch", Price: 50, Tool: leatherworkers},
+	{Name: "Rations", Price: 50, Tool: cooks},
+	{Name: "Sack", Price: 1, Tool: weavers},
+	{Name: "Silk Rope", Price: 1000, Tool: weavers},
+	{Name: "Spellbook", Price: 5000},
+	{Name: "Tinderbox", Price: 50, Tool: tinkers},
+	{Name: "Torch", Price: 1},
+	{Name: "Waterskin", Price: 20, Tool: leatherworkers},
+
+	// Packs
+	{Name: "Burglar's Pack", Price: 1600},
+	{Name: "Diplomat's Pack", Price: 3900},
+	{Name: "Dungeoneer's Pack", Price: 1200},
+	{Name: "Entertainer's Pack", Price: 4000},
+	{Name: "Explorer's Pack", Price: 1000},
+	{Name: "Priest's Pack", Price: 1900},
+	{Name: "Scholar's Pack", Price: 4000},
+
+	// Tools
+	{Name: "Alchemist's supplies", Price: 5000},
+	{Name: "Carpenter's tools", Price: 800},
+	{Name: "Cook's utensils", Price: 100},
+	{Name: "Herbalism kit", Price: 500},
+	{Name: "Leatherworker's tools", Price: 500},
+	{Name: "Smith's tools", Price: 2000},
+	{Name: "Thieves' tools", Price: 2500},
+	{Name: "Tinker's tools", Price: 5000},
+	{Name: "Weaver's tools", Price: 100},
+	{Name: "Woodcarver's tools", Price: 100},
+}
+
+// FindEquipment looks up an item's price by name, ignoring case
+func FindEquipment(name string) (Equipment, bool) {
+	name = strings.TrimSpace(name)
+	for _, e := range EquipmentTable {
+		if strings.EqualFold(name, e.Name) {
+			return e, true
+		}
+	}
+	return Equipment{}, false
+}
+
+// Recipes are the equipment that can be crafted, in table order
+func Recipes() []Equipment {
+	var recipes []Equipment
+	for _, e := range EquipmentTable {
+		if e.Tool != "" {
+			recipes = append(recipes, e)
+		}
+	}
+	return recipes
+}
+
+// CraftingMaterials is what the raw materials for crafting an item cost:
+// half its price, rounded up
+func CraftingMaterials(e Equipment) int {
+	return (e.Price + 1) / 2
+}
@@ -0,0 +1,40 @@
+package character
+
+// MaxExhaustion is the exhaustion level at which a character dies
+const MaxExhaustion = 6
+
+// exhaustionEffects are the effects gained at each exhaustion level. They
+// are cumulative, so level 3 has the effects of levels 1 through 3.
+var exhaustionEffects = []string{
+	"Disadvantage on ability checks",
+	"Speed halved",
+	"Disadvantage on attack rolls and saving throws",
+	"Hit point maximum halved",
+	"Speed reduced to 0",
+	"Death",
+}
+
+// ExhaustionEffects returns every effect that applies at an exhaustion level
+func ExhaustionEffects(level int) []string {
+	return exhaustionEffects[:min(max(level, 0), MaxExhaustion)]
+}
+
+// ExhaustedSpeed returns walking speed after exhaustion
+func ExhaustedSpeed(speed, level int) int {
+	switch {
+	case level >= 5:
+		return 0
+	case level >= 2:
+		return speed / 2
+	default:
+		return speed
+	}
+}
+
+// ExhaustedMaxHP returns the hit point maximum after exhaustion
+func ExhaustedMaxHP(maxHP, level int) int {
+	if level >= 4 {
+		return maxHP / 2
+	}
+	return maxHP
+}
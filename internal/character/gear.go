@@ -0,0 +1,84 @@
+package character
+
+import "strings"
+
+// Container is an item that holds other items
+type Container struct {
+	Name       string
+	Capacity   float64 // pounds
+	Weightless bool    // contents don't count toward carried weight, as in a bag of holding
+}
+
+// ContainerTable lists the SRD containers that hold a weight of items
+var ContainerTable = []Container{
+	{Name: "Backpack", Capacity: 30},
+	{Name: "Bag of Holding", Capacity: 500, Weightless: true},
+	{Name: "Basket", Capacity: 40},
+	{Name: "Chest", Capacity: 300},
+	{Name: "Handy Haversack", Capacity: 120, Weightless: true},
+	{Name: "Pouch", Capacity: 6},
+	{Name: "Sack", Capacity: 30},
+}
+
+// FindContainer looks up a container by item name, ignoring case
+func FindContainer(name string) (Container, bool) {
+	name = strings.TrimSpace(name)
+	for _, c := range ContainerTable {
+		if strings.EqualFold(name, c.Name) {
+			return c, true
+		}
+	}
+	return Container{}, false
+}
+
+// gearWeights are the SRD weights in pounds of weapons, armor, containers,
+// and common adventuring gear
+var gearWeights = map[string]float64{
+	// Weapons
+	"club": 2, "dagger": 1, "greatclub": 10, "handaxe": 2, "javelin": 2,
+	"light hammer": 2, "mace": 4, "quarterstaff": 4, "sickle": 2, "spear": 3,
+	"light crossbow": 5, "dart": 0.25, "shortbow": 2, "sling": 0,
+	"battleaxe": 4, "flail": 2, "glaive": 6, "greataxe": 7, "greatsword": 6,
+	"halberd": 6, "lance": 6, "longsword": 3, "maul": 10, "morningstar": 4,
+	"pike": 18, "rapier": 2, "scimitar": 3, "shortsword": 2, "trident": 4,
+	"war pick": 2, "warhammer": 2, "whip": 3, "blowgun": 1,
+	"hand crossbow": 3, "heavy crossbow": 18, "longbow": 2,
+
+	// Armor
+	"padded": 8, "leather": 10, "studded leather": 13, "hide": 12,
+	"chain shirt": 20, "scale mail": 45, "breastplate": 20, "half plate": 40,
+	"ring mail": 40, "chain mail": 55, "splint": 60, "plate": 65, "shield": 6,
+
+	// Containers
+	"backpack": 5, "bag of holding": 15, "basket": 2, "chest": 25,
+	"handy haversack": 5, "pouch": 1, "sack": 0.5,
+
+	// Adventuring gear
+	"arrows": 0.05, "bolts": 0.075, "bedroll": 7, "blanket": 3,
+	"candle": 0, "crowbar": 5, "flask": 1, "flask of oil": 1, "oil": 1,
+	"grappling hook": 4, "hammer": 3, "healer's kit": 3, "holy symbol": 1,
+	"hooded lantern": 2, "lantern": 2, "mess kit": 1, "piton": 0.25,
+	"potion of healing": 0.5, "rations": 2, "rope": 10, "hempen rope": 10,
+	"silk rope": 5, "tinderbox": 1, "torch": 1, "waterskin": 5,
+	"spellbook": 3, "component pouch": 2, "arcane focus": 1, "druidic focus": 1,
+	"thieves' tools": 1, "explorer's pack": 59, "dungeoneer's pack": 61.5,
+	"priest's pack": 24, "scholar's pack": 10, "burglar's pack": 44.5,
+	"diplomat's pack": 36, "entertainer's pack": 38,
+
+	// Coins, 50 to the pound
+	"copper pieces": 0.02, "silver pieces": 0.02, "electrum pieces": 0.02,
+	"gold pieces": 0.02, "platinum pieces": 0.02,
+}
+
+// ItemWeight returns the weight in pounds of one of an item, or false if
+// the item isn't in the SRD tables. Magic bonuses and an "Armor" suffix are
+// ignored, so "+1 Longsword" and "Leather Armor" are found.
+func ItemWeight(name string) (float64, bool) {
+	name, _ = splitMagicBonus(name)
+	name = strings.ToLower(strings.TrimSpace(name))
+	if w, ok := gearWeights[name]; ok {
+		return w, true
+	}
+	w, ok := gearWeights[strings.TrimSuffix(name, " armor")]
+	return w, ok
+}
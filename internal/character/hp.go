@@ -0,0 +1,67 @@
+package character
+
+// HitPoints is a character's current, temporary, and maximum hit points.
+// Max is the effective maximum from MaxHitPoints. Every HP change, on the
+// sheet or through the API, goes through these methods so they all follow
+// the same rules.
+type HitPoints struct {
+	Current int
+	Temp    int
+	Max     int
+}
+
+// MaxHitPoints returns the effective hit point maximum: the base maximum
+// raised by effects such as Aid, then reduced by exhaustion if the ruleset
+// says so. It's never below 1.
+func MaxHitPoints(r Ruleset, base, bonus, exhaustion int) int {
+	return max(r.ExhaustedMaxHP(base+bonus, exhaustion), 1)
+}
+
+// Clamp keeps current hit points between 0 and the maximum and temporary
+// hit points at 0 or more
+func (h HitPoints) Clamp() HitPoints {
+	h.Current = min(max(h.Current, 0), h.Max)
+	h.Temp = max(h.Temp, 0)
+	return h
+}
+
+// Damage takes damage. Temporary hit points are lost first, and current hit
+// points don't drop below 0.
+func (h HitPoints) Damage(amount int) HitPoints {
+	amount = max(amount, 0)
+	absorbed := min(h.Temp, amount)
+	h.Temp -= absorbed
+	h.Current -= amount - absorbed
+	return h.Clamp()
+}
+
+// Heal restores hit points up to the maximum. Healing never restores
+// temporary hit points.
+func (h HitPoints) Heal(amount int) HitPoints {
+	h.Current += max(amount, 0)
+	return h.Clamp()
+}
+
+// Set sets current hit points, within 0 and the maximum
+func (h HitPoints) Set(current int) HitPoints {
+	h.Current = current
+	return h.Clamp()
+}
+
+// SetTemp sets temporary hit points. They don't stack, so the new value
+// replaces the old one.
+func (h HitPoints) SetTemp(temp int) HitPoints {
+	h.Temp = temp
+	return h.Clamp()
+}
+
+// ChangeMaxBonus applies a change to the maximum from an effect such as
+// Aid. Raising the maximum this way raises current hit points by the same
+// amount; lowering it only caps them.
+func (h HitPoints) ChangeMaxBonus(delta int) HitPoints {
+	h.Max = max(h.Max+delta, 1)
+	if delta > 0 {
+		h.Current += delta
+	}
+	return h.Clamp()
+}
@@ -0,0 +1,21 @@
+package character
+
+// Languages are the standard and exotic languages from the SRD, offered as
+// suggestions; characters can know others the DM allows
+var Languages = []string{
+	"Common", "Dwarvish", "Elvish", "Giant", "Gnomish", "Goblin", "Halfling", "Orc",
+	"Abyssal", "Celestial", "Deep Speech", "Draconic", "Infernal", "Primordial", "Sylvan", "Undercommon",
+}
+
+// Tools are the tools, kits, gaming sets, and instruments a character can
+// be proficient with, offered as suggestions
+var Tools = []string{
+	"Alchemist's supplies", "Brewer's supplies", "Calligrapher's supplies", "Carpenter's tools",
+	"Cartographer's tools", "Cobbler's tools", "Cook's utensils", "Glassblower's tools",
+	"Jeweler's tools", "Leatherworker's tools", "Mason's tools", "Painter's supplies",
+	"Potter's tools", "Smith's tools", "Tinker's tools", "Weaver's tools", "Woodcarver's tools",
+	"Disguise kit", "Forgery kit", "Herbalism kit", "Navigator's tools", "Poisoner's kit", "Thieves' tools",
+	"Dice set", "Dragonchess set", "Playing card set", "Three-Dragon Ante set",
+	"Bagpipes", "Drum", "Dulcimer", "Flute", "Lute", "Lyre", "Horn", "Pan flute", "Shawm", "Viol",
+	"Vehicles (land)", "Vehicles (water)",
+}
@@ -0,0 +1,68 @@
+package character
+
+import (
+	_ "embed"
+	"encoding/json"
+	"slices"
+)
+
+// Name styles for SuggestName
+const (
+	NamesAny       = ""
+	NamesFeminine  = "feminine"
+	NamesMasculine = "masculine"
+)
+
+// NameStyles lists the name styles in the order the creation screen cycles
+// through them
+var NameStyles = []string{NamesAny, NamesFeminine, NamesMasculine}
+
+// nameTable is the names for one race. Neutral names suit any character,
+// and family names are added after the given name when the race has them.
+type nameTable struct {
+	Feminine  []string `json:"feminine"`
+	Masculine []string `json:"masculine"`
+	Neutral   []string `json:"neutral"`
+	Family    []string `json:"family"`
+}
+
+//go:embed names.json
+var namesJSON []byte
+
+var nameTables = func() map[string]nameTable {
+	var tables map[string]nameTable
+	if err := json.Unmarshal(namesJSON, &tables); err != nil {
+		panic("character: bad names.json: " + err.Error())
+	}
+	return tables
+}()
+
+// SuggestName makes up a name for a character of this race in one of the
+// NameStyles. Races without names of their own get human ones.
+func SuggestName(race, style string) string {
+	table, ok := nameTables[race]
+	if !ok {
+		table = nameTables["Human"]
+	}
+
+	var given []string
+	switch style {
+	case NamesFeminine:
+		given = slices.Concat(table.Feminine, table.Neutral)
+	case NamesMasculine:
+		given = slices.Concat(table.Masculine, table.Neutral)
+	default:
+		given = slices.Concat(table.Feminine, table.Masculine, table.Neutral)
+	}
+
+	name := pick(given)
+	if len(table.Family) > 0 {
+		name += " " + pick(table.Family)
+	}
+	return name
+}
+
+// pick returns one of the items at random
+func pick(items []string) string {
+	return items[rollDie(len(items))-1]
+}
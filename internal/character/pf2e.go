@@ -0,0 +1,131 @@
+package character
+
+import "fmt"
+
+// ProficiencyRank is a Pathfinder 2e proficiency rank
+type ProficiencyRank int
+
+const (
+	Untrained ProficiencyRank = iota
+	Trained
+	Expert
+	Master
+	Legendary
+)
+
+var rankNames = []string{"Untrained", "Trained", "Expert", "Master", "Legendary"}
+
+func (r ProficiencyRank) String() string {
+	return rankNames[min(max(int(r), 0), len(rankNames)-1)]
+}
+
+// RankBonus returns the Pathfinder 2e proficiency bonus for a rank: the
+// character's level plus 2 per rank above untrained, or 0 when untrained
+func RankBonus(rank ProficiencyRank, level int) int {
+	if rank <= Untrained {
+		return 0
+	}
+	return level + 2*int(rank)
+}
+
+// AbilityBoost applies a Pathfinder 2e ability boost, which adds 2 to a
+// score below 18 and 1 to a score of 18 or more
+func AbilityBoost(score int) int {
+	if score < 18 {
+		return score + 2
+	}
+	return score + 1
+}
+
+// pathfinder2e is an experimental, partial Pathfinder 2e ruleset. Ability
+// scores, classes, and ancestries still come from the 5e data; only the
+// calculations behind the Ruleset interface follow Pathfinder.
+type pathfinder2e struct{}
+
+func (pathfinder2e) ID() string   { return RulesPF2e }
+func (pathfinder2e) Name() string { return "Pathfinder 2e (experimental)" }
+
+// ProficiencyBonus returns the trained bonus, the rank most proficiencies
+// start at
+func (pathfinder2e) ProficiencyBonus(level int) int {
+	return RankBonus(Trained, level)
+}
+
+// SpellSlots isn't modeled yet; Pathfinder casters have per-rank slots
+// that don't follow the 5e tables
+func (pathfinder2e) SpellSlots(class string, level int) []int {
+	return nil
+}
+
+// ClassResources isn't modeled yet; Pathfinder tracks focus points and
+// per-day abilities differently
+func (pathfinder2e) ClassResources(class string, level, charisma int) []Resource {
+	return nil
+}
+
+// CarryingCapacity is measured in Bulk: encumbered above 5 + Strength
+// modifier, and unable to carry more than 10 + Strength modifier
+func (pathfinder2e) CarryingCapacity(strength int) string {
+	mod := AbilityModifier(strength)
+	return fmt.Sprintf("%d Bulk (encumbered above %d)", 10+mod, 5+mod)
+}
+
+// CarryingLimit is 0 since Bulk isn't a weight
+func (pathfinder2e) CarryingLimit(strength int) float64 {
+	return 0
+}
+
+// Pathfinder has no exhaustion track. The nearest conditions are fatigued
+// and drained, which are better kept in notes for now.
+func (pathfinder2e) ExhaustionEffects(level int) []string {
+	if level <= 0 {
+		return nil
+	}
+	return []string{"No exhaustion in Pathfinder 2e; track fatigued or drained instead"}
+}
+
+func (pathfinder2e) ExhaustedSpeed(speed, level int) int {
+	return speed
+}
+
+func (pathfinder2e) ExhaustedMaxHP(maxHP, level int) int {
+	return maxHP
+}
+
+// LongRest is a night's rest: hit points recover by the Constitution
+// modifier (at least 1) times the level, rather than in full
+func (pathfinder2e) LongRest(s RestState) RestState {
+	healed := max(AbilityModifier(s.Constitution), 1) * max(s.Level, 1)
+	return RestState{
+		CurrentHP:   min(s.MaxHP, s.CurrentHP+healed),
+		MaxHP:       s.MaxHP,
+		Exhaustion:  s.Exhaustion,
+		Inspiration: s.Inspiration,
+		Level:       s.Level,
+	}
+}
+
+func (pathfinder2e) Reference() []string {
+	return []string{
+		"Three actions and one reaction each turn",
+		"Ability boosts: +2 to a score below 18, +1 at 18 or more; four boosts at levels 5, 10, 15, and 20",
+		fmt.Sprintf("Proficiency: %s +0, %s/%s/%s/%s level +2/+4/+6/+8",
+			Untrained, Trained, Expert, Master, Legendary),
+	}
+}
+
+// WeaponMastery is a 5e 2024 rule; Pathfinder weapons have traits instead
+func (pathfinder2e) WeaponMastery(class string, w Weapon) string {
+	return ""
+}
+
+// InspirationOnRoll is always false; Pathfinder has hero points instead
+func (pathfinder2e) InspirationOnRoll(roll int) bool {
+	return false
+}
+
+// CraftingPerDay is 0: Pathfinder crafting takes a Crafting check and a
+// formula, not a price worked off day by day
+func (pathfinder2e) CraftingPerDay() int {
+	return 0
+}
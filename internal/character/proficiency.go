@@ -0,0 +1,74 @@
+package character
+
+// Proficiency is how much of the proficiency bonus applies to a check
+type Proficiency int
+
+const (
+	NotProficient  Proficiency = iota
+	HalfProficient             // e.g. Jack of All Trades
+	Proficient
+	Expertise // double proficiency
+)
+
+// Bonus returns what this proficiency adds on top of the ability modifier.
+// Half proficiency rounds down.
+func (p Proficiency) Bonus(profBonus int) int {
+	switch p {
+	case HalfProficient:
+		return profBonus / 2
+	case Proficient:
+		return profBonus
+	case Expertise:
+		return profBonus * 2
+	}
+	return 0
+}
+
+// Next cycles through the proficiencies in the order they're usually
+// gained: none, proficient, expertise, half, then back to none
+func (p Proficiency) Next() Proficiency {
+	switch p {
+	case NotProficient:
+		return Proficient
+	case Proficient:
+		return Expertise
+	case Expertise:
+		return HalfProficient
+	}
+	return NotProficient
+}
+
+// SkillProficiency returns a skill's proficiency given the character's
+// proficient, expertise, and half-proficient skill lists. Expertise wins
+// over the others when a skill is in more than one list.
+func SkillProficiency(skill string, proficient, expertise, half []string) Proficiency {
+	switch {
+	case contains(expertise, skill):
+		return Expertise
+	case contains(proficient, skill):
+		return Proficient
+	case contains(half, skill):
+		return HalfProficient
+	}
+	return NotProficient
+}
+
+// SkillLists splits skill proficiencies back into the proficient,
+// expertise, and half-proficient lists they're stored as. Expertise skills
+// are also listed as proficient, so code that only knows about proficiency
+// still sees them. Skills are returned in SkillList order.
+func SkillLists(skills map[string]Proficiency) (proficient, expertise, half []string) {
+	proficient, expertise, half = []string{}, []string{}, []string{}
+	for _, skill := range SkillList {
+		switch skills[skill] {
+		case Expertise:
+			expertise = append(expertise, skill)
+			proficient = append(proficient, skill)
+		case Proficient:
+			proficient = append(proficient, skill)
+		case HalfProficient:
+			half = append(half, skill)
+		}
+	}
+	return proficient, expertise, half
+}
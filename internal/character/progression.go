@@ -0,0 +1,64 @@
+package character
+
+// MaxLevel is the highest character level
+const MaxLevel = 20
+
+// MaxAbilityScore is the cap for ability score increases
+const MaxAbilityScore = 20
+
+// ClassSubclassLevel maps class to the class level a subclass is chosen
+var ClassSubclassLevel = map[string]int{
+	"Barbarian": 3,
+	"Bard":      3,
+	"Cleric":    1,
+	"Druid":     2,
+	"Fighter":   3,
+	"Monk":      3,
+	"Paladin":   3,
+	"Ranger":    3,
+	"Rogue":     3,
+	"Sorcerer":  1,
+	"Warlock":   1,
+	"Wizard":    2,
+}
+
+// asiLevels are the class levels every class gains an ability score
+// improvement
+var asiLevels = []int{4, 8, 12, 16, 19}
+
+// classExtraASILevels are additional improvement levels for some classes
+var classExtraASILevels = map[string][]int{
+	"Fighter": {6, 14},
+	"Rogue":   {10},
+}
+
+// IsASILevel returns true if reaching classLevel in class grants an ability
+// score improvement (or a feat in its place)
+func IsASILevel(class string, classLevel int) bool {
+	for _, l := range asiLevels {
+		if l == classLevel {
+			return true
+		}
+	}
+	for _, l := range classExtraASILevels[class] {
+		if l == classLevel {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSubclassLevel returns true if classLevel is when class picks a subclass
+func IsSubclassLevel(class string, classLevel int) bool {
+	return ClassSubclassLevel[class] == classLevel
+}
+
+// LevelUpHP returns the hit points gained for a level in class, using the
+// fixed average of the hit die
+func LevelUpHP(class string, constitution int) int {
+	hitDie := ClassHitDice[class]
+	if hitDie == 0 {
+		hitDie = 8 // default
+	}
+	return max(1, hitDie/2+1+AbilityModifier(constitution))
+}
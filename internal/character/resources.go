@@ -0,0 +1,95 @@
+package character
+
+// Rests a resource comes back on
+const (
+	RechargeShortRest = "short"
+	RechargeLongRest  = "long"
+)
+
+// Resource is a limited-use class feature, such as Ki or Rage, with how
+// many uses it has and the rest that restores them. A short-rest resource
+// also comes back on a long rest.
+type Resource struct {
+	Name     string
+	Max      int
+	Recharge string
+}
+
+// classResourceNames are every resource ClassResources can return, so ones
+// a character no longer has can be told apart from ones added by hand
+var classResourceNames = []string{
+	"Action Surge", "Bardic Inspiration", "Channel Divinity", "Ki",
+	"Lay on Hands", "Rage", "Second Wind", "Sorcery Points", "Wild Shape",
+}
+
+// IsClassResource reports whether a resource is one ClassResources manages
+func IsClassResource(name string) bool {
+	return contains(classResourceNames, name)
+}
+
+// ClassResources returns the limited-use features of a class at a level.
+// Bardic Inspiration is the one that depends on an ability, Charisma.
+func ClassResources(class string, level, charisma int) []Resource {
+	if level < 1 {
+		return nil
+	}
+	var resources []Resource
+	add := func(name string, uses int, recharge string) {
+		resources = append(resources, Resource{Name: name, Max: uses, Recharge: recharge})
+	}
+
+	switch class {
+	case "Barbarian":
+		// Rages are unlimited at 20th level, so there's nothing to track
+		if level < 20 {
+			add("Rage", levelValue(level, map[int]int{1: 2, 3: 3, 6: 4, 12: 5, 17: 6}), RechargeLongRest)
+		}
+	case "Bard":
+		// Font of Inspiration brings uses back on a short rest from 5th
+		recharge := RechargeLongRest
+		if level >= 5 {
+			recharge = RechargeShortRest
+		}
+		add("Bardic Inspiration", max(AbilityModifier(charisma), 1), recharge)
+	case "Cleric":
+		if level >= 2 {
+			add("Channel Divinity", levelValue(level, map[int]int{2: 1, 6: 2, 18: 3}), RechargeShortRest)
+		}
+	case "Druid":
+		if level >= 2 {
+			add("Wild Shape", 2, RechargeShortRest)
+		}
+	case "Fighter":
+		add("Second Wind", 1, RechargeShortRest)
+		if level >= 2 {
+			add("Action Surge", levelValue(level, map[int]int{2: 1, 17: 2}), RechargeShortRest)
+		}
+	case "Monk":
+		if level >= 2 {
+			add("Ki", level, RechargeShortRest)
+		}
+	case "Paladin":
+		// Lay on Hands is a pool of hit points rather than uses
+		add("Lay on Hands", 5*level, RechargeLongRest)
+		if level >= 3 {
+			add("Channel Divinity", 1, RechargeShortRest)
+		}
+	case "Sorcerer":
+		if level >= 2 {
+			add("Sorcery Points", level, RechargeLongRest)
+		}
+	}
+	return resources
+}
+
+// levelValue looks up a value that changes at certain levels, given as the
+// level each value starts at
+func levelValue(level int, from map[int]int) int {
+	best, value := 0, 0
+	for l, v := range from {
+		if l <= level && l > best {
+			best, value = l, v
+		}
+	}
+	return value
+}
@@ -0,0 +1,254 @@
+package character
+
+import "fmt"
+
+// Ruleset IDs stored on characters
+const (
+	Rules5e2014 = "5e-2014"
+	Rules5e2024 = "5e-2024"
+	RulesPF2e   = "pf2e"
+)
+
+// DefaultRuleset is used for new characters and for characters created
+// before rulesets were tracked
+const DefaultRuleset = Rules5e2014
+
+// Ruleset holds the calculations that differ between game systems and
+// editions. Screens ask a character's ruleset for these rather than calling
+// the 5e helpers directly, so adding a system doesn't mean forking the TUI.
+type Ruleset interface {
+	ID() string
+	Name() string
+
+	ProficiencyBonus(level int) int
+	SpellSlots(class string, level int) []int
+
+	// ClassResources lists a class's limited-use features at a level, such
+	// as Ki or Rage
+	ClassResources(class string, level, charisma int) []Resource
+
+	// CarryingCapacity describes how much a character can carry, in the
+	// system's own units
+	CarryingCapacity(strength int) string
+
+	// CarryingLimit is the most a character can carry in pounds, or 0 if the
+	// system doesn't measure by weight
+	CarryingLimit(strength int) float64
+
+	ExhaustionEffects(level int) []string
+	ExhaustedSpeed(speed, level int) int
+	ExhaustedMaxHP(maxHP, level int) int
+
+	// LongRest returns the state after a long rest
+	LongRest(r RestState) RestState
+
+	// WeaponMastery returns the mastery property a class can use with a
+	// weapon, or "" if the system or class has none
+	WeaponMastery(class string, w Weapon) string
+
+	// InspirationOnRoll reports whether a d20 roll grants inspiration
+	InspirationOnRoll(roll int) bool
+
+	// CraftingPerDay is how much of an item's price, in copper pieces, a
+	// day of downtime crafting makes, or 0 if the system crafts another way
+	CraftingPerDay() int
+
+	// Reference lists short rules reminders for the sheet, if any
+	Reference() []string
+}
+
+// RestState is the part of a character that rests change, along with what
+// the amount recovered can depend on
+type RestState struct {
+	CurrentHP   int
+	TempHP      int
+	MaxHP       int
+	Exhaustion  int
+	Inspiration bool
+
+	Level        int
+	Constitution int
+	Race         string
+}
+
+// rulesetOrder lists the rulesets in the order they're offered
+var rulesetOrder = []Ruleset{
+	fifth2014{},
+	fifth2024{},
+}
+
+// experimentalRulesets are only offered for new characters once enabled
+var experimentalRulesets = []Ruleset{
+	pathfinder2e{},
+}
+
+var experimentalEnabled bool
+
+var natOneInspiration bool
+
+// EnableInspirationOnNatOne turns on the optional 2024 rule that grants
+// Heroic Inspiration when a character rolls a natural 1
+func EnableInspirationOnNatOne() {
+	natOneInspiration = true
+}
+
+// EnableExperimentalRulesets offers the experimental rulesets when creating
+// characters. Characters already using one always keep it.
+func EnableExperimentalRulesets() {
+	experimentalEnabled = true
+}
+
+// Rulesets returns the rulesets offered for new characters
+func Rulesets() []Ruleset {
+	if experimentalEnabled {
+		return append(append([]Ruleset{}, rulesetOrder...), experimentalRulesets...)
+	}
+	return rulesetOrder
+}
+
+// RulesetFor returns the ruleset with an ID, or the default ruleset if the
+// ID is unknown
+func RulesetFor(id string) Ruleset {
+	for _, r := range append(rulesetOrder, experimentalRulesets...) {
+		if r.ID() == id {
+			return r
+		}
+	}
+	return fifth2014{}
+}
+
+// fifth2014 is the 2014 Player's Handbook
+type fifth2014 struct{}
+
+func (fifth2014) ID() string   { return Rules5e2014 }
+func (fifth2014) Name() string { return "D&D 5e (2014)" }
+
+func (fifth2014) ProficiencyBonus(level int) int {
+	return ProficiencyBonus(level)
+}
+
+func (fifth2014) SpellSlots(class string, level int) []int {
+	return SpellSlots(class, level)
+}
+
+func (fifth2014) ClassResources(class string, level, charisma int) []Resource {
+	return ClassResources(class, level, charisma)
+}
+
+func (fifth2014) CarryingCapacity(strength int) string {
+	return fmt.Sprintf("%d lb", strength*15)
+}
+
+func (fifth2014) CarryingLimit(strength int) float64 {
+	return float64(strength * 15)
+}
+
+func (fifth2014) ExhaustionEffects(level int) []string {
+	return ExhaustionEffects(level)
+}
+
+func (fifth2014) ExhaustedSpeed(speed, level int) int {
+	return ExhaustedSpeed(speed, level)
+}
+
+func (fifth2014) ExhaustedMaxHP(maxHP, level int) int {
+	return ExhaustedMaxHP(maxHP, level)
+}
+
+func (r fifth2014) LongRest(s RestState) RestState {
+	return longRest(r, s)
+}
+
+func (fifth2014) Reference() []string {
+	return nil
+}
+
+func (fifth2014) WeaponMastery(class string, w Weapon) string {
+	return ""
+}
+
+func (fifth2014) InspirationOnRoll(roll int) bool {
+	return false
+}
+
+// CraftingPerDay is 5 gp of the item's price for each day of work
+func (fifth2014) CraftingPerDay() int {
+	return 500
+}
+
+// fifth2024 is the 2024 Player's Handbook. Only exhaustion, weapon
+// masteries, Heroic Inspiration, and crafting time differ from 2014 among
+// the rules covered here.
+type fifth2024 struct {
+	fifth2014
+}
+
+func (fifth2024) ID() string   { return Rules5e2024 }
+func (fifth2024) Name() string { return "D&D 5e (2024)" }
+
+// ExhaustionEffects returns the 2024 penalties, which scale with the level
+// rather than adding a new effect at each one
+func (fifth2024) ExhaustionEffects(level int) []string {
+	level = min(max(level, 0), MaxExhaustion)
+	switch {
+	case level == 0:
+		return nil
+	case level >= MaxExhaustion:
+		return []string{"Death"}
+	}
+	return []string{
+		fmt.Sprintf("-%d to D20 Tests", 2*level),
+		fmt.Sprintf("Speed reduced by %d ft", 5*level),
+	}
+}
+
+func (fifth2024) ExhaustedSpeed(speed, level int) int {
+	return max(speed-5*max(level, 0), 0)
+}
+
+func (fifth2024) ExhaustedMaxHP(maxHP, level int) int {
+	return maxHP
+}
+
+// LongRest also gives humans Heroic Inspiration, from their Resourceful
+// trait
+func (r fifth2024) LongRest(s RestState) RestState {
+	rested := longRest(r, s)
+	if s.Race == "Human" {
+		rested.Inspiration = true
+	}
+	return rested
+}
+
+func (fifth2024) InspirationOnRoll(roll int) bool {
+	return natOneInspiration && roll == 1
+}
+
+// CraftingPerDay is 10 GP: crafting takes a day for every 10 GP of the
+// item's cost
+func (fifth2024) CraftingPerDay() int {
+	return 1000
+}
+
+// WeaponMastery returns the weapon's mastery for classes with the Weapon
+// Mastery feature. They choose a few weapons to master; every proficient
+// weapon is shown since the choices aren't tracked.
+func (fifth2024) WeaponMastery(class string, w Weapon) string {
+	if !contains(classMasteries, class) || !WeaponProficient(class, w) {
+		return ""
+	}
+	return w.Mastery
+}
+
+// longRest restores hit points to the maximum, clears temporary hit points,
+// and removes one level of exhaustion. Both 5e editions rest this way; the
+// maximum depends on the edition's exhaustion rules.
+func longRest(r Ruleset, s RestState) RestState {
+	exhaustion := max(s.Exhaustion-1, 0)
+	return RestState{
+		CurrentHP:   r.ExhaustedMaxHP(s.MaxHP, exhaustion),
+		MaxHP:       s.MaxHP,
+		Exhaustion:  exhaustion,
+		Inspiration: s.Inspiration,
+	}
+}
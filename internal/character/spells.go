@@ -0,0 +1,704 @@
+package character
+
+import (
+	"sort"
+	"sync"
+)
+
+// Spell represents a spell from the SRD
+type Spell struct {
+	Name          string
+	Level         int // 0 for cantrips
+	School        string
+	CastingTime   string
+	Range         string
+	Components    string
+	Duration      string
+	Concentration bool
+	Ritual        bool
+	Classes       []string
+	Description   string
+	HigherLevels  string
+}
+
+// SpellcastingInfo describes how a class casts spells at 1st level
+type SpellcastingInfo struct {
+	Ability       string
+	CantripsKnown int
+	SpellsKnown   int  // leveled spells learned at 1st level
+	Prepared      bool // prepares spells each day instead of knowing a fixed list
+}
+
+// ClassSpellcasting maps classes that cast spells at 1st level to their spellcasting rules.
+// Paladins and Rangers gain spellcasting at 2nd level and are not listed.
+var ClassSpellcasting = map[string]SpellcastingInfo{
+	"Bard":     {Ability: "Charisma", CantripsKnown: 2, SpellsKnown: 4},
+	"Cleric":   {Ability: "Wisdom", CantripsKnown: 3, Prepared: true},
+	"Druid":    {Ability: "Wisdom", CantripsKnown: 2, Prepared: true},
+	"Sorcerer": {Ability: "Charisma", CantripsKnown: 4, SpellsKnown: 2},
+	"Warlock":  {Ability: "Charisma", CantripsKnown: 2, SpellsKnown: 2},
+	"Wizard":   {Ability: "Intelligence", CantripsKnown: 3, SpellsKnown: 6, Prepared: true},
+}
+
+// IsSpellcaster returns true if the class casts spells at 1st level
+func IsSpellcaster(class string) bool {
+	_, ok := ClassSpellcasting[class]
+	return ok
+}
+
+// halfCasterAbility gives the spellcasting ability of the classes that
+// gain spellcasting at 2nd level
+var halfCasterAbility = map[string]string{
+	"Paladin": "Charisma",
+	"Ranger":  "Wisdom",
+}
+
+// thirdCasters are the subclasses that gain spellcasting at 3rd level,
+// with the class they belong to and the ability they cast with. They're
+// recorded as the spellcasting class in place of their class.
+var thirdCasters = map[string]struct{ class, ability string }{
+	"Arcane Trickster": {"Rogue", "Intelligence"},
+	"Eldritch Knight":  {"Fighter", "Intelligence"},
+}
+
+// SpellcastingAbility returns the ability a class casts spells with, or
+// false if the class doesn't cast spells
+func SpellcastingAbility(class string) (string, bool) {
+	if info, ok := ClassSpellcasting[class]; ok {
+		return info.Ability, true
+	}
+	if sub, ok := thirdCasters[class]; ok {
+		return sub.ability, true
+	}
+	ability, ok := halfCasterAbility[class]
+	return ability, ok
+}
+
+// SpellcastingBaseClass returns the class whose levels count toward a
+// spellcasting class: the class itself, or the class an Eldritch Knight
+// or Arcane Trickster belongs to
+func SpellcastingBaseClass(class string) string {
+	if sub, ok := thirdCasters[class]; ok {
+		return sub.class
+	}
+	return class
+}
+
+// SpellcastingClasses lists every class that casts spells, sorted by name
+func SpellcastingClasses() []string {
+	var classes []string
+	for _, class := range Classes {
+		if _, ok := SpellcastingAbility(class); ok {
+			classes = append(classes, class)
+		}
+	}
+	for sub := range thirdCasters {
+		classes = append(classes, sub)
+	}
+	sort.Strings(classes)
+	return classes
+}
+
+// PreparedSpellCount returns how many spells a prepared caster can prepare
+func PreparedSpellCount(abilityScore int, level int) int {
+	count := AbilityModifier(abilityScore) + level
+	if count < 1 {
+		return 1
+	}
+	return count
+}
+
+// PreparedSpellLimit returns how many spells a class prepares after a long
+// rest, or false if it knows a fixed list instead. Paladins add half their
+// level rather than all of it.
+func PreparedSpellLimit(class string, abilityScore, level int) (int, bool) {
+	if class == "Paladin" {
+		return max(AbilityModifier(abilityScore)+level/2, 1), true
+	}
+	if !ClassSpellcasting[class].Prepared {
+		return 0, false
+	}
+	return PreparedSpellCount(abilityScore, level), true
+}
+
+// SpellsToChoose returns how many 1st-level spells a new character picks.
+// Known casters (and the Wizard's spellbook) use a fixed count; Clerics and
+// Druids pick their prepared spells.
+func SpellsToChoose(class string, abilityScore int) int {
+	info, ok := ClassSpellcasting[class]
+	if !ok {
+		return 0
+	}
+	if info.SpellsKnown > 0 {
+		return info.SpellsKnown
+	}
+	return PreparedSpellCount(abilityScore, 1)
+}
+
+// SpellsForClass returns the SRD spells of the given level available to a class, sorted by name
+func SpellsForClass(class string, level int) []Spell {
+	spellsMu.RLock()
+	defer spellsMu.RUnlock()
+	var spells []Spell
+	for _, spell := range SRDSpells {
+		if spell.Level == level && contains(spell.Classes, class) {
+			spells = append(spells, spell)
+		}
+	}
+	sort.Slice(spells, func(i, j int) bool { return spells[i].Name < spells[j].Name })
+	return spells
+}
+
+// ritualCasters are the classes with the Ritual Casting feature
+var ritualCasters = map[string]bool{"Bard": true, "Cleric": true, "Druid": true, "Wizard": true}
+
+// CanCastAsRitual reports whether a class can cast a spell as a ritual,
+// taking 10 minutes longer but no spell slot. Wizards cast rituals from
+// their spellbook whether or not the spell is prepared.
+func CanCastAsRitual(class string, spell Spell, prepared bool) bool {
+	if !spell.Ritual || !ritualCasters[class] {
+		return false
+	}
+	return prepared || class == "Wizard"
+}
+
+// FindSpell looks up an SRD spell by name
+func FindSpell(name string) (Spell, bool) {
+	spellsMu.RLock()
+	defer spellsMu.RUnlock()
+	return findSpell(name)
+}
+
+func findSpell(name string) (Spell, bool) {
+	for _, spell := range SRDSpells {
+		if spell.Name == name {
+			return spell, true
+		}
+	}
+	return Spell{}, false
+}
+
+// spellsMu guards SRDSpells, which grows when spells are looked up online
+var spellsMu sync.RWMutex
+
+// AddSpells adds spells from content packs or online lookups to SRDSpells,
+// returning how many were new. Spells already there keep their built-in
+// text.
+func AddSpells(spells []Spell) int {
+	spellsMu.Lock()
+	defer spellsMu.Unlock()
+	added := 0
+	for _, spell := range spells {
+		if _, ok := findSpell(spell.Name); !ok {
+			SRDSpells = append(SRDSpells, spell)
+			added++
+		}
+	}
+	return added
+}
+
+// SRDSpells contains the cantrips and 1st-level spells from the 5e SRD,
+// along with any added from content packs
+var SRDSpells = []Spell{
+	// Cantrips
+	{
+		Name: "Acid Splash", Level: 0, School: "Conjuration",
+		CastingTime: "1 action", Range: "60 feet", Components: "V, S", Duration: "Instantaneous",
+		Classes:      []string{"Sorcerer", "Wizard"},
+		Description:  "Hurl a bubble of acid at one creature, or two within 5 feet of each other. A target must succeed on a DEX save or take 1d6 acid damage.",
+		HigherLevels: "Damage increases by 1d6 at 5th, 11th, and 17th level.",
+	},
+	{
+		Name: "Chill Touch", Level: 0, School: "Necromancy",
+		CastingTime: "1 action", Range: "120 feet", Components: "V, S", Duration: "1 round",
+		Classes:      []string{"Sorcerer", "Warlock", "Wizard"},
+		Description:  "Make a ranged spell attack. On a hit the target takes 1d8 necrotic damage and can't regain hit points until the start of your next turn.",
+		HigherLevels: "Damage increases by 1d8 at 5th, 11th, and 17th level.",
+	},
+	{
+		Name: "Dancing Lights", Level: 0, School: "Evocation",
+		CastingTime: "1 action", Range: "120 feet", Components: "V, S, M (a bit of phosphorus or wychwood, or a glowworm)", Duration: "Up to 1 minute",
+		Concentration: true,
+		Classes:       []string{"Bard", "Sorcerer", "Wizard"},
+		Description:   "Create up to four torch-sized lights that shed dim light in a 10-foot radius and can be moved up to 60 feet as a bonus action.",
+	},
+	{
+		Name: "Druidcraft", Level: 0, School: "Transmutation",
+		CastingTime: "1 action", Range: "30 feet", Components: "V, S", Duration: "Instantaneous",
+		Classes:     []string{"Druid"},
+		Description: "Whisper to the spirits of nature to create a minor effect: predict the weather, make a flower bloom, or create a harmless sensory effect.",
+	},
+	{
+		Name: "Eldritch Blast", Level: 0, School: "Evocation",
+		CastingTime: "1 action", Range: "120 feet", Components: "V, S", Duration: "Instantaneous",
+		Classes:      []string{"Warlock"},
+		Description:  "A beam of crackling energy streaks toward a creature. Make a ranged spell attack; on a hit the target takes 1d10 force damage.",
+		HigherLevels: "Creates two beams at 5th level, three at 11th, and four at 17th.",
+	},
+	{
+		Name: "Fire Bolt", Level: 0, School: "Evocation",
+		CastingTime: "1 action", Range: "120 feet", Components: "V, S", Duration: "Instantaneous",
+		Classes:      []string{"Sorcerer", "Wizard"},
+		Description:  "Hurl a mote of fire. Make a ranged spell attack; on a hit the target takes 1d10 fire damage. Unattended flammable objects ignite.",
+		HigherLevels: "Damage increases by 1d10 at 5th, 11th, and 17th level.",
+	},
+	{
+		Name: "Guidance", Level: 0, School: "Divination",
+		CastingTime: "1 action", Range: "Touch", Components: "V, S", Duration: "Up to 1 minute",
+		Concentration: true,
+		Classes:       []string{"Cleric", "Druid"},
+		Description:   "Touch a willing creature. Once before the spell ends, it can roll a d4 and add the number rolled to one ability check.",
+	},
+	{
+		Name: "Light", Level: 0, School: "Evocation",
+		CastingTime: "1 action", Range: "Touch", Components: "V, M (a firefly or phosphorescent moss)", Duration: "1 hour",
+		Classes:     []string{"Bard", "Cleric", "Sorcerer", "Wizard"},
+		Description: "An object you touch sheds bright light in a 20-foot radius and dim light for an additional 20 feet.",
+	},
+	{
+		Name: "Mage Hand", Level: 0, School: "Conjuration",
+		CastingTime: "1 action", Range: "30 feet", Components: "V, S", Duration: "1 minute",
+		Classes:     []string{"Bard", "Sorcerer", "Warlock", "Wizard"},
+		Description: "A spectral hand appears that can manipulate objects, open unlocked doors, or carry up to 10 pounds.",
+	},
+	{
+		Name: "Mending", Level: 0, School: "Transmutation",
+		CastingTime: "1 minute", Range: "Touch", Components: "V, S, M (two lodestones)", Duration: "Instantaneous",
+		Classes:     []string{"Bard", "Cleric", "Druid", "Sorcerer", "Wizard"},
+		Description: "Repair a single break or tear in an object you touch, no larger than 1 foot in any dimension.",
+	},
+	{
+		Name: "Message", Level: 0, School: "Transmutation",
+		CastingTime: "1 action", Range: "120 feet", Components: "V, S, M (a short piece of copper wire)", Duration: "1 round",
+		Classes:     []string{"Bard", "Sorcerer", "Wizard"},
+		Description: "Whisper a message to a creature within range; only the target hears it and can reply in a whisper.",
+	},
+	{
+		Name: "Minor Illusion", Level: 0, School: "Illusion",
+		CastingTime: "1 action", Range: "30 feet", Components: "S, M (a bit of fleece)", Duration: "1 minute",
+		Classes:     []string{"Bard", "Sorcerer", "Warlock", "Wizard"},
+		Description: "Create a sound or an image of an object no larger than a 5-foot cube. An Investigation check against your save DC reveals the illusion.",
+	},
+	{
+		Name: "Poison Spray", Level: 0, School: "Conjuration",
+		CastingTime: "1 action", Range: "10 feet", Components: "V, S", Duration: "Instantaneous",
+		Classes:      []string{"Druid", "Sorcerer", "Warlock", "Wizard"},
+		Description:  "Project a puff of noxious gas. The target must succeed on a CON save or take 1d12 poison damage.",
+		HigherLevels: "Damage increases by 1d12 at 5th, 11th, and 17th level.",
+	},
+	{
+		Name: "Prestidigitation", Level: 0, School: "Transmutation",
+		CastingTime: "1 action", Range: "10 feet", Components: "V, S", Duration: "Up to 1 hour",
+		Classes:     []string{"Bard", "Sorcerer", "Warlock", "Wizard"},
+		Description: "Perform a minor magical trick: a harmless sensory effect, light or snuff a candle, clean or soil an object, chill or warm food.",
+	},
+	{
+		Name: "Produce Flame", Level: 0, School: "Conjuration",
+		CastingTime: "1 action", Range: "Self", Components: "V, S", Duration: "10 minutes",
+		Classes:      []string{"Druid"},
+		Description:  "A flame in your hand sheds light. You can hurl it as a ranged spell attack for 1d8 fire damage.",
+		HigherLevels: "Damage increases by 1d8 at 5th, 11th, and 17th level.",
+	},
+	{
+		Name: "Ray of Frost", Level: 0, School: "Evocation",
+		CastingTime: "1 action", Range: "60 feet", Components: "V, S", Duration: "Instantaneous",
+		Classes:      []string{"Sorcerer", "Wizard"},
+		Description:  "Make a ranged spell attack. On a hit the target takes 1d8 cold damage and its speed is reduced by 10 feet until your next turn.",
+		HigherLevels: "Damage increases by 1d8 at 5th, 11th, and 17th level.",
+	},
+	{
+		Name: "Resistance", Level: 0, School: "Abjuration",
+		CastingTime: "1 action", Range: "Touch", Components: "V, S, M (a miniature cloak)", Duration: "Up to 1 minute",
+		Concentration: true,
+		Classes:       []string{"Cleric", "Druid"},
+		Description:   "Touch a willing creature. Once before the spell ends, it can roll a d4 and add the number rolled to one saving throw.",
+	},
+	{
+		Name: "Sacred Flame", Level: 0, School: "Evocation",
+		CastingTime: "1 action", Range: "60 feet", Components: "V, S", Duration: "Instantaneous",
+		Classes:      []string{"Cleric"},
+		Description:  "Flame-like radiance descends on a creature. It must succeed on a DEX save or take 1d8 radiant damage, ignoring cover.",
+		HigherLevels: "Damage increases by 1d8 at 5th, 11th, and 17th level.",
+	},
+	{
+		Name: "Shillelagh", Level: 0, School: "Transmutation",
+		CastingTime: "1 bonus action", Range: "Touch", Components: "V, S, M (mistletoe, a shamrock leaf, and a club or quarterstaff)", Duration: "1 minute",
+		Classes:     []string{"Druid"},
+		Description: "Your club or quarterstaff uses your spellcasting ability for attacks and damage, and its damage die becomes a d8.",
+	},
+	{
+		Name: "Shocking Grasp", Level: 0, School: "Evocation",
+		CastingTime: "1 action", Range: "Touch", Components: "V, S", Duration: "Instantaneous",
+		Classes:      []string{"Sorcerer", "Wizard"},
+		Description:  "Make a melee spell attack with advantage against metal armor. On a hit the target takes 1d8 lightning damage and can't take reactions.",
+		HigherLevels: "Damage increases by 1d8 at 5th, 11th, and 17th level.",
+	},
+	{
+		Name: "Spare the Dying", Level: 0, School: "Necromancy",
+		CastingTime: "1 action", Range: "Touch", Components: "V, S", Duration: "Instantaneous",
+		Classes:     []string{"Cleric"},
+		Description: "Touch a living creature that has 0 hit points. The creature becomes stable.",
+	},
+	{
+		Name: "Thaumaturgy", Level: 0, School: "Transmutation",
+		CastingTime: "1 action", Range: "30 feet", Components: "V", Duration: "Up to 1 minute",
+		Classes:     []string{"Cleric"},
+		Description: "Manifest a minor wonder: booming voice, flickering flames, harmless tremors, or eerie sounds.",
+	},
+	{
+		Name: "True Strike", Level: 0, School: "Divination",
+		CastingTime: "1 action", Range: "30 feet", Components: "S", Duration: "Up to 1 round",
+		Concentration: true,
+		Classes:       []string{"Bard", "Sorcerer", "Warlock", "Wizard"},
+		Description:   "Gain insight into a target's defenses. On your next turn you have advantage on your first attack roll against it.",
+	},
+	{
+		Name: "Vicious Mockery", Level: 0, School: "Enchantment",
+		CastingTime: "1 action", Range: "60 feet", Components: "V", Duration: "Instantaneous",
+		Classes:      []string{"Bard"},
+		Description:  "Unleash a string of insults. The target must succeed on a WIS save or take 1d4 psychic damage and have disadvantage on its next attack roll.",
+		HigherLevels: "Damage increases by 1d4 at 5th, 11th, and 17th level.",
+	},
+
+	// 1st level
+	{
+		Name: "Alarm", Level: 1, School: "Abjuration",
+		CastingTime: "1 minute", Range: "30 feet", Components: "V, S, M (a tiny bell and a piece of fine silver wire)", Duration: "8 hours",
+		Ritual:      true,
+		Classes:     []string{"Ranger", "Wizard"},
+		Description: "Set an alarm against intrusion on a door, window, or area no larger than a 20-foot cube. You are alerted when a creature enters.",
+	},
+	{
+		Name: "Animal Friendship", Level: 1, School: "Enchantment",
+		CastingTime: "1 action", Range: "30 feet", Components: "V, S, M (a morsel of food)", Duration: "24 hours",
+		Classes:      []string{"Bard", "Druid", "Ranger"},
+		Description:  "Convince a beast with Intelligence 3 or lower that you mean it no harm. It must succeed on a WIS save or be charmed.",
+		HigherLevels: "Affect one additional beast for each slot level above 1st.",
+	},
+	{
+		Name: "Bane", Level: 1, School: "Enchantment",
+		CastingTime: "1 action", Range: "30 feet", Components: "V, S, M (a drop of blood)", Duration: "Up to 1 minute",
+		Concentration: true,
+		Classes:       []string{"Bard", "Cleric"},
+		Description:   "Up to three creatures must make CHA saves. On a failure they subtract a d4 from attack rolls and saving throws.",
+		HigherLevels:  "Target one additional creature for each slot level above 1st.",
+	},
+	{
+		Name: "Bless", Level: 1, School: "Enchantment",
+		CastingTime: "1 action", Range: "30 feet", Components: "V, S, M (a sprinkling of holy water)", Duration: "Up to 1 minute",
+		Concentration: true,
+		Classes:       []string{"Cleric", "Paladin"},
+		Description:   "Bless up to three creatures. They add a d4 to attack rolls and saving throws.",
+		HigherLevels:  "Target one additional creature for each slot level above 1st.",
+	},
+	{
+		Name: "Burning Hands", Level: 1, School: "Evocation",
+		CastingTime: "1 action", Range: "Self (15-foot cone)", Components: "V, S", Duration: "Instantaneous",
+		Classes:      []string{"Sorcerer", "Wizard"},
+		Description:  "A sheet of flame shoots from your fingertips. Creatures in the cone make a DEX save, taking 3d6 fire damage on a failure or half on a success.",
+		HigherLevels: "Damage increases by 1d6 for each slot level above 1st.",
+	},
+	{
+		Name: "Charm Person", Level: 1, School: "Enchantment",
+		CastingTime: "1 action", Range: "30 feet", Components: "V, S", Duration: "1 hour",
+		Classes:      []string{"Bard", "Druid", "Sorcerer", "Warlock", "Wizard"},
+		Description:  "A humanoid must succeed on a WIS save or be charmed by you, regarding you as a friendly acquaintance.",
+		HigherLevels: "Target one additional creature for each slot level above 1st.",
+	},
+	{
+		Name: "Color Spray", Level: 1, School: "Illusion",
+		CastingTime: "1 action", Range: "Self (15-foot cone)", Components: "V, S, M (a pinch of colored sand)", Duration: "1 round",
+		Classes:      []string{"Sorcerer", "Wizard"},
+		Description:  "Roll 6d10; creatures in the cone are blinded in ascending order of current hit points until the total is exhausted.",
+		HigherLevels: "Roll an additional 2d10 for each slot level above 1st.",
+	},
+	{
+		Name: "Command", Level: 1, School: "Enchantment",
+		CastingTime: "1 action", Range: "60 feet", Components: "V", Duration: "1 round",
+		Classes:      []string{"Cleric", "Paladin"},
+		Description:  "Speak a one-word command. The target must succeed on a WIS save or follow it on its next turn.",
+		HigherLevels: "Target one additional creature for each slot level above 1st.",
+	},
+	{
+		Name: "Comprehend Languages", Level: 1, School: "Divination",
+		CastingTime: "1 action", Range: "Self", Components: "V, S, M (a pinch of soot and salt)", Duration: "1 hour",
+		Ritual:      true,
+		Classes:     []string{"Bard", "Sorcerer", "Warlock", "Wizard"},
+		Description: "Understand the literal meaning of any spoken language you hear and any written language you touch.",
+	},
+	{
+		Name: "Create or Destroy Water", Level: 1, School: "Transmutation",
+		CastingTime: "1 action", Range: "30 feet", Components: "V, S, M (a drop of water or a few grains of sand)", Duration: "Instantaneous",
+		Classes:      []string{"Cleric", "Druid"},
+		Description:  "Create up to 10 gallons of clean water, or destroy up to 10 gallons of water in an open container.",
+		HigherLevels: "Create or destroy 10 additional gallons for each slot level above 1st.",
+	},
+	{
+		Name: "Cure Wounds", Level: 1, School: "Evocation",
+		CastingTime: "1 action", Range: "Touch", Components: "V, S", Duration: "Instantaneous",
+		Classes:      []string{"Bard", "Cleric", "Druid", "Paladin", "Ranger"},
+		Description:  "A creature you touch regains hit points equal to 1d8 + your spellcasting ability modifier.",
+		HigherLevels: "Healing increases by 1d8 for each slot level above 1st.",
+	},
+	{
+		Name: "Detect Evil and Good", Level: 1, School: "Divination",
+		CastingTime: "1 action", Range: "Self", Components: "V, S", Duration: "Up to 10 minutes",
+		Concentration: true,
+		Classes:       []string{"Cleric", "Paladin"},
+		Description:   "Know if an aberration, celestial, elemental, fey, fiend, or undead is within 30 feet of you, and where it is located.",
+	},
+	{
+		Name: "Detect Magic", Level: 1, School: "Divination",
+		CastingTime: "1 action", Range: "Self", Components: "V, S", Duration: "Up to 10 minutes",
+		Concentration: true, Ritual: true,
+		Classes:     []string{"Bard", "Cleric", "Druid", "Paladin", "Ranger", "Sorcerer", "Wizard"},
+		Description: "Sense the presence of magic within 30 feet of you and see a faint aura around visible magical creatures or objects.",
+	},
+	{
+		Name: "Detect Poison and Disease", Level: 1, School: "Divination",
+		CastingTime: "1 action", Range: "Self", Components: "V, S, M (a yew leaf)", Duration: "Up to 10 minutes",
+		Concentration: true, Ritual: true,
+		Classes:     []string{"Cleric", "Druid", "Paladin", "Ranger"},
+		Description: "Sense the presence and location of poisons, poisonous creatures, and diseases within 30 feet of you.",
+	},
+	{
+		Name: "Disguise Self", Level: 1, School: "Illusion",
+		CastingTime: "1 action", Range: "Self", Components: "V, S", Duration: "1 hour",
+		Classes:     []string{"Bard", "Sorcerer", "Wizard"},
+		Description: "Make yourself, including clothing and gear, look different until the spell ends or you use your action to dismiss it.",
+	},
+	{
+		Name: "Divine Favor", Level: 1, School: "Evocation",
+		CastingTime: "1 bonus action", Range: "Self", Components: "V, S", Duration: "Up to 1 minute",
+		Concentration: true,
+		Classes:       []string{"Paladin"},
+		Description:   "Your weapon attacks deal an extra 1d4 radiant damage on a hit.",
+	},
+	{
+		Name: "Entangle", Level: 1, School: "Conjuration",
+		CastingTime: "1 action", Range: "90 feet", Components: "V, S", Duration: "Up to 1 minute",
+		Concentration: true,
+		Classes:       []string{"Druid"},
+		Description:   "Grasping weeds sprout in a 20-foot square. Creatures there must succeed on a STR save or be restrained.",
+	},
+	{
+		Name: "Expeditious Retreat", Level: 1, School: "Transmutation",
+		CastingTime: "1 bonus action", Range: "Self", Components: "V, S", Duration: "Up to 10 minutes",
+		Concentration: true,
+		Classes:       []string{"Sorcerer", "Warlock", "Wizard"},
+		Description:   "You can take the Dash action as a bonus action on each of your turns.",
+	},
+	{
+		Name: "Faerie Fire", Level: 1, School: "Evocation",
+		CastingTime: "1 action", Range: "60 feet", Components: "V", Duration: "Up to 1 minute",
+		Concentration: true,
+		Classes:       []string{"Bard", "Druid"},
+		Description:   "Objects and creatures in a 20-foot cube are outlined in light on a failed DEX save. Attacks against them have advantage.",
+	},
+	{
+		Name: "False Life", Level: 1, School: "Necromancy",
+		CastingTime: "1 action", Range: "Self", Components: "V, S, M (a small amount of alcohol or distilled spirits)", Duration: "1 hour",
+		Classes:      []string{"Sorcerer", "Wizard"},
+		Description:  "Gain 1d4 + 4 temporary hit points for the duration.",
+		HigherLevels: "Gain 5 additional temporary hit points for each slot level above 1st.",
+	},
+	{
+		Name: "Feather Fall", Level: 1, School: "Transmutation",
+		CastingTime: "1 reaction", Range: "60 feet", Components: "V, M (a small feather or piece of down)", Duration: "1 minute",
+		Classes:     []string{"Bard", "Sorcerer", "Wizard"},
+		Description: "Up to five falling creatures descend slowly at 60 feet per round and take no falling damage.",
+	},
+	{
+		Name: "Find Familiar", Level: 1, School: "Conjuration",
+		CastingTime: "1 hour", Range: "10 feet", Components: "V, S, M (10 gp worth of charcoal, incense, and herbs)", Duration: "Instantaneous",
+		Ritual:      true,
+		Classes:     []string{"Wizard"},
+		Description: "Gain the service of a familiar, a spirit that takes an animal form you choose and obeys your commands.",
+	},
+	{
+		Name: "Floating Disk", Level: 1, School: "Conjuration",
+		CastingTime: "1 action", Range: "30 feet", Components: "V, S, M (a drop of mercury)", Duration: "1 hour",
+		Ritual:      true,
+		Classes:     []string{"Wizard"},
+		Description: "Create a floating plane of force that can carry up to 500 pounds and follows you.",
+	},
+	{
+		Name: "Fog Cloud", Level: 1, School: "Conjuration",
+		CastingTime: "1 action", Range: "120 feet", Components: "V, S", Duration: "Up to 1 hour",
+		Concentration: true,
+		Classes:       []string{"Druid", "Ranger", "Sorcerer", "Wizard"},
+		Description:   "Create a 20-foot-radius sphere of fog that heavily obscures its area.",
+		HigherLevels:  "Radius increases by 20 feet for each slot level above 1st.",
+	},
+	{
+		Name: "Goodberry", Level: 1, School: "Transmutation",
+		CastingTime: "1 action", Range: "Touch", Components: "V, S, M (a sprig of mistletoe)", Duration: "Instantaneous",
+		Classes:     []string{"Druid", "Ranger"},
+		Description: "Up to ten berries appear. Eating one restores 1 hit point and provides nourishment for a day.",
+	},
+	{
+		Name: "Grease", Level: 1, School: "Conjuration",
+		CastingTime: "1 action", Range: "60 feet", Components: "V, S, M (a bit of pork rind or butter)", Duration: "1 minute",
+		Classes:     []string{"Wizard"},
+		Description: "Slick grease covers a 10-foot square. Creatures there must succeed on a DEX save or fall prone.",
+	},
+	{
+		Name: "Guiding Bolt", Level: 1, School: "Evocation",
+		CastingTime: "1 action", Range: "120 feet", Components: "V, S", Duration: "1 round",
+		Classes:      []string{"Cleric"},
+		Description:  "Make a ranged spell attack. On a hit the target takes 4d6 radiant damage and the next attack against it has advantage.",
+		HigherLevels: "Damage increases by 1d6 for each slot level above 1st.",
+	},
+	{
+		Name: "Healing Word", Level: 1, School: "Evocation",
+		CastingTime: "1 bonus action", Range: "60 feet", Components: "V", Duration: "Instantaneous",
+		Classes:      []string{"Bard", "Cleric", "Druid"},
+		Description:  "A creature you can see regains hit points equal to 1d4 + your spellcasting ability modifier.",
+		HigherLevels: "Healing increases by 1d4 for each slot level above 1st.",
+	},
+	{
+		Name: "Hellish Rebuke", Level: 1, School: "Evocation",
+		CastingTime: "1 reaction", Range: "60 feet", Components: "V, S", Duration: "Instantaneous",
+		Classes:      []string{"Warlock"},
+		Description:  "When a creature damages you, it is wreathed in flames and makes a DEX save, taking 2d10 fire damage on a failure or half on a success.",
+		HigherLevels: "Damage increases by 1d10 for each slot level above 1st.",
+	},
+	{
+		Name: "Heroism", Level: 1, School: "Enchantment",
+		CastingTime: "1 action", Range: "Touch", Components: "V, S", Duration: "Up to 1 minute",
+		Concentration: true,
+		Classes:       []string{"Bard", "Paladin"},
+		Description:   "A willing creature is immune to being frightened and gains temporary hit points equal to your spellcasting modifier each turn.",
+		HigherLevels:  "Target one additional creature for each slot level above 1st.",
+	},
+	{
+		Name: "Hideous Laughter", Level: 1, School: "Enchantment",
+		CastingTime: "1 action", Range: "30 feet", Components: "V, S, M (tiny tarts and a feather)", Duration: "Up to 1 minute",
+		Concentration: true,
+		Classes:       []string{"Bard", "Wizard"},
+		Description:   "A creature must succeed on a WIS save or fall prone, incapacitated by laughter, for the duration.",
+	},
+	{
+		Name: "Hunter's Mark", Level: 1, School: "Divination",
+		CastingTime: "1 bonus action", Range: "90 feet", Components: "V", Duration: "Up to 1 hour",
+		Concentration: true,
+		Classes:       []string{"Ranger"},
+		Description:   "Mark a creature as your quarry. Your weapon attacks deal an extra 1d6 damage to it and you have advantage to track it.",
+		HigherLevels:  "Duration increases to 8 hours with a 3rd- or 4th-level slot and 24 hours with 5th or higher.",
+	},
+	{
+		Name: "Identify", Level: 1, School: "Divination",
+		CastingTime: "1 minute", Range: "Touch", Components: "V, S, M (a pearl worth at least 100 gp and an owl feather)", Duration: "Instantaneous",
+		Ritual:      true,
+		Classes:     []string{"Bard", "Wizard"},
+		Description: "Learn the properties of a magic item you touch, including how to use it and how many charges it has.",
+	},
+	{
+		Name: "Illusory Script", Level: 1, School: "Illusion",
+		CastingTime: "1 minute", Range: "Touch", Components: "S, M (a lead-based ink worth at least 10 gp)", Duration: "10 days",
+		Ritual:      true,
+		Classes:     []string{"Bard", "Warlock", "Wizard"},
+		Description: "Write a message that only creatures you designate can read; to others it appears as unintelligible or a different message.",
+	},
+	{
+		Name: "Inflict Wounds", Level: 1, School: "Necromancy",
+		CastingTime: "1 action", Range: "Touch", Components: "V, S", Duration: "Instantaneous",
+		Classes:      []string{"Cleric"},
+		Description:  "Make a melee spell attack. On a hit the target takes 3d10 necrotic damage.",
+		HigherLevels: "Damage increases by 1d10 for each slot level above 1st.",
+	},
+	{
+		Name: "Jump", Level: 1, School: "Transmutation",
+		CastingTime: "1 action", Range: "Touch", Components: "V, S, M (a grasshopper's hind leg)", Duration: "1 minute",
+		Classes:     []string{"Druid", "Ranger", "Sorcerer", "Wizard"},
+		Description: "A creature's jump distance is tripled until the spell ends.",
+	},
+	{
+		Name: "Longstrider", Level: 1, School: "Transmutation",
+		CastingTime: "1 action", Range: "Touch", Components: "V, S, M (a pinch of dirt)", Duration: "1 hour",
+		Classes:      []string{"Bard", "Druid", "Ranger", "Wizard"},
+		Description:  "A creature's speed increases by 10 feet until the spell ends.",
+		HigherLevels: "Target one additional creature for each slot level above 1st.",
+	},
+	{
+		Name: "Mage Armor", Level: 1, School: "Abjuration",
+		CastingTime: "1 action", Range: "Touch", Components: "V, S, M (a piece of cured leather)", Duration: "8 hours",
+		Classes:     []string{"Sorcerer", "Wizard"},
+		Description: "A willing creature not wearing armor has a base AC of 13 + its DEX modifier until the spell ends.",
+	},
+	{
+		Name: "Magic Missile", Level: 1, School: "Evocation",
+		CastingTime: "1 action", Range: "120 feet", Components: "V, S", Duration: "Instantaneous",
+		Classes:      []string{"Sorcerer", "Wizard"},
+		Description:  "Create three glowing darts of force that each hit a creature you choose for 1d4 + 1 force damage.",
+		HigherLevels: "Create one additional dart for each slot level above 1st.",
+	},
+	{
+		Name: "Protection from Evil and Good", Level: 1, School: "Abjuration",
+		CastingTime: "1 action", Range: "Touch", Components: "V, S, M (holy water or powdered silver and iron)", Duration: "Up to 10 minutes",
+		Concentration: true,
+		Classes:       []string{"Cleric", "Paladin", "Warlock", "Wizard"},
+		Description:   "Aberrations, celestials, elementals, fey, fiends, and undead have disadvantage on attacks against the target and can't charm, frighten, or possess it.",
+	},
+	{
+		Name: "Purify Food and Drink", Level: 1, School: "Transmutation",
+		CastingTime: "1 action", Range: "10 feet", Components: "V, S", Duration: "Instantaneous",
+		Ritual:      true,
+		Classes:     []string{"Cleric", "Druid", "Paladin"},
+		Description: "All nonmagical food and drink within a 5-foot-radius sphere is purified and rendered free of poison and disease.",
+	},
+	{
+		Name: "Sanctuary", Level: 1, School: "Abjuration",
+		CastingTime: "1 bonus action", Range: "30 feet", Components: "V, S, M (a small silver mirror)", Duration: "1 minute",
+		Classes:     []string{"Cleric"},
+		Description: "Ward a creature. Anyone targeting it with an attack or harmful spell must first succeed on a WIS save or choose a new target.",
+	},
+	{
+		Name: "Shield", Level: 1, School: "Abjuration",
+		CastingTime: "1 reaction", Range: "Self", Components: "V, S", Duration: "1 round",
+		Classes:     []string{"Sorcerer", "Wizard"},
+		Description: "When you are hit by an attack or targeted by magic missile, gain +5 AC until the start of your next turn.",
+	},
+	{
+		Name: "Shield of Faith", Level: 1, School: "Abjuration",
+		CastingTime: "1 bonus action", Range: "60 feet", Components: "V, S, M (a small parchment with holy text)", Duration: "Up to 10 minutes",
+		Concentration: true,
+		Classes:       []string{"Cleric", "Paladin"},
+		Description:   "A shimmering field surrounds a creature, granting it +2 AC for the duration.",
+	},
+	{
+		Name: "Silent Image", Level: 1, School: "Illusion",
+		CastingTime: "1 action", Range: "60 feet", Components: "V, S, M (a bit of fleece)", Duration: "Up to 10 minutes",
+		Concentration: true,
+		Classes:       []string{"Bard", "Sorcerer", "Wizard"},
+		Description:   "Create the image of an object, creature, or phenomenon no larger than a 15-foot cube. It is purely visual.",
+	},
+	{
+		Name: "Sleep", Level: 1, School: "Enchantment",
+		CastingTime: "1 action", Range: "90 feet", Components: "V, S, M (a pinch of fine sand, rose petals, or a cricket)", Duration: "1 minute",
+		Classes:      []string{"Bard", "Sorcerer", "Wizard"},
+		Description:  "Roll 5d8; creatures within 20 feet of a point fall unconscious in ascending order of current hit points until the total is exhausted.",
+		HigherLevels: "Roll an additional 2d8 for each slot level above 1st.",
+	},
+	{
+		Name: "Speak with Animals", Level: 1, School: "Divination",
+		CastingTime: "1 action", Range: "Self", Components: "V, S", Duration: "10 minutes",
+		Ritual:      true,
+		Classes:     []string{"Bard", "Druid", "Ranger"},
+		Description: "Comprehend and verbally communicate with beasts for the duration.",
+	},
+	{
+		Name: "Thunderwave", Level: 1, School: "Evocation",
+		CastingTime: "1 action", Range: "Self (15-foot cube)", Components: "V, S", Duration: "Instantaneous",
+		Classes:      []string{"Bard", "Druid", "Sorcerer", "Wizard"},
+		Description:  "A wave of thunderous force sweeps out. Creatures make a CON save, taking 2d8 thunder damage and being pushed 10 feet on a failure, or half damage on a success.",
+		HigherLevels: "Damage increases by 1d8 for each slot level above 1st.",
+	},
+	{
+		Name: "Unseen Servant", Level: 1, School: "Conjuration",
+		CastingTime: "1 action", Range: "60 feet", Components: "V, S, M (a piece of string and a bit of wood)", Duration: "1 hour",
+		Ritual:      true,
+		Classes:     []string{"Bard", "Warlock", "Wizard"},
+		Description: "Create an invisible, mindless force that performs simple tasks at your command.",
+	},
+}
@@ -43,6 +43,22 @@ func PassivePerception(wisdom int, level int, proficient bool) int {
 	return 10 + SkillBonus(wisdom, level, proficient)
 }
 
+// SpellSaveDC calculates the spell save DC (8 + proficiency + ability modifier)
+func SpellSaveDC(abilityScore int, level int) int {
+	return 8 + ProficiencyBonus(level) + AbilityModifier(abilityScore)
+}
+
+// SpellAttackBonus calculates the spell attack bonus (proficiency + ability modifier)
+func SpellAttackBonus(abilityScore int, level int) int {
+	return ProficiencyBonus(level) + AbilityModifier(abilityScore)
+}
+
+// ConcentrationDC returns the Constitution save DC to keep concentrating
+// after taking damage: 10 or half the damage, whichever is higher
+func ConcentrationDC(damage int) int {
+	return max(10, damage/2)
+}
+
 // FormatModifier formats a modifier with +/- sign
 func FormatModifier(mod int) string {
 	if mod >= 0 {
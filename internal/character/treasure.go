@@ -0,0 +1,207 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TreasureBands are the challenge rating ranges the treasure tables are
+// split into, following the DMG
+var TreasureBands = []string{"CR 0-4", "CR 5-10", "CR 11-16", "CR 17+"}
+
+// Coin names, smallest first, as they're stored in inventory
+var CoinNames = []string{"Copper pieces", "Silver pieces", "Electrum pieces", "Gold pieces", "Platinum pieces"}
+
+// coinAbbreviations match CoinNames
+var coinAbbreviations = []string{"cp", "sp", "ep", "gp", "pp"}
+
+// Treasure is what one roll on the treasure tables turned up
+type Treasure struct {
+	Coins [5]int   // in CoinNames order
+	Items []string // gems, art objects, and magic items, one entry each
+}
+
+// Empty reports whether the roll turned up nothing
+func (t Treasure) Empty() bool {
+	return t.Coins == [5]int{} && len(t.Items) == 0
+}
+
+// String lists the treasure on one line, like "120 gp, 30 sp, Moonstone
+// (50 gp gem)"
+func (t Treasure) String() string {
+	var parts []string
+	for i := len(t.Coins) - 1; i >= 0; i-- {
+		if t.Coins[i] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", t.Coins[i], coinAbbreviations[i]))
+		}
+	}
+	parts = append(parts, t.Items...)
+	if len(parts) == 0 {
+		return "nothing"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// coinRoll is some number of d6 times a multiplier, in one coin
+type coinRoll struct {
+	coin, dice, times int
+}
+
+// Coin indexes into Treasure.Coins
+const (
+	cp = iota
+	sp
+	ep
+	gp
+	pp
+)
+
+// individualTreasure is, per band, the d100 ceiling of each row and the
+// coins it gives
+var individualTreasure = [][]struct {
+	upTo  int
+	coins []coinRoll
+}{
+	{
+		{30, []coinRoll{{cp, 5, 1}}},
+		{60, []coinRoll{{sp, 4, 1}}},
+		{70, []coinRoll{{ep, 3, 1}}},
+		{95, []coinRoll{{gp, 3, 1}}},
+		{100, []coinRoll{{pp, 1, 1}}},
+	},
+	{
+		{30, []coinRoll{{cp, 4, 100}, {ep, 1, 10}}},
+		{60, []coinRoll{{sp, 6, 10}, {gp, 2, 10}}},
+		{70, []coinRoll{{ep, 3, 10}, {gp, 2, 10}}},
+		{95, []coinRoll{{gp, 4, 10}}},
+		{100, []coinRoll{{gp, 2, 10}, {pp, 3, 1}}},
+	},
+	{
+		{20, []coinRoll{{sp, 4, 100}, {gp, 1, 100}}},
+		{35, []coinRoll{{ep, 1, 100}, {gp, 1, 100}}},
+		{75, []coinRoll{{gp, 2, 100}, {pp, 1, 10}}},
+		{100, []coinRoll{{gp, 2, 100}, {pp, 2, 10}}},
+	},
+	{
+		{15, []coinRoll{{ep, 2, 1000}, {gp, 8, 100}}},
+		{55, []coinRoll{{gp, 1, 1000}, {pp, 1, 100}}},
+		{100, []coinRoll{{gp, 1, 1000}, {pp, 2, 100}}},
+	},
+}
+
+// hoardCoins are the coins in a hoard for each band
+var hoardCoins = [][]coinRoll{
+	{{cp, 6, 100}, {sp, 3, 100}, {gp, 2, 10}},
+	{{cp, 2, 100}, {sp, 2, 1000}, {gp, 6, 100}, {pp, 3, 10}},
+	{{gp, 4, 1000}, {pp, 5, 100}},
+	{{gp, 12, 1000}, {pp, 8, 1000}},
+}
+
+// valuables is a roll of 2d6 gems or art objects worth value gp each
+type valuables struct {
+	art   bool
+	value int
+}
+
+// hoardValuables is, per band, the d100 ceiling of each row and the gems
+// or art it gives; the first row of each band gives none
+var hoardValuables = [][]struct {
+	upTo int
+	what valuables
+}{
+	{{40, valuables{}}, {70, valuables{false, 10}}, {85, valuables{true, 25}}, {100, valuables{false, 50}}},
+	{{25, valuables{}}, {50, valuables{true, 25}}, {75, valuables{false, 50}}, {90, valuables{false, 100}}, {100, valuables{true, 250}}},
+	{{15, valuables{}}, {40, valuables{true, 250}}, {65, valuables{true, 750}}, {85, valuables{false, 500}}, {100, valuables{false, 1000}}},
+	{{5, valuables{}}, {40, valuables{false, 1000}}, {75, valuables{true, 2500}}, {90, valuables{true, 7500}}, {100, valuables{false, 5000}}},
+}
+
+// gems and artObjects by value in gp
+var (
+	gems = map[int][]string{
+		10:   {"Azurite", "Blue quartz", "Hematite", "Lapis lazuli", "Malachite", "Obsidian", "Tiger eye"},
+		50:   {"Bloodstone", "Carnelian", "Chalcedony", "Citrine", "Jasper", "Moonstone", "Onyx", "Zircon"},
+		100:  {"Amber", "Amethyst", "Coral", "Garnet", "Jade", "Pearl", "Spinel", "Tourmaline"},
+		500:  {"Alexandrite", "Aquamarine", "Black pearl", "Blue spinel", "Peridot", "Topaz"},
+		1000: {"Black opal", "Blue sapphire", "Emerald", "Fire opal", "Opal", "Star ruby", "Star sapphire", "Yellow sapphire"},
+		5000: {"Black sapphire", "Diamond", "Jacinth", "Ruby"},
+	}
+	artObjects = map[int][]string{
+		25:   {"Silver ewer", "Carved bone statuette", "Small gold bracelet", "Cloth-of-gold vestments", "Copper chalice with silver filigree"},
+		250:  {"Gold ring set with bloodstones", "Carved ivory statuette", "Large gold bracelet", "Bronze crown", "Silk robe with gold embroidery"},
+		750:  {"Silver chalice set with moonstones", "Carved harp of exotic wood", "Small gold idol", "Gold dragon comb set with red garnets"},
+		2500: {"Fine gold chain set with a fire opal", "Old masterpiece painting", "Platinum bracelet set with a sapphire", "Embroidered silk mantle set with moonstones"},
+		7500: {"Jeweled gold crown", "Jeweled platinum ring", "Small gold statuette set with rubies", "Gold cup set with emeralds"},
+	}
+)
+
+// magicItemTables hold SRD magic items from least to most powerful
+var magicItemTables = [][]string{
+	{"Potion of Healing", "Potion of Climbing", "Spell Scroll (cantrip)", "Spell Scroll (1st level)", "Bag of Holding", "Driftglobe"},
+	{"Potion of Greater Healing", "Potion of Fire Breath", "Spell Scroll (2nd level)", "+1 Longsword", "+1 Shield", "Boots of Elvenkind", "Cloak of Protection", "Goggles of Night", "Wand of Magic Missiles"},
+	{"Potion of Superior Healing", "Spell Scroll (4th level)", "+2 Longsword", "+1 Breastplate", "Ring of Protection", "Amulet of Health", "Handy Haversack", "Wand of Fireballs"},
+	{"Potion of Supreme Healing", "Spell Scroll (6th level)", "+3 Longsword", "+2 Plate", "Belt of Fire Giant Strength", "Staff of Power", "Ring of Regeneration"},
+	{"Spell Scroll (9th level)", "Vorpal Sword", "+3 Plate", "Ring of Three Wishes", "Robe of the Archmagi", "Staff of the Magi"},
+}
+
+// hoardMagic is, per band, the d100 roll needed for magic items, how many
+// d4 of them, and the two tables they come from
+var hoardMagic = []struct {
+	atLeast, dice int
+	tables        [2]int
+}{
+	{61, 1, [2]int{0, 1}},
+	{41, 1, [2]int{1, 2}},
+	{26, 1, [2]int{2, 3}},
+	{11, 2, [2]int{3, 4}},
+}
+
+// RollTreasure rolls individual treasure, carried by one creature, or a
+// hoard for a band of TreasureBands
+func RollTreasure(band int, hoard bool) Treasure {
+	band = max(0, min(band, len(TreasureBands)-1))
+	var t Treasure
+	addCoins := func(rolls []coinRoll) {
+		for _, r := range rolls {
+			t.Coins[r.coin] += RollDiceTotal(r.dice, 6) * r.times
+		}
+	}
+
+	if !hoard {
+		roll := rollDie(100)
+		for _, row := range individualTreasure[band] {
+			if roll <= row.upTo {
+				addCoins(row.coins)
+				break
+			}
+		}
+		return t
+	}
+
+	addCoins(hoardCoins[band])
+
+	roll := rollDie(100)
+	for _, row := range hoardValuables[band] {
+		if roll > row.upTo {
+			continue
+		}
+		if row.what.value > 0 {
+			names, kind := gems[row.what.value], "gem"
+			if row.what.art {
+				names, kind = artObjects[row.what.value], "art"
+			}
+			for range RollDiceTotal(2, 6) {
+				t.Items = append(t.Items, fmt.Sprintf("%s (%d gp %s)", pick(names), row.what.value, kind))
+			}
+		}
+		break
+	}
+
+	magic := hoardMagic[band]
+	if rollDie(100) >= magic.atLeast {
+		for range RollDiceTotal(magic.dice, 4) {
+			table := magicItemTables[magic.tables[rollDie(2)-1]]
+			t.Items = append(t.Items, pick(table))
+		}
+	}
+	return t
+}
@@ -0,0 +1,70 @@
+package character
+
+import (
+	"fmt"
+	"slices"
+)
+
+// StartingChoices are the selections made while creating a 1st-level character
+type StartingChoices struct {
+	Class             string
+	Background        string
+	Skills            []string
+	Cantrips          []string
+	Spells            []string
+	SpellcastingScore int // score of the spellcasting ability, for prepared casters
+	PointBuyRemaining int // unspent points when using point buy
+}
+
+// ValidateStartingChoices checks creation choices against the class and
+// background rules and describes each deviation. An empty result means the
+// choices are by the book.
+func ValidateStartingChoices(c StartingChoices) []string {
+	var issues []string
+
+	if c.Background != "" && !slices.Contains(Backgrounds, c.Background) {
+		issues = append(issues, fmt.Sprintf("%s is not a standard background", c.Background))
+	}
+
+	if c.PointBuyRemaining > 0 {
+		issues = append(issues, fmt.Sprintf("%d point-buy points left unspent", c.PointBuyRemaining))
+	}
+
+	if choice, ok := ClassSkillChoices[c.Class]; ok {
+		issues = append(issues, checkPicks("skill", c.Class, c.Skills, choice.Options, choice.Count)...)
+	}
+
+	if info, ok := ClassSpellcasting[c.Class]; ok {
+		issues = append(issues, checkPicks("cantrip", c.Class, c.Cantrips, spellNames(SpellsForClass(c.Class, 0)), info.CantripsKnown)...)
+		issues = append(issues, checkPicks("1st-level spell", c.Class, c.Spells, spellNames(SpellsForClass(c.Class, 1)), SpellsToChoose(c.Class, c.SpellcastingScore))...)
+	} else if len(c.Cantrips)+len(c.Spells) > 0 {
+		issues = append(issues, fmt.Sprintf("%s doesn't cast spells at 1st level", c.Class))
+	}
+
+	return issues
+}
+
+// checkPicks reports picks that are off the allowed list, repeated, or the
+// wrong number
+func checkPicks(kind, class string, picks, allowed []string, count int) []string {
+	var issues []string
+	if len(picks) != count {
+		issues = append(issues, fmt.Sprintf("%s picks %d %ss, %d chosen", class, count, kind, len(picks)))
+	}
+	for i, p := range picks {
+		if slices.Contains(picks[:i], p) {
+			issues = append(issues, fmt.Sprintf("%s chosen twice", p))
+		} else if !slices.Contains(allowed, p) {
+			issues = append(issues, fmt.Sprintf("%s is not a %s %s", p, class, kind))
+		}
+	}
+	return issues
+}
+
+func spellNames(spells []Spell) []string {
+	names := make([]string, len(spells))
+	for i, s := range spells {
+		names[i] = s.Name
+	}
+	return names
+}
@@ -0,0 +1,199 @@
+package character
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Weapon is an SRD weapon
+type Weapon struct {
+	Name       string
+	Martial    bool
+	Ranged     bool
+	Damage     string // Damage dice, e.g. "1d8"
+	DamageType string
+	Versatile  string // Two-handed damage dice for versatile weapons
+	Finesse    bool
+	Properties []string // Other properties, for display
+	Mastery    string   // 2024 weapon mastery property
+}
+
+// WeaponTable lists the SRD weapons, simple before martial and melee before
+// ranged
+var WeaponTable = []Weapon{
+	{Name: "Club", Damage: "1d4", DamageType: "bludgeoning", Properties: []string{"Light"}, Mastery: "Slow"},
+	{Name: "Dagger", Damage: "1d4", DamageType: "piercing", Finesse: true, Properties: []string{"Light", "Thrown (20/60)"}, Mastery: "Nick"},
+	{Name: "Greatclub", Damage: "1d8", DamageType: "bludgeoning", Properties: []string{"Two-handed"}, Mastery: "Push"},
+	{Name: "Handaxe", Damage: "1d6", DamageType: "slashing", Properties: []string{"Light", "Thrown (20/60)"}, Mastery: "Vex"},
+	{Name: "Javelin", Damage: "1d6", DamageType: "piercing", Properties: []string{"Thrown (30/120)"}, Mastery: "Slow"},
+	{Name: "Light Hammer", Damage: "1d4", DamageType: "bludgeoning", Properties: []string{"Light", "Thrown (20/60)"}, Mastery: "Nick"},
+	{Name: "Mace", Damage: "1d6", DamageType: "bludgeoning", Mastery: "Sap"},
+	{Name: "Quarterstaff", Damage: "1d6", DamageType: "bludgeoning", Versatile: "1d8", Mastery: "Topple"},
+	{Name: "Sickle", Damage: "1d4", DamageType: "slashing", Properties: []string{"Light"}, Mastery: "Nick"},
+	{Name: "Spear", Damage: "1d6", DamageType: "piercing", Versatile: "1d8", Properties: []string{"Thrown (20/60)"}, Mastery: "Sap"},
+	{Name: "Light Crossbow", Ranged: true, Damage: "1d8", DamageType: "piercing", Properties: []string{"Ammunition (80/320)", "Loading", "Two-handed"}, Mastery: "Slow"},
+	{Name: "Dart", Ranged: true, Damage: "1d4", DamageType: "piercing", Finesse: true, Properties: []string{"Thrown (20/60)"}, Mastery: "Vex"},
+	{Name: "Shortbow", Ranged: true, Damage: "1d6", DamageType: "piercing", Properties: []string{"Ammunition (80/320)", "Two-handed"}, Mastery: "Vex"},
+	{Name: "Sling", Ranged: true, Damage: "1d4", DamageType: "bludgeoning", Properties: []string{"Ammunition (30/120)"}, Mastery: "Slow"},
+
+	{Name: "Battleaxe", Martial: true, Damage: "1d8", DamageType: "slashing", Versatile: "1d10", Mastery: "Topple"},
+	{Name: "Flail", Martial: true, Damage: "1d8", DamageType: "bludgeoning", Mastery: "Sap"},
+	{Name: "Glaive", Martial: true, Damage: "1d10", DamageType: "slashing", Properties: []string{"Heavy", "Reach", "Two-handed"}, Mastery: "Graze"},
+	{Name: "Greataxe", Martial: true, Damage: "1d12", DamageType: "slashing", Properties: []string{"Heavy", "Two-handed"}, Mastery: "Cleave"},
+	{Name: "Greatsword", Martial: true, Damage: "2d6", DamageType: "slashing", Properties: []string{"Heavy", "Two-handed"}, Mastery: "Graze"},
+	{Name: "Halberd", Martial: true, Damage: "1d10", DamageType: "slashing", Properties: []string{"Heavy", "Reach", "Two-handed"}, Mastery: "Cleave"},
+	{Name: "Lance", Martial: true, Damage: "1d12", DamageType: "piercing", Properties: []string{"Reach", "Special"}, Mastery: "Topple"},
+	{Name: "Longsword", Martial: true, Damage: "1d8", DamageType: "slashing", Versatile: "1d10", Mastery: "Sap"},
+	{Name: "Maul", Martial: true, Damage: "2d6", DamageType: "bludgeoning", Properties: []string{"Heavy", "Two-handed"}, Mastery: "Topple"},
+	{Name: "Morningstar", Martial: true, Damage: "1d8", DamageType: "piercing", Mastery: "Sap"},
+	{Name: "Pike", Martial: true, Damage: "1d10", DamageType: "piercing", Properties: []string{"Heavy", "Reach", "Two-handed"}, Mastery: "Push"},
+	{Name: "Rapier", Martial: true, Damage: "1d8", DamageType: "piercing", Finesse: true, Mastery: "Vex"},
+	{Name: "Scimitar", Martial: true, Damage: "1d6", DamageType: "slashing", Finesse: true, Properties: []string{"Light"}, Mastery: "Nick"},
+	{Name: "Shortsword", Martial: true, Damage: "1d6", DamageType: "piercing", Finesse: true, Properties: []string{"Light"}, Mastery: "Vex"},
+	{Name: "Trident", Martial: true, Damage: "1d6", DamageType: "piercing", Versatile: "1d8", Properties: []string{"Thrown (20/60)"}, Mastery: "Topple"},
+	{Name: "War Pick", Martial: true, Damage: "1d8", DamageType: "piercing", Mastery: "Sap"},
+	{Name: "Warhammer", Martial: true, Damage: "1d8", DamageType: "bludgeoning", Versatile: "1d10", Mastery: "Push"},
+	{Name: "Whip", Martial: true, Damage: "1d4", DamageType: "slashing", Finesse: true, Properties: []string{"Reach"}, Mastery: "Slow"},
+	{Name: "Blowgun", Martial: true, Ranged: true, Damage: "1", DamageType: "piercing", Properties: []string{"Ammunition (25/100)", "Loading"}, Mastery: "Vex"},
+	{Name: "Hand Crossbow", Martial: true, Ranged: true, Damage: "1d6", DamageType: "piercing", Properties: []string{"Ammunition (30/120)", "Light", "Loading"}, Mastery: "Vex"},
+	{Name: "Heavy Crossbow", Martial: true, Ranged: true, Damage: "1d10", DamageType: "piercing", Properties: []string{"Ammunition (100/400)", "Heavy", "Loading", "Two-handed"}, Mastery: "Push"},
+	{Name: "Longbow", Martial: true, Ranged: true, Damage: "1d8", DamageType: "piercing", Properties: []string{"Ammunition (150/600)", "Heavy", "Two-handed"}, Mastery: "Slow"},
+}
+
+// WeaponMasteries explains each 2024 weapon mastery property
+var WeaponMasteries = map[string]string{
+	"Cleave": "On a melee hit, make an attack against a second creature within 5 feet of the first and in reach. It takes the weapon's damage without your ability modifier (unless negative). Once per turn.",
+	"Graze":  "On a miss, the target still takes damage equal to the attack's ability modifier.",
+	"Nick":   "Make the Light property's extra attack as part of the Attack action instead of a Bonus Action. Once per turn.",
+	"Push":   "On a hit, push a Large or smaller creature up to 10 feet straight away from you.",
+	"Sap":    "On a hit, the target has Disadvantage on its next attack roll before the start of your next turn.",
+	"Slow":   "On a hit that deals damage, reduce the target's Speed by 10 feet until the start of your next turn.",
+	"Topple": "On a hit, the target makes a Constitution save (DC 8 + attack ability modifier + Proficiency Bonus) or falls Prone.",
+	"Vex":    "On a hit that deals damage, you have Advantage on your next attack roll against the target before the end of your next turn.",
+}
+
+// classMasteries are the classes with the 2024 Weapon Mastery feature
+var classMasteries = []string{"Barbarian", "Fighter", "Paladin", "Ranger", "Rogue"}
+
+// classWeapons lists the weapons a class is proficient with beyond its
+// categories. Classes not in classMartial or classSimple only get these.
+var classWeapons = map[string][]string{
+	"Bard":     {"Hand Crossbow", "Longsword", "Rapier", "Shortsword"},
+	"Druid":    {"Club", "Dagger", "Dart", "Javelin", "Mace", "Quarterstaff", "Scimitar", "Sickle", "Sling", "Spear"},
+	"Monk":     {"Shortsword"},
+	"Rogue":    {"Hand Crossbow", "Longsword", "Rapier", "Shortsword"},
+	"Sorcerer": {"Dagger", "Dart", "Sling", "Quarterstaff", "Light Crossbow"},
+	"Wizard":   {"Dagger", "Dart", "Sling", "Quarterstaff", "Light Crossbow"},
+}
+
+// classMartial are the classes proficient with all simple and martial weapons
+var classMartial = []string{"Barbarian", "Fighter", "Paladin", "Ranger"}
+
+// classSimple are the classes proficient with all simple weapons
+var classSimple = []string{"Bard", "Cleric", "Monk", "Rogue", "Warlock"}
+
+// WeaponProficient reports whether a class is proficient with a weapon
+func WeaponProficient(class string, w Weapon) bool {
+	switch {
+	case contains(classMartial, class):
+		return true
+	case !w.Martial && contains(classSimple, class):
+		return true
+	}
+	return contains(classWeapons[class], w.Name)
+}
+
+// FindWeapon looks up a weapon by item name, ignoring case. A magic bonus
+// written before or after the name, as in "+1 Longsword" or
+// "Longsword +1", is returned separately.
+func FindWeapon(name string) (Weapon, int, bool) {
+	name, magic := splitMagicBonus(name)
+	for _, w := range WeaponTable {
+		if strings.EqualFold(name, w.Name) {
+			return w, magic, true
+		}
+	}
+	return Weapon{}, 0, false
+}
+
+// splitMagicBonus separates a "+N" prefix or suffix from an item name
+func splitMagicBonus(name string) (string, int) {
+	fields := strings.Fields(name)
+	if len(fields) < 2 {
+		return strings.TrimSpace(name), 0
+	}
+	if n, ok := parseBonus(fields[0]); ok {
+		return strings.Join(fields[1:], " "), n
+	}
+	if n, ok := parseBonus(fields[len(fields)-1]); ok {
+		return strings.TrimSuffix(strings.Join(fields[:len(fields)-1], " "), ","), n
+	}
+	return strings.Join(fields, " "), 0
+}
+
+func parseBonus(s string) (int, bool) {
+	if !strings.HasPrefix(s, "+") {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s[1:])
+	return n, err == nil
+}
+
+// AttackInput is what a weapon attack depends on
+type AttackInput struct {
+	Class            string
+	Strength         int
+	Dexterity        int
+	ProficiencyBonus int
+}
+
+// Attack is a weapon attack with its bonuses worked out
+type Attack struct {
+	Weapon      Weapon
+	Magic       int
+	Ability     string // "STR" or "DEX"
+	Proficient  bool
+	AttackBonus int
+	DamageBonus int
+}
+
+// CalculateAttack works out the attack and damage bonus for a weapon.
+// Ranged weapons use Dexterity, finesse weapons the better of Strength and
+// Dexterity, and everything else Strength. Proficiency applies to the
+// attack roll only; a magic bonus applies to both.
+func CalculateAttack(w Weapon, magic int, in AttackInput) Attack {
+	str, dex := AbilityModifier(in.Strength), AbilityModifier(in.Dexterity)
+	a := Attack{Weapon: w, Magic: magic, Ability: "STR", DamageBonus: str}
+	if w.Ranged || (w.Finesse && dex > str) {
+		a.Ability, a.DamageBonus = "DEX", dex
+	}
+	a.AttackBonus = a.DamageBonus + magic
+	a.DamageBonus += magic
+	a.Proficient = WeaponProficient(in.Class, w)
+	if a.Proficient {
+		a.AttackBonus += in.ProficiencyBonus
+	}
+	return a
+}
+
+// Damage formats the damage roll, e.g. "1d8+3 slashing"
+func (a Attack) Damage() string {
+	return fmt.Sprintf("%s %s", withBonus(a.Weapon.Damage, a.DamageBonus), a.Weapon.DamageType)
+}
+
+// VersatileDamage formats the two-handed damage roll, or "" if the weapon
+// isn't versatile
+func (a Attack) VersatileDamage() string {
+	if a.Weapon.Versatile == "" {
+		return ""
+	}
+	return withBonus(a.Weapon.Versatile, a.DamageBonus)
+}
+
+func withBonus(dice string, bonus int) string {
+	if bonus == 0 {
+		return dice
+	}
+	return dice + FormatModifierInt(bonus)
+}
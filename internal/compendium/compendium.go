@@ -0,0 +1,64 @@
+// Package compendium imports content packs, such as Open5e API dumps and
+// 5eTools data files, so a server can offer more than the built-in SRD
+// spells
+package compendium
+
+import (
+	"context"
+
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+)
+
+// Store saves spells from a content pack, replacing earlier imports of the
+// same names. source records which pack they came from.
+func Store(ctx context.Context, queries *db.Queries, spells []character.Spell, source string) error {
+	for _, spell := range spells {
+		err := queries.UpsertCompendiumSpell(ctx, db.UpsertCompendiumSpellParams{
+			Name:          spell.Name,
+			Level:         int32(spell.Level),
+			School:        spell.School,
+			CastingTime:   spell.CastingTime,
+			SpellRange:    spell.Range,
+			Components:    spell.Components,
+			Duration:      spell.Duration,
+			Concentration: spell.Concentration,
+			Ritual:        spell.Ritual,
+			Classes:       spell.Classes,
+			Description:   spell.Description,
+			HigherLevels:  spell.HigherLevels,
+			Source:        source,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load adds the imported spells to the ones the app offers, returning how
+// many were new. Call it once at startup.
+func Load(ctx context.Context, queries *db.Queries) (int, error) {
+	rows, err := queries.GetCompendiumSpells(ctx)
+	if err != nil {
+		return 0, err
+	}
+	spells := make([]character.Spell, len(rows))
+	for i, row := range rows {
+		spells[i] = character.Spell{
+			Name:          row.Name,
+			Level:         int(row.Level),
+			School:        row.School,
+			CastingTime:   row.CastingTime,
+			Range:         row.SpellRange,
+			Components:    row.Components,
+			Duration:      row.Duration,
+			Concentration: row.Concentration,
+			Ritual:        row.Ritual,
+			Classes:       row.Classes,
+			Description:   row.Description,
+			HigherLevels:  row.HigherLevels,
+		}
+	}
+	return character.AddSpells(spells), nil
+}
@@ -0,0 +1,106 @@
+package compendium
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+)
+
+// ErrNotFound is returned when Open5e has no spell by the name asked for
+var ErrNotFound = errors.New("not found on Open5e")
+
+// maxResponse caps how much of an Open5e response is read
+const maxResponse = 1 << 20
+
+// Open5e looks up spells missing from the local list on an Open5e API
+// server, saving what it finds with the imported spells so later lookups,
+// and restarts, don't need the network. A nil Open5e, used when the
+// operator hasn't set a URL, finds nothing.
+type Open5e struct {
+	url     string
+	client  *http.Client
+	queries *db.Queries
+
+	mu     sync.Mutex
+	missed map[string]bool // names Open5e didn't have, not asked for again
+}
+
+// NewOpen5e returns a lookup against the API at baseURL, such as
+// https://api.open5e.com, or nil if baseURL is empty
+func NewOpen5e(baseURL string, queries *db.Queries) *Open5e {
+	if baseURL == "" {
+		return nil
+	}
+	return &Open5e{
+		url:     strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+		queries: queries,
+		missed:  map[string]bool{},
+	}
+}
+
+// Enabled reports whether spells are looked up online at all
+func (o *Open5e) Enabled() bool {
+	return o != nil
+}
+
+// Spell fetches a spell by name, adds it to the local list, and caches it
+// in the database
+func (o *Open5e) Spell(ctx context.Context, name string) (character.Spell, error) {
+	if o == nil {
+		return character.Spell{}, ErrNotFound
+	}
+	key := strings.ToLower(name)
+	o.mu.Lock()
+	missed := o.missed[key]
+	o.mu.Unlock()
+	if missed {
+		return character.Spell{}, ErrNotFound
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.url+"/v1/spells/?name__iexact="+url.QueryEscape(name), nil)
+	if err != nil {
+		return character.Spell{}, err
+	}
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return character.Spell{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return character.Spell{}, fmt.Errorf("open5e returned %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponse))
+	if err != nil {
+		return character.Spell{}, err
+	}
+
+	spells, err := ParseSpells(data)
+	if err != nil && !errors.Is(err, ErrNoSpells) {
+		return character.Spell{}, err
+	}
+	for _, spell := range spells {
+		if !strings.EqualFold(spell.Name, name) {
+			continue
+		}
+		if err := Store(ctx, o.queries, []character.Spell{spell}, "Open5e"); err != nil {
+			return character.Spell{}, err
+		}
+		character.AddSpells([]character.Spell{spell})
+		return spell, nil
+	}
+
+	o.mu.Lock()
+	o.missed[key] = true
+	o.mu.Unlock()
+	return character.Spell{}, ErrNotFound
+}
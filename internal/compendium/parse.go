@@ -0,0 +1,335 @@
+package compendium
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/sanitize"
+)
+
+// ErrNoSpells is returned for a file with nothing that looks like a spell
+var ErrNoSpells = errors.New("no spells found")
+
+// ParseSpells reads the spells in a content pack. It accepts Open5e spells,
+// as an API response with "results" or a bare array, and 5eTools spell
+// files, which list them under "spell". Monsters and items in the same
+// file are skipped.
+func ParseSpells(data []byte) ([]character.Spell, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var spells []open5eSpell
+		if err := json.Unmarshal(data, &spells); err != nil {
+			return nil, err
+		}
+		return convert(spells, open5eSpell.spell)
+	}
+
+	var pack struct {
+		Results []open5eSpell    `json:"results"`
+		Spell   []fiveToolsSpell `json:"spell"`
+	}
+	if err := json.Unmarshal(data, &pack); err != nil {
+		return nil, err
+	}
+	if len(pack.Spell) > 0 {
+		return convert(pack.Spell, fiveToolsSpell.spell)
+	}
+	return convert(pack.Results, open5eSpell.spell)
+}
+
+// convert turns each entry into a spell, cleaning its text, and skips
+// entries without a name, such as monsters in a mixed Open5e dump
+func convert[T any](entries []T, toSpell func(T) (character.Spell, bool)) ([]character.Spell, error) {
+	var spells []character.Spell
+	for _, entry := range entries {
+		spell, ok := toSpell(entry)
+		if !ok {
+			continue
+		}
+		spell.Name = sanitize.Line(spell.Name, sanitize.MaxName)
+		if spell.Name == "" || spell.Level < 0 || spell.Level > 9 {
+			continue
+		}
+		spell.School = sanitize.Line(spell.School, sanitize.MaxShort)
+		spell.CastingTime = sanitize.Line(spell.CastingTime, sanitize.MaxName)
+		spell.Range = sanitize.Line(spell.Range, sanitize.MaxName)
+		spell.Components = sanitize.Line(spell.Components, sanitize.MaxText)
+		spell.Duration = sanitize.Line(spell.Duration, sanitize.MaxName)
+		spell.Description = sanitize.Text(spell.Description, sanitize.MaxText)
+		spell.HigherLevels = sanitize.Text(spell.HigherLevels, sanitize.MaxText)
+		for i, class := range spell.Classes {
+			spell.Classes[i] = sanitize.Line(class, sanitize.MaxShort)
+		}
+		spells = append(spells, spell)
+	}
+	if len(spells) == 0 {
+		return nil, ErrNoSpells
+	}
+	return spells, nil
+}
+
+// yesNo is a flag Open5e writes as "yes"/"no" in v1 and as a bool in v2
+type yesNo bool
+
+func (y *yesNo) UnmarshalJSON(data []byte) error {
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		*y = yesNo(b)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*y = yesNo(strings.EqualFold(s, "yes") || strings.EqualFold(s, "true"))
+	return nil
+}
+
+// open5eSpell is a spell from Open5e's /v1/spells endpoint
+type open5eSpell struct {
+	Name          string   `json:"name"`
+	Desc          string   `json:"desc"`
+	HigherLevel   string   `json:"higher_level"`
+	Range         string   `json:"range"`
+	Components    string   `json:"components"`
+	Material      string   `json:"material"`
+	Ritual        yesNo    `json:"ritual"`
+	Duration      string   `json:"duration"`
+	Concentration yesNo    `json:"concentration"`
+	CastingTime   string   `json:"casting_time"`
+	LevelInt      *int     `json:"level_int"`
+	School        string   `json:"school"`
+	DndClass      string   `json:"dnd_class"`
+	SpellLists    []string `json:"spell_lists"`
+}
+
+func (o open5eSpell) spell() (character.Spell, bool) {
+	if o.LevelInt == nil {
+		// Not a spell
+		return character.Spell{}, false
+	}
+	components := o.Components
+	if o.Material != "" {
+		components += " (" + o.Material + ")"
+	}
+	classes := splitList(o.DndClass)
+	if len(classes) == 0 {
+		for _, list := range o.SpellLists {
+			classes = append(classes, titleCase(list))
+		}
+	}
+	return character.Spell{
+		Name:          o.Name,
+		Level:         *o.LevelInt,
+		School:        titleCase(o.School),
+		CastingTime:   o.CastingTime,
+		Range:         o.Range,
+		Components:    components,
+		Duration:      o.Duration,
+		Concentration: bool(o.Concentration),
+		Ritual:        bool(o.Ritual),
+		Classes:       classes,
+		Description:   o.Desc,
+		HigherLevels:  o.HigherLevel,
+	}, true
+}
+
+// fiveToolsSpell is the part of a 5eTools spell the app uses
+type fiveToolsSpell struct {
+	Name   string `json:"name"`
+	Level  *int   `json:"level"`
+	School string `json:"school"`
+	Time   []struct {
+		Number int    `json:"number"`
+		Unit   string `json:"unit"`
+	} `json:"time"`
+	Range struct {
+		Type     string `json:"type"`
+		Distance struct {
+			Type   string `json:"type"`
+			Amount int    `json:"amount"`
+		} `json:"distance"`
+	} `json:"range"`
+	Components struct {
+		V bool `json:"v"`
+		S bool `json:"s"`
+		M any  `json:"m"` // a string, or an object with "text"
+	} `json:"components"`
+	Duration []struct {
+		Type     string `json:"type"`
+		Duration struct {
+			Type   string `json:"type"`
+			Amount int    `json:"amount"`
+		} `json:"duration"`
+		Concentration bool `json:"concentration"`
+	} `json:"duration"`
+	Meta struct {
+		Ritual bool `json:"ritual"`
+	} `json:"meta"`
+	Entries            []any `json:"entries"`
+	EntriesHigherLevel []any `json:"entriesHigherLevel"`
+	Classes            struct {
+		FromClassList []struct {
+			Name string `json:"name"`
+		} `json:"fromClassList"`
+	} `json:"classes"`
+}
+
+// fiveToolsSchools maps 5eTools' one-letter school codes
+var fiveToolsSchools = map[string]string{
+	"A": "Abjuration", "C": "Conjuration", "D": "Divination", "E": "Enchantment",
+	"V": "Evocation", "I": "Illusion", "N": "Necromancy", "T": "Transmutation",
+}
+
+func (f fiveToolsSpell) spell() (character.Spell, bool) {
+	if f.Level == nil {
+		return character.Spell{}, false
+	}
+	spell := character.Spell{
+		Name:         f.Name,
+		Level:        *f.Level,
+		School:       fiveToolsSchools[f.School],
+		Ritual:       f.Meta.Ritual,
+		Description:  strings.Join(flattenEntries(f.Entries), "\n\n"),
+		HigherLevels: strings.Join(flattenEntries(f.EntriesHigherLevel), "\n\n"),
+	}
+
+	var times []string
+	for _, t := range f.Time {
+		times = append(times, plural(t.Number, t.Unit))
+	}
+	spell.CastingTime = strings.Join(times, " or ")
+
+	switch d := f.Range.Distance; {
+	case f.Range.Type != "point" && d.Type == "feet":
+		// Areas centered on the caster, like a 15-foot cone
+		spell.Range = fmt.Sprintf("Self (%d-foot %s)", d.Amount, f.Range.Type)
+	case d.Type == "feet":
+		spell.Range = fmt.Sprintf("%d feet", d.Amount)
+	case d.Amount > 0:
+		spell.Range = plural(d.Amount, strings.TrimSuffix(d.Type, "s"))
+	case d.Type != "":
+		spell.Range = titleCase(d.Type)
+	default:
+		spell.Range = titleCase(f.Range.Type)
+	}
+
+	var components []string
+	if f.Components.V {
+		components = append(components, "V")
+	}
+	if f.Components.S {
+		components = append(components, "S")
+	}
+	switch m := f.Components.M.(type) {
+	case string:
+		components = append(components, "M ("+m+")")
+	case map[string]any:
+		text, _ := m["text"].(string)
+		components = append(components, "M ("+text+")")
+	case bool:
+		if m {
+			components = append(components, "M")
+		}
+	}
+	spell.Components = strings.Join(components, ", ")
+
+	if len(f.Duration) > 0 {
+		d := f.Duration[0]
+		spell.Concentration = d.Concentration
+		switch d.Type {
+		case "instant":
+			spell.Duration = "Instantaneous"
+		case "permanent":
+			spell.Duration = "Until dispelled"
+		case "timed":
+			spell.Duration = plural(d.Duration.Amount, d.Duration.Type)
+			if d.Concentration {
+				spell.Duration = "Concentration, up to " + spell.Duration
+			}
+		default:
+			spell.Duration = titleCase(d.Type)
+		}
+	}
+
+	for _, c := range f.Classes.FromClassList {
+		spell.Classes = append(spell.Classes, c.Name)
+	}
+	return spell, true
+}
+
+// flattenEntries turns 5eTools entries, which are strings or objects with
+// entries or items of their own, into paragraphs. Inline tags such as
+// {@damage 1d6} are reduced to their text.
+func flattenEntries(entries []any) []string {
+	var paragraphs []string
+	for _, entry := range entries {
+		switch e := entry.(type) {
+		case string:
+			paragraphs = append(paragraphs, stripTags(e))
+		case map[string]any:
+			for _, key := range []string{"entries", "items"} {
+				if nested, ok := e[key].([]any); ok {
+					paragraphs = append(paragraphs, flattenEntries(nested)...)
+				}
+			}
+		}
+	}
+	return paragraphs
+}
+
+// stripTags replaces 5eTools tags like {@spell fireball|phb} with their
+// display text, "fireball"
+func stripTags(s string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "{@")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(s[start:], "}")
+		if end < 0 {
+			break
+		}
+		b.WriteString(s[:start])
+		tag := s[start+2 : start+end]
+		if _, text, ok := strings.Cut(tag, " "); ok {
+			text, _, _ = strings.Cut(text, "|")
+			b.WriteString(text)
+		}
+		s = s[start+end+1:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// plural writes an amount of a unit, like "1 action" or "10 minutes"
+func plural(n int, unit string) string {
+	if n != 1 && !strings.HasSuffix(unit, "s") {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s", n, unit)
+}
+
+// splitList splits a comma-separated list, such as Open5e's "Bard, Wizard"
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// titleCase capitalizes the first letter, e.g. "evocation" to "Evocation"
+func titleCase(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
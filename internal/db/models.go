@@ -8,6 +8,15 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ApiToken struct {
+	ID         pgtype.UUID        `json:"id"`
+	UserID     pgtype.UUID        `json:"user_id"`
+	Name       string             `json:"name"`
+	TokenHash  string             `json:"token_hash"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
 type Character struct {
 	ID                       pgtype.UUID        `json:"id"`
 	UserID                   pgtype.UUID        `json:"user_id"`
@@ -31,18 +40,213 @@ type Character struct {
 	Speed                    int32              `json:"speed"`
 	SavingThrowProficiencies []string           `json:"saving_throw_proficiencies"`
 	SkillProficiencies       []string           `json:"skill_proficiencies"`
-	Equipment                []byte             `json:"equipment"`
 	FeaturesTraits           string             `json:"features_traits"`
 	Notes                    string             `json:"notes"`
 	CreatedAt                pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt                pgtype.Timestamptz `json:"updated_at"`
+	LastPlayedAt             pgtype.Timestamptz `json:"last_played_at"`
+	IsTemplate               bool               `json:"is_template"`
+	ConcentratingOn          string             `json:"concentrating_on"`
+	Exhaustion               int32              `json:"exhaustion"`
+	Inspiration              bool               `json:"inspiration"`
+	LuckPoints               int32              `json:"luck_points"`
+	Ruleset                  string             `json:"ruleset"`
+	SkillExpertise           []string           `json:"skill_expertise"`
+	SkillHalfProficiencies   []string           `json:"skill_half_proficiencies"`
+	ArmorClassOverride       pgtype.Int4        `json:"armor_class_override"`
+	MaxHitPointsBonus        int32              `json:"max_hit_points_bonus"`
+	Accent                   string             `json:"accent"`
+	Icon                     string             `json:"icon"`
+	Languages                []string           `json:"languages"`
+	ToolProficiencies        []string           `json:"tool_proficiencies"`
+	FlySpeed                 int32              `json:"fly_speed"`
+	SwimSpeed                int32              `json:"swim_speed"`
+	ClimbSpeed               int32              `json:"climb_speed"`
+	BurrowSpeed              int32              `json:"burrow_speed"`
+	Darkvision               int32              `json:"darkvision"`
+	Blindsight               int32              `json:"blindsight"`
+	Truesight                int32              `json:"truesight"`
+}
+
+type CharacterCrafting struct {
+	CharacterID pgtype.UUID        `json:"character_id"`
+	Item        string             `json:"item"`
+	Price       int32              `json:"price"`
+	Progress    int32              `json:"progress"`
+	StartedAt   pgtype.Timestamptz `json:"started_at"`
+}
+
+type CharacterEvent struct {
+	ID          pgtype.UUID        `json:"id"`
+	CharacterID pgtype.UUID        `json:"character_id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Kind        string             `json:"kind"`
+	Description string             `json:"description"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type CharacterHpHistory struct {
+	ID                 pgtype.UUID        `json:"id"`
+	CharacterID        pgtype.UUID        `json:"character_id"`
+	CurrentHitPoints   int32              `json:"current_hit_points"`
+	TemporaryHitPoints int32              `json:"temporary_hit_points"`
+	MaxHitPoints       int32              `json:"max_hit_points"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+}
+
+type CharacterInventory struct {
+	ID          pgtype.UUID        `json:"id"`
+	CharacterID pgtype.UUID        `json:"character_id"`
+	Name        string             `json:"name"`
+	Quantity    int32              `json:"quantity"`
+	Equipped    bool               `json:"equipped"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	Location    string             `json:"location"`
+}
+
+type CharacterJournal struct {
+	ID          pgtype.UUID        `json:"id"`
+	CharacterID pgtype.UUID        `json:"character_id"`
+	Title       string             `json:"title"`
+	Body        string             `json:"body"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type CharacterPlanLevel struct {
+	CharacterID      pgtype.UUID        `json:"character_id"`
+	Level            int32              `json:"level"`
+	Class            string             `json:"class"`
+	Subclass         string             `json:"subclass"`
+	Feat             string             `json:"feat"`
+	AbilityIncreases []string           `json:"ability_increases"`
+	Notes            string             `json:"notes"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+}
+
+type CharacterPortrait struct {
+	CharacterID pgtype.UUID        `json:"character_id"`
+	Width       int32              `json:"width"`
+	Height      int32              `json:"height"`
+	Pixels      []byte             `json:"pixels"`
+	SourceUrl   string             `json:"source_url"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type CharacterResource struct {
+	CharacterID pgtype.UUID `json:"character_id"`
+	Name        string      `json:"name"`
+	MaxUses     int32       `json:"max_uses"`
+	Used        int32       `json:"used"`
+	Recharge    string      `json:"recharge"`
+}
+
+type CharacterSpell struct {
+	ID            pgtype.UUID        `json:"id"`
+	CharacterID   pgtype.UUID        `json:"character_id"`
+	Name          string             `json:"name"`
+	Level         int32              `json:"level"`
+	Prepared      bool               `json:"prepared"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	Concentration bool               `json:"concentration"`
+}
+
+type CharacterSpellcasting struct {
+	CharacterID         pgtype.UUID        `json:"character_id"`
+	SpellcastingClass   string             `json:"spellcasting_class"`
+	SpellcastingAbility string             `json:"spellcasting_ability"`
+	SpellSaveDc         int32              `json:"spell_save_dc"`
+	SpellAttackBonus    int32              `json:"spell_attack_bonus"`
+	SlotsMax            []int32            `json:"slots_max"`
+	SlotsUsed           []int32            `json:"slots_used"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+}
+
+type CompendiumSpell struct {
+	Name          string             `json:"name"`
+	Level         int32              `json:"level"`
+	School        string             `json:"school"`
+	CastingTime   string             `json:"casting_time"`
+	SpellRange    string             `json:"spell_range"`
+	Components    string             `json:"components"`
+	Duration      string             `json:"duration"`
+	Concentration bool               `json:"concentration"`
+	Ritual        bool               `json:"ritual"`
+	Classes       []string           `json:"classes"`
+	Description   string             `json:"description"`
+	HigherLevels  string             `json:"higher_levels"`
+	Source        string             `json:"source"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type IdentityLinkCode struct {
+	Code      string             `json:"code"`
+	Provider  string             `json:"provider"`
+	Subject   string             `json:"subject"`
+	Email     pgtype.Text        `json:"email"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Invite struct {
+	ID        pgtype.UUID        `json:"id"`
+	Code      string             `json:"code"`
+	CreatedBy pgtype.UUID        `json:"created_by"`
+	UsedBy    pgtype.UUID        `json:"used_by"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type PasswordReset struct {
+	TokenHash string             `json:"token_hash"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type ScheduledJob struct {
+	Name           string             `json:"name"`
+	LastRunAt      pgtype.Timestamptz `json:"last_run_at"`
+	LastFinishedAt pgtype.Timestamptz `json:"last_finished_at"`
+	LastError      pgtype.Text        `json:"last_error"`
+}
+
+type ServerAnnouncement struct {
+	ID        pgtype.UUID        `json:"id"`
+	Message   string             `json:"message"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type ShareLink struct {
+	TokenHash   string             `json:"token_hash"`
+	CharacterID pgtype.UUID        `json:"character_id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
 
 type User struct {
-	ID           pgtype.UUID        `json:"id"`
-	Email        pgtype.Text        `json:"email"`
-	PasswordHash pgtype.Text        `json:"password_hash"`
-	PublicKey    pgtype.Text        `json:"public_key"`
-	CreatedAt    pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	ID            pgtype.UUID        `json:"id"`
+	Email         pgtype.Text        `json:"email"`
+	PasswordHash  pgtype.Text        `json:"password_hash"`
+	PublicKey     pgtype.Text        `json:"public_key"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	UsageStats    bool               `json:"usage_stats"`
+	Theme         string             `json:"theme"`
+	Notifications string             `json:"notifications"`
+	Role          string             `json:"role"`
+	DisabledAt    pgtype.Timestamptz `json:"disabled_at"`
+}
+
+type UserIdentity struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Provider  string             `json:"provider"`
+	Subject   string             `json:"subject"`
+	Email     pgtype.Text        `json:"email"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
@@ -31,16 +31,72 @@ UPDATE users SET password_hash = $2 WHERE id = $1 RETURNING *;
 -- name: UpdateUserEmail :one
 UPDATE users SET email = $2 WHERE id = $1 RETURNING *;
 
+-- name: UpdateUserUsageStats :one
+UPDATE users SET usage_stats = $2 WHERE id = $1 RETURNING *;
+
+-- name: UpdateUserTheme :one
+UPDATE users SET theme = $2 WHERE id = $1 RETURNING *;
+
+-- name: UpdateUserNotifications :one
+UPDATE users SET notifications = $2 WHERE id = $1 RETURNING *;
+
 -- name: DeleteUser :exec
 DELETE FROM users WHERE id = $1;
 
+-- name: SetUserRole :one
+UPDATE users SET role = $2 WHERE id = $1 RETURNING *;
+
+-- name: DisableUser :one
+UPDATE users SET disabled_at = NOW() WHERE id = $1 RETURNING *;
+
+-- name: EnableUser :one
+UPDATE users SET disabled_at = NULL WHERE id = $1 RETURNING *;
+
+-- name: ListDisabledUserIDs :many
+SELECT id FROM users WHERE disabled_at IS NOT NULL;
+
+-- name: ListUsersWithCharacterCounts :many
+SELECT u.id, u.email, u.public_key, u.created_at, u.role, u.disabled_at, COUNT(c.id) AS character_count FROM users u
+LEFT JOIN characters c ON c.user_id = u.id AND NOT c.is_template
+GROUP BY u.id
+ORDER BY u.created_at;
+
 -- Character Queries
 
 -- name: GetCharacterByID :one
 SELECT * FROM characters WHERE id = $1;
 
 -- name: GetCharactersByUserID :many
-SELECT * FROM characters WHERE user_id = $1 ORDER BY updated_at DESC;
+SELECT * FROM characters WHERE user_id = $1 AND NOT is_template ORDER BY updated_at DESC;
+
+-- name: GetCharactersByUserIDPaged :many
+SELECT * FROM characters
+WHERE user_id = @user_id
+  AND is_template = @templates
+  AND (@search::text = '' OR name ILIKE @search OR race ILIKE @search OR class ILIKE @search)
+ORDER BY
+  CASE WHEN @sort_by::text = 'level' THEN level END DESC,
+  CASE WHEN @sort_by::text = 'last_played' THEN last_played_at END DESC NULLS LAST,
+  name ASC
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountCharactersByUserID :one
+SELECT COUNT(*) FROM characters
+WHERE user_id = @user_id
+  AND is_template = @templates
+  AND (@search::text = '' OR name ILIKE @search OR race ILIKE @search OR class ILIKE @search);
+
+-- name: CharacterNameTaken :one
+SELECT EXISTS (
+    SELECT 1 FROM characters
+    WHERE user_id = @user_id
+      AND NOT is_template
+      AND lower(name) = lower(@name::text)
+      AND id IS DISTINCT FROM @exclude_id
+);
+
+-- name: UpdateCharacterLastPlayed :exec
+UPDATE characters SET last_played_at = NOW() WHERE id = $1;
 
 -- name: CreateCharacter :one
 INSERT INTO characters (
@@ -49,17 +105,42 @@ INSERT INTO characters (
     max_hit_points, current_hit_points, temporary_hit_points,
     armor_class, speed,
     saving_throw_proficiencies, skill_proficiencies,
-    equipment, features_traits, notes
+    features_traits, notes, ruleset, darkvision
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8,
     $9, $10, $11, $12, $13, $14,
     $15, $16, $17,
     $18, $19,
     $20, $21,
-    $22, $23, $24
+    $22, $23, $24, $25
 )
 RETURNING *;
 
+-- name: CopyCharacter :one
+INSERT INTO characters (
+    user_id, name, class, level, race, background, alignment, experience_points,
+    strength, dexterity, constitution, intelligence, wisdom, charisma,
+    max_hit_points, current_hit_points, temporary_hit_points,
+    armor_class, speed,
+    saving_throw_proficiencies, skill_proficiencies,
+    features_traits, notes, is_template, ruleset,
+    skill_expertise, skill_half_proficiencies, armor_class_override,
+    accent, icon, languages, tool_proficiencies,
+    fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
+)
+SELECT
+    user_id, @name::text, class, level, race, background, alignment, experience_points,
+    strength, dexterity, constitution, intelligence, wisdom, charisma,
+    max_hit_points, current_hit_points, temporary_hit_points,
+    armor_class, speed,
+    saving_throw_proficiencies, skill_proficiencies,
+    features_traits, notes, @is_template::boolean, ruleset,
+    skill_expertise, skill_half_proficiencies, armor_class_override,
+    accent, icon, languages, tool_proficiencies,
+    fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
+FROM characters WHERE id = @id
+RETURNING *;
+
 -- name: UpdateCharacterBasicInfo :one
 UPDATE characters SET
     name = $2,
@@ -72,6 +153,12 @@ UPDATE characters SET
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateCharacterName :one
+UPDATE characters SET name = $2 WHERE id = $1 RETURNING *;
+
+-- name: UpdateCharacterAppearance :one
+UPDATE characters SET accent = $2, icon = $3 WHERE id = $1 RETURNING *;
+
 -- name: UpdateCharacterAbilities :one
 UPDATE characters SET
     strength = $2,
@@ -100,15 +187,69 @@ UPDATE characters SET
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateCharacterMaxHitPointsBonus :one
+UPDATE characters SET
+    max_hit_points_bonus = $2,
+    current_hit_points = $3
+WHERE id = $1
+RETURNING *;
+
 -- name: UpdateCharacterProficiencies :one
 UPDATE characters SET
     saving_throw_proficiencies = $2,
-    skill_proficiencies = $3
+    skill_proficiencies = $3,
+    skill_expertise = $4,
+    skill_half_proficiencies = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateCharacterLanguages :one
+UPDATE characters SET languages = $2, tool_proficiencies = $3 WHERE id = $1 RETURNING *;
+
+-- name: UpdateCharacterMovement :one
+UPDATE characters SET
+    speed = $2,
+    fly_speed = $3,
+    swim_speed = $4,
+    climb_speed = $5,
+    burrow_speed = $6,
+    darkvision = $7,
+    blindsight = $8,
+    truesight = $9
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateCharacterArmorClass :one
+UPDATE characters SET
+    armor_class = $2,
+    armor_class_override = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateCharacterExperience :one
+UPDATE characters SET experience_points = $2 WHERE id = $1 RETURNING *;
+
+-- name: UpdateCharacterConcentration :one
+UPDATE characters SET concentrating_on = $2 WHERE id = $1 RETURNING *;
+
+-- name: UpdateCharacterExhaustion :one
+UPDATE characters SET exhaustion = $2 WHERE id = $1 RETURNING *;
+
+-- name: UpdateCharacterInspiration :one
+UPDATE characters SET
+    inspiration = $2,
+    luck_points = $3
 WHERE id = $1
 RETURNING *;
 
--- name: UpdateCharacterEquipment :one
-UPDATE characters SET equipment = $2 WHERE id = $1 RETURNING *;
+-- name: UpdateCharacterRest :one
+UPDATE characters SET
+    current_hit_points = $2,
+    temporary_hit_points = $3,
+    exhaustion = $4,
+    inspiration = $5
+WHERE id = $1
+RETURNING *;
 
 -- name: UpdateCharacterNotes :one
 UPDATE characters SET
@@ -117,8 +258,403 @@ UPDATE characters SET
 WHERE id = $1
 RETURNING *;
 
+-- name: LevelUpCharacter :one
+UPDATE characters SET
+    level = $2,
+    max_hit_points = $3,
+    current_hit_points = $4,
+    strength = $5,
+    dexterity = $6,
+    constitution = $7,
+    intelligence = $8,
+    wisdom = $9,
+    charisma = $10
+WHERE id = $1 AND level = $2 - 1
+RETURNING *;
+
 -- name: DeleteCharacter :exec
 DELETE FROM characters WHERE id = $1;
 
 -- name: DeleteCharacterByUserID :exec
 DELETE FROM characters WHERE id = $1 AND user_id = $2;
+
+-- Spellcasting Queries
+
+-- name: CreateCharacterSpellcasting :one
+INSERT INTO character_spellcasting (
+    character_id, spellcasting_class, spellcasting_ability,
+    spell_save_dc, spell_attack_bonus, slots_max
+) VALUES (
+    $1, $2, $3,
+    $4, $5, $6
+)
+RETURNING *;
+
+-- name: CopyCharacterSpellcasting :exec
+INSERT INTO character_spellcasting (
+    character_id, spellcasting_class, spellcasting_ability,
+    spell_save_dc, spell_attack_bonus, slots_max, slots_used
+)
+SELECT
+    @new_id::uuid, spellcasting_class, spellcasting_ability,
+    spell_save_dc, spell_attack_bonus, slots_max, slots_used
+FROM character_spellcasting WHERE character_id = @source_id;
+
+-- name: GetCharacterSpellcasting :one
+SELECT * FROM character_spellcasting WHERE character_id = $1;
+
+-- name: AddCharacterSpell :one
+INSERT INTO character_spells (character_id, name, level, prepared, concentration)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetCharacterSpells :many
+SELECT * FROM character_spells WHERE character_id = $1 ORDER BY level, name;
+
+-- name: SetCharacterSpellPrepared :exec
+UPDATE character_spells SET prepared = $2 WHERE id = $1;
+
+-- name: DeleteCharacterSpell :exec
+DELETE FROM character_spells WHERE id = $1;
+
+-- name: UpdateSpellSlotsUsed :exec
+UPDATE character_spellcasting SET slots_used = $2 WHERE character_id = $1;
+
+-- name: UpdateCharacterSpellcasting :exec
+UPDATE character_spellcasting SET
+    spell_save_dc = $2,
+    spell_attack_bonus = $3,
+    slots_max = $4,
+    slots_used = $5
+WHERE character_id = $1;
+
+-- name: DeleteCharacterSpellcasting :exec
+DELETE FROM character_spellcasting WHERE character_id = $1;
+
+-- name: CopyCharacterSpells :exec
+INSERT INTO character_spells (character_id, name, level, prepared, concentration)
+SELECT @new_id::uuid, name, level, prepared, concentration
+FROM character_spells WHERE character_id = @source_id;
+
+-- Inventory Queries
+
+-- name: AddInventoryItem :one
+INSERT INTO character_inventory (character_id, name, quantity, equipped, location)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetCharacterInventory :many
+SELECT * FROM character_inventory WHERE character_id = $1 ORDER BY name, created_at;
+
+-- name: AddInventoryQuantity :one
+UPDATE character_inventory SET quantity = quantity + $2 WHERE id = $1 RETURNING *;
+
+-- name: SetInventoryItemEquipped :one
+UPDATE character_inventory SET equipped = $2 WHERE id = $1 RETURNING *;
+
+-- name: SetInventoryItemLocation :one
+UPDATE character_inventory SET location = $2 WHERE id = $1 RETURNING *;
+
+-- name: DeleteInventoryItem :exec
+DELETE FROM character_inventory WHERE id = $1;
+
+-- name: DeleteCharacterInventory :exec
+DELETE FROM character_inventory WHERE character_id = $1;
+
+-- name: CopyCharacterInventory :exec
+INSERT INTO character_inventory (character_id, name, quantity, equipped, location)
+SELECT @new_id::uuid, name, quantity, equipped, location
+FROM character_inventory WHERE character_id = @source_id;
+
+-- Journal Queries
+
+-- name: AddJournalEntry :one
+INSERT INTO character_journal (character_id, title, body)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetCharacterJournal :many
+SELECT * FROM character_journal WHERE character_id = $1 ORDER BY updated_at DESC, title;
+
+-- name: UpdateJournalEntry :one
+UPDATE character_journal SET title = $2, body = $3, updated_at = NOW() WHERE id = $1 RETURNING *;
+
+-- name: DeleteJournalEntry :exec
+DELETE FROM character_journal WHERE id = $1;
+
+-- name: CopyCharacterJournal :exec
+INSERT INTO character_journal (character_id, title, body, created_at, updated_at)
+SELECT @new_id::uuid, title, body, created_at, updated_at
+FROM character_journal WHERE character_id = @source_id;
+
+-- Resource Queries
+
+-- name: GetCharacterResources :many
+SELECT * FROM character_resources WHERE character_id = $1 ORDER BY name;
+
+-- name: UpsertCharacterResource :one
+INSERT INTO character_resources (character_id, name, max_uses, recharge)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (character_id, name) DO UPDATE
+SET max_uses = EXCLUDED.max_uses, recharge = EXCLUDED.recharge,
+    used = LEAST(character_resources.used, EXCLUDED.max_uses)
+RETURNING *;
+
+-- name: SetResourceUsed :exec
+UPDATE character_resources SET used = $3 WHERE character_id = $1 AND name = $2;
+
+-- name: DeleteCharacterResource :exec
+DELETE FROM character_resources WHERE character_id = $1 AND name = $2;
+
+-- name: CopyCharacterResources :exec
+INSERT INTO character_resources (character_id, name, max_uses, used, recharge)
+SELECT @new_id::uuid, name, max_uses, used, recharge
+FROM character_resources WHERE character_id = @source_id;
+
+-- API Token Queries
+
+-- name: CreateAPIToken :one
+INSERT INTO api_tokens (user_id, name, token_hash)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetAPITokenByHash :one
+SELECT * FROM api_tokens WHERE token_hash = $1;
+
+-- name: GetAPITokensByUserID :many
+SELECT * FROM api_tokens WHERE user_id = $1 ORDER BY created_at DESC;
+
+-- name: TouchAPIToken :exec
+UPDATE api_tokens SET last_used_at = NOW() WHERE id = $1;
+
+-- name: DeleteAPIToken :exec
+DELETE FROM api_tokens WHERE id = $1 AND user_id = $2;
+
+-- HP History Queries
+
+-- name: AddHPHistory :exec
+INSERT INTO character_hp_history (character_id, current_hit_points, temporary_hit_points, max_hit_points)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetRecentHPHistory :many
+SELECT * FROM character_hp_history
+WHERE character_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: PruneHPHistory :execrows
+DELETE FROM character_hp_history
+WHERE created_at < $1;
+
+-- Character Event Queries
+
+-- name: AddCharacterEvent :exec
+INSERT INTO character_events (character_id, user_id, kind, description)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetCharacterEvents :many
+SELECT e.*, u.email FROM character_events e
+LEFT JOIN users u ON u.id = e.user_id
+WHERE e.character_id = $1
+ORDER BY e.created_at DESC
+LIMIT $2;
+
+-- name: PruneCharacterEvents :execrows
+DELETE FROM character_events
+WHERE created_at < $1;
+
+-- Scheduled Job Queries
+
+-- name: ClaimJob :one
+INSERT INTO scheduled_jobs (name, last_run_at)
+VALUES ($1, NOW())
+ON CONFLICT (name) DO UPDATE SET last_run_at = NOW()
+WHERE scheduled_jobs.last_run_at < $2
+RETURNING *;
+
+-- name: CompleteJob :exec
+UPDATE scheduled_jobs SET last_finished_at = NOW(), last_error = $2
+WHERE name = $1;
+
+-- Invite Queries
+
+-- name: CreateInvite :one
+INSERT INTO invites (code, created_by)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetUnusedInviteByCode :one
+SELECT * FROM invites WHERE code = $1 AND used_by IS NULL;
+
+-- name: ClaimInvite :one
+UPDATE invites SET used_by = $2, used_at = NOW()
+WHERE code = $1 AND used_by IS NULL
+RETURNING *;
+
+-- name: GetInvitesByCreator :many
+SELECT * FROM invites WHERE created_by = $1 ORDER BY created_at DESC;
+
+-- Identity Queries
+
+-- name: CreateLinkCode :exec
+INSERT INTO identity_link_codes (code, provider, subject, email, expires_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ConsumeLinkCode :one
+DELETE FROM identity_link_codes
+WHERE code = $1 AND expires_at > NOW()
+RETURNING *;
+
+-- name: DeleteExpiredLinkCodes :exec
+DELETE FROM identity_link_codes WHERE expires_at <= NOW();
+
+-- Password Reset Queries
+
+-- name: UpsertPasswordReset :exec
+INSERT INTO password_resets (token_hash, user_id, expires_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET token_hash = EXCLUDED.token_hash, expires_at = EXCLUDED.expires_at, created_at = NOW();
+
+-- name: GetPasswordResetByUserID :one
+SELECT * FROM password_resets WHERE user_id = $1;
+
+-- name: ConsumePasswordReset :one
+DELETE FROM password_resets
+WHERE token_hash = $1 AND expires_at > NOW()
+RETURNING *;
+
+-- name: DeleteExpiredPasswordResets :exec
+DELETE FROM password_resets WHERE expires_at <= NOW();
+
+-- name: DeleteUserPasswordResets :exec
+DELETE FROM password_resets WHERE user_id = $1;
+
+-- name: GetUserIdentity :one
+SELECT * FROM user_identities WHERE provider = $1 AND subject = $2;
+
+-- name: GetUserIdentitiesByUserID :many
+SELECT * FROM user_identities WHERE user_id = $1 ORDER BY provider;
+
+-- name: CreateUserIdentity :one
+INSERT INTO user_identities (user_id, provider, subject, email)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- Share Link Queries
+
+-- name: CreateShareLink :exec
+INSERT INTO share_links (token_hash, character_id, user_id, expires_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetShareLink :one
+SELECT * FROM share_links WHERE token_hash = $1 AND expires_at > NOW();
+
+-- name: DeleteExpiredShareLinks :exec
+DELETE FROM share_links WHERE expires_at <= NOW();
+
+-- Build Plan Queries
+
+-- name: GetCharacterPlan :many
+SELECT * FROM character_plan_levels WHERE character_id = $1 ORDER BY level;
+
+-- name: UpsertCharacterPlanLevel :one
+INSERT INTO character_plan_levels (
+    character_id, level, class, subclass, feat, ability_increases, notes
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (character_id, level) DO UPDATE SET
+    class = EXCLUDED.class,
+    subclass = EXCLUDED.subclass,
+    feat = EXCLUDED.feat,
+    ability_increases = EXCLUDED.ability_increases,
+    notes = EXCLUDED.notes
+RETURNING *;
+
+-- name: DeleteCharacterPlanLevel :exec
+DELETE FROM character_plan_levels WHERE character_id = $1 AND level = $2;
+
+-- name: CopyCharacterPlan :exec
+INSERT INTO character_plan_levels (
+    character_id, level, class, subclass, feat, ability_increases, notes
+)
+SELECT
+    @new_id::uuid, level, class, subclass, feat, ability_increases, notes
+FROM character_plan_levels WHERE character_id = @source_id;
+
+-- Announcement Queries
+
+-- name: CreateAnnouncement :one
+INSERT INTO server_announcements (message, expires_at)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetCurrentAnnouncement :one
+SELECT * FROM server_announcements
+WHERE expires_at > NOW()
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- Compendium Queries
+
+-- name: GetCompendiumSpells :many
+SELECT * FROM compendium_spells ORDER BY name;
+
+-- name: UpsertCompendiumSpell :exec
+INSERT INTO compendium_spells (
+    name, level, school, casting_time, spell_range, components, duration,
+    concentration, ritual, classes, description, higher_levels, source
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+ON CONFLICT (name) DO UPDATE
+SET level = EXCLUDED.level, school = EXCLUDED.school,
+    casting_time = EXCLUDED.casting_time, spell_range = EXCLUDED.spell_range,
+    components = EXCLUDED.components, duration = EXCLUDED.duration,
+    concentration = EXCLUDED.concentration, ritual = EXCLUDED.ritual,
+    classes = EXCLUDED.classes, description = EXCLUDED.description,
+    higher_levels = EXCLUDED.higher_levels, source = EXCLUDED.source;
+
+-- Portrait Queries
+
+-- name: GetCharacterPortrait :one
+SELECT * FROM character_portraits WHERE character_id = $1;
+
+-- name: GetUserCharacterPortraits :many
+SELECT * FROM character_portraits
+WHERE character_id IN (SELECT id FROM characters WHERE user_id = $1);
+
+-- name: UpsertCharacterPortrait :exec
+INSERT INTO character_portraits (character_id, width, height, pixels, source_url)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (character_id) DO UPDATE
+SET width = EXCLUDED.width, height = EXCLUDED.height, pixels = EXCLUDED.pixels,
+    source_url = EXCLUDED.source_url, updated_at = NOW();
+
+-- name: DeleteCharacterPortrait :exec
+DELETE FROM character_portraits WHERE character_id = $1;
+
+-- name: CopyCharacterPortrait :exec
+INSERT INTO character_portraits (character_id, width, height, pixels, source_url)
+SELECT @new_id::uuid, width, height, pixels, source_url
+FROM character_portraits WHERE character_id = @source_id;
+
+-- Crafting Queries
+
+-- name: GetCharacterCrafting :one
+SELECT * FROM character_crafting WHERE character_id = $1;
+
+-- name: StartCrafting :exec
+INSERT INTO character_crafting (character_id, item, price, progress)
+VALUES ($1, $2, $3, $4);
+
+-- name: SetCraftingProgress :exec
+UPDATE character_crafting SET progress = $2 WHERE character_id = $1;
+
+-- name: DeleteCharacterCrafting :exec
+DELETE FROM character_crafting WHERE character_id = $1;
+
+-- name: CopyCharacterCrafting :exec
+INSERT INTO character_crafting (character_id, item, price, progress)
+SELECT @new_id::uuid, item, price, progress
+FROM character_crafting WHERE character_id = @source_id;
@@ -11,223 +11,340 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const createCharacter = `-- name: CreateCharacter :one
-INSERT INTO characters (
-    user_id, name, class, level, race, background, alignment, experience_points,
-    strength, dexterity, constitution, intelligence, wisdom, charisma,
-    max_hit_points, current_hit_points, temporary_hit_points,
-    armor_class, speed,
-    saving_throw_proficiencies, skill_proficiencies,
-    equipment, features_traits, notes
-) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8,
-    $9, $10, $11, $12, $13, $14,
-    $15, $16, $17,
-    $18, $19,
-    $20, $21,
-    $22, $23, $24
-)
-RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, equipment, features_traits, notes, created_at, updated_at
+const addCharacterEvent = `-- name: AddCharacterEvent :exec
+
+INSERT INTO character_events (character_id, user_id, kind, description)
+VALUES ($1, $2, $3, $4)
 `
 
-type CreateCharacterParams struct {
-	UserID                   pgtype.UUID `json:"user_id"`
-	Name                     string      `json:"name"`
-	Class                    string      `json:"class"`
-	Level                    int32       `json:"level"`
-	Race                     string      `json:"race"`
-	Background               pgtype.Text `json:"background"`
-	Alignment                pgtype.Text `json:"alignment"`
-	ExperiencePoints         int32       `json:"experience_points"`
-	Strength                 int32       `json:"strength"`
-	Dexterity                int32       `json:"dexterity"`
-	Constitution             int32       `json:"constitution"`
-	Intelligence             int32       `json:"intelligence"`
-	Wisdom                   int32       `json:"wisdom"`
-	Charisma                 int32       `json:"charisma"`
-	MaxHitPoints             int32       `json:"max_hit_points"`
-	CurrentHitPoints         int32       `json:"current_hit_points"`
-	TemporaryHitPoints       int32       `json:"temporary_hit_points"`
-	ArmorClass               int32       `json:"armor_class"`
-	Speed                    int32       `json:"speed"`
-	SavingThrowProficiencies []string    `json:"saving_throw_proficiencies"`
-	SkillProficiencies       []string    `json:"skill_proficiencies"`
-	Equipment                []byte      `json:"equipment"`
-	FeaturesTraits           string      `json:"features_traits"`
-	Notes                    string      `json:"notes"`
+type AddCharacterEventParams struct {
+	CharacterID pgtype.UUID `json:"character_id"`
+	UserID      pgtype.UUID `json:"user_id"`
+	Kind        string      `json:"kind"`
+	Description string      `json:"description"`
 }
 
-func (q *Queries) CreateCharacter(ctx context.Context, arg CreateCharacterParams) (Character, error) {
-	row := q.db.QueryRow(ctx, createCharacter,
+// Character Event Queries
+func (q *Queries) AddCharacterEvent(ctx context.Context, arg AddCharacterEventParams) error {
+	_, err := q.db.Exec(ctx, addCharacterEvent,
+		arg.CharacterID,
 		arg.UserID,
+		arg.Kind,
+		arg.Description,
+	)
+	return err
+}
+
+const addCharacterSpell = `-- name: AddCharacterSpell :one
+INSERT INTO character_spells (character_id, name, level, prepared, concentration)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, character_id, name, level, prepared, created_at, concentration
+`
+
+type AddCharacterSpellParams struct {
+	CharacterID   pgtype.UUID `json:"character_id"`
+	Name          string      `json:"name"`
+	Level         int32       `json:"level"`
+	Prepared      bool        `json:"prepared"`
+	Concentration bool        `json:"concentration"`
+}
+
+func (q *Queries) AddCharacterSpell(ctx context.Context, arg AddCharacterSpellParams) (CharacterSpell, error) {
+	row := q.db.QueryRow(ctx, addCharacterSpell,
+		arg.CharacterID,
 		arg.Name,
-		arg.Class,
 		arg.Level,
-		arg.Race,
-		arg.Background,
-		arg.Alignment,
-		arg.ExperiencePoints,
-		arg.Strength,
-		arg.Dexterity,
-		arg.Constitution,
-		arg.Intelligence,
-		arg.Wisdom,
-		arg.Charisma,
-		arg.MaxHitPoints,
+		arg.Prepared,
+		arg.Concentration,
+	)
+	var i CharacterSpell
+	err := row.Scan(
+		&i.ID,
+		&i.CharacterID,
+		&i.Name,
+		&i.Level,
+		&i.Prepared,
+		&i.CreatedAt,
+		&i.Concentration,
+	)
+	return i, err
+}
+
+const addHPHistory = `-- name: AddHPHistory :exec
+
+INSERT INTO character_hp_history (character_id, current_hit_points, temporary_hit_points, max_hit_points)
+VALUES ($1, $2, $3, $4)
+`
+
+type AddHPHistoryParams struct {
+	CharacterID        pgtype.UUID `json:"character_id"`
+	CurrentHitPoints   int32       `json:"current_hit_points"`
+	TemporaryHitPoints int32       `json:"temporary_hit_points"`
+	MaxHitPoints       int32       `json:"max_hit_points"`
+}
+
+// HP History Queries
+func (q *Queries) AddHPHistory(ctx context.Context, arg AddHPHistoryParams) error {
+	_, err := q.db.Exec(ctx, addHPHistory,
+		arg.CharacterID,
 		arg.CurrentHitPoints,
 		arg.TemporaryHitPoints,
-		arg.ArmorClass,
-		arg.Speed,
-		arg.SavingThrowProficiencies,
-		arg.SkillProficiencies,
-		arg.Equipment,
-		arg.FeaturesTraits,
-		arg.Notes,
+		arg.MaxHitPoints,
 	)
-	var i Character
+	return err
+}
+
+const addInventoryItem = `-- name: AddInventoryItem :one
+
+INSERT INTO character_inventory (character_id, name, quantity, equipped, location)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, character_id, name, quantity, equipped, created_at, location
+`
+
+type AddInventoryItemParams struct {
+	CharacterID pgtype.UUID `json:"character_id"`
+	Name        string      `json:"name"`
+	Quantity    int32       `json:"quantity"`
+	Equipped    bool        `json:"equipped"`
+	Location    string      `json:"location"`
+}
+
+// Inventory Queries
+func (q *Queries) AddInventoryItem(ctx context.Context, arg AddInventoryItemParams) (CharacterInventory, error) {
+	row := q.db.QueryRow(ctx, addInventoryItem,
+		arg.CharacterID,
+		arg.Name,
+		arg.Quantity,
+		arg.Equipped,
+		arg.Location,
+	)
+	var i CharacterInventory
 	err := row.Scan(
 		&i.ID,
-		&i.UserID,
+		&i.CharacterID,
 		&i.Name,
-		&i.Class,
-		&i.Level,
-		&i.Race,
-		&i.Background,
-		&i.Alignment,
-		&i.ExperiencePoints,
-		&i.Strength,
-		&i.Dexterity,
-		&i.Constitution,
-		&i.Intelligence,
-		&i.Wisdom,
-		&i.Charisma,
-		&i.MaxHitPoints,
-		&i.CurrentHitPoints,
-		&i.TemporaryHitPoints,
-		&i.ArmorClass,
-		&i.Speed,
-		&i.SavingThrowProficiencies,
-		&i.SkillProficiencies,
-		&i.Equipment,
-		&i.FeaturesTraits,
-		&i.Notes,
+		&i.Quantity,
+		&i.Equipped,
 		&i.CreatedAt,
-		&i.UpdatedAt,
+		&i.Location,
 	)
 	return i, err
 }
 
-const createUserWithBoth = `-- name: CreateUserWithBoth :one
-INSERT INTO users (email, password_hash, public_key)
-VALUES ($1, $2, $3)
-RETURNING id, email, password_hash, public_key, created_at, updated_at
+const addInventoryQuantity = `-- name: AddInventoryQuantity :one
+UPDATE character_inventory SET quantity = quantity + $2 WHERE id = $1 RETURNING id, character_id, name, quantity, equipped, created_at, location
 `
 
-type CreateUserWithBothParams struct {
-	Email        pgtype.Text `json:"email"`
-	PasswordHash pgtype.Text `json:"password_hash"`
-	PublicKey    pgtype.Text `json:"public_key"`
+type AddInventoryQuantityParams struct {
+	ID       pgtype.UUID `json:"id"`
+	Quantity int32       `json:"quantity"`
 }
 
-func (q *Queries) CreateUserWithBoth(ctx context.Context, arg CreateUserWithBothParams) (User, error) {
-	row := q.db.QueryRow(ctx, createUserWithBoth, arg.Email, arg.PasswordHash, arg.PublicKey)
-	var i User
+func (q *Queries) AddInventoryQuantity(ctx context.Context, arg AddInventoryQuantityParams) (CharacterInventory, error) {
+	row := q.db.QueryRow(ctx, addInventoryQuantity, arg.ID, arg.Quantity)
+	var i CharacterInventory
 	err := row.Scan(
 		&i.ID,
-		&i.Email,
-		&i.PasswordHash,
-		&i.PublicKey,
+		&i.CharacterID,
+		&i.Name,
+		&i.Quantity,
+		&i.Equipped,
 		&i.CreatedAt,
-		&i.UpdatedAt,
+		&i.Location,
 	)
 	return i, err
 }
 
-const createUserWithPassword = `-- name: CreateUserWithPassword :one
-INSERT INTO users (email, password_hash)
-VALUES ($1, $2)
-RETURNING id, email, password_hash, public_key, created_at, updated_at
+const addJournalEntry = `-- name: AddJournalEntry :one
+
+INSERT INTO character_journal (character_id, title, body)
+VALUES ($1, $2, $3)
+RETURNING id, character_id, title, body, created_at, updated_at
 `
 
-type CreateUserWithPasswordParams struct {
-	Email        pgtype.Text `json:"email"`
-	PasswordHash pgtype.Text `json:"password_hash"`
+type AddJournalEntryParams struct {
+	CharacterID pgtype.UUID `json:"character_id"`
+	Title       string      `json:"title"`
+	Body        string      `json:"body"`
 }
 
-func (q *Queries) CreateUserWithPassword(ctx context.Context, arg CreateUserWithPasswordParams) (User, error) {
-	row := q.db.QueryRow(ctx, createUserWithPassword, arg.Email, arg.PasswordHash)
-	var i User
+// Journal Queries
+func (q *Queries) AddJournalEntry(ctx context.Context, arg AddJournalEntryParams) (CharacterJournal, error) {
+	row := q.db.QueryRow(ctx, addJournalEntry, arg.CharacterID, arg.Title, arg.Body)
+	var i CharacterJournal
 	err := row.Scan(
 		&i.ID,
-		&i.Email,
-		&i.PasswordHash,
-		&i.PublicKey,
+		&i.CharacterID,
+		&i.Title,
+		&i.Body,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const createUserWithPublicKey = `-- name: CreateUserWithPublicKey :one
-INSERT INTO users (public_key)
-VALUES ($1)
-RETURNING id, email, password_hash, public_key, created_at, updated_at
+const characterNameTaken = `-- name: CharacterNameTaken :one
+SELECT EXISTS (
+    SELECT 1 FROM characters
+    WHERE user_id = $1
+      AND NOT is_template
+      AND lower(name) = lower($2::text)
+      AND id IS DISTINCT FROM $3
+)
 `
 
-func (q *Queries) CreateUserWithPublicKey(ctx context.Context, publicKey pgtype.Text) (User, error) {
-	row := q.db.QueryRow(ctx, createUserWithPublicKey, publicKey)
-	var i User
+type CharacterNameTakenParams struct {
+	UserID    pgtype.UUID `json:"user_id"`
+	Name      string      `json:"name"`
+	ExcludeID pgtype.UUID `json:"exclude_id"`
+}
+
+func (q *Queries) CharacterNameTaken(ctx context.Context, arg CharacterNameTakenParams) (bool, error) {
+	row := q.db.QueryRow(ctx, characterNameTaken, arg.UserID, arg.Name, arg.ExcludeID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const claimInvite = `-- name: ClaimInvite :one
+UPDATE invites SET used_by = $2, used_at = NOW()
+WHERE code = $1 AND used_by IS NULL
+RETURNING id, code, created_by, used_by, used_at, created_at
+`
+
+type ClaimInviteParams struct {
+	Code   string      `json:"code"`
+	UsedBy pgtype.UUID `json:"used_by"`
+}
+
+func (q *Queries) ClaimInvite(ctx context.Context, arg ClaimInviteParams) (Invite, error) {
+	row := q.db.QueryRow(ctx, claimInvite, arg.Code, arg.UsedBy)
+	var i Invite
 	err := row.Scan(
 		&i.ID,
-		&i.Email,
-		&i.PasswordHash,
-		&i.PublicKey,
+		&i.Code,
+		&i.CreatedBy,
+		&i.UsedBy,
+		&i.UsedAt,
 		&i.CreatedAt,
-		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const deleteCharacter = `-- name: DeleteCharacter :exec
-DELETE FROM characters WHERE id = $1
+const claimJob = `-- name: ClaimJob :one
+
+INSERT INTO scheduled_jobs (name, last_run_at)
+VALUES ($1, NOW())
+ON CONFLICT (name) DO UPDATE SET last_run_at = NOW()
+WHERE scheduled_jobs.last_run_at < $2
+RETURNING name, last_run_at, last_finished_at, last_error
 `
 
-func (q *Queries) DeleteCharacter(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteCharacter, id)
-	return err
+type ClaimJobParams struct {
+	Name      string             `json:"name"`
+	LastRunAt pgtype.Timestamptz `json:"last_run_at"`
 }
 
-const deleteCharacterByUserID = `-- name: DeleteCharacterByUserID :exec
-DELETE FROM characters WHERE id = $1 AND user_id = $2
+// Scheduled Job Queries
+func (q *Queries) ClaimJob(ctx context.Context, arg ClaimJobParams) (ScheduledJob, error) {
+	row := q.db.QueryRow(ctx, claimJob, arg.Name, arg.LastRunAt)
+	var i ScheduledJob
+	err := row.Scan(
+		&i.Name,
+		&i.LastRunAt,
+		&i.LastFinishedAt,
+		&i.LastError,
+	)
+	return i, err
+}
+
+const completeJob = `-- name: CompleteJob :exec
+UPDATE scheduled_jobs SET last_finished_at = NOW(), last_error = $2
+WHERE name = $1
 `
 
-type DeleteCharacterByUserIDParams struct {
-	ID     pgtype.UUID `json:"id"`
-	UserID pgtype.UUID `json:"user_id"`
+type CompleteJobParams struct {
+	Name      string      `json:"name"`
+	LastError pgtype.Text `json:"last_error"`
 }
 
-func (q *Queries) DeleteCharacterByUserID(ctx context.Context, arg DeleteCharacterByUserIDParams) error {
-	_, err := q.db.Exec(ctx, deleteCharacterByUserID, arg.ID, arg.UserID)
+func (q *Queries) CompleteJob(ctx context.Context, arg CompleteJobParams) error {
+	_, err := q.db.Exec(ctx, completeJob, arg.Name, arg.LastError)
 	return err
 }
 
-const deleteUser = `-- name: DeleteUser :exec
-DELETE FROM users WHERE id = $1
+const consumeLinkCode = `-- name: ConsumeLinkCode :one
+DELETE FROM identity_link_codes
+WHERE code = $1 AND expires_at > NOW()
+RETURNING code, provider, subject, email, expires_at, created_at
 `
 
-func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteUser, id)
-	return err
+func (q *Queries) ConsumeLinkCode(ctx context.Context, code string) (IdentityLinkCode, error) {
+	row := q.db.QueryRow(ctx, consumeLinkCode, code)
+	var i IdentityLinkCode
+	err := row.Scan(
+		&i.Code,
+		&i.Provider,
+		&i.Subject,
+		&i.Email,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
 }
 
-const getCharacterByID = `-- name: GetCharacterByID :one
+const consumePasswordReset = `-- name: ConsumePasswordReset :one
+DELETE FROM password_resets
+WHERE token_hash = $1 AND expires_at > NOW()
+RETURNING token_hash, user_id, expires_at, created_at
+`
+
+func (q *Queries) ConsumePasswordReset(ctx context.Context, tokenHash string) (PasswordReset, error) {
+	row := q.db.QueryRow(ctx, consumePasswordReset, tokenHash)
+	var i PasswordReset
+	err := row.Scan(
+		&i.TokenHash,
+		&i.UserID,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
 
-SELECT id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, equipment, features_traits, notes, created_at, updated_at FROM characters WHERE id = $1
+const copyCharacter = `-- name: CopyCharacter :one
+INSERT INTO characters (
+    user_id, name, class, level, race, background, alignment, experience_points,
+    strength, dexterity, constitution, intelligence, wisdom, charisma,
+    max_hit_points, current_hit_points, temporary_hit_points,
+    armor_class, speed,
+    saving_throw_proficiencies, skill_proficiencies,
+    features_traits, notes, is_template, ruleset,
+    skill_expertise, skill_half_proficiencies, armor_class_override,
+    accent, icon, languages, tool_proficiencies,
+    fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
+)
+SELECT
+    user_id, $1::text, class, level, race, background, alignment, experience_points,
+    strength, dexterity, constitution, intelligence, wisdom, charisma,
+    max_hit_points, current_hit_points, temporary_hit_points,
+    armor_class, speed,
+    saving_throw_proficiencies, skill_proficiencies,
+    features_traits, notes, $2::boolean, ruleset,
+    skill_expertise, skill_half_proficiencies, armor_class_override,
+    accent, icon, languages, tool_proficiencies,
+    fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
+FROM characters WHERE id = $3
+RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
 `
 
-// Character Queries
-func (q *Queries) GetCharacterByID(ctx context.Context, id pgtype.UUID) (Character, error) {
-	row := q.db.QueryRow(ctx, getCharacterByID, id)
+type CopyCharacterParams struct {
+	Name       string      `json:"name"`
+	IsTemplate bool        `json:"is_template"`
+	ID         pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) CopyCharacter(ctx context.Context, arg CopyCharacterParams) (Character, error) {
+	row := q.db.QueryRow(ctx, copyCharacter, arg.Name, arg.IsTemplate, arg.ID)
 	var i Character
 	err := row.Scan(
 		&i.ID,
@@ -252,56 +369,1870 @@ func (q *Queries) GetCharacterByID(ctx context.Context, id pgtype.UUID) (Charact
 		&i.Speed,
 		&i.SavingThrowProficiencies,
 		&i.SkillProficiencies,
-		&i.Equipment,
 		&i.FeaturesTraits,
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
 	)
 	return i, err
 }
 
-const getCharactersByUserID = `-- name: GetCharactersByUserID :many
-SELECT id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, equipment, features_traits, notes, created_at, updated_at FROM characters WHERE user_id = $1 ORDER BY updated_at DESC
+const copyCharacterCrafting = `-- name: CopyCharacterCrafting :exec
+INSERT INTO character_crafting (character_id, item, price, progress)
+SELECT $1::uuid, item, price, progress
+FROM character_crafting WHERE character_id = $2
 `
 
-func (q *Queries) GetCharactersByUserID(ctx context.Context, userID pgtype.UUID) ([]Character, error) {
-	rows, err := q.db.Query(ctx, getCharactersByUserID, userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []Character{}
-	for rows.Next() {
-		var i Character
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.Name,
-			&i.Class,
-			&i.Level,
-			&i.Race,
-			&i.Background,
-			&i.Alignment,
-			&i.ExperiencePoints,
-			&i.Strength,
-			&i.Dexterity,
-			&i.Constitution,
-			&i.Intelligence,
-			&i.Wisdom,
-			&i.Charisma,
-			&i.MaxHitPoints,
-			&i.CurrentHitPoints,
-			&i.TemporaryHitPoints,
-			&i.ArmorClass,
-			&i.Speed,
-			&i.SavingThrowProficiencies,
-			&i.SkillProficiencies,
-			&i.Equipment,
-			&i.FeaturesTraits,
+type CopyCharacterCraftingParams struct {
+	NewID    pgtype.UUID `json:"new_id"`
+	SourceID pgtype.UUID `json:"source_id"`
+}
+
+func (q *Queries) CopyCharacterCrafting(ctx context.Context, arg CopyCharacterCraftingParams) error {
+	_, err := q.db.Exec(ctx, copyCharacterCrafting, arg.NewID, arg.SourceID)
+	return err
+}
+
+const copyCharacterInventory = `-- name: CopyCharacterInventory :exec
+INSERT INTO character_inventory (character_id, name, quantity, equipped, location)
+SELECT $1::uuid, name, quantity, equipped, location
+FROM character_inventory WHERE character_id = $2
+`
+
+type CopyCharacterInventoryParams struct {
+	NewID    pgtype.UUID `json:"new_id"`
+	SourceID pgtype.UUID `json:"source_id"`
+}
+
+func (q *Queries) CopyCharacterInventory(ctx context.Context, arg CopyCharacterInventoryParams) error {
+	_, err := q.db.Exec(ctx, copyCharacterInventory, arg.NewID, arg.SourceID)
+	return err
+}
+
+const copyCharacterJournal = `-- name: CopyCharacterJournal :exec
+INSERT INTO character_journal (character_id, title, body, created_at, updated_at)
+SELECT $1::uuid, title, body, created_at, updated_at
+FROM character_journal WHERE character_id = $2
+`
+
+type CopyCharacterJournalParams struct {
+	NewID    pgtype.UUID `json:"new_id"`
+	SourceID pgtype.UUID `json:"source_id"`
+}
+
+func (q *Queries) CopyCharacterJournal(ctx context.Context, arg CopyCharacterJournalParams) error {
+	_, err := q.db.Exec(ctx, copyCharacterJournal, arg.NewID, arg.SourceID)
+	return err
+}
+
+const copyCharacterPlan = `-- name: CopyCharacterPlan :exec
+INSERT INTO character_plan_levels (
+    character_id, level, class, subclass, feat, ability_increases, notes
+)
+SELECT
+    $1::uuid, level, class, subclass, feat, ability_increases, notes
+FROM character_plan_levels WHERE character_id = $2
+`
+
+type CopyCharacterPlanParams struct {
+	NewID    pgtype.UUID `json:"new_id"`
+	SourceID pgtype.UUID `json:"source_id"`
+}
+
+func (q *Queries) CopyCharacterPlan(ctx context.Context, arg CopyCharacterPlanParams) error {
+	_, err := q.db.Exec(ctx, copyCharacterPlan, arg.NewID, arg.SourceID)
+	return err
+}
+
+const copyCharacterPortrait = `-- name: CopyCharacterPortrait :exec
+INSERT INTO character_portraits (character_id, width, height, pixels, source_url)
+SELECT $1::uuid, width, height, pixels, source_url
+FROM character_portraits WHERE character_id = $2
+`
+
+type CopyCharacterPortraitParams struct {
+	NewID    pgtype.UUID `json:"new_id"`
+	SourceID pgtype.UUID `json:"source_id"`
+}
+
+func (q *Queries) CopyCharacterPortrait(ctx context.Context, arg CopyCharacterPortraitParams) error {
+	_, err := q.db.Exec(ctx, copyCharacterPortrait, arg.NewID, arg.SourceID)
+	return err
+}
+
+const copyCharacterResources = `-- name: CopyCharacterResources :exec
+INSERT INTO character_resources (character_id, name, max_uses, used, recharge)
+SELECT $1::uuid, name, max_uses, used, recharge
+FROM character_resources WHERE character_id = $2
+`
+
+type CopyCharacterResourcesParams struct {
+	NewID    pgtype.UUID `json:"new_id"`
+	SourceID pgtype.UUID `json:"source_id"`
+}
+
+func (q *Queries) CopyCharacterResources(ctx context.Context, arg CopyCharacterResourcesParams) error {
+	_, err := q.db.Exec(ctx, copyCharacterResources, arg.NewID, arg.SourceID)
+	return err
+}
+
+const copyCharacterSpellcasting = `-- name: CopyCharacterSpellcasting :exec
+INSERT INTO character_spellcasting (
+    character_id, spellcasting_class, spellcasting_ability,
+    spell_save_dc, spell_attack_bonus, slots_max, slots_used
+)
+SELECT
+    $1::uuid, spellcasting_class, spellcasting_ability,
+    spell_save_dc, spell_attack_bonus, slots_max, slots_used
+FROM character_spellcasting WHERE character_id = $2
+`
+
+type CopyCharacterSpellcastingParams struct {
+	NewID    pgtype.UUID `json:"new_id"`
+	SourceID pgtype.UUID `json:"source_id"`
+}
+
+func (q *Queries) CopyCharacterSpellcasting(ctx context.Context, arg CopyCharacterSpellcastingParams) error {
+	_, err := q.db.Exec(ctx, copyCharacterSpellcasting, arg.NewID, arg.SourceID)
+	return err
+}
+
+const copyCharacterSpells = `-- name: CopyCharacterSpells :exec
+INSERT INTO character_spells (character_id, name, level, prepared, concentration)
+SELECT $1::uuid, name, level, prepared, concentration
+FROM character_spells WHERE character_id = $2
+`
+
+type CopyCharacterSpellsParams struct {
+	NewID    pgtype.UUID `json:"new_id"`
+	SourceID pgtype.UUID `json:"source_id"`
+}
+
+func (q *Queries) CopyCharacterSpells(ctx context.Context, arg CopyCharacterSpellsParams) error {
+	_, err := q.db.Exec(ctx, copyCharacterSpells, arg.NewID, arg.SourceID)
+	return err
+}
+
+const countCharactersByUserID = `-- name: CountCharactersByUserID :one
+SELECT COUNT(*) FROM characters
+WHERE user_id = $1
+  AND is_template = $2
+  AND ($3::text = '' OR name ILIKE $3 OR race ILIKE $3 OR class ILIKE $3)
+`
+
+type CountCharactersByUserIDParams struct {
+	UserID    pgtype.UUID `json:"user_id"`
+	Templates bool        `json:"templates"`
+	Search    string      `json:"search"`
+}
+
+func (q *Queries) CountCharactersByUserID(ctx context.Context, arg CountCharactersByUserIDParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countCharactersByUserID, arg.UserID, arg.Templates, arg.Search)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAPIToken = `-- name: CreateAPIToken :one
+
+INSERT INTO api_tokens (user_id, name, token_hash)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, name, token_hash, last_used_at, created_at
+`
+
+type CreateAPITokenParams struct {
+	UserID    pgtype.UUID `json:"user_id"`
+	Name      string      `json:"name"`
+	TokenHash string      `json:"token_hash"`
+}
+
+// API Token Queries
+func (q *Queries) CreateAPIToken(ctx context.Context, arg CreateAPITokenParams) (ApiToken, error) {
+	row := q.db.QueryRow(ctx, createAPIToken, arg.UserID, arg.Name, arg.TokenHash)
+	var i ApiToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.TokenHash,
+		&i.LastUsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createAnnouncement = `-- name: CreateAnnouncement :one
+
+INSERT INTO server_announcements (message, expires_at)
+VALUES ($1, $2)
+RETURNING id, message, expires_at, created_at
+`
+
+type CreateAnnouncementParams struct {
+	Message   string             `json:"message"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+// Announcement Queries
+func (q *Queries) CreateAnnouncement(ctx context.Context, arg CreateAnnouncementParams) (ServerAnnouncement, error) {
+	row := q.db.QueryRow(ctx, createAnnouncement, arg.Message, arg.ExpiresAt)
+	var i ServerAnnouncement
+	err := row.Scan(
+		&i.ID,
+		&i.Message,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createCharacter = `-- name: CreateCharacter :one
+INSERT INTO characters (
+    user_id, name, class, level, race, background, alignment, experience_points,
+    strength, dexterity, constitution, intelligence, wisdom, charisma,
+    max_hit_points, current_hit_points, temporary_hit_points,
+    armor_class, speed,
+    saving_throw_proficiencies, skill_proficiencies,
+    features_traits, notes, ruleset, darkvision
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8,
+    $9, $10, $11, $12, $13, $14,
+    $15, $16, $17,
+    $18, $19,
+    $20, $21,
+    $22, $23, $24, $25
+)
+RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
+`
+
+type CreateCharacterParams struct {
+	UserID                   pgtype.UUID `json:"user_id"`
+	Name                     string      `json:"name"`
+	Class                    string      `json:"class"`
+	Level                    int32       `json:"level"`
+	Race                     string      `json:"race"`
+	Background               pgtype.Text `json:"background"`
+	Alignment                pgtype.Text `json:"alignment"`
+	ExperiencePoints         int32       `json:"experience_points"`
+	Strength                 int32       `json:"strength"`
+	Dexterity                int32       `json:"dexterity"`
+	Constitution             int32       `json:"constitution"`
+	Intelligence             int32       `json:"intelligence"`
+	Wisdom                   int32       `json:"wisdom"`
+	Charisma                 int32       `json:"charisma"`
+	MaxHitPoints             int32       `json:"max_hit_points"`
+	CurrentHitPoints         int32       `json:"current_hit_points"`
+	TemporaryHitPoints       int32       `json:"temporary_hit_points"`
+	ArmorClass               int32       `json:"armor_class"`
+	Speed                    int32       `json:"speed"`
+	SavingThrowProficiencies []string    `json:"saving_throw_proficiencies"`
+	SkillProficiencies       []string    `json:"skill_proficiencies"`
+	FeaturesTraits           string      `json:"features_traits"`
+	Notes                    string      `json:"notes"`
+	Ruleset                  string      `json:"ruleset"`
+	Darkvision               int32       `json:"darkvision"`
+}
+
+func (q *Queries) CreateCharacter(ctx context.Context, arg CreateCharacterParams) (Character, error) {
+	row := q.db.QueryRow(ctx, createCharacter,
+		arg.UserID,
+		arg.Name,
+		arg.Class,
+		arg.Level,
+		arg.Race,
+		arg.Background,
+		arg.Alignment,
+		arg.ExperiencePoints,
+		arg.Strength,
+		arg.Dexterity,
+		arg.Constitution,
+		arg.Intelligence,
+		arg.Wisdom,
+		arg.Charisma,
+		arg.MaxHitPoints,
+		arg.CurrentHitPoints,
+		arg.TemporaryHitPoints,
+		arg.ArmorClass,
+		arg.Speed,
+		arg.SavingThrowProficiencies,
+		arg.SkillProficiencies,
+		arg.FeaturesTraits,
+		arg.Notes,
+		arg.Ruleset,
+		arg.Darkvision,
+	)
+	var i Character
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Class,
+		&i.Level,
+		&i.Race,
+		&i.Background,
+		&i.Alignment,
+		&i.ExperiencePoints,
+		&i.Strength,
+		&i.Dexterity,
+		&i.Constitution,
+		&i.Intelligence,
+		&i.Wisdom,
+		&i.Charisma,
+		&i.MaxHitPoints,
+		&i.CurrentHitPoints,
+		&i.TemporaryHitPoints,
+		&i.ArmorClass,
+		&i.Speed,
+		&i.SavingThrowProficiencies,
+		&i.SkillProficiencies,
+		&i.FeaturesTraits,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
+	)
+	return i, err
+}
+
+const createCharacterSpellcasting = `-- name: CreateCharacterSpellcasting :one
+
+INSERT INTO character_spellcasting (
+    character_id, spellcasting_class, spellcasting_ability,
+    spell_save_dc, spell_attack_bonus, slots_max
+) VALUES (
+    $1, $2, $3,
+    $4, $5, $6
+)
+RETURNING character_id, spellcasting_class, spellcasting_ability, spell_save_dc, spell_attack_bonus, slots_max, slots_used, created_at, updated_at
+`
+
+type CreateCharacterSpellcastingParams struct {
+	CharacterID         pgtype.UUID `json:"character_id"`
+	SpellcastingClass   string      `json:"spellcasting_class"`
+	SpellcastingAbility string      `json:"spellcasting_ability"`
+	SpellSaveDc         int32       `json:"spell_save_dc"`
+	SpellAttackBonus    int32       `json:"spell_attack_bonus"`
+	SlotsMax            []int32     `json:"slots_max"`
+}
+
+// Spellcasting Queries
+func (q *Queries) CreateCharacterSpellcasting(ctx context.Context, arg CreateCharacterSpellcastingParams) (CharacterSpellcasting, error) {
+	row := q.db.QueryRow(ctx, createCharacterSpellcasting,
+		arg.CharacterID,
+		arg.SpellcastingClass,
+		arg.SpellcastingAbility,
+		arg.SpellSaveDc,
+		arg.SpellAttackBonus,
+		arg.SlotsMax,
+	)
+	var i CharacterSpellcasting
+	err := row.Scan(
+		&i.CharacterID,
+		&i.SpellcastingClass,
+		&i.SpellcastingAbility,
+		&i.SpellSaveDc,
+		&i.SpellAttackBonus,
+		&i.SlotsMax,
+		&i.SlotsUsed,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createInvite = `-- name: CreateInvite :one
+
+INSERT INTO invites (code, created_by)
+VALUES ($1, $2)
+RETURNING id, code, created_by, used_by, used_at, created_at
+`
+
+type CreateInviteParams struct {
+	Code      string      `json:"code"`
+	CreatedBy pgtype.UUID `json:"created_by"`
+}
+
+// Invite Queries
+func (q *Queries) CreateInvite(ctx context.Context, arg CreateInviteParams) (Invite, error) {
+	row := q.db.QueryRow(ctx, createInvite, arg.Code, arg.CreatedBy)
+	var i Invite
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.CreatedBy,
+		&i.UsedBy,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createLinkCode = `-- name: CreateLinkCode :exec
+
+INSERT INTO identity_link_codes (code, provider, subject, email, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateLinkCodeParams struct {
+	Code      string             `json:"code"`
+	Provider  string             `json:"provider"`
+	Subject   string             `json:"subject"`
+	Email     pgtype.Text        `json:"email"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+// Identity Queries
+func (q *Queries) CreateLinkCode(ctx context.Context, arg CreateLinkCodeParams) error {
+	_, err := q.db.Exec(ctx, createLinkCode,
+		arg.Code,
+		arg.Provider,
+		arg.Subject,
+		arg.Email,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const createShareLink = `-- name: CreateShareLink :exec
+
+INSERT INTO share_links (token_hash, character_id, user_id, expires_at)
+VALUES ($1, $2, $3, $4)
+`
+
+type CreateShareLinkParams struct {
+	TokenHash   string             `json:"token_hash"`
+	CharacterID pgtype.UUID        `json:"character_id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+}
+
+// Share Link Queries
+func (q *Queries) CreateShareLink(ctx context.Context, arg CreateShareLinkParams) error {
+	_, err := q.db.Exec(ctx, createShareLink,
+		arg.TokenHash,
+		arg.CharacterID,
+		arg.UserID,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const createUserIdentity = `-- name: CreateUserIdentity :one
+INSERT INTO user_identities (user_id, provider, subject, email)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, provider, subject, email, created_at
+`
+
+type CreateUserIdentityParams struct {
+	UserID   pgtype.UUID `json:"user_id"`
+	Provider string      `json:"provider"`
+	Subject  string      `json:"subject"`
+	Email    pgtype.Text `json:"email"`
+}
+
+func (q *Queries) CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) (UserIdentity, error) {
+	row := q.db.QueryRow(ctx, createUserIdentity,
+		arg.UserID,
+		arg.Provider,
+		arg.Subject,
+		arg.Email,
+	)
+	var i UserIdentity
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Provider,
+		&i.Subject,
+		&i.Email,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createUserWithBoth = `-- name: CreateUserWithBoth :one
+INSERT INTO users (email, password_hash, public_key)
+VALUES ($1, $2, $3)
+RETURNING id, email, password_hash, public_key, created_at, updated_at, usage_stats, theme, notifications, role, disabled_at
+`
+
+type CreateUserWithBothParams struct {
+	Email        pgtype.Text `json:"email"`
+	PasswordHash pgtype.Text `json:"password_hash"`
+	PublicKey    pgtype.Text `json:"public_key"`
+}
+
+func (q *Queries) CreateUserWithBoth(ctx context.Context, arg CreateUserWithBothParams) (User, error) {
+	row := q.db.QueryRow(ctx, createUserWithBoth, arg.Email, arg.PasswordHash, arg.PublicKey)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.PublicKey,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UsageStats,
+		&i.Theme,
+		&i.Notifications,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const createUserWithPassword = `-- name: CreateUserWithPassword :one
+INSERT INTO users (email, password_hash)
+VALUES ($1, $2)
+RETURNING id, email, password_hash, public_key, created_at, updated_at, usage_stats, theme, notifications, role, disabled_at
+`
+
+type CreateUserWithPasswordParams struct {
+	Email        pgtype.Text `json:"email"`
+	PasswordHash pgtype.Text `json:"password_hash"`
+}
+
+func (q *Queries) CreateUserWithPassword(ctx context.Context, arg CreateUserWithPasswordParams) (User, error) {
+	row := q.db.QueryRow(ctx, createUserWithPassword, arg.Email, arg.PasswordHash)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.PublicKey,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UsageStats,
+		&i.Theme,
+		&i.Notifications,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const createUserWithPublicKey = `-- name: CreateUserWithPublicKey :one
+INSERT INTO users (public_key)
+VALUES ($1)
+RETURNING id, email, password_hash, public_key, created_at, updated_at, usage_stats, theme, notifications, role, disabled_at
+`
+
+func (q *Queries) CreateUserWithPublicKey(ctx context.Context, publicKey pgtype.Text) (User, error) {
+	row := q.db.QueryRow(ctx, createUserWithPublicKey, publicKey)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.PublicKey,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UsageStats,
+		&i.Theme,
+		&i.Notifications,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const deleteAPIToken = `-- name: DeleteAPIToken :exec
+DELETE FROM api_tokens WHERE id = $1 AND user_id = $2
+`
+
+type DeleteAPITokenParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) DeleteAPIToken(ctx context.Context, arg DeleteAPITokenParams) error {
+	_, err := q.db.Exec(ctx, deleteAPIToken, arg.ID, arg.UserID)
+	return err
+}
+
+const deleteCharacter = `-- name: DeleteCharacter :exec
+DELETE FROM characters WHERE id = $1
+`
+
+func (q *Queries) DeleteCharacter(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCharacter, id)
+	return err
+}
+
+const deleteCharacterByUserID = `-- name: DeleteCharacterByUserID :exec
+DELETE FROM characters WHERE id = $1 AND user_id = $2
+`
+
+type DeleteCharacterByUserIDParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) DeleteCharacterByUserID(ctx context.Context, arg DeleteCharacterByUserIDParams) error {
+	_, err := q.db.Exec(ctx, deleteCharacterByUserID, arg.ID, arg.UserID)
+	return err
+}
+
+const deleteCharacterCrafting = `-- name: DeleteCharacterCrafting :exec
+DELETE FROM character_crafting WHERE character_id = $1
+`
+
+func (q *Queries) DeleteCharacterCrafting(ctx context.Context, characterID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCharacterCrafting, characterID)
+	return err
+}
+
+const deleteCharacterInventory = `-- name: DeleteCharacterInventory :exec
+DELETE FROM character_inventory WHERE character_id = $1
+`
+
+func (q *Queries) DeleteCharacterInventory(ctx context.Context, characterID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCharacterInventory, characterID)
+	return err
+}
+
+const deleteCharacterPlanLevel = `-- name: DeleteCharacterPlanLevel :exec
+DELETE FROM character_plan_levels WHERE character_id = $1 AND level = $2
+`
+
+type DeleteCharacterPlanLevelParams struct {
+	CharacterID pgtype.UUID `json:"character_id"`
+	Level       int32       `json:"level"`
+}
+
+func (q *Queries) DeleteCharacterPlanLevel(ctx context.Context, arg DeleteCharacterPlanLevelParams) error {
+	_, err := q.db.Exec(ctx, deleteCharacterPlanLevel, arg.CharacterID, arg.Level)
+	return err
+}
+
+const deleteCharacterPortrait = `-- name: DeleteCharacterPortrait :exec
+DELETE FROM character_portraits WHERE character_id = $1
+`
+
+func (q *Queries) DeleteCharacterPortrait(ctx context.Context, characterID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCharacterPortrait, characterID)
+	return err
+}
+
+const deleteCharacterResource = `-- name: DeleteCharacterResource :exec
+DELETE FROM character_resources WHERE character_id = $1 AND name = $2
+`
+
+type DeleteCharacterResourceParams struct {
+	CharacterID pgtype.UUID `json:"character_id"`
+	Name        string      `json:"name"`
+}
+
+func (q *Queries) DeleteCharacterResource(ctx context.Context, arg DeleteCharacterResourceParams) error {
+	_, err := q.db.Exec(ctx, deleteCharacterResource, arg.CharacterID, arg.Name)
+	return err
+}
+
+const deleteCharacterSpell = `-- name: DeleteCharacterSpell :exec
+DELETE FROM character_spells WHERE id = $1
+`
+
+func (q *Queries) DeleteCharacterSpell(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCharacterSpell, id)
+	return err
+}
+
+const deleteCharacterSpellcasting = `-- name: DeleteCharacterSpellcasting :exec
+DELETE FROM character_spellcasting WHERE character_id = $1
+`
+
+func (q *Queries) DeleteCharacterSpellcasting(ctx context.Context, characterID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCharacterSpellcasting, characterID)
+	return err
+}
+
+const deleteExpiredLinkCodes = `-- name: DeleteExpiredLinkCodes :exec
+DELETE FROM identity_link_codes WHERE expires_at <= NOW()
+`
+
+func (q *Queries) DeleteExpiredLinkCodes(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteExpiredLinkCodes)
+	return err
+}
+
+const deleteExpiredPasswordResets = `-- name: DeleteExpiredPasswordResets :exec
+DELETE FROM password_resets WHERE expires_at <= NOW()
+`
+
+func (q *Queries) DeleteExpiredPasswordResets(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteExpiredPasswordResets)
+	return err
+}
+
+const deleteExpiredShareLinks = `-- name: DeleteExpiredShareLinks :exec
+DELETE FROM share_links WHERE expires_at <= NOW()
+`
+
+func (q *Queries) DeleteExpiredShareLinks(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteExpiredShareLinks)
+	return err
+}
+
+const deleteInventoryItem = `-- name: DeleteInventoryItem :exec
+DELETE FROM character_inventory WHERE id = $1
+`
+
+func (q *Queries) DeleteInventoryItem(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteInventoryItem, id)
+	return err
+}
+
+const deleteJournalEntry = `-- name: DeleteJournalEntry :exec
+DELETE FROM character_journal WHERE id = $1
+`
+
+func (q *Queries) DeleteJournalEntry(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteJournalEntry, id)
+	return err
+}
+
+const deleteUser = `-- name: DeleteUser :exec
+DELETE FROM users WHERE id = $1
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUser, id)
+	return err
+}
+
+const deleteUserPasswordResets = `-- name: DeleteUserPasswordResets :exec
+DELETE FROM password_resets WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserPasswordResets(ctx context.Context, userID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUserPasswordResets, userID)
+	return err
+}
+
+const disableUser = `-- name: DisableUser :one
+UPDATE users SET disabled_at = NOW() WHERE id = $1 RETURNING id, email, password_hash, public_key, created_at, updated_at, usage_stats, theme, notifications, role, disabled_at
+`
+
+func (q *Queries) DisableUser(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, disableUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.PublicKey,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UsageStats,
+		&i.Theme,
+		&i.Notifications,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const enableUser = `-- name: EnableUser :one
+UPDATE users SET disabled_at = NULL WHERE id = $1 RETURNING id, email, password_hash, public_key, created_at, updated_at, usage_stats, theme, notifications, role, disabled_at
+`
+
+func (q *Queries) EnableUser(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, enableUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.PublicKey,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UsageStats,
+		&i.Theme,
+		&i.Notifications,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const getAPITokenByHash = `-- name: GetAPITokenByHash :one
+SELECT id, user_id, name, token_hash, last_used_at, created_at FROM api_tokens WHERE token_hash = $1
+`
+
+func (q *Queries) GetAPITokenByHash(ctx context.Context, tokenHash string) (ApiToken, error) {
+	row := q.db.QueryRow(ctx, getAPITokenByHash, tokenHash)
+	var i ApiToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.TokenHash,
+		&i.LastUsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getAPITokensByUserID = `-- name: GetAPITokensByUserID :many
+SELECT id, user_id, name, token_hash, last_used_at, created_at FROM api_tokens WHERE user_id = $1 ORDER BY created_at DESC
+`
+
+func (q *Queries) GetAPITokensByUserID(ctx context.Context, userID pgtype.UUID) ([]ApiToken, error) {
+	rows, err := q.db.Query(ctx, getAPITokensByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ApiToken{}
+	for rows.Next() {
+		var i ApiToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.TokenHash,
+			&i.LastUsedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCharacterByID = `-- name: GetCharacterByID :one
+
+SELECT id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight FROM characters WHERE id = $1
+`
+
+// Character Queries
+func (q *Queries) GetCharacterByID(ctx context.Context, id pgtype.UUID) (Character, error) {
+	row := q.db.QueryRow(ctx, getCharacterByID, id)
+	var i Character
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Class,
+		&i.Level,
+		&i.Race,
+		&i.Background,
+		&i.Alignment,
+		&i.ExperiencePoints,
+		&i.Strength,
+		&i.Dexterity,
+		&i.Constitution,
+		&i.Intelligence,
+		&i.Wisdom,
+		&i.Charisma,
+		&i.MaxHitPoints,
+		&i.CurrentHitPoints,
+		&i.TemporaryHitPoints,
+		&i.ArmorClass,
+		&i.Speed,
+		&i.SavingThrowProficiencies,
+		&i.SkillProficiencies,
+		&i.FeaturesTraits,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
+	)
+	return i, err
+}
+
+const getCharacterCrafting = `-- name: GetCharacterCrafting :one
+SELECT character_id, item, price, progress, started_at FROM character_crafting WHERE character_id = $1
+`
+
+func (q *Queries) GetCharacterCrafting(ctx context.Context, characterID pgtype.UUID) (CharacterCrafting, error) {
+	row := q.db.QueryRow(ctx, getCharacterCrafting, characterID)
+	var i CharacterCrafting
+	err := row.Scan(
+		&i.CharacterID,
+		&i.Item,
+		&i.Price,
+		&i.Progress,
+		&i.StartedAt,
+	)
+	return i, err
+}
+
+const getCharacterEvents = `-- name: GetCharacterEvents :many
+SELECT e.id, e.character_id, e.user_id, e.kind, e.description, e.created_at, u.email FROM character_events e
+LEFT JOIN users u ON u.id = e.user_id
+WHERE e.character_id = $1
+ORDER BY e.created_at DESC
+LIMIT $2
+`
+
+type GetCharacterEventsParams struct {
+	CharacterID pgtype.UUID `json:"character_id"`
+	Limit       int32       `json:"limit"`
+}
+
+type GetCharacterEventsRow struct {
+	ID          pgtype.UUID        `json:"id"`
+	CharacterID pgtype.UUID        `json:"character_id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Kind        string             `json:"kind"`
+	Description string             `json:"description"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	Email       pgtype.Text        `json:"email"`
+}
+
+func (q *Queries) GetCharacterEvents(ctx context.Context, arg GetCharacterEventsParams) ([]GetCharacterEventsRow, error) {
+	rows, err := q.db.Query(ctx, getCharacterEvents, arg.CharacterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCharacterEventsRow{}
+	for rows.Next() {
+		var i GetCharacterEventsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CharacterID,
+			&i.UserID,
+			&i.Kind,
+			&i.Description,
+			&i.CreatedAt,
+			&i.Email,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCharacterInventory = `-- name: GetCharacterInventory :many
+SELECT id, character_id, name, quantity, equipped, created_at, location FROM character_inventory WHERE character_id = $1 ORDER BY name, created_at
+`
+
+func (q *Queries) GetCharacterInventory(ctx context.Context, characterID pgtype.UUID) ([]CharacterInventory, error) {
+	rows, err := q.db.Query(ctx, getCharacterInventory, characterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CharacterInventory{}
+	for rows.Next() {
+		var i CharacterInventory
+		if err := rows.Scan(
+			&i.ID,
+			&i.CharacterID,
+			&i.Name,
+			&i.Quantity,
+			&i.Equipped,
+			&i.CreatedAt,
+			&i.Location,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCharacterJournal = `-- name: GetCharacterJournal :many
+SELECT id, character_id, title, body, created_at, updated_at FROM character_journal WHERE character_id = $1 ORDER BY updated_at DESC, title
+`
+
+func (q *Queries) GetCharacterJournal(ctx context.Context, characterID pgtype.UUID) ([]CharacterJournal, error) {
+	rows, err := q.db.Query(ctx, getCharacterJournal, characterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CharacterJournal{}
+	for rows.Next() {
+		var i CharacterJournal
+		if err := rows.Scan(
+			&i.ID,
+			&i.CharacterID,
+			&i.Title,
+			&i.Body,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCharacterPlan = `-- name: GetCharacterPlan :many
+
+SELECT character_id, level, class, subclass, feat, ability_increases, notes, created_at, updated_at FROM character_plan_levels WHERE character_id = $1 ORDER BY level
+`
+
+// Build Plan Queries
+func (q *Queries) GetCharacterPlan(ctx context.Context, characterID pgtype.UUID) ([]CharacterPlanLevel, error) {
+	rows, err := q.db.Query(ctx, getCharacterPlan, characterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CharacterPlanLevel{}
+	for rows.Next() {
+		var i CharacterPlanLevel
+		if err := rows.Scan(
+			&i.CharacterID,
+			&i.Level,
+			&i.Class,
+			&i.Subclass,
+			&i.Feat,
+			&i.AbilityIncreases,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCharacterPortrait = `-- name: GetCharacterPortrait :one
+SELECT character_id, width, height, pixels, source_url, updated_at FROM character_portraits WHERE character_id = $1
+`
+
+func (q *Queries) GetCharacterPortrait(ctx context.Context, characterID pgtype.UUID) (CharacterPortrait, error) {
+	row := q.db.QueryRow(ctx, getCharacterPortrait, characterID)
+	var i CharacterPortrait
+	err := row.Scan(
+		&i.CharacterID,
+		&i.Width,
+		&i.Height,
+		&i.Pixels,
+		&i.SourceUrl,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getCharacterResources = `-- name: GetCharacterResources :many
+SELECT character_id, name, max_uses, used, recharge FROM character_resources WHERE character_id = $1 ORDER BY name
+`
+
+func (q *Queries) GetCharacterResources(ctx context.Context, characterID pgtype.UUID) ([]CharacterResource, error) {
+	rows, err := q.db.Query(ctx, getCharacterResources, characterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CharacterResource{}
+	for rows.Next() {
+		var i CharacterResource
+		if err := rows.Scan(
+			&i.CharacterID,
+			&i.Name,
+			&i.MaxUses,
+			&i.Used,
+			&i.Recharge,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCharacterSpellcasting = `-- name: GetCharacterSpellcasting :one
+SELECT character_id, spellcasting_class, spellcasting_ability, spell_save_dc, spell_attack_bonus, slots_max, slots_used, created_at, updated_at FROM character_spellcasting WHERE character_id = $1
+`
+
+func (q *Queries) GetCharacterSpellcasting(ctx context.Context, characterID pgtype.UUID) (CharacterSpellcasting, error) {
+	row := q.db.QueryRow(ctx, getCharacterSpellcasting, characterID)
+	var i CharacterSpellcasting
+	err := row.Scan(
+		&i.CharacterID,
+		&i.SpellcastingClass,
+		&i.SpellcastingAbility,
+		&i.SpellSaveDc,
+		&i.SpellAttackBonus,
+		&i.SlotsMax,
+		&i.SlotsUsed,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getCharacterSpells = `-- name: GetCharacterSpells :many
+SELECT id, character_id, name, level, prepared, created_at, concentration FROM character_spells WHERE character_id = $1 ORDER BY level, name
+`
+
+func (q *Queries) GetCharacterSpells(ctx context.Context, characterID pgtype.UUID) ([]CharacterSpell, error) {
+	rows, err := q.db.Query(ctx, getCharacterSpells, characterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CharacterSpell{}
+	for rows.Next() {
+		var i CharacterSpell
+		if err := rows.Scan(
+			&i.ID,
+			&i.CharacterID,
+			&i.Name,
+			&i.Level,
+			&i.Prepared,
+			&i.CreatedAt,
+			&i.Concentration,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCharactersByUserID = `-- name: GetCharactersByUserID :many
+SELECT id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight FROM characters WHERE user_id = $1 AND NOT is_template ORDER BY updated_at DESC
+`
+
+func (q *Queries) GetCharactersByUserID(ctx context.Context, userID pgtype.UUID) ([]Character, error) {
+	rows, err := q.db.Query(ctx, getCharactersByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Character{}
+	for rows.Next() {
+		var i Character
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Class,
+			&i.Level,
+			&i.Race,
+			&i.Background,
+			&i.Alignment,
+			&i.ExperiencePoints,
+			&i.Strength,
+			&i.Dexterity,
+			&i.Constitution,
+			&i.Intelligence,
+			&i.Wisdom,
+			&i.Charisma,
+			&i.MaxHitPoints,
+			&i.CurrentHitPoints,
+			&i.TemporaryHitPoints,
+			&i.ArmorClass,
+			&i.Speed,
+			&i.SavingThrowProficiencies,
+			&i.SkillProficiencies,
+			&i.FeaturesTraits,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LastPlayedAt,
+			&i.IsTemplate,
+			&i.ConcentratingOn,
+			&i.Exhaustion,
+			&i.Inspiration,
+			&i.LuckPoints,
+			&i.Ruleset,
+			&i.SkillExpertise,
+			&i.SkillHalfProficiencies,
+			&i.ArmorClassOverride,
+			&i.MaxHitPointsBonus,
+			&i.Accent,
+			&i.Icon,
+			&i.Languages,
+			&i.ToolProficiencies,
+			&i.FlySpeed,
+			&i.SwimSpeed,
+			&i.ClimbSpeed,
+			&i.BurrowSpeed,
+			&i.Darkvision,
+			&i.Blindsight,
+			&i.Truesight,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCharactersByUserIDPaged = `-- name: GetCharactersByUserIDPaged :many
+SELECT id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight FROM characters
+WHERE user_id = $1
+  AND is_template = $2
+  AND ($3::text = '' OR name ILIKE $3 OR race ILIKE $3 OR class ILIKE $3)
+ORDER BY
+  CASE WHEN $4::text = 'level' THEN level END DESC,
+  CASE WHEN $4::text = 'last_played' THEN last_played_at END DESC NULLS LAST,
+  name ASC
+LIMIT $5 OFFSET $6
+`
+
+type GetCharactersByUserIDPagedParams struct {
+	UserID     pgtype.UUID `json:"user_id"`
+	Templates  bool        `json:"templates"`
+	Search     string      `json:"search"`
+	SortBy     string      `json:"sort_by"`
+	PageLimit  int32       `json:"page_limit"`
+	PageOffset int32       `json:"page_offset"`
+}
+
+func (q *Queries) GetCharactersByUserIDPaged(ctx context.Context, arg GetCharactersByUserIDPagedParams) ([]Character, error) {
+	rows, err := q.db.Query(ctx, getCharactersByUserIDPaged,
+		arg.UserID,
+		arg.Templates,
+		arg.Search,
+		arg.SortBy,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Character{}
+	for rows.Next() {
+		var i Character
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Class,
+			&i.Level,
+			&i.Race,
+			&i.Background,
+			&i.Alignment,
+			&i.ExperiencePoints,
+			&i.Strength,
+			&i.Dexterity,
+			&i.Constitution,
+			&i.Intelligence,
+			&i.Wisdom,
+			&i.Charisma,
+			&i.MaxHitPoints,
+			&i.CurrentHitPoints,
+			&i.TemporaryHitPoints,
+			&i.ArmorClass,
+			&i.Speed,
+			&i.SavingThrowProficiencies,
+			&i.SkillProficiencies,
+			&i.FeaturesTraits,
 			&i.Notes,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.LastPlayedAt,
+			&i.IsTemplate,
+			&i.ConcentratingOn,
+			&i.Exhaustion,
+			&i.Inspiration,
+			&i.LuckPoints,
+			&i.Ruleset,
+			&i.SkillExpertise,
+			&i.SkillHalfProficiencies,
+			&i.ArmorClassOverride,
+			&i.MaxHitPointsBonus,
+			&i.Accent,
+			&i.Icon,
+			&i.Languages,
+			&i.ToolProficiencies,
+			&i.FlySpeed,
+			&i.SwimSpeed,
+			&i.ClimbSpeed,
+			&i.BurrowSpeed,
+			&i.Darkvision,
+			&i.Blindsight,
+			&i.Truesight,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCompendiumSpells = `-- name: GetCompendiumSpells :many
+SELECT name, level, school, casting_time, spell_range, components, duration, concentration, ritual, classes, description, higher_levels, source, created_at FROM compendium_spells ORDER BY name
+`
+
+func (q *Queries) GetCompendiumSpells(ctx context.Context) ([]CompendiumSpell, error) {
+	rows, err := q.db.Query(ctx, getCompendiumSpells)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CompendiumSpell{}
+	for rows.Next() {
+		var i CompendiumSpell
+		if err := rows.Scan(
+			&i.Name,
+			&i.Level,
+			&i.School,
+			&i.CastingTime,
+			&i.SpellRange,
+			&i.Components,
+			&i.Duration,
+			&i.Concentration,
+			&i.Ritual,
+			&i.Classes,
+			&i.Description,
+			&i.HigherLevels,
+			&i.Source,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCurrentAnnouncement = `-- name: GetCurrentAnnouncement :one
+SELECT id, message, expires_at, created_at FROM server_announcements
+WHERE expires_at > NOW()
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetCurrentAnnouncement(ctx context.Context) (ServerAnnouncement, error) {
+	row := q.db.QueryRow(ctx, getCurrentAnnouncement)
+	var i ServerAnnouncement
+	err := row.Scan(
+		&i.ID,
+		&i.Message,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getInvitesByCreator = `-- name: GetInvitesByCreator :many
+SELECT id, code, created_by, used_by, used_at, created_at FROM invites WHERE created_by = $1 ORDER BY created_at DESC
+`
+
+func (q *Queries) GetInvitesByCreator(ctx context.Context, createdBy pgtype.UUID) ([]Invite, error) {
+	rows, err := q.db.Query(ctx, getInvitesByCreator, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Invite{}
+	for rows.Next() {
+		var i Invite
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.CreatedBy,
+			&i.UsedBy,
+			&i.UsedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPasswordResetByUserID = `-- name: GetPasswordResetByUserID :one
+SELECT token_hash, user_id, expires_at, created_at FROM password_resets WHERE user_id = $1
+`
+
+func (q *Queries) GetPasswordResetByUserID(ctx context.Context, userID pgtype.UUID) (PasswordReset, error) {
+	row := q.db.QueryRow(ctx, getPasswordResetByUserID, userID)
+	var i PasswordReset
+	err := row.Scan(
+		&i.TokenHash,
+		&i.UserID,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getRecentHPHistory = `-- name: GetRecentHPHistory :many
+SELECT id, character_id, current_hit_points, temporary_hit_points, max_hit_points, created_at FROM character_hp_history
+WHERE character_id = $1
+ORDER BY created_at DESC
+LIMIT $2
+`
+
+type GetRecentHPHistoryParams struct {
+	CharacterID pgtype.UUID `json:"character_id"`
+	Limit       int32       `json:"limit"`
+}
+
+func (q *Queries) GetRecentHPHistory(ctx context.Context, arg GetRecentHPHistoryParams) ([]CharacterHpHistory, error) {
+	rows, err := q.db.Query(ctx, getRecentHPHistory, arg.CharacterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CharacterHpHistory{}
+	for rows.Next() {
+		var i CharacterHpHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.CharacterID,
+			&i.CurrentHitPoints,
+			&i.TemporaryHitPoints,
+			&i.MaxHitPoints,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getShareLink = `-- name: GetShareLink :one
+SELECT token_hash, character_id, user_id, expires_at, created_at FROM share_links WHERE token_hash = $1 AND expires_at > NOW()
+`
+
+func (q *Queries) GetShareLink(ctx context.Context, tokenHash string) (ShareLink, error) {
+	row := q.db.QueryRow(ctx, getShareLink, tokenHash)
+	var i ShareLink
+	err := row.Scan(
+		&i.TokenHash,
+		&i.CharacterID,
+		&i.UserID,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUnusedInviteByCode = `-- name: GetUnusedInviteByCode :one
+SELECT id, code, created_by, used_by, used_at, created_at FROM invites WHERE code = $1 AND used_by IS NULL
+`
+
+func (q *Queries) GetUnusedInviteByCode(ctx context.Context, code string) (Invite, error) {
+	row := q.db.QueryRow(ctx, getUnusedInviteByCode, code)
+	var i Invite
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.CreatedBy,
+		&i.UsedBy,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, email, password_hash, public_key, created_at, updated_at, usage_stats, theme, notifications, role, disabled_at FROM users WHERE email = $1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email pgtype.Text) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.PublicKey,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UsageStats,
+		&i.Theme,
+		&i.Notifications,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const getUserByID = `-- name: GetUserByID :one
+SELECT id, email, password_hash, public_key, created_at, updated_at, usage_stats, theme, notifications, role, disabled_at FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByID, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.PublicKey,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UsageStats,
+		&i.Theme,
+		&i.Notifications,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const getUserByPublicKey = `-- name: GetUserByPublicKey :one
+SELECT id, email, password_hash, public_key, created_at, updated_at, usage_stats, theme, notifications, role, disabled_at FROM users WHERE public_key = $1
+`
+
+func (q *Queries) GetUserByPublicKey(ctx context.Context, publicKey pgtype.Text) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByPublicKey, publicKey)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.PublicKey,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UsageStats,
+		&i.Theme,
+		&i.Notifications,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const getUserCharacterPortraits = `-- name: GetUserCharacterPortraits :many
+SELECT character_id, width, height, pixels, source_url, updated_at FROM character_portraits
+WHERE character_id IN (SELECT id FROM characters WHERE user_id = $1)
+`
+
+func (q *Queries) GetUserCharacterPortraits(ctx context.Context, userID pgtype.UUID) ([]CharacterPortrait, error) {
+	rows, err := q.db.Query(ctx, getUserCharacterPortraits, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CharacterPortrait{}
+	for rows.Next() {
+		var i CharacterPortrait
+		if err := rows.Scan(
+			&i.CharacterID,
+			&i.Width,
+			&i.Height,
+			&i.Pixels,
+			&i.SourceUrl,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserIdentitiesByUserID = `-- name: GetUserIdentitiesByUserID :many
+SELECT id, user_id, provider, subject, email, created_at FROM user_identities WHERE user_id = $1 ORDER BY provider
+`
+
+func (q *Queries) GetUserIdentitiesByUserID(ctx context.Context, userID pgtype.UUID) ([]UserIdentity, error) {
+	rows, err := q.db.Query(ctx, getUserIdentitiesByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserIdentity{}
+	for rows.Next() {
+		var i UserIdentity
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Provider,
+			&i.Subject,
+			&i.Email,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserIdentity = `-- name: GetUserIdentity :one
+SELECT id, user_id, provider, subject, email, created_at FROM user_identities WHERE provider = $1 AND subject = $2
+`
+
+type GetUserIdentityParams struct {
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+}
+
+func (q *Queries) GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (UserIdentity, error) {
+	row := q.db.QueryRow(ctx, getUserIdentity, arg.Provider, arg.Subject)
+	var i UserIdentity
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Provider,
+		&i.Subject,
+		&i.Email,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const levelUpCharacter = `-- name: LevelUpCharacter :one
+UPDATE characters SET
+    level = $2,
+    max_hit_points = $3,
+    current_hit_points = $4,
+    strength = $5,
+    dexterity = $6,
+    constitution = $7,
+    intelligence = $8,
+    wisdom = $9,
+    charisma = $10
+WHERE id = $1 AND level = $2 - 1
+RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
+`
+
+type LevelUpCharacterParams struct {
+	ID               pgtype.UUID `json:"id"`
+	Level            int32       `json:"level"`
+	MaxHitPoints     int32       `json:"max_hit_points"`
+	CurrentHitPoints int32       `json:"current_hit_points"`
+	Strength         int32       `json:"strength"`
+	Dexterity        int32       `json:"dexterity"`
+	Constitution     int32       `json:"constitution"`
+	Intelligence     int32       `json:"intelligence"`
+	Wisdom           int32       `json:"wisdom"`
+	Charisma         int32       `json:"charisma"`
+}
+
+func (q *Queries) LevelUpCharacter(ctx context.Context, arg LevelUpCharacterParams) (Character, error) {
+	row := q.db.QueryRow(ctx, levelUpCharacter,
+		arg.ID,
+		arg.Level,
+		arg.MaxHitPoints,
+		arg.CurrentHitPoints,
+		arg.Strength,
+		arg.Dexterity,
+		arg.Constitution,
+		arg.Intelligence,
+		arg.Wisdom,
+		arg.Charisma,
+	)
+	var i Character
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Class,
+		&i.Level,
+		&i.Race,
+		&i.Background,
+		&i.Alignment,
+		&i.ExperiencePoints,
+		&i.Strength,
+		&i.Dexterity,
+		&i.Constitution,
+		&i.Intelligence,
+		&i.Wisdom,
+		&i.Charisma,
+		&i.MaxHitPoints,
+		&i.CurrentHitPoints,
+		&i.TemporaryHitPoints,
+		&i.ArmorClass,
+		&i.Speed,
+		&i.SavingThrowProficiencies,
+		&i.SkillProficiencies,
+		&i.FeaturesTraits,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
+	)
+	return i, err
+}
+
+const listDisabledUserIDs = `-- name: ListDisabledUserIDs :many
+SELECT id FROM users WHERE disabled_at IS NOT NULL
+`
+
+func (q *Queries) ListDisabledUserIDs(ctx context.Context) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listDisabledUserIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersWithCharacterCounts = `-- name: ListUsersWithCharacterCounts :many
+SELECT u.id, u.email, u.public_key, u.created_at, u.role, u.disabled_at, COUNT(c.id) AS character_count FROM users u
+LEFT JOIN characters c ON c.user_id = u.id AND NOT c.is_template
+GROUP BY u.id
+ORDER BY u.created_at
+`
+
+type ListUsersWithCharacterCountsRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	Email          pgtype.Text        `json:"email"`
+	PublicKey      pgtype.Text        `json:"public_key"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	Role           string             `json:"role"`
+	DisabledAt     pgtype.Timestamptz `json:"disabled_at"`
+	CharacterCount int64              `json:"character_count"`
+}
+
+func (q *Queries) ListUsersWithCharacterCounts(ctx context.Context) ([]ListUsersWithCharacterCountsRow, error) {
+	rows, err := q.db.Query(ctx, listUsersWithCharacterCounts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUsersWithCharacterCountsRow{}
+	for rows.Next() {
+		var i ListUsersWithCharacterCountsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.PublicKey,
+			&i.CreatedAt,
+			&i.Role,
+			&i.DisabledAt,
+			&i.CharacterCount,
 		); err != nil {
 			return nil, err
 		}
@@ -310,95 +2241,860 @@ func (q *Queries) GetCharactersByUserID(ctx context.Context, userID pgtype.UUID)
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	return items, nil
+	return items, nil
+}
+
+const pruneCharacterEvents = `-- name: PruneCharacterEvents :execrows
+DELETE FROM character_events
+WHERE created_at < $1
+`
+
+func (q *Queries) PruneCharacterEvents(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, pruneCharacterEvents, createdAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const pruneHPHistory = `-- name: PruneHPHistory :execrows
+DELETE FROM character_hp_history
+WHERE created_at < $1
+`
+
+func (q *Queries) PruneHPHistory(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, pruneHPHistory, createdAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setCharacterSpellPrepared = `-- name: SetCharacterSpellPrepared :exec
+UPDATE character_spells SET prepared = $2 WHERE id = $1
+`
+
+type SetCharacterSpellPreparedParams struct {
+	ID       pgtype.UUID `json:"id"`
+	Prepared bool        `json:"prepared"`
+}
+
+func (q *Queries) SetCharacterSpellPrepared(ctx context.Context, arg SetCharacterSpellPreparedParams) error {
+	_, err := q.db.Exec(ctx, setCharacterSpellPrepared, arg.ID, arg.Prepared)
+	return err
+}
+
+const setCraftingProgress = `-- name: SetCraftingProgress :exec
+UPDATE character_crafting SET progress = $2 WHERE character_id = $1
+`
+
+type SetCraftingProgressParams struct {
+	CharacterID pgtype.UUID `json:"character_id"`
+	Progress    int32       `json:"progress"`
+}
+
+func (q *Queries) SetCraftingProgress(ctx context.Context, arg SetCraftingProgressParams) error {
+	_, err := q.db.Exec(ctx, setCraftingProgress, arg.CharacterID, arg.Progress)
+	return err
+}
+
+const setInventoryItemEquipped = `-- name: SetInventoryItemEquipped :one
+UPDATE character_inventory SET equipped = $2 WHERE id = $1 RETURNING id, character_id, name, quantity, equipped, created_at, location
+`
+
+type SetInventoryItemEquippedParams struct {
+	ID       pgtype.UUID `json:"id"`
+	Equipped bool        `json:"equipped"`
+}
+
+func (q *Queries) SetInventoryItemEquipped(ctx context.Context, arg SetInventoryItemEquippedParams) (CharacterInventory, error) {
+	row := q.db.QueryRow(ctx, setInventoryItemEquipped, arg.ID, arg.Equipped)
+	var i CharacterInventory
+	err := row.Scan(
+		&i.ID,
+		&i.CharacterID,
+		&i.Name,
+		&i.Quantity,
+		&i.Equipped,
+		&i.CreatedAt,
+		&i.Location,
+	)
+	return i, err
+}
+
+const setInventoryItemLocation = `-- name: SetInventoryItemLocation :one
+UPDATE character_inventory SET location = $2 WHERE id = $1 RETURNING id, character_id, name, quantity, equipped, created_at, location
+`
+
+type SetInventoryItemLocationParams struct {
+	ID       pgtype.UUID `json:"id"`
+	Location string      `json:"location"`
+}
+
+func (q *Queries) SetInventoryItemLocation(ctx context.Context, arg SetInventoryItemLocationParams) (CharacterInventory, error) {
+	row := q.db.QueryRow(ctx, setInventoryItemLocation, arg.ID, arg.Location)
+	var i CharacterInventory
+	err := row.Scan(
+		&i.ID,
+		&i.CharacterID,
+		&i.Name,
+		&i.Quantity,
+		&i.Equipped,
+		&i.CreatedAt,
+		&i.Location,
+	)
+	return i, err
+}
+
+const setResourceUsed = `-- name: SetResourceUsed :exec
+UPDATE character_resources SET used = $3 WHERE character_id = $1 AND name = $2
+`
+
+type SetResourceUsedParams struct {
+	CharacterID pgtype.UUID `json:"character_id"`
+	Name        string      `json:"name"`
+	Used        int32       `json:"used"`
+}
+
+func (q *Queries) SetResourceUsed(ctx context.Context, arg SetResourceUsedParams) error {
+	_, err := q.db.Exec(ctx, setResourceUsed, arg.CharacterID, arg.Name, arg.Used)
+	return err
+}
+
+const setUserRole = `-- name: SetUserRole :one
+UPDATE users SET role = $2 WHERE id = $1 RETURNING id, email, password_hash, public_key, created_at, updated_at, usage_stats, theme, notifications, role, disabled_at
+`
+
+type SetUserRoleParams struct {
+	ID   pgtype.UUID `json:"id"`
+	Role string      `json:"role"`
+}
+
+func (q *Queries) SetUserRole(ctx context.Context, arg SetUserRoleParams) (User, error) {
+	row := q.db.QueryRow(ctx, setUserRole, arg.ID, arg.Role)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.PublicKey,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UsageStats,
+		&i.Theme,
+		&i.Notifications,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const startCrafting = `-- name: StartCrafting :exec
+INSERT INTO character_crafting (character_id, item, price, progress)
+VALUES ($1, $2, $3, $4)
+`
+
+type StartCraftingParams struct {
+	CharacterID pgtype.UUID `json:"character_id"`
+	Item        string      `json:"item"`
+	Price       int32       `json:"price"`
+	Progress    int32       `json:"progress"`
+}
+
+func (q *Queries) StartCrafting(ctx context.Context, arg StartCraftingParams) error {
+	_, err := q.db.Exec(ctx, startCrafting,
+		arg.CharacterID,
+		arg.Item,
+		arg.Price,
+		arg.Progress,
+	)
+	return err
+}
+
+const touchAPIToken = `-- name: TouchAPIToken :exec
+UPDATE api_tokens SET last_used_at = NOW() WHERE id = $1
+`
+
+func (q *Queries) TouchAPIToken(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, touchAPIToken, id)
+	return err
+}
+
+const updateCharacterAbilities = `-- name: UpdateCharacterAbilities :one
+UPDATE characters SET
+    strength = $2,
+    dexterity = $3,
+    constitution = $4,
+    intelligence = $5,
+    wisdom = $6,
+    charisma = $7
+WHERE id = $1
+RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
+`
+
+type UpdateCharacterAbilitiesParams struct {
+	ID           pgtype.UUID `json:"id"`
+	Strength     int32       `json:"strength"`
+	Dexterity    int32       `json:"dexterity"`
+	Constitution int32       `json:"constitution"`
+	Intelligence int32       `json:"intelligence"`
+	Wisdom       int32       `json:"wisdom"`
+	Charisma     int32       `json:"charisma"`
+}
+
+func (q *Queries) UpdateCharacterAbilities(ctx context.Context, arg UpdateCharacterAbilitiesParams) (Character, error) {
+	row := q.db.QueryRow(ctx, updateCharacterAbilities,
+		arg.ID,
+		arg.Strength,
+		arg.Dexterity,
+		arg.Constitution,
+		arg.Intelligence,
+		arg.Wisdom,
+		arg.Charisma,
+	)
+	var i Character
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Class,
+		&i.Level,
+		&i.Race,
+		&i.Background,
+		&i.Alignment,
+		&i.ExperiencePoints,
+		&i.Strength,
+		&i.Dexterity,
+		&i.Constitution,
+		&i.Intelligence,
+		&i.Wisdom,
+		&i.Charisma,
+		&i.MaxHitPoints,
+		&i.CurrentHitPoints,
+		&i.TemporaryHitPoints,
+		&i.ArmorClass,
+		&i.Speed,
+		&i.SavingThrowProficiencies,
+		&i.SkillProficiencies,
+		&i.FeaturesTraits,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
+	)
+	return i, err
+}
+
+const updateCharacterAppearance = `-- name: UpdateCharacterAppearance :one
+UPDATE characters SET accent = $2, icon = $3 WHERE id = $1 RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
+`
+
+type UpdateCharacterAppearanceParams struct {
+	ID     pgtype.UUID `json:"id"`
+	Accent string      `json:"accent"`
+	Icon   string      `json:"icon"`
+}
+
+func (q *Queries) UpdateCharacterAppearance(ctx context.Context, arg UpdateCharacterAppearanceParams) (Character, error) {
+	row := q.db.QueryRow(ctx, updateCharacterAppearance, arg.ID, arg.Accent, arg.Icon)
+	var i Character
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Class,
+		&i.Level,
+		&i.Race,
+		&i.Background,
+		&i.Alignment,
+		&i.ExperiencePoints,
+		&i.Strength,
+		&i.Dexterity,
+		&i.Constitution,
+		&i.Intelligence,
+		&i.Wisdom,
+		&i.Charisma,
+		&i.MaxHitPoints,
+		&i.CurrentHitPoints,
+		&i.TemporaryHitPoints,
+		&i.ArmorClass,
+		&i.Speed,
+		&i.SavingThrowProficiencies,
+		&i.SkillProficiencies,
+		&i.FeaturesTraits,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
+	)
+	return i, err
+}
+
+const updateCharacterArmorClass = `-- name: UpdateCharacterArmorClass :one
+UPDATE characters SET
+    armor_class = $2,
+    armor_class_override = $3
+WHERE id = $1
+RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
+`
+
+type UpdateCharacterArmorClassParams struct {
+	ID                 pgtype.UUID `json:"id"`
+	ArmorClass         int32       `json:"armor_class"`
+	ArmorClassOverride pgtype.Int4 `json:"armor_class_override"`
+}
+
+func (q *Queries) UpdateCharacterArmorClass(ctx context.Context, arg UpdateCharacterArmorClassParams) (Character, error) {
+	row := q.db.QueryRow(ctx, updateCharacterArmorClass, arg.ID, arg.ArmorClass, arg.ArmorClassOverride)
+	var i Character
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Class,
+		&i.Level,
+		&i.Race,
+		&i.Background,
+		&i.Alignment,
+		&i.ExperiencePoints,
+		&i.Strength,
+		&i.Dexterity,
+		&i.Constitution,
+		&i.Intelligence,
+		&i.Wisdom,
+		&i.Charisma,
+		&i.MaxHitPoints,
+		&i.CurrentHitPoints,
+		&i.TemporaryHitPoints,
+		&i.ArmorClass,
+		&i.Speed,
+		&i.SavingThrowProficiencies,
+		&i.SkillProficiencies,
+		&i.FeaturesTraits,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
+	)
+	return i, err
+}
+
+const updateCharacterBasicInfo = `-- name: UpdateCharacterBasicInfo :one
+UPDATE characters SET
+    name = $2,
+    class = $3,
+    level = $4,
+    race = $5,
+    background = $6,
+    alignment = $7,
+    experience_points = $8
+WHERE id = $1
+RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
+`
+
+type UpdateCharacterBasicInfoParams struct {
+	ID               pgtype.UUID `json:"id"`
+	Name             string      `json:"name"`
+	Class            string      `json:"class"`
+	Level            int32       `json:"level"`
+	Race             string      `json:"race"`
+	Background       pgtype.Text `json:"background"`
+	Alignment        pgtype.Text `json:"alignment"`
+	ExperiencePoints int32       `json:"experience_points"`
+}
+
+func (q *Queries) UpdateCharacterBasicInfo(ctx context.Context, arg UpdateCharacterBasicInfoParams) (Character, error) {
+	row := q.db.QueryRow(ctx, updateCharacterBasicInfo,
+		arg.ID,
+		arg.Name,
+		arg.Class,
+		arg.Level,
+		arg.Race,
+		arg.Background,
+		arg.Alignment,
+		arg.ExperiencePoints,
+	)
+	var i Character
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Class,
+		&i.Level,
+		&i.Race,
+		&i.Background,
+		&i.Alignment,
+		&i.ExperiencePoints,
+		&i.Strength,
+		&i.Dexterity,
+		&i.Constitution,
+		&i.Intelligence,
+		&i.Wisdom,
+		&i.Charisma,
+		&i.MaxHitPoints,
+		&i.CurrentHitPoints,
+		&i.TemporaryHitPoints,
+		&i.ArmorClass,
+		&i.Speed,
+		&i.SavingThrowProficiencies,
+		&i.SkillProficiencies,
+		&i.FeaturesTraits,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
+	)
+	return i, err
 }
 
-const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, password_hash, public_key, created_at, updated_at FROM users WHERE email = $1
+const updateCharacterCombat = `-- name: UpdateCharacterCombat :one
+UPDATE characters SET
+    max_hit_points = $2,
+    current_hit_points = $3,
+    temporary_hit_points = $4,
+    armor_class = $5,
+    speed = $6
+WHERE id = $1
+RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
 `
 
-func (q *Queries) GetUserByEmail(ctx context.Context, email pgtype.Text) (User, error) {
-	row := q.db.QueryRow(ctx, getUserByEmail, email)
-	var i User
+type UpdateCharacterCombatParams struct {
+	ID                 pgtype.UUID `json:"id"`
+	MaxHitPoints       int32       `json:"max_hit_points"`
+	CurrentHitPoints   int32       `json:"current_hit_points"`
+	TemporaryHitPoints int32       `json:"temporary_hit_points"`
+	ArmorClass         int32       `json:"armor_class"`
+	Speed              int32       `json:"speed"`
+}
+
+func (q *Queries) UpdateCharacterCombat(ctx context.Context, arg UpdateCharacterCombatParams) (Character, error) {
+	row := q.db.QueryRow(ctx, updateCharacterCombat,
+		arg.ID,
+		arg.MaxHitPoints,
+		arg.CurrentHitPoints,
+		arg.TemporaryHitPoints,
+		arg.ArmorClass,
+		arg.Speed,
+	)
+	var i Character
 	err := row.Scan(
 		&i.ID,
-		&i.Email,
-		&i.PasswordHash,
-		&i.PublicKey,
+		&i.UserID,
+		&i.Name,
+		&i.Class,
+		&i.Level,
+		&i.Race,
+		&i.Background,
+		&i.Alignment,
+		&i.ExperiencePoints,
+		&i.Strength,
+		&i.Dexterity,
+		&i.Constitution,
+		&i.Intelligence,
+		&i.Wisdom,
+		&i.Charisma,
+		&i.MaxHitPoints,
+		&i.CurrentHitPoints,
+		&i.TemporaryHitPoints,
+		&i.ArmorClass,
+		&i.Speed,
+		&i.SavingThrowProficiencies,
+		&i.SkillProficiencies,
+		&i.FeaturesTraits,
+		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
 	)
 	return i, err
 }
 
-const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, password_hash, public_key, created_at, updated_at FROM users WHERE id = $1
+const updateCharacterConcentration = `-- name: UpdateCharacterConcentration :one
+UPDATE characters SET concentrating_on = $2 WHERE id = $1 RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
 `
 
-func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error) {
-	row := q.db.QueryRow(ctx, getUserByID, id)
-	var i User
+type UpdateCharacterConcentrationParams struct {
+	ID              pgtype.UUID `json:"id"`
+	ConcentratingOn string      `json:"concentrating_on"`
+}
+
+func (q *Queries) UpdateCharacterConcentration(ctx context.Context, arg UpdateCharacterConcentrationParams) (Character, error) {
+	row := q.db.QueryRow(ctx, updateCharacterConcentration, arg.ID, arg.ConcentratingOn)
+	var i Character
 	err := row.Scan(
 		&i.ID,
-		&i.Email,
-		&i.PasswordHash,
-		&i.PublicKey,
+		&i.UserID,
+		&i.Name,
+		&i.Class,
+		&i.Level,
+		&i.Race,
+		&i.Background,
+		&i.Alignment,
+		&i.ExperiencePoints,
+		&i.Strength,
+		&i.Dexterity,
+		&i.Constitution,
+		&i.Intelligence,
+		&i.Wisdom,
+		&i.Charisma,
+		&i.MaxHitPoints,
+		&i.CurrentHitPoints,
+		&i.TemporaryHitPoints,
+		&i.ArmorClass,
+		&i.Speed,
+		&i.SavingThrowProficiencies,
+		&i.SkillProficiencies,
+		&i.FeaturesTraits,
+		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
 	)
 	return i, err
 }
 
-const getUserByPublicKey = `-- name: GetUserByPublicKey :one
-SELECT id, email, password_hash, public_key, created_at, updated_at FROM users WHERE public_key = $1
+const updateCharacterExhaustion = `-- name: UpdateCharacterExhaustion :one
+UPDATE characters SET exhaustion = $2 WHERE id = $1 RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
 `
 
-func (q *Queries) GetUserByPublicKey(ctx context.Context, publicKey pgtype.Text) (User, error) {
-	row := q.db.QueryRow(ctx, getUserByPublicKey, publicKey)
-	var i User
+type UpdateCharacterExhaustionParams struct {
+	ID         pgtype.UUID `json:"id"`
+	Exhaustion int32       `json:"exhaustion"`
+}
+
+func (q *Queries) UpdateCharacterExhaustion(ctx context.Context, arg UpdateCharacterExhaustionParams) (Character, error) {
+	row := q.db.QueryRow(ctx, updateCharacterExhaustion, arg.ID, arg.Exhaustion)
+	var i Character
 	err := row.Scan(
 		&i.ID,
-		&i.Email,
-		&i.PasswordHash,
-		&i.PublicKey,
+		&i.UserID,
+		&i.Name,
+		&i.Class,
+		&i.Level,
+		&i.Race,
+		&i.Background,
+		&i.Alignment,
+		&i.ExperiencePoints,
+		&i.Strength,
+		&i.Dexterity,
+		&i.Constitution,
+		&i.Intelligence,
+		&i.Wisdom,
+		&i.Charisma,
+		&i.MaxHitPoints,
+		&i.CurrentHitPoints,
+		&i.TemporaryHitPoints,
+		&i.ArmorClass,
+		&i.Speed,
+		&i.SavingThrowProficiencies,
+		&i.SkillProficiencies,
+		&i.FeaturesTraits,
+		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
 	)
 	return i, err
 }
 
-const updateCharacterAbilities = `-- name: UpdateCharacterAbilities :one
+const updateCharacterExperience = `-- name: UpdateCharacterExperience :one
+UPDATE characters SET experience_points = $2 WHERE id = $1 RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
+`
+
+type UpdateCharacterExperienceParams struct {
+	ID               pgtype.UUID `json:"id"`
+	ExperiencePoints int32       `json:"experience_points"`
+}
+
+func (q *Queries) UpdateCharacterExperience(ctx context.Context, arg UpdateCharacterExperienceParams) (Character, error) {
+	row := q.db.QueryRow(ctx, updateCharacterExperience, arg.ID, arg.ExperiencePoints)
+	var i Character
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Class,
+		&i.Level,
+		&i.Race,
+		&i.Background,
+		&i.Alignment,
+		&i.ExperiencePoints,
+		&i.Strength,
+		&i.Dexterity,
+		&i.Constitution,
+		&i.Intelligence,
+		&i.Wisdom,
+		&i.Charisma,
+		&i.MaxHitPoints,
+		&i.CurrentHitPoints,
+		&i.TemporaryHitPoints,
+		&i.ArmorClass,
+		&i.Speed,
+		&i.SavingThrowProficiencies,
+		&i.SkillProficiencies,
+		&i.FeaturesTraits,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
+	)
+	return i, err
+}
+
+const updateCharacterHitPoints = `-- name: UpdateCharacterHitPoints :one
 UPDATE characters SET
-    strength = $2,
-    dexterity = $3,
-    constitution = $4,
-    intelligence = $5,
-    wisdom = $6,
-    charisma = $7
+    current_hit_points = $2,
+    temporary_hit_points = $3
 WHERE id = $1
-RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, equipment, features_traits, notes, created_at, updated_at
+RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
 `
 
-type UpdateCharacterAbilitiesParams struct {
-	ID           pgtype.UUID `json:"id"`
-	Strength     int32       `json:"strength"`
-	Dexterity    int32       `json:"dexterity"`
-	Constitution int32       `json:"constitution"`
-	Intelligence int32       `json:"intelligence"`
-	Wisdom       int32       `json:"wisdom"`
-	Charisma     int32       `json:"charisma"`
+type UpdateCharacterHitPointsParams struct {
+	ID                 pgtype.UUID `json:"id"`
+	CurrentHitPoints   int32       `json:"current_hit_points"`
+	TemporaryHitPoints int32       `json:"temporary_hit_points"`
 }
 
-func (q *Queries) UpdateCharacterAbilities(ctx context.Context, arg UpdateCharacterAbilitiesParams) (Character, error) {
-	row := q.db.QueryRow(ctx, updateCharacterAbilities,
-		arg.ID,
-		arg.Strength,
-		arg.Dexterity,
-		arg.Constitution,
-		arg.Intelligence,
-		arg.Wisdom,
-		arg.Charisma,
+func (q *Queries) UpdateCharacterHitPoints(ctx context.Context, arg UpdateCharacterHitPointsParams) (Character, error) {
+	row := q.db.QueryRow(ctx, updateCharacterHitPoints, arg.ID, arg.CurrentHitPoints, arg.TemporaryHitPoints)
+	var i Character
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Class,
+		&i.Level,
+		&i.Race,
+		&i.Background,
+		&i.Alignment,
+		&i.ExperiencePoints,
+		&i.Strength,
+		&i.Dexterity,
+		&i.Constitution,
+		&i.Intelligence,
+		&i.Wisdom,
+		&i.Charisma,
+		&i.MaxHitPoints,
+		&i.CurrentHitPoints,
+		&i.TemporaryHitPoints,
+		&i.ArmorClass,
+		&i.Speed,
+		&i.SavingThrowProficiencies,
+		&i.SkillProficiencies,
+		&i.FeaturesTraits,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
 	)
+	return i, err
+}
+
+const updateCharacterInspiration = `-- name: UpdateCharacterInspiration :one
+UPDATE characters SET
+    inspiration = $2,
+    luck_points = $3
+WHERE id = $1
+RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
+`
+
+type UpdateCharacterInspirationParams struct {
+	ID          pgtype.UUID `json:"id"`
+	Inspiration bool        `json:"inspiration"`
+	LuckPoints  int32       `json:"luck_points"`
+}
+
+func (q *Queries) UpdateCharacterInspiration(ctx context.Context, arg UpdateCharacterInspirationParams) (Character, error) {
+	row := q.db.QueryRow(ctx, updateCharacterInspiration, arg.ID, arg.Inspiration, arg.LuckPoints)
 	var i Character
 	err := row.Scan(
 		&i.ID,
@@ -423,50 +3119,48 @@ func (q *Queries) UpdateCharacterAbilities(ctx context.Context, arg UpdateCharac
 		&i.Speed,
 		&i.SavingThrowProficiencies,
 		&i.SkillProficiencies,
-		&i.Equipment,
 		&i.FeaturesTraits,
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
 	)
 	return i, err
 }
 
-const updateCharacterBasicInfo = `-- name: UpdateCharacterBasicInfo :one
-UPDATE characters SET
-    name = $2,
-    class = $3,
-    level = $4,
-    race = $5,
-    background = $6,
-    alignment = $7,
-    experience_points = $8
-WHERE id = $1
-RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, equipment, features_traits, notes, created_at, updated_at
+const updateCharacterLanguages = `-- name: UpdateCharacterLanguages :one
+UPDATE characters SET languages = $2, tool_proficiencies = $3 WHERE id = $1 RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
 `
 
-type UpdateCharacterBasicInfoParams struct {
-	ID               pgtype.UUID `json:"id"`
-	Name             string      `json:"name"`
-	Class            string      `json:"class"`
-	Level            int32       `json:"level"`
-	Race             string      `json:"race"`
-	Background       pgtype.Text `json:"background"`
-	Alignment        pgtype.Text `json:"alignment"`
-	ExperiencePoints int32       `json:"experience_points"`
+type UpdateCharacterLanguagesParams struct {
+	ID                pgtype.UUID `json:"id"`
+	Languages         []string    `json:"languages"`
+	ToolProficiencies []string    `json:"tool_proficiencies"`
 }
 
-func (q *Queries) UpdateCharacterBasicInfo(ctx context.Context, arg UpdateCharacterBasicInfoParams) (Character, error) {
-	row := q.db.QueryRow(ctx, updateCharacterBasicInfo,
-		arg.ID,
-		arg.Name,
-		arg.Class,
-		arg.Level,
-		arg.Race,
-		arg.Background,
-		arg.Alignment,
-		arg.ExperiencePoints,
-	)
+func (q *Queries) UpdateCharacterLanguages(ctx context.Context, arg UpdateCharacterLanguagesParams) (Character, error) {
+	row := q.db.QueryRow(ctx, updateCharacterLanguages, arg.ID, arg.Languages, arg.ToolProficiencies)
 	var i Character
 	err := row.Scan(
 		&i.ID,
@@ -491,44 +3185,61 @@ func (q *Queries) UpdateCharacterBasicInfo(ctx context.Context, arg UpdateCharac
 		&i.Speed,
 		&i.SavingThrowProficiencies,
 		&i.SkillProficiencies,
-		&i.Equipment,
 		&i.FeaturesTraits,
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
 	)
 	return i, err
 }
 
-const updateCharacterCombat = `-- name: UpdateCharacterCombat :one
+const updateCharacterLastPlayed = `-- name: UpdateCharacterLastPlayed :exec
+UPDATE characters SET last_played_at = NOW() WHERE id = $1
+`
+
+func (q *Queries) UpdateCharacterLastPlayed(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, updateCharacterLastPlayed, id)
+	return err
+}
+
+const updateCharacterMaxHitPointsBonus = `-- name: UpdateCharacterMaxHitPointsBonus :one
 UPDATE characters SET
-    max_hit_points = $2,
-    current_hit_points = $3,
-    temporary_hit_points = $4,
-    armor_class = $5,
-    speed = $6
+    max_hit_points_bonus = $2,
+    current_hit_points = $3
 WHERE id = $1
-RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, equipment, features_traits, notes, created_at, updated_at
+RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
 `
 
-type UpdateCharacterCombatParams struct {
-	ID                 pgtype.UUID `json:"id"`
-	MaxHitPoints       int32       `json:"max_hit_points"`
-	CurrentHitPoints   int32       `json:"current_hit_points"`
-	TemporaryHitPoints int32       `json:"temporary_hit_points"`
-	ArmorClass         int32       `json:"armor_class"`
-	Speed              int32       `json:"speed"`
+type UpdateCharacterMaxHitPointsBonusParams struct {
+	ID                pgtype.UUID `json:"id"`
+	MaxHitPointsBonus int32       `json:"max_hit_points_bonus"`
+	CurrentHitPoints  int32       `json:"current_hit_points"`
 }
 
-func (q *Queries) UpdateCharacterCombat(ctx context.Context, arg UpdateCharacterCombatParams) (Character, error) {
-	row := q.db.QueryRow(ctx, updateCharacterCombat,
-		arg.ID,
-		arg.MaxHitPoints,
-		arg.CurrentHitPoints,
-		arg.TemporaryHitPoints,
-		arg.ArmorClass,
-		arg.Speed,
-	)
+func (q *Queries) UpdateCharacterMaxHitPointsBonus(ctx context.Context, arg UpdateCharacterMaxHitPointsBonusParams) (Character, error) {
+	row := q.db.QueryRow(ctx, updateCharacterMaxHitPointsBonus, arg.ID, arg.MaxHitPointsBonus, arg.CurrentHitPoints)
 	var i Character
 	err := row.Scan(
 		&i.ID,
@@ -553,26 +3264,64 @@ func (q *Queries) UpdateCharacterCombat(ctx context.Context, arg UpdateCharacter
 		&i.Speed,
 		&i.SavingThrowProficiencies,
 		&i.SkillProficiencies,
-		&i.Equipment,
 		&i.FeaturesTraits,
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
 	)
 	return i, err
 }
 
-const updateCharacterEquipment = `-- name: UpdateCharacterEquipment :one
-UPDATE characters SET equipment = $2 WHERE id = $1 RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, equipment, features_traits, notes, created_at, updated_at
+const updateCharacterMovement = `-- name: UpdateCharacterMovement :one
+UPDATE characters SET
+    speed = $2,
+    fly_speed = $3,
+    swim_speed = $4,
+    climb_speed = $5,
+    burrow_speed = $6,
+    darkvision = $7,
+    blindsight = $8,
+    truesight = $9
+WHERE id = $1
+RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
 `
 
-type UpdateCharacterEquipmentParams struct {
-	ID        pgtype.UUID `json:"id"`
-	Equipment []byte      `json:"equipment"`
+type UpdateCharacterMovementParams struct {
+	ID          pgtype.UUID `json:"id"`
+	Speed       int32       `json:"speed"`
+	FlySpeed    int32       `json:"fly_speed"`
+	SwimSpeed   int32       `json:"swim_speed"`
+	ClimbSpeed  int32       `json:"climb_speed"`
+	BurrowSpeed int32       `json:"burrow_speed"`
+	Darkvision  int32       `json:"darkvision"`
+	Blindsight  int32       `json:"blindsight"`
+	Truesight   int32       `json:"truesight"`
 }
 
-func (q *Queries) UpdateCharacterEquipment(ctx context.Context, arg UpdateCharacterEquipmentParams) (Character, error) {
-	row := q.db.QueryRow(ctx, updateCharacterEquipment, arg.ID, arg.Equipment)
+func (q *Queries) UpdateCharacterMovement(ctx context.Context, arg UpdateCharacterMovementParams) (Character, error) {
+	row := q.db.QueryRow(ctx, updateCharacterMovement, arg.ID, arg.Speed, arg.FlySpeed, arg.SwimSpeed, arg.ClimbSpeed, arg.BurrowSpeed, arg.Darkvision, arg.Blindsight, arg.Truesight)
 	var i Character
 	err := row.Scan(
 		&i.ID,
@@ -597,31 +3346,47 @@ func (q *Queries) UpdateCharacterEquipment(ctx context.Context, arg UpdateCharac
 		&i.Speed,
 		&i.SavingThrowProficiencies,
 		&i.SkillProficiencies,
-		&i.Equipment,
 		&i.FeaturesTraits,
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
 	)
 	return i, err
 }
 
-const updateCharacterHitPoints = `-- name: UpdateCharacterHitPoints :one
-UPDATE characters SET
-    current_hit_points = $2,
-    temporary_hit_points = $3
-WHERE id = $1
-RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, equipment, features_traits, notes, created_at, updated_at
+const updateCharacterName = `-- name: UpdateCharacterName :one
+UPDATE characters SET name = $2 WHERE id = $1 RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
 `
 
-type UpdateCharacterHitPointsParams struct {
-	ID                 pgtype.UUID `json:"id"`
-	CurrentHitPoints   int32       `json:"current_hit_points"`
-	TemporaryHitPoints int32       `json:"temporary_hit_points"`
+type UpdateCharacterNameParams struct {
+	ID   pgtype.UUID `json:"id"`
+	Name string      `json:"name"`
 }
 
-func (q *Queries) UpdateCharacterHitPoints(ctx context.Context, arg UpdateCharacterHitPointsParams) (Character, error) {
-	row := q.db.QueryRow(ctx, updateCharacterHitPoints, arg.ID, arg.CurrentHitPoints, arg.TemporaryHitPoints)
+func (q *Queries) UpdateCharacterName(ctx context.Context, arg UpdateCharacterNameParams) (Character, error) {
+	row := q.db.QueryRow(ctx, updateCharacterName, arg.ID, arg.Name)
 	var i Character
 	err := row.Scan(
 		&i.ID,
@@ -646,11 +3411,32 @@ func (q *Queries) UpdateCharacterHitPoints(ctx context.Context, arg UpdateCharac
 		&i.Speed,
 		&i.SavingThrowProficiencies,
 		&i.SkillProficiencies,
-		&i.Equipment,
 		&i.FeaturesTraits,
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
 	)
 	return i, err
 }
@@ -660,7 +3446,7 @@ UPDATE characters SET
     features_traits = $2,
     notes = $3
 WHERE id = $1
-RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, equipment, features_traits, notes, created_at, updated_at
+RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
 `
 
 type UpdateCharacterNotesParams struct {
@@ -695,11 +3481,32 @@ func (q *Queries) UpdateCharacterNotes(ctx context.Context, arg UpdateCharacterN
 		&i.Speed,
 		&i.SavingThrowProficiencies,
 		&i.SkillProficiencies,
-		&i.Equipment,
 		&i.FeaturesTraits,
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
 	)
 	return i, err
 }
@@ -707,19 +3514,103 @@ func (q *Queries) UpdateCharacterNotes(ctx context.Context, arg UpdateCharacterN
 const updateCharacterProficiencies = `-- name: UpdateCharacterProficiencies :one
 UPDATE characters SET
     saving_throw_proficiencies = $2,
-    skill_proficiencies = $3
+    skill_proficiencies = $3,
+    skill_expertise = $4,
+    skill_half_proficiencies = $5
 WHERE id = $1
-RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, equipment, features_traits, notes, created_at, updated_at
+RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
 `
 
 type UpdateCharacterProficienciesParams struct {
 	ID                       pgtype.UUID `json:"id"`
 	SavingThrowProficiencies []string    `json:"saving_throw_proficiencies"`
 	SkillProficiencies       []string    `json:"skill_proficiencies"`
+	SkillExpertise           []string    `json:"skill_expertise"`
+	SkillHalfProficiencies   []string    `json:"skill_half_proficiencies"`
 }
 
 func (q *Queries) UpdateCharacterProficiencies(ctx context.Context, arg UpdateCharacterProficienciesParams) (Character, error) {
-	row := q.db.QueryRow(ctx, updateCharacterProficiencies, arg.ID, arg.SavingThrowProficiencies, arg.SkillProficiencies)
+	row := q.db.QueryRow(ctx, updateCharacterProficiencies, arg.ID, arg.SavingThrowProficiencies, arg.SkillProficiencies, arg.SkillExpertise, arg.SkillHalfProficiencies)
+	var i Character
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Class,
+		&i.Level,
+		&i.Race,
+		&i.Background,
+		&i.Alignment,
+		&i.ExperiencePoints,
+		&i.Strength,
+		&i.Dexterity,
+		&i.Constitution,
+		&i.Intelligence,
+		&i.Wisdom,
+		&i.Charisma,
+		&i.MaxHitPoints,
+		&i.CurrentHitPoints,
+		&i.TemporaryHitPoints,
+		&i.ArmorClass,
+		&i.Speed,
+		&i.SavingThrowProficiencies,
+		&i.SkillProficiencies,
+		&i.FeaturesTraits,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
+	)
+	return i, err
+}
+
+const updateCharacterRest = `-- name: UpdateCharacterRest :one
+UPDATE characters SET
+    current_hit_points = $2,
+    temporary_hit_points = $3,
+    exhaustion = $4,
+    inspiration = $5
+WHERE id = $1
+RETURNING id, user_id, name, class, level, race, background, alignment, experience_points, strength, dexterity, constitution, intelligence, wisdom, charisma, max_hit_points, current_hit_points, temporary_hit_points, armor_class, speed, saving_throw_proficiencies, skill_proficiencies, features_traits, notes, created_at, updated_at, last_played_at, is_template, concentrating_on, exhaustion, inspiration, luck_points, ruleset, skill_expertise, skill_half_proficiencies, armor_class_override, max_hit_points_bonus, accent, icon, languages, tool_proficiencies, fly_speed, swim_speed, climb_speed, burrow_speed, darkvision, blindsight, truesight
+`
+
+type UpdateCharacterRestParams struct {
+	ID                 pgtype.UUID `json:"id"`
+	CurrentHitPoints   int32       `json:"current_hit_points"`
+	TemporaryHitPoints int32       `json:"temporary_hit_points"`
+	Exhaustion         int32       `json:"exhaustion"`
+	Inspiration        bool        `json:"inspiration"`
+}
+
+func (q *Queries) UpdateCharacterRest(ctx context.Context, arg UpdateCharacterRestParams) (Character, error) {
+	row := q.db.QueryRow(ctx, updateCharacterRest,
+		arg.ID,
+		arg.CurrentHitPoints,
+		arg.TemporaryHitPoints,
+		arg.Exhaustion,
+		arg.Inspiration,
+	)
 	var i Character
 	err := row.Scan(
 		&i.ID,
@@ -744,17 +3635,104 @@ func (q *Queries) UpdateCharacterProficiencies(ctx context.Context, arg UpdateCh
 		&i.Speed,
 		&i.SavingThrowProficiencies,
 		&i.SkillProficiencies,
-		&i.Equipment,
 		&i.FeaturesTraits,
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LastPlayedAt,
+		&i.IsTemplate,
+		&i.ConcentratingOn,
+		&i.Exhaustion,
+		&i.Inspiration,
+		&i.LuckPoints,
+		&i.Ruleset,
+		&i.SkillExpertise,
+		&i.SkillHalfProficiencies,
+		&i.ArmorClassOverride,
+		&i.MaxHitPointsBonus,
+		&i.Accent,
+		&i.Icon,
+		&i.Languages,
+		&i.ToolProficiencies,
+		&i.FlySpeed,
+		&i.SwimSpeed,
+		&i.ClimbSpeed,
+		&i.BurrowSpeed,
+		&i.Darkvision,
+		&i.Blindsight,
+		&i.Truesight,
+	)
+	return i, err
+}
+
+const updateCharacterSpellcasting = `-- name: UpdateCharacterSpellcasting :exec
+UPDATE character_spellcasting SET
+    spell_save_dc = $2,
+    spell_attack_bonus = $3,
+    slots_max = $4,
+    slots_used = $5
+WHERE character_id = $1
+`
+
+type UpdateCharacterSpellcastingParams struct {
+	CharacterID      pgtype.UUID `json:"character_id"`
+	SpellSaveDc      int32       `json:"spell_save_dc"`
+	SpellAttackBonus int32       `json:"spell_attack_bonus"`
+	SlotsMax         []int32     `json:"slots_max"`
+	SlotsUsed        []int32     `json:"slots_used"`
+}
+
+func (q *Queries) UpdateCharacterSpellcasting(ctx context.Context, arg UpdateCharacterSpellcastingParams) error {
+	_, err := q.db.Exec(ctx, updateCharacterSpellcasting,
+		arg.CharacterID,
+		arg.SpellSaveDc,
+		arg.SpellAttackBonus,
+		arg.SlotsMax,
+		arg.SlotsUsed,
+	)
+	return err
+}
+
+const updateJournalEntry = `-- name: UpdateJournalEntry :one
+UPDATE character_journal SET title = $2, body = $3, updated_at = NOW() WHERE id = $1 RETURNING id, character_id, title, body, created_at, updated_at
+`
+
+type UpdateJournalEntryParams struct {
+	ID    pgtype.UUID `json:"id"`
+	Title string      `json:"title"`
+	Body  string      `json:"body"`
+}
+
+func (q *Queries) UpdateJournalEntry(ctx context.Context, arg UpdateJournalEntryParams) (CharacterJournal, error) {
+	row := q.db.QueryRow(ctx, updateJournalEntry, arg.ID, arg.Title, arg.Body)
+	var i CharacterJournal
+	err := row.Scan(
+		&i.ID,
+		&i.CharacterID,
+		&i.Title,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const updateSpellSlotsUsed = `-- name: UpdateSpellSlotsUsed :exec
+UPDATE character_spellcasting SET slots_used = $2 WHERE character_id = $1
+`
+
+type UpdateSpellSlotsUsedParams struct {
+	CharacterID pgtype.UUID `json:"character_id"`
+	SlotsUsed   []int32     `json:"slots_used"`
+}
+
+func (q *Queries) UpdateSpellSlotsUsed(ctx context.Context, arg UpdateSpellSlotsUsedParams) error {
+	_, err := q.db.Exec(ctx, updateSpellSlotsUsed, arg.CharacterID, arg.SlotsUsed)
+	return err
+}
+
 const updateUserEmail = `-- name: UpdateUserEmail :one
-UPDATE users SET email = $2 WHERE id = $1 RETURNING id, email, password_hash, public_key, created_at, updated_at
+UPDATE users SET email = $2 WHERE id = $1 RETURNING id, email, password_hash, public_key, created_at, updated_at, usage_stats, theme, notifications, role, disabled_at
 `
 
 type UpdateUserEmailParams struct {
@@ -772,12 +3750,45 @@ func (q *Queries) UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams
 		&i.PublicKey,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UsageStats,
+		&i.Theme,
+		&i.Notifications,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const updateUserNotifications = `-- name: UpdateUserNotifications :one
+UPDATE users SET notifications = $2 WHERE id = $1 RETURNING id, email, password_hash, public_key, created_at, updated_at, usage_stats, theme, notifications, role, disabled_at
+`
+
+type UpdateUserNotificationsParams struct {
+	ID            pgtype.UUID `json:"id"`
+	Notifications string      `json:"notifications"`
+}
+
+func (q *Queries) UpdateUserNotifications(ctx context.Context, arg UpdateUserNotificationsParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserNotifications, arg.ID, arg.Notifications)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.PublicKey,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UsageStats,
+		&i.Theme,
+		&i.Notifications,
+		&i.Role,
+		&i.DisabledAt,
 	)
 	return i, err
 }
 
 const updateUserPassword = `-- name: UpdateUserPassword :one
-UPDATE users SET password_hash = $2 WHERE id = $1 RETURNING id, email, password_hash, public_key, created_at, updated_at
+UPDATE users SET password_hash = $2 WHERE id = $1 RETURNING id, email, password_hash, public_key, created_at, updated_at, usage_stats, theme, notifications, role, disabled_at
 `
 
 type UpdateUserPasswordParams struct {
@@ -795,12 +3806,17 @@ func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPassword
 		&i.PublicKey,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UsageStats,
+		&i.Theme,
+		&i.Notifications,
+		&i.Role,
+		&i.DisabledAt,
 	)
 	return i, err
 }
 
 const updateUserPublicKey = `-- name: UpdateUserPublicKey :one
-UPDATE users SET public_key = $2 WHERE id = $1 RETURNING id, email, password_hash, public_key, created_at, updated_at
+UPDATE users SET public_key = $2 WHERE id = $1 RETURNING id, email, password_hash, public_key, created_at, updated_at, usage_stats, theme, notifications, role, disabled_at
 `
 
 type UpdateUserPublicKeyParams struct {
@@ -818,6 +3834,246 @@ func (q *Queries) UpdateUserPublicKey(ctx context.Context, arg UpdateUserPublicK
 		&i.PublicKey,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UsageStats,
+		&i.Theme,
+		&i.Notifications,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const updateUserTheme = `-- name: UpdateUserTheme :one
+UPDATE users SET theme = $2 WHERE id = $1 RETURNING id, email, password_hash, public_key, created_at, updated_at, usage_stats, theme, notifications, role, disabled_at
+`
+
+type UpdateUserThemeParams struct {
+	ID    pgtype.UUID `json:"id"`
+	Theme string      `json:"theme"`
+}
+
+func (q *Queries) UpdateUserTheme(ctx context.Context, arg UpdateUserThemeParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserTheme, arg.ID, arg.Theme)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.PublicKey,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UsageStats,
+		&i.Theme,
+		&i.Notifications,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const updateUserUsageStats = `-- name: UpdateUserUsageStats :one
+UPDATE users SET usage_stats = $2 WHERE id = $1 RETURNING id, email, password_hash, public_key, created_at, updated_at, usage_stats, theme, notifications, role, disabled_at
+`
+
+type UpdateUserUsageStatsParams struct {
+	ID         pgtype.UUID `json:"id"`
+	UsageStats bool        `json:"usage_stats"`
+}
+
+func (q *Queries) UpdateUserUsageStats(ctx context.Context, arg UpdateUserUsageStatsParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserUsageStats, arg.ID, arg.UsageStats)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.PublicKey,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UsageStats,
+		&i.Theme,
+		&i.Notifications,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const upsertCharacterPlanLevel = `-- name: UpsertCharacterPlanLevel :one
+INSERT INTO character_plan_levels (
+    character_id, level, class, subclass, feat, ability_increases, notes
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (character_id, level) DO UPDATE SET
+    class = EXCLUDED.class,
+    subclass = EXCLUDED.subclass,
+    feat = EXCLUDED.feat,
+    ability_increases = EXCLUDED.ability_increases,
+    notes = EXCLUDED.notes
+RETURNING character_id, level, class, subclass, feat, ability_increases, notes, created_at, updated_at
+`
+
+type UpsertCharacterPlanLevelParams struct {
+	CharacterID      pgtype.UUID `json:"character_id"`
+	Level            int32       `json:"level"`
+	Class            string      `json:"class"`
+	Subclass         string      `json:"subclass"`
+	Feat             string      `json:"feat"`
+	AbilityIncreases []string    `json:"ability_increases"`
+	Notes            string      `json:"notes"`
+}
+
+func (q *Queries) UpsertCharacterPlanLevel(ctx context.Context, arg UpsertCharacterPlanLevelParams) (CharacterPlanLevel, error) {
+	row := q.db.QueryRow(ctx, upsertCharacterPlanLevel,
+		arg.CharacterID,
+		arg.Level,
+		arg.Class,
+		arg.Subclass,
+		arg.Feat,
+		arg.AbilityIncreases,
+		arg.Notes,
+	)
+	var i CharacterPlanLevel
+	err := row.Scan(
+		&i.CharacterID,
+		&i.Level,
+		&i.Class,
+		&i.Subclass,
+		&i.Feat,
+		&i.AbilityIncreases,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertCharacterPortrait = `-- name: UpsertCharacterPortrait :exec
+INSERT INTO character_portraits (character_id, width, height, pixels, source_url)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (character_id) DO UPDATE
+SET width = EXCLUDED.width, height = EXCLUDED.height, pixels = EXCLUDED.pixels,
+    source_url = EXCLUDED.source_url, updated_at = NOW()
+`
+
+type UpsertCharacterPortraitParams struct {
+	CharacterID pgtype.UUID `json:"character_id"`
+	Width       int32       `json:"width"`
+	Height      int32       `json:"height"`
+	Pixels      []byte      `json:"pixels"`
+	SourceUrl   string      `json:"source_url"`
+}
+
+func (q *Queries) UpsertCharacterPortrait(ctx context.Context, arg UpsertCharacterPortraitParams) error {
+	_, err := q.db.Exec(ctx, upsertCharacterPortrait,
+		arg.CharacterID,
+		arg.Width,
+		arg.Height,
+		arg.Pixels,
+		arg.SourceUrl,
+	)
+	return err
+}
+
+const upsertCharacterResource = `-- name: UpsertCharacterResource :one
+INSERT INTO character_resources (character_id, name, max_uses, recharge)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (character_id, name) DO UPDATE
+SET max_uses = EXCLUDED.max_uses, recharge = EXCLUDED.recharge,
+    used = LEAST(character_resources.used, EXCLUDED.max_uses)
+RETURNING character_id, name, max_uses, used, recharge
+`
+
+type UpsertCharacterResourceParams struct {
+	CharacterID pgtype.UUID `json:"character_id"`
+	Name        string      `json:"name"`
+	MaxUses     int32       `json:"max_uses"`
+	Recharge    string      `json:"recharge"`
+}
+
+func (q *Queries) UpsertCharacterResource(ctx context.Context, arg UpsertCharacterResourceParams) (CharacterResource, error) {
+	row := q.db.QueryRow(ctx, upsertCharacterResource,
+		arg.CharacterID,
+		arg.Name,
+		arg.MaxUses,
+		arg.Recharge,
+	)
+	var i CharacterResource
+	err := row.Scan(
+		&i.CharacterID,
+		&i.Name,
+		&i.MaxUses,
+		&i.Used,
+		&i.Recharge,
 	)
 	return i, err
 }
+
+const upsertCompendiumSpell = `-- name: UpsertCompendiumSpell :exec
+INSERT INTO compendium_spells (
+    name, level, school, casting_time, spell_range, components, duration,
+    concentration, ritual, classes, description, higher_levels, source
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+ON CONFLICT (name) DO UPDATE
+SET level = EXCLUDED.level, school = EXCLUDED.school,
+    casting_time = EXCLUDED.casting_time, spell_range = EXCLUDED.spell_range,
+    components = EXCLUDED.components, duration = EXCLUDED.duration,
+    concentration = EXCLUDED.concentration, ritual = EXCLUDED.ritual,
+    classes = EXCLUDED.classes, description = EXCLUDED.description,
+    higher_levels = EXCLUDED.higher_levels, source = EXCLUDED.source
+`
+
+type UpsertCompendiumSpellParams struct {
+	Name          string   `json:"name"`
+	Level         int32    `json:"level"`
+	School        string   `json:"school"`
+	CastingTime   string   `json:"casting_time"`
+	SpellRange    string   `json:"spell_range"`
+	Components    string   `json:"components"`
+	Duration      string   `json:"duration"`
+	Concentration bool     `json:"concentration"`
+	Ritual        bool     `json:"ritual"`
+	Classes       []string `json:"classes"`
+	Description   string   `json:"description"`
+	HigherLevels  string   `json:"higher_levels"`
+	Source        string   `json:"source"`
+}
+
+func (q *Queries) UpsertCompendiumSpell(ctx context.Context, arg UpsertCompendiumSpellParams) error {
+	_, err := q.db.Exec(ctx, upsertCompendiumSpell,
+		arg.Name,
+		arg.Level,
+		arg.School,
+		arg.CastingTime,
+		arg.SpellRange,
+		arg.Components,
+		arg.Duration,
+		arg.Concentration,
+		arg.Ritual,
+		arg.Classes,
+		arg.Description,
+		arg.HigherLevels,
+		arg.Source,
+	)
+	return err
+}
+
+const upsertPasswordReset = `-- name: UpsertPasswordReset :exec
+INSERT INTO password_resets (token_hash, user_id, expires_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET token_hash = EXCLUDED.token_hash, expires_at = EXCLUDED.expires_at, created_at = NOW()
+`
+
+type UpsertPasswordResetParams struct {
+	TokenHash string             `json:"token_hash"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) UpsertPasswordReset(ctx context.Context, arg UpsertPasswordResetParams) error {
+	_, err := q.db.Exec(ctx, upsertPasswordReset, arg.TokenHash, arg.UserID, arg.ExpiresAt)
+	return err
+}
@@ -0,0 +1,93 @@
+package db
+
+// Written by hand: sqlc batches only repeat one query, and a sheet needs
+// several different ones in a single round trip.
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SheetData is everything a character sheet shows besides the character
+type SheetData struct {
+	Spellcasting *CharacterSpellcasting // nil for non-casters
+	Spells       []CharacterSpell
+	HPHistory    []CharacterHpHistory
+	Events       []GetCharacterEventsRow
+	Inventory    []CharacterInventory
+	Journal      []CharacterJournal
+	Resources    []CharacterResource
+	Portrait     *CharacterPortrait // nil without one
+	Crafting     *CharacterCrafting // nil when nothing is being crafted
+}
+
+type GetSheetDataParams struct {
+	CharacterID    pgtype.UUID `json:"character_id"`
+	HPHistoryLimit int32       `json:"hp_history_limit"`
+	EventLimit     int32       `json:"event_limit"`
+}
+
+// batcher is a DBTX that can send batches, as pools, connections, and
+// transactions all can
+type batcher interface {
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+var errNoBatches = errors.New("db: connection can't send batches")
+
+// GetSheetData runs the queries behind a character sheet as one batch,
+// returning the same rows as running each of them alone
+func (q *Queries) GetSheetData(ctx context.Context, arg GetSheetDataParams) (SheetData, error) {
+	conn, ok := q.db.(batcher)
+	if !ok {
+		return SheetData{}, errNoBatches
+	}
+
+	var data SheetData
+	b := &pgx.Batch{}
+	b.Queue(getCharacterSpellcasting, arg.CharacterID).Query(func(rows pgx.Rows) error {
+		sc, err := pgx.CollectRows(rows, pgx.RowToStructByPos[CharacterSpellcasting])
+		if len(sc) > 0 {
+			data.Spellcasting = &sc[0]
+		}
+		return err
+	})
+	queueRows(b, &data.Spells, getCharacterSpells, arg.CharacterID)
+	queueRows(b, &data.HPHistory, getRecentHPHistory, arg.CharacterID, arg.HPHistoryLimit)
+	queueRows(b, &data.Events, getCharacterEvents, arg.CharacterID, arg.EventLimit)
+	queueRows(b, &data.Inventory, getCharacterInventory, arg.CharacterID)
+	queueRows(b, &data.Journal, getCharacterJournal, arg.CharacterID)
+	queueRows(b, &data.Resources, getCharacterResources, arg.CharacterID)
+	b.Queue(getCharacterPortrait, arg.CharacterID).Query(func(rows pgx.Rows) error {
+		p, err := pgx.CollectRows(rows, pgx.RowToStructByPos[CharacterPortrait])
+		if len(p) > 0 {
+			data.Portrait = &p[0]
+		}
+		return err
+	})
+	b.Queue(getCharacterCrafting, arg.CharacterID).Query(func(rows pgx.Rows) error {
+		c, err := pgx.CollectRows(rows, pgx.RowToStructByPos[CharacterCrafting])
+		if len(c) > 0 {
+			data.Crafting = &c[0]
+		}
+		return err
+	})
+
+	if err := conn.SendBatch(ctx, b).Close(); err != nil {
+		return SheetData{}, err
+	}
+	return data, nil
+}
+
+// queueRows queues a query whose rows are scanned into dest in column order,
+// like the generated code does
+func queueRows[T any](b *pgx.Batch, dest *[]T, sql string, args ...any) {
+	b.Queue(sql, args...).Query(func(rows pgx.Rows) error {
+		items, err := pgx.CollectRows(rows, pgx.RowToStructByPos[T])
+		*dest = items
+		return err
+	})
+}
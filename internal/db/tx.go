@@ -0,0 +1,31 @@
+package db
+
+// Written by hand: sqlc only generates WithTx, and screens don't hold the
+// pool needed to begin a transaction.
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// beginner is a DBTX that can start a transaction, as pools, connections,
+// and transactions (with a savepoint) all can
+type beginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+var errNoTransactions = errors.New("db: connection can't begin transactions")
+
+// InTx runs fn with queries that all go through one transaction, which is
+// committed if fn returns nil and rolled back otherwise
+func (q *Queries) InTx(ctx context.Context, fn func(*Queries) error) error {
+	conn, ok := q.db.(beginner)
+	if !ok {
+		return errNoTransactions
+	}
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		return fn(q.WithTx(tx))
+	})
+}
@@ -0,0 +1,173 @@
+// Package dbhealth watches the database connection. When the database stops
+// answering, it retries with backoff until it's back, so sessions can tell
+// players what's happening instead of showing empty screens, and the HTTP
+// server can report it on /healthz.
+package dbhealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// checkInterval is how often a healthy connection is checked
+const checkInterval = 10 * time.Second
+
+// pingTimeout is how long one check waits for the database
+const pingTimeout = 5 * time.Second
+
+// Backoff between checks while the database is unreachable
+const (
+	minBackoff = time.Second
+	maxBackoff = 30 * time.Second
+)
+
+// Pool is the database connection pool being watched, such as a
+// *pgxpool.Pool
+type Pool interface {
+	Ping(ctx context.Context) error
+	Reset()
+}
+
+// Supervisor checks the database connection until its context is done
+type Supervisor struct {
+	pool Pool
+	wake chan struct{}
+
+	mu         sync.RWMutex
+	healthy    bool
+	since      time.Time // when healthy last changed
+	recoveries uint64
+}
+
+// NewSupervisor creates a supervisor for a pool that just connected
+func NewSupervisor(pool Pool) *Supervisor {
+	return &Supervisor{
+		pool:    pool,
+		wake:    make(chan struct{}, 1),
+		healthy: true,
+		since:   time.Now(),
+	}
+}
+
+// Run checks the connection until ctx is done
+func (s *Supervisor) Run(ctx context.Context) {
+	backoff := minBackoff
+	timer := time.NewTimer(checkInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-s.wake:
+			if !s.Healthy() {
+				// Already retrying on the backoff schedule
+				continue
+			}
+			timer.Stop()
+		}
+
+		if s.check(ctx) {
+			backoff = minBackoff
+			timer.Reset(checkInterval)
+		} else {
+			timer.Reset(backoff)
+			backoff = min(backoff*2, maxBackoff)
+		}
+	}
+}
+
+// check pings the database and records the result, reporting whether it
+// answered
+func (s *Supervisor) check(ctx context.Context) bool {
+	pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+	err := s.pool.Ping(pingCtx)
+	cancel()
+	if ctx.Err() != nil {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case err != nil && s.healthy:
+		log.Printf("Lost the database connection, reconnecting: %v", err)
+		s.healthy = false
+		s.since = time.Now()
+	case err == nil && !s.healthy:
+		log.Printf("Reconnected to the database after %s", time.Since(s.since).Round(time.Second))
+		// Connections opened before the outage may be broken, so start
+		// over with fresh ones
+		s.pool.Reset()
+		s.healthy = true
+		s.since = time.Now()
+		s.recoveries++
+	}
+	return err == nil
+}
+
+// Report tells the supervisor a query failed, so it checks the connection
+// now rather than at the next interval
+func (s *Supervisor) Report(err error) {
+	if s == nil || err == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Healthy reports whether the database answered the last check
+func (s *Supervisor) Healthy() bool {
+	if s == nil {
+		return true
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.healthy
+}
+
+// Recoveries counts how many times the database has come back after being
+// unreachable, so a session can tell when to reload what it failed to load
+func (s *Supervisor) Recoveries() uint64 {
+	if s == nil {
+		return 0
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.recoveries
+}
+
+// healthResponse is the JSON body of /healthz
+type healthResponse struct {
+	Status   string `json:"status"`
+	Database string `json:"database"`
+	Since    string `json:"since"`
+}
+
+// Handler serves /healthz: 200 while the database answers, 503 while it
+// doesn't. The database's error is only logged, since it can name hosts.
+func (s *Supervisor) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.RLock()
+		resp := healthResponse{Status: "ok", Database: "ok", Since: s.since.UTC().Format(time.RFC3339)}
+		status := http.StatusOK
+		if !s.healthy {
+			resp.Status, resp.Database = "unavailable", "unreachable"
+			status = http.StatusServiceUnavailable
+		}
+		s.mu.RUnlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
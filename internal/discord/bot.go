@@ -0,0 +1,176 @@
+// Package discord answers Discord slash commands, so a remote table can
+// roll dice and check on characters in its Discord channel. Discord sends
+// each command to an HTTP endpoint as an interaction; players see their own
+// characters once they've linked their Discord account from the home screen.
+package discord
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/brady1408/dnd/internal/db"
+)
+
+// Interaction and response types from Discord's API
+const (
+	interactionPing    = 1
+	interactionCommand = 2
+
+	responsePong    = 1
+	responseMessage = 4
+
+	// flagEphemeral shows a message only to the player who ran the command
+	flagEphemeral = 1 << 6
+)
+
+// maxBody caps the size of an interaction Discord sends
+const maxBody = 64 << 10
+
+// Bot serves Discord's interactions endpoint
+type Bot struct {
+	queries   *db.Queries
+	publicKey ed25519.PublicKey
+}
+
+// NewBot creates a bot that checks interactions against the application's
+// public key, given in hex as Discord shows it
+func NewBot(queries *db.Queries, publicKey string) (*Bot, error) {
+	key, err := hex.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, errBadPublicKey
+	}
+	return &Bot{queries: queries, publicKey: key}, nil
+}
+
+// Handler returns the HTTP handler for the interactions endpoint
+func (b *Bot) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /interactions", b.interactions)
+	return mux
+}
+
+// interaction is the part of a Discord interaction the bot reads
+type interaction struct {
+	Type int `json:"type"`
+	Data struct {
+		Name    string   `json:"name"`
+		Options []option `json:"options"`
+	} `json:"data"`
+	// Member is set in servers and User in direct messages
+	Member *struct {
+		Nick string `json:"nick"`
+		User user   `json:"user"`
+	} `json:"member"`
+	User *user `json:"user"`
+}
+
+type user struct {
+	ID         string `json:"id"`
+	Username   string `json:"username"`
+	GlobalName string `json:"global_name"`
+}
+
+type option struct {
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+}
+
+// option returns a string option's value, or "" if it wasn't given
+func (in interaction) option(name string) string {
+	for _, o := range in.Data.Options {
+		if o.Name == name {
+			s, _ := o.Value.(string)
+			return s
+		}
+	}
+	return ""
+}
+
+// player returns the Discord user who ran the command and the name to show
+// for them
+func (in interaction) player() (user, string) {
+	var u user
+	nick := ""
+	switch {
+	case in.Member != nil:
+		u, nick = in.Member.User, in.Member.Nick
+	case in.User != nil:
+		u = *in.User
+	}
+	switch {
+	case nick != "":
+		return u, nick
+	case u.GlobalName != "":
+		return u, u.GlobalName
+	}
+	return u, u.Username
+}
+
+// reply is a message in answer to a command
+type reply struct {
+	Content string `json:"content"`
+	Flags   int    `json:"flags,omitempty"`
+}
+
+// private makes a reply only the player who ran the command can see, for
+// errors that would clutter the channel
+func private(content string) reply {
+	return reply{Content: content, Flags: flagEphemeral}
+}
+
+func (b *Bot) interactions(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBody))
+	if err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	// Discord signs every interaction and checks that unsigned ones are
+	// refused before it accepts the endpoint
+	signature, err := hex.DecodeString(r.Header.Get("X-Signature-Ed25519"))
+	timestamp := r.Header.Get("X-Signature-Timestamp")
+	if err != nil || !ed25519.Verify(b.publicKey, append([]byte(timestamp), body...), signature) {
+		http.Error(w, "invalid request signature", http.StatusUnauthorized)
+		return
+	}
+
+	var in interaction
+	if err := json.Unmarshal(body, &in); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	switch in.Type {
+	case interactionPing:
+		writeJSON(w, map[string]int{"type": responsePong})
+	case interactionCommand:
+		writeJSON(w, map[string]any{"type": responseMessage, "data": b.command(r.Context(), in)})
+	default:
+		http.Error(w, "unsupported interaction", http.StatusBadRequest)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write Discord response: %v", err)
+	}
+}
+
+// command runs a slash command
+func (b *Bot) command(ctx context.Context, in interaction) reply {
+	switch in.Data.Name {
+	case "roll":
+		return b.roll(in)
+	case "check":
+		return b.check(ctx, in)
+	case "character":
+		return b.character(ctx, in)
+	}
+	return private("Unknown command.")
+}
@@ -0,0 +1,295 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+)
+
+var (
+	errBadPublicKey = errors.New("discord public key must be 64 hex characters")
+	errNotLinked    = errors.New("link your Discord account from the home screen (a) to use your characters here")
+	errNoCharacters = errors.New("you don't have any characters yet")
+	errDisabled     = errors.New("your account has been disabled")
+)
+
+// apiURL is Discord's REST API
+const apiURL = "https://discord.com/api/v10"
+
+// Option types from Discord's API
+const optionString = 3
+
+type commandOption struct {
+	Type        int      `json:"type"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Required    bool     `json:"required,omitempty"`
+	Choices     []choice `json:"choices,omitempty"`
+}
+
+type choice struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type command struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Options     []commandOption `json:"options,omitempty"`
+}
+
+// checkChoices are the checks /check can roll: every skill, then the six
+// abilities. Discord allows 25 choices.
+func checkChoices() []choice {
+	var choices []choice
+	for _, name := range append(slices.Clone(character.SkillList), character.Abilities...) {
+		choices = append(choices, choice{Name: name, Value: name})
+	}
+	return choices
+}
+
+var characterOption = commandOption{
+	Type:        optionString,
+	Name:        "character",
+	Description: "Your character's name, or the start of it (defaults to the one played most recently)",
+}
+
+var rollModeOption = commandOption{
+	Type:        optionString,
+	Name:        "mode",
+	Description: "Roll with advantage or disadvantage",
+	Choices:     []choice{{"Advantage", "advantage"}, {"Disadvantage", "disadvantage"}},
+}
+
+// commands are the bot's slash commands
+var commands = []command{
+	{
+		Name:        "roll",
+		Description: "Roll dice, like 2d6+3",
+		Options: []commandOption{
+			{Type: optionString, Name: "dice", Description: "Dice to roll, like d20 or 2d6+3", Required: true},
+		},
+	},
+	{
+		Name:        "check",
+		Description: "Roll a skill or ability check for one of your characters",
+		Options: []commandOption{
+			{Type: optionString, Name: "check", Description: "Skill or ability", Required: true, Choices: checkChoices()},
+			characterOption,
+			rollModeOption,
+		},
+	},
+	{
+		Name:        "character",
+		Description: "Show one of your characters' hit points, AC, and conditions",
+		Options:     []commandOption{characterOption},
+	},
+}
+
+// RegisterCommands installs the bot's slash commands for the application,
+// replacing any it had before. Discord can take a while to show changes.
+func RegisterCommands(ctx context.Context, applicationID, botToken string) error {
+	body, err := json.Marshal(commands)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, apiURL+"/applications/"+applicationID+"/commands", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bot "+botToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("registering commands: %s: %s", resp.Status, msg)
+	}
+	return nil
+}
+
+// roll rolls a dice expression for everyone in the channel to see
+func (b *Bot) roll(in interaction) reply {
+	_, name := in.player()
+	dice, err := character.ParseDice(in.option("dice"))
+	if err != nil {
+		return private(err.Error())
+	}
+	rolls, total := dice.Roll()
+	faces := make([]string, len(rolls))
+	for i, r := range rolls {
+		faces[i] = strconv.Itoa(r)
+	}
+	return reply{Content: fmt.Sprintf("%s rolled %s: **%d** (%s)", name, dice, total, strings.Join(faces, ", "))}
+}
+
+// check rolls a skill or ability check with the character's modifier
+func (b *Bot) check(ctx context.Context, in interaction) reply {
+	char, err := b.playerCharacter(ctx, in)
+	if err != nil {
+		return private(err.Error())
+	}
+
+	check := in.option("check")
+	var mod int
+	if ability, ok := character.Skills[check]; ok {
+		rules := character.RulesetFor(char.Ruleset)
+		p := character.SkillProficiency(check, char.SkillProficiencies, char.SkillExpertise, char.SkillHalfProficiencies)
+		mod = character.AbilityModifier(abilityScore(char, ability)) + p.Bonus(rules.ProficiencyBonus(int(char.Level)))
+	} else if slices.Contains(character.Abilities, check) {
+		mod = character.AbilityModifier(abilityScore(char, check))
+	} else {
+		return private(fmt.Sprintf("%q isn't a skill or ability.", check))
+	}
+
+	d20, dice := character.RollD20(), ""
+	switch in.option("mode") {
+	case "advantage":
+		var r1, r2 int
+		d20, r1, r2 = character.RollWithAdvantage()
+		dice = fmt.Sprintf(" with advantage (%d, %d)", r1, r2)
+	case "disadvantage":
+		var r1, r2 int
+		d20, r1, r2 = character.RollWithDisadvantage()
+		dice = fmt.Sprintf(" with disadvantage (%d, %d)", r1, r2)
+	}
+	content := fmt.Sprintf("%s rolled %s%s: **%d** (%d%s)",
+		displayName(char), check, dice, d20+mod, d20, character.FormatModifierInt(mod))
+	switch d20 {
+	case 20:
+		content += " Natural 20!"
+	case 1:
+		content += " Natural 1."
+	}
+	return reply{Content: content}
+}
+
+// character shows a character's quick stats
+func (b *Bot) character(ctx context.Context, in interaction) reply {
+	char, err := b.playerCharacter(ctx, in)
+	if err != nil {
+		return private(err.Error())
+	}
+
+	rules := character.RulesetFor(char.Ruleset)
+	maxHP := character.MaxHitPoints(rules, int(char.MaxHitPoints), int(char.MaxHitPointsBonus), int(char.Exhaustion))
+	hp := fmt.Sprintf("%d/%d", char.CurrentHitPoints, maxHP)
+	if char.TemporaryHitPoints > 0 {
+		hp += fmt.Sprintf(" (+%d temp)", char.TemporaryHitPoints)
+	}
+
+	lines := []string{
+		fmt.Sprintf("**%s**, level %d %s %s", displayName(char), char.Level, char.Race, char.Class),
+		fmt.Sprintf("HP %s • AC %d • Speed %d ft", hp, char.ArmorClass, rules.ExhaustedSpeed(int(char.Speed), int(char.Exhaustion))),
+	}
+	var conditions []string
+	if char.ConcentratingOn != "" {
+		conditions = append(conditions, "Concentrating on "+char.ConcentratingOn)
+	}
+	if char.Exhaustion > 0 {
+		conditions = append(conditions, fmt.Sprintf("Exhaustion %d", char.Exhaustion))
+	}
+	if char.Inspiration {
+		conditions = append(conditions, "Inspired")
+	}
+	if len(conditions) > 0 {
+		lines = append(lines, strings.Join(conditions, " • "))
+	}
+	return reply{Content: strings.Join(lines, "\n")}
+}
+
+// playerCharacter finds the character a command is about among those of the
+// player's linked account: the one named, or else the one played most
+// recently
+func (b *Bot) playerCharacter(ctx context.Context, in interaction) (db.Character, error) {
+	u, _ := in.player()
+	identity, err := b.queries.GetUserIdentity(ctx, db.GetUserIdentityParams{Provider: "discord", Subject: u.ID})
+	if err != nil {
+		return db.Character{}, errNotLinked
+	}
+	user, err := b.queries.GetUserByID(ctx, identity.UserID)
+	if err != nil {
+		return db.Character{}, errNotLinked
+	}
+	if user.DisabledAt.Valid {
+		return db.Character{}, errDisabled
+	}
+	chars, err := b.queries.GetCharactersByUserID(ctx, identity.UserID)
+	if err != nil {
+		return db.Character{}, errors.New("couldn't load your characters; try again")
+	}
+	if len(chars) == 0 {
+		return db.Character{}, errNoCharacters
+	}
+
+	name := strings.ToLower(in.option("character"))
+	if name == "" {
+		return chars[0], nil
+	}
+	var prefixed []db.Character
+	for _, char := range chars {
+		if strings.ToLower(char.Name) == name {
+			return char, nil
+		}
+		if strings.HasPrefix(strings.ToLower(char.Name), name) {
+			prefixed = append(prefixed, char)
+		}
+	}
+	switch len(prefixed) {
+	case 0:
+		return db.Character{}, fmt.Errorf("you don't have a character named %q", in.option("character"))
+	case 1:
+		return prefixed[0], nil
+	}
+	names := make([]string, len(prefixed))
+	for i, char := range prefixed {
+		names[i] = char.Name
+	}
+	return db.Character{}, fmt.Errorf("%q could be %s", in.option("character"), strings.Join(names, ", "))
+}
+
+// displayName is a character's name with their icon, so the table can
+// tell characters apart at a glance
+func displayName(char db.Character) string {
+	if char.Icon != "" {
+		return char.Icon + " " + char.Name
+	}
+	return char.Name
+}
+
+// abilityScore returns a character's score in an ability, named in any case
+func abilityScore(char db.Character, ability string) int {
+	switch strings.ToLower(ability) {
+	case "strength":
+		return int(char.Strength)
+	case "dexterity":
+		return int(char.Dexterity)
+	case "constitution":
+		return int(char.Constitution)
+	case "intelligence":
+		return int(char.Intelligence)
+	case "wisdom":
+		return int(char.Wisdom)
+	case "charisma":
+		return int(char.Charisma)
+	}
+	return 10
+}
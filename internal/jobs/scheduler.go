@@ -0,0 +1,91 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// pollInterval is how often the scheduler checks for due jobs
+const pollInterval = time.Minute
+
+// Job is a recurring task run by the scheduler
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs recurring jobs in-process. Each run is claimed in the
+// database first, so several servers sharing a database never run the same
+// job twice in one interval.
+type Scheduler struct {
+	queries *db.Queries
+	jobs    []Job
+}
+
+// NewScheduler creates a new scheduler
+func NewScheduler(queries *db.Queries) *Scheduler {
+	return &Scheduler{queries: queries}
+}
+
+// Register adds a job to run every interval
+func (s *Scheduler) Register(name string, interval time.Duration, run func(ctx context.Context) error) {
+	s.jobs = append(s.jobs, Job{
+		Name:     name,
+		Interval: interval,
+		Run:      run,
+	})
+}
+
+// Run checks for due jobs until ctx is done
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, job := range s.jobs {
+			s.runIfDue(ctx, job)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runIfDue claims and runs a job if its interval has passed
+func (s *Scheduler) runIfDue(ctx context.Context, job Job) {
+	_, err := s.queries.ClaimJob(ctx, db.ClaimJobParams{
+		Name:      job.Name,
+		LastRunAt: pgtype.Timestamptz{Time: time.Now().Add(-job.Interval), Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Not due yet, or another server claimed it
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to claim job %s: %v", job.Name, err)
+		return
+	}
+
+	var lastError pgtype.Text
+	if err := job.Run(ctx); err != nil {
+		log.Printf("Job %s failed: %v", job.Name, err)
+		lastError = pgtype.Text{String: err.Error(), Valid: true}
+	}
+
+	if err := s.queries.CompleteJob(ctx, db.CompleteJobParams{
+		Name:      job.Name,
+		LastError: lastError,
+	}); err != nil {
+		log.Printf("Failed to complete job %s: %v", job.Name, err)
+	}
+}
@@ -0,0 +1,142 @@
+// Package manual holds the in-app manual. Each topic is a markdown file in
+// topics/ whose name orders it and whose front matter gives its title and
+// search keywords:
+//
+//	---
+//	title: Combat
+//	keywords: hp, damage, rest
+//	---
+//	Markdown text...
+//
+// Line breaks are kept when topics are shown, so write each paragraph and
+// list item on one line.
+package manual
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+//go:embed topics/*.md
+var files embed.FS
+
+// Topic is one page of the manual
+type Topic struct {
+	Title    string
+	Keywords []string
+	Body     string // markdown
+}
+
+// Manual is the parsed set of topics
+type Manual struct {
+	topics []Topic
+}
+
+// Topics returns every topic in manual order
+func (m *Manual) Topics() []Topic {
+	return m.topics
+}
+
+// Search returns the topics mentioning every word in query, ignoring case,
+// with title and keyword matches first
+func (m *Manual) Search(query string) []Topic {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return m.topics
+	}
+
+	type scored struct {
+		topic Topic
+		score int
+	}
+	var matches []scored
+	for _, t := range m.topics {
+		title := strings.ToLower(t.Title)
+		keywords := strings.ToLower(strings.Join(t.Keywords, " "))
+		body := strings.ToLower(t.Body)
+		score := 0
+		for _, w := range words {
+			switch {
+			case strings.Contains(title, w):
+				score += 3
+			case strings.Contains(keywords, w):
+				score += 2
+			case strings.Contains(body, w):
+				score++
+			default:
+				score = -1
+			}
+			if score < 0 {
+				break
+			}
+		}
+		if score > 0 {
+			matches = append(matches, scored{t, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	result := make([]Topic, len(matches))
+	for i, m := range matches {
+		result[i] = m.topic
+	}
+	return result
+}
+
+// Load reads the embedded topics in file name order
+func Load() (*Manual, error) {
+	names, err := fs.Glob(files, "topics/*.md")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	m := &Manual{}
+	for _, name := range names {
+		data, err := files.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		t, err := parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("manual %s: %w", name, err)
+		}
+		m.topics = append(m.topics, t)
+	}
+	return m, nil
+}
+
+// parse reads a topic's front matter and body
+func parse(data string) (Topic, error) {
+	rest, ok := strings.CutPrefix(data, "---\n")
+	if !ok {
+		return Topic{}, fmt.Errorf("missing front matter")
+	}
+	header, body, ok := strings.Cut(rest, "\n---\n")
+	if !ok {
+		return Topic{}, fmt.Errorf("unterminated front matter")
+	}
+
+	t := Topic{Body: strings.TrimSpace(body)}
+	for _, line := range strings.Split(header, "\n") {
+		key, value, _ := strings.Cut(line, ":")
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "title":
+			t.Title = value
+		case "keywords":
+			for _, k := range strings.Split(value, ",") {
+				if k = strings.TrimSpace(k); k != "" {
+					t.Keywords = append(t.Keywords, k)
+				}
+			}
+		}
+	}
+	if t.Title == "" {
+		return Topic{}, fmt.Errorf("no title")
+	}
+	return t, nil
+}
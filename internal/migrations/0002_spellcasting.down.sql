@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS character_spells;
+DROP TABLE IF EXISTS character_spellcasting;
@@ -0,0 +1,34 @@
+-- Spellcasting stats for characters that cast spells
+CREATE TABLE IF NOT EXISTS character_spellcasting (
+    character_id UUID PRIMARY KEY REFERENCES characters(id) ON DELETE CASCADE,
+    spellcasting_class VARCHAR(50) NOT NULL,
+    spellcasting_ability VARCHAR(20) NOT NULL,
+    spell_save_dc INTEGER NOT NULL,
+    spell_attack_bonus INTEGER NOT NULL,
+
+    -- Slots per spell level (index 1 = 1st level)
+    slots_max INTEGER[] NOT NULL DEFAULT '{0,0,0,0,0,0,0,0,0}',
+    slots_used INTEGER[] NOT NULL DEFAULT '{0,0,0,0,0,0,0,0,0}',
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Spells known or prepared by a character
+CREATE TABLE IF NOT EXISTS character_spells (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    character_id UUID NOT NULL REFERENCES characters(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    level INTEGER NOT NULL CHECK (level >= 0 AND level <= 9),
+    prepared BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    UNIQUE (character_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_character_spells_character_id ON character_spells(character_id);
+
+CREATE OR REPLACE TRIGGER update_character_spellcasting_updated_at
+    BEFORE UPDATE ON character_spellcasting
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,11 @@
+-- HP changes over time, used for the Combat tab history graph
+CREATE TABLE IF NOT EXISTS character_hp_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    character_id UUID NOT NULL REFERENCES characters(id) ON DELETE CASCADE,
+    current_hit_points INTEGER NOT NULL,
+    temporary_hit_points INTEGER NOT NULL,
+    max_hit_points INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_character_hp_history_character_id ON character_hp_history(character_id, created_at);
@@ -0,0 +1,8 @@
+-- Recurring background jobs. A server claims a job by advancing last_run_at,
+-- so only one instance runs each job per interval.
+CREATE TABLE IF NOT EXISTS scheduled_jobs (
+    name VARCHAR(100) PRIMARY KEY,
+    last_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_finished_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT
+);
@@ -0,0 +1,12 @@
+-- Invite codes for invite-only registration. created_by is NULL for codes
+-- issued by the server operator.
+CREATE TABLE IF NOT EXISTS invites (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(20) NOT NULL UNIQUE,
+    created_by UUID REFERENCES users(id) ON DELETE CASCADE,
+    used_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_invites_created_by ON invites(created_by);
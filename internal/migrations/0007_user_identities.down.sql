@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS identity_link_codes;
+DROP TABLE IF EXISTS user_identities;
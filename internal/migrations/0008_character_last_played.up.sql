@@ -0,0 +1,2 @@
+-- When the character sheet was last opened, for sorting the character list
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS last_played_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,22 @@
+-- Planned level progression for a character, one row per planned level
+CREATE TABLE IF NOT EXISTS character_plan_levels (
+    character_id UUID NOT NULL REFERENCES characters(id) ON DELETE CASCADE,
+    level INTEGER NOT NULL CHECK (level >= 1 AND level <= 20),
+    class VARCHAR(50) NOT NULL,
+    subclass VARCHAR(100) NOT NULL DEFAULT '',
+    feat VARCHAR(100) NOT NULL DEFAULT '',
+
+    -- One entry per +1, so {Strength,Strength} is +2 Strength
+    ability_increases TEXT[] NOT NULL DEFAULT '{}',
+    notes TEXT NOT NULL DEFAULT '',
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    PRIMARY KEY (character_id, level)
+);
+
+CREATE OR REPLACE TRIGGER update_character_plan_levels_updated_at
+    BEFORE UPDATE ON character_plan_levels
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
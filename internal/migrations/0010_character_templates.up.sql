@@ -0,0 +1,3 @@
+-- Templates are characters kept aside for stamping out copies, such as
+-- pre-generated characters for one-shots
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS is_template BOOLEAN NOT NULL DEFAULT FALSE;
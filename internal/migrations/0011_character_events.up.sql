@@ -0,0 +1,13 @@
+-- Change log of character mutations, shown on the sheet's History tab
+CREATE TABLE IF NOT EXISTS character_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    character_id UUID NOT NULL REFERENCES characters(id) ON DELETE CASCADE,
+
+    -- The user who made the change; kept as NULL if they are deleted
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    kind VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_character_events_character_id ON character_events(character_id, created_at);
@@ -0,0 +1,2 @@
+ALTER TABLE characters DROP COLUMN IF EXISTS concentrating_on;
+ALTER TABLE character_spells DROP COLUMN IF EXISTS concentration;
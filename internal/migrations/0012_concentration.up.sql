@@ -0,0 +1,15 @@
+-- Whether a known spell requires concentration
+ALTER TABLE character_spells ADD COLUMN IF NOT EXISTS concentration BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE character_spells SET concentration = TRUE
+WHERE name IN (
+    'Dancing Lights', 'Guidance', 'Resistance', 'True Strike',
+    'Bane', 'Bless', 'Detect Evil and Good', 'Detect Magic',
+    'Detect Poison and Disease', 'Divine Favor', 'Entangle',
+    'Expeditious Retreat', 'Faerie Fire', 'Fog Cloud', 'Heroism',
+    'Hideous Laughter', 'Hunter''s Mark', 'Protection from Evil and Good',
+    'Shield of Faith', 'Silent Image'
+);
+
+-- The spell a character is concentrating on, empty when none
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS concentrating_on VARCHAR(100) NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+-- Exhaustion level, 0 (none) through 6 (death)
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS exhaustion INTEGER NOT NULL DEFAULT 0
+    CHECK (exhaustion >= 0 AND exhaustion <= 6);
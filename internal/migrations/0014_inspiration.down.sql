@@ -0,0 +1,2 @@
+ALTER TABLE characters DROP COLUMN IF EXISTS luck_points;
+ALTER TABLE characters DROP COLUMN IF EXISTS inspiration;
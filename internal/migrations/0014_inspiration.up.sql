@@ -0,0 +1,5 @@
+-- Inspiration is held or not; luck points are a separate spendable pool,
+-- e.g. from the Lucky feat
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS inspiration BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS luck_points INTEGER NOT NULL DEFAULT 0
+    CHECK (luck_points >= 0);
@@ -0,0 +1,2 @@
+-- Which rules edition the character's sheet follows
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS ruleset TEXT NOT NULL DEFAULT '5e-2014';
@@ -0,0 +1,2 @@
+ALTER TABLE characters DROP COLUMN IF EXISTS skill_half_proficiencies;
+ALTER TABLE characters DROP COLUMN IF EXISTS skill_expertise;
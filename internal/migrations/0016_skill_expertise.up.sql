@@ -0,0 +1,4 @@
+-- Skills with double proficiency, and skills with half proficiency such as
+-- from Jack of All Trades. Expertise skills are also in skill_proficiencies.
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS skill_expertise TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS skill_half_proficiencies TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+ALTER TABLE characters DROP COLUMN IF EXISTS armor_class_override;
+DROP TABLE IF EXISTS character_inventory;
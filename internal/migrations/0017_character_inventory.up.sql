@@ -0,0 +1,15 @@
+-- Items a character carries. Equipped armor and shields set the character's
+-- armor class.
+CREATE TABLE IF NOT EXISTS character_inventory (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    character_id UUID NOT NULL REFERENCES characters(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity >= 0),
+    equipped BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_character_inventory_character_id ON character_inventory(character_id);
+
+-- AC set by hand, replacing the calculated value when not null
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS armor_class_override INTEGER CHECK (armor_class_override > 0);
@@ -0,0 +1,9 @@
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS equipment JSONB NOT NULL DEFAULT '[]';
+
+UPDATE characters c SET equipment = items.names
+FROM (
+    SELECT character_id, jsonb_agg(name ORDER BY created_at, name) AS names
+    FROM character_inventory
+    GROUP BY character_id
+) items
+WHERE items.character_id = c.id;
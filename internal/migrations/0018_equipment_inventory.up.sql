@@ -0,0 +1,11 @@
+-- Move the equipment JSON array into inventory rows, then drop it so items
+-- are only stored in one place
+INSERT INTO character_inventory (character_id, name)
+SELECT c.id, LEFT(TRIM(item.name), 100)
+FROM characters c
+CROSS JOIN LATERAL jsonb_array_elements_text(
+    CASE WHEN jsonb_typeof(c.equipment) = 'array' THEN c.equipment ELSE '[]' END
+) AS item(name)
+WHERE TRIM(item.name) <> '';
+
+ALTER TABLE characters DROP COLUMN IF EXISTS equipment;
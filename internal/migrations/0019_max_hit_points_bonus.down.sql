@@ -0,0 +1 @@
+ALTER TABLE characters DROP COLUMN IF EXISTS max_hit_points_bonus;
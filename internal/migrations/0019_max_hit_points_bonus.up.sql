@@ -0,0 +1,2 @@
+-- Temporary increase to the hit point maximum from effects such as Aid
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS max_hit_points_bonus INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE character_inventory DROP COLUMN IF EXISTS location;
@@ -0,0 +1,3 @@
+-- Name of the container item an item is stored in; empty when it's carried
+-- directly
+ALTER TABLE character_inventory ADD COLUMN IF NOT EXISTS location VARCHAR(100) NOT NULL DEFAULT '';
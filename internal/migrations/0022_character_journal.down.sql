@@ -0,0 +1,10 @@
+-- Put each character's oldest Notes entry back in the notes field
+UPDATE characters c SET notes = j.body
+FROM (
+    SELECT DISTINCT ON (character_id) character_id, body
+    FROM character_journal WHERE title = 'Notes'
+    ORDER BY character_id, created_at
+) j
+WHERE c.id = j.character_id;
+
+DROP TABLE IF EXISTS character_journal;
@@ -0,0 +1,17 @@
+-- Named journal entries for session logs, NPCs, quests, and the like. They
+-- replace the single notes field, whose text becomes an entry called Notes.
+CREATE TABLE IF NOT EXISTS character_journal (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    character_id UUID NOT NULL REFERENCES characters(id) ON DELETE CASCADE,
+    title VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_character_journal_character_id ON character_journal(character_id);
+
+INSERT INTO character_journal (character_id, title, body)
+SELECT id, 'Notes', notes FROM characters WHERE notes <> '';
+
+UPDATE characters SET notes = '' WHERE notes <> '';
@@ -0,0 +1,10 @@
+-- Messages the server operator broadcasts to every connected session until
+-- they expire
+CREATE TABLE IF NOT EXISTS server_announcements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    message TEXT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_server_announcements_expires_at ON server_announcements(expires_at);
@@ -0,0 +1,3 @@
+-- Players opt in to sending anonymous usage statistics; it's off until
+-- they turn it on
+ALTER TABLE users ADD COLUMN IF NOT EXISTS usage_stats BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+-- Each player's color scheme, by name from the TUI's theme list
+ALTER TABLE users ADD COLUMN IF NOT EXISTS theme VARCHAR(30) NOT NULL DEFAULT 'dark';
@@ -0,0 +1,3 @@
+-- How a player wants to be alerted to things that need their attention:
+-- bell, flash, or silent
+ALTER TABLE users ADD COLUMN IF NOT EXISTS notifications VARCHAR(10) NOT NULL DEFAULT 'bell';
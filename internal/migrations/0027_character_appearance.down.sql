@@ -0,0 +1,2 @@
+ALTER TABLE characters DROP COLUMN IF EXISTS icon;
+ALTER TABLE characters DROP COLUMN IF EXISTS accent;
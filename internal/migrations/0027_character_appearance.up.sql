@@ -0,0 +1,4 @@
+-- A color and a glyph each player picks to tell their characters apart at
+-- a glance; empty means none. Colors are names from the TUI's accent list.
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS accent VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS icon VARCHAR(8) NOT NULL DEFAULT '';
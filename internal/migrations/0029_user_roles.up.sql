@@ -0,0 +1,5 @@
+-- Admins can manage other players from the admin screen; everyone else is
+-- a player
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(10) NOT NULL DEFAULT 'player';
+-- Disabled accounts can't sign in
+ALTER TABLE users ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMPTZ;
@@ -0,0 +1,11 @@
+-- Limited-use class features such as Ki, Rage, and Channel Divinity: how
+-- many uses a character has, how many are spent, and the rest that brings
+-- them back ('short' or 'long')
+CREATE TABLE IF NOT EXISTS character_resources (
+    character_id UUID NOT NULL REFERENCES characters(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    max_uses INTEGER NOT NULL,
+    used INTEGER NOT NULL DEFAULT 0,
+    recharge VARCHAR(10) NOT NULL DEFAULT 'long',
+    PRIMARY KEY (character_id, name)
+);
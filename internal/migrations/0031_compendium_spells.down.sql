@@ -0,0 +1 @@
+DROP TABLE IF EXISTS compendium_spells;
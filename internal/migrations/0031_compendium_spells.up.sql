@@ -0,0 +1,18 @@
+-- Spells imported from content packs with `dnd import`. The server adds
+-- them to its built-in SRD spells when it starts.
+CREATE TABLE IF NOT EXISTS compendium_spells (
+    name VARCHAR(100) PRIMARY KEY,
+    level INTEGER NOT NULL,
+    school VARCHAR(50) NOT NULL DEFAULT '',
+    casting_time VARCHAR(100) NOT NULL DEFAULT '',
+    spell_range VARCHAR(100) NOT NULL DEFAULT '',
+    components TEXT NOT NULL DEFAULT '',
+    duration VARCHAR(100) NOT NULL DEFAULT '',
+    concentration BOOLEAN NOT NULL DEFAULT FALSE,
+    ritual BOOLEAN NOT NULL DEFAULT FALSE,
+    classes TEXT[] NOT NULL DEFAULT '{}',
+    description TEXT NOT NULL DEFAULT '',
+    higher_levels TEXT NOT NULL DEFAULT '',
+    source VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
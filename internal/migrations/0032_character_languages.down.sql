@@ -0,0 +1,2 @@
+ALTER TABLE characters DROP COLUMN IF EXISTS tool_proficiencies;
+ALTER TABLE characters DROP COLUMN IF EXISTS languages;
@@ -0,0 +1,4 @@
+-- Languages a character speaks and tools they're proficient with, which
+-- don't affect any roll the app makes so are kept as plain names
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS languages TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS tool_proficiencies TEXT[] NOT NULL DEFAULT '{}';
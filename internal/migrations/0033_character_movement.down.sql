@@ -0,0 +1,7 @@
+ALTER TABLE characters DROP COLUMN IF EXISTS truesight;
+ALTER TABLE characters DROP COLUMN IF EXISTS blindsight;
+ALTER TABLE characters DROP COLUMN IF EXISTS darkvision;
+ALTER TABLE characters DROP COLUMN IF EXISTS burrow_speed;
+ALTER TABLE characters DROP COLUMN IF EXISTS climb_speed;
+ALTER TABLE characters DROP COLUMN IF EXISTS swim_speed;
+ALTER TABLE characters DROP COLUMN IF EXISTS fly_speed;
@@ -0,0 +1,13 @@
+-- Speeds besides walking, and special senses, in feet; zero means the
+-- character doesn't have one
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS fly_speed INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS swim_speed INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS climb_speed INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS burrow_speed INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS darkvision INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS blindsight INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS truesight INTEGER NOT NULL DEFAULT 0;
+
+-- Existing characters of races with darkvision get it
+UPDATE characters SET darkvision = 60
+WHERE race IN ('Dwarf', 'Elf', 'Gnome', 'Half-Elf', 'Half-Orc', 'Tiefling');
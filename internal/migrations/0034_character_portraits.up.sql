@@ -0,0 +1,10 @@
+-- A small picture of each character, shrunk from an image the player
+-- linked: width * height pixels as red, green, and blue bytes
+CREATE TABLE IF NOT EXISTS character_portraits (
+    character_id UUID PRIMARY KEY REFERENCES characters(id) ON DELETE CASCADE,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    pixels BYTEA NOT NULL,
+    source_url TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
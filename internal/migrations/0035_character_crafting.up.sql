@@ -0,0 +1,10 @@
+-- The item a character is crafting during downtime. Price and progress
+-- are in copper pieces of market value; the item is done when progress
+-- reaches its price.
+CREATE TABLE IF NOT EXISTS character_crafting (
+    character_id UUID PRIMARY KEY REFERENCES characters(id) ON DELETE CASCADE,
+    item TEXT NOT NULL,
+    price INTEGER NOT NULL CHECK (price > 0),
+    progress INTEGER NOT NULL DEFAULT 0 CHECK (progress >= 0),
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
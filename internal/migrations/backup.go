@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PgDump returns a backup hook that saves the whole database with pg_dump
+// into dir, in pg_dump's custom format so it can be restored with
+// pg_restore. pg_dump must be on the PATH.
+func PgDump(databaseURL, dir string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return err
+		}
+		path := filepath.Join(dir, "dnd-"+time.Now().UTC().Format("20060102-150405")+".dump")
+
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, "pg_dump", "--format=custom", "--file="+path, databaseURL)
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			_ = os.Remove(path)
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return fmt.Errorf("pg_dump: %s", msg)
+			}
+			return fmt.Errorf("pg_dump: %w", err)
+		}
+		log.Printf("Backed up database to %s", path)
+		return nil
+	}
+}
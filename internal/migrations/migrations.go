@@ -0,0 +1,440 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Migration files are named NNNN_name.up.sql and NNNN_name.down.sql
+//
+//go:embed *.sql
+var files embed.FS
+
+// lockID is the advisory lock held while migrating so servers starting
+// together don't apply the same migration twice
+const lockID = 4815162342
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status reports whether a migration has been applied
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator applies embedded migrations to a database
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+	backup     func(ctx context.Context) error
+}
+
+// step is one migration script to run and the bookkeeping that records it
+type step struct {
+	migration Migration
+	script    string
+	record    string
+	args      []any
+}
+
+// New creates a migrator for the embedded migrations
+func New(pool *pgxpool.Pool) (*Migrator, error) {
+	migrations, err := load()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		pool:       pool,
+		migrations: migrations,
+	}, nil
+}
+
+// load reads and orders the embedded migration files
+func load() ([]Migration, error) {
+	names, err := fs.Glob(files, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, file := range names {
+		base, direction := "", ""
+		if b, ok := strings.CutSuffix(file, ".up.sql"); ok {
+			base, direction = b, "up"
+		} else if b, ok := strings.CutSuffix(file, ".down.sql"); ok {
+			base, direction = b, "down"
+		} else {
+			return nil, fmt.Errorf("migration %s: must end in .up.sql or .down.sql", file)
+		}
+
+		versionStr, name, ok := strings.Cut(base, "_")
+		version, err := strconv.Atoi(versionStr)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("migration %s: must start with a version number", file)
+		}
+
+		contents, err := files.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.Up = string(contents)
+		} else {
+			m.Down = string(contents)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d: missing up file", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// SetBackup sets a hook run before any migration is applied or rolled
+// back. If it fails, the database is left unchanged.
+func (m *Migrator) SetBackup(backup func(ctx context.Context) error) {
+	m.backup = backup
+}
+
+// Up applies every pending migration and returns how many were applied
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	conn, err := m.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer m.unlock(conn)
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+
+	steps := m.upSteps(applied)
+	if err := m.runBackup(ctx, steps); err != nil {
+		return 0, err
+	}
+	for i, st := range steps {
+		if err := m.run(ctx, conn, st); err != nil {
+			return i, err
+		}
+	}
+	return len(steps), nil
+}
+
+// Down rolls back the most recently applied migration. It returns nil when
+// nothing is applied.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	conn, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer m.unlock(conn)
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := m.downSteps(applied, -1, 1)
+	if err != nil || len(steps) == 0 {
+		return nil, err
+	}
+	if err := m.runBackup(ctx, steps); err != nil {
+		return nil, err
+	}
+	if err := m.run(ctx, conn, steps[0]); err != nil {
+		return nil, err
+	}
+	return &steps[0].migration, nil
+}
+
+// DownTo rolls back every applied migration newer than version, newest
+// first, and returns those rolled back. Nothing is rolled back unless every
+// one of them has a down file.
+func (m *Migrator) DownTo(ctx context.Context, version int) ([]Migration, error) {
+	conn, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer m.unlock(conn)
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := m.downSteps(applied, version, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.runBackup(ctx, steps); err != nil {
+		return nil, err
+	}
+	var done []Migration
+	for _, st := range steps {
+		if err := m.run(ctx, conn, st); err != nil {
+			return done, err
+		}
+		done = append(done, st.migration)
+	}
+	return done, nil
+}
+
+// DryRunUp runs every pending migration in a transaction that is then
+// rolled back, reporting which would be applied and the first that would
+// fail, without changing the database
+func (m *Migrator) DryRunUp(ctx context.Context) ([]Migration, error) {
+	conn, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer m.unlock(conn)
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	return m.dryRun(ctx, conn, m.upSteps(applied))
+}
+
+// DryRunDownTo is DownTo run in a transaction that is then rolled back
+func (m *Migrator) DryRunDownTo(ctx context.Context, version int) ([]Migration, error) {
+	conn, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer m.unlock(conn)
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := m.downSteps(applied, version, 0)
+	if err != nil {
+		return nil, err
+	}
+	return m.dryRun(ctx, conn, steps)
+}
+
+// upSteps returns the pending migrations in order
+func (m *Migrator) upSteps(applied map[int]time.Time) []step {
+	var steps []step
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		steps = append(steps, step{
+			migration: migration,
+			script:    migration.Up,
+			record:    "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)",
+			args:      []any{migration.Version, migration.Name},
+		})
+	}
+	return steps
+}
+
+// downSteps returns the applied migrations newer than version, newest
+// first and at most limit of them if limit is positive, or an error if any
+// can't be rolled back
+func (m *Migrator) downSteps(applied map[int]time.Time, version, limit int) ([]step, error) {
+	var steps []step
+	for i := len(m.migrations) - 1; i >= 0 && (limit <= 0 || len(steps) < limit); i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok || migration.Version <= version {
+			continue
+		}
+		if migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s: no down file", migration.Version, migration.Name)
+		}
+		steps = append(steps, step{
+			migration: migration,
+			script:    migration.Down,
+			record:    "DELETE FROM schema_migrations WHERE version = $1",
+			args:      []any{migration.Version},
+		})
+	}
+	return steps, nil
+}
+
+// runBackup calls the backup hook if there's anything to change
+func (m *Migrator) runBackup(ctx context.Context, steps []step) error {
+	if m.backup == nil || len(steps) == 0 {
+		return nil
+	}
+	if err := m.backup(ctx); err != nil {
+		return fmt.Errorf("backup: %w", err)
+	}
+	return nil
+}
+
+// errDryRun rolls back a dry run's transaction
+var errDryRun = errors.New("dry run")
+
+// dryRun runs steps in one transaction and rolls it back
+func (m *Migrator) dryRun(ctx context.Context, conn *pgxpool.Conn, steps []step) ([]Migration, error) {
+	migrations := make([]Migration, len(steps))
+	for i, st := range steps {
+		migrations[i] = st.migration
+	}
+
+	err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		for _, st := range steps {
+			if err := m.exec(ctx, tx, st); err != nil {
+				return err
+			}
+		}
+		return errDryRun
+	})
+	if errors.Is(err, errDryRun) {
+		err = nil
+	}
+	return migrations, err
+}
+
+// Status lists every migration and whether it has been applied
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(m.migrations))
+	for i, migration := range m.migrations {
+		appliedAt, ok := applied[migration.Version]
+		statuses[i] = Status{
+			Migration: migration,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		}
+	}
+	return statuses, nil
+}
+
+// lock acquires a connection holding the migration advisory lock
+func (m *Migrator) lock(ctx context.Context) (*pgxpool.Conn, error) {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+		conn.Release()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (m *Migrator) unlock(conn *pgxpool.Conn) {
+	_, _ = conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", lockID)
+	conn.Release()
+}
+
+// applied returns the applied migration versions, creating the tracking
+// table if needed
+func (m *Migrator) applied(ctx context.Context, conn *pgxpool.Conn) (map[int]time.Time, error) {
+	if err := m.ensureTable(ctx, conn); err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// ensureTable creates schema_migrations. Databases created from the old
+// schema.sql already have the initial tables, so the first migration is
+// recorded as applied for them instead of run.
+func (m *Migrator) ensureTable(ctx context.Context, conn *pgxpool.Conn) error {
+	var exists bool
+	if err := conn.QueryRow(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	var hasUsers bool
+	if err := conn.QueryRow(ctx, "SELECT to_regclass('users') IS NOT NULL").Scan(&hasUsers); err != nil {
+		return err
+	}
+
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `CREATE TABLE schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+		)`); err != nil {
+			return err
+		}
+		if hasUsers && len(m.migrations) > 0 {
+			first := m.migrations[0]
+			_, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)",
+				first.Version, first.Name)
+			return err
+		}
+		return nil
+	})
+}
+
+// run executes a migration script and its bookkeeping in one transaction
+func (m *Migrator) run(ctx context.Context, conn *pgxpool.Conn, st step) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		return m.exec(ctx, tx, st)
+	})
+}
+
+func (m *Migrator) exec(ctx context.Context, tx pgx.Tx, st step) error {
+	if _, err := tx.Exec(ctx, st.script); err != nil {
+		return fmt.Errorf("migration %d_%s: %w", st.migration.Version, st.migration.Name, err)
+	}
+	_, err := tx.Exec(ctx, st.record, st.args...)
+	return err
+}
@@ -0,0 +1,237 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/brady1408/dnd/internal/auth"
+)
+
+const stateCookie = "dnd_oauth_state"
+
+// Handler serves the web sign-in flow. After a provider verifies the user,
+// it shows a short-lived link code to type into the SSH app.
+type Handler struct {
+	auth      *auth.Service
+	baseURL   string
+	providers []Provider
+	client    *http.Client
+	mux       *http.ServeMux
+}
+
+// NewHandler creates the sign-in handler. baseURL is the public address of
+// the HTTP server, used to build provider redirect URLs.
+func NewHandler(authService *auth.Service, baseURL string, providers ...Provider) *Handler {
+	h := &Handler{
+		auth:      authService,
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		providers: providers,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		mux:       http.NewServeMux(),
+	}
+	h.mux.HandleFunc("GET /auth", h.index)
+	h.mux.HandleFunc("GET /auth/{provider}/login", h.login)
+	h.mux.HandleFunc("GET /auth/{provider}/callback", h.callback)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) provider(r *http.Request) (Provider, bool) {
+	name := r.PathValue("provider")
+	for _, p := range h.providers {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Provider{}, false
+}
+
+func (h *Handler) redirectURL(p Provider) string {
+	return h.baseURL + "/auth/" + p.Name + "/callback"
+}
+
+func (h *Handler) index(w http.ResponseWriter, r *http.Request) {
+	render(w, http.StatusOK, indexPage, h.providers)
+}
+
+func (h *Handler) login(w http.ResponseWriter, r *http.Request) {
+	p, ok := h.provider(r)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	state, err := randomState()
+	if err != nil {
+		http.Error(w, "failed to start sign-in", http.StatusInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     stateCookie,
+		Value:    state,
+		Path:     "/auth/",
+		MaxAge:   int((10 * time.Minute).Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(h.baseURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	params := url.Values{
+		"client_id":     {p.ClientID},
+		"redirect_uri":  {h.redirectURL(p)},
+		"response_type": {"code"},
+		"scope":         {strings.Join(p.Scopes, " ")},
+		"state":         {state},
+	}
+	http.Redirect(w, r, p.AuthURL+"?"+params.Encode(), http.StatusFound)
+}
+
+func (h *Handler) callback(w http.ResponseWriter, r *http.Request) {
+	p, ok := h.provider(r)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	cookie, err := r.Cookie(stateCookie)
+	if err != nil || cookie.Value == "" || cookie.Value != r.URL.Query().Get("state") {
+		render(w, http.StatusBadRequest, errorPage, "Sign-in expired or was started in another browser. Please try again.")
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: stateCookie, Path: "/auth/", MaxAge: -1})
+
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		render(w, http.StatusBadRequest, errorPage, "Sign-in was cancelled.")
+		return
+	}
+
+	subject, email, err := h.verify(r.Context(), p, code)
+	if err != nil {
+		log.Printf("OAuth %s verification failed: %v", p.Name, err)
+		render(w, http.StatusBadGateway, errorPage, "Could not verify your "+p.Label+" account.")
+		return
+	}
+
+	linkCode, err := h.auth.CreateLinkCode(r.Context(), p.Name, subject, email)
+	if err != nil {
+		render(w, http.StatusInternalServerError, errorPage, "Could not create a link code.")
+		return
+	}
+
+	render(w, http.StatusOK, codePage, struct {
+		Provider string
+		Code     string
+		Minutes  int
+	}{p.Label, linkCode, int(auth.LinkCodeTTL.Minutes())})
+}
+
+// verify exchanges an authorization code and fetches the user's identity
+func (h *Handler) verify(ctx context.Context, p Provider, code string) (string, string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {h.redirectURL(p)},
+		"client_id":     {p.ClientID},
+		"client_secret": {p.ClientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	body, err := h.do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("token exchange: %w", err)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", "", err
+	}
+	if token.AccessToken == "" {
+		return "", "", errors.New("token exchange: no access token")
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, p.UserInfoURL, nil)
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	body, err = h.do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("user info: %w", err)
+	}
+	return p.parseUser(body)
+}
+
+func (h *Handler) do(req *http.Request) ([]byte, error) {
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return body, nil
+}
+
+func randomState() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func render(w http.ResponseWriter, status int, page *template.Template, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_ = page.Execute(w, data)
+}
+
+const pageHead = `<!DOCTYPE html><html><head><meta charset="utf-8"><title>D&amp;D Character Tracker</title>
+<style>body{font-family:monospace;max-width:32rem;margin:4rem auto;padding:0 1rem}code{font-size:2rem}</style></head><body>`
+
+var indexPage = template.Must(template.New("index").Parse(pageHead + `
+<h1>Sign in</h1>
+<p>Sign in to get a link code, then type it into the SSH app.</p>
+<ul>{{range .}}<li><a href="/auth/{{.Name}}/login">Sign in with {{.Label}}</a></li>{{end}}</ul>
+</body></html>`))
+
+var codePage = template.Must(template.New("code").Parse(pageHead + `
+<h1>{{.Provider}} account verified</h1>
+<p>Your link code:</p>
+<p><code>{{.Code}}</code></p>
+<p>Type it into the SSH app within {{.Minutes}} minutes: choose "Login with Link Code" to sign in, or press "a" on the home screen to link this account.</p>
+</body></html>`))
+
+var errorPage = template.Must(template.New("error").Parse(pageHead + `
+<h1>Sign-in failed</h1>
+<p>{{.}}</p>
+<p><a href="/auth">Try again</a></p>
+</body></html>`))
@@ -0,0 +1,86 @@
+package oauth
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Provider is an OAuth 2.0 identity provider
+type Provider struct {
+	Name         string // used in URLs and stored with linked identities
+	Label        string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	Scopes       []string
+	ClientID     string
+	ClientSecret string
+
+	// parseUser extracts the stable user ID and verified email from the
+	// provider's user info response
+	parseUser func(body []byte) (subject, email string, err error)
+}
+
+var errNoSubject = errors.New("provider did not return a user ID")
+
+// Discord returns the Discord provider
+func Discord(clientID, clientSecret string) Provider {
+	return Provider{
+		Name:         "discord",
+		Label:        "Discord",
+		AuthURL:      "https://discord.com/oauth2/authorize",
+		TokenURL:     "https://discord.com/api/oauth2/token",
+		UserInfoURL:  "https://discord.com/api/users/@me",
+		Scopes:       []string{"identify", "email"},
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		parseUser: func(body []byte) (string, string, error) {
+			var user struct {
+				ID       string `json:"id"`
+				Email    string `json:"email"`
+				Verified bool   `json:"verified"`
+			}
+			if err := json.Unmarshal(body, &user); err != nil {
+				return "", "", err
+			}
+			if user.ID == "" {
+				return "", "", errNoSubject
+			}
+			if !user.Verified {
+				user.Email = ""
+			}
+			return user.ID, user.Email, nil
+		},
+	}
+}
+
+// Google returns the Google provider
+func Google(clientID, clientSecret string) Provider {
+	return Provider{
+		Name:         "google",
+		Label:        "Google",
+		AuthURL:      "https://accounts.google.com/o/oauth2/v2/auth",
+		TokenURL:     "https://oauth2.googleapis.com/token",
+		UserInfoURL:  "https://openidconnect.googleapis.com/v1/userinfo",
+		Scopes:       []string{"openid", "email"},
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		parseUser: func(body []byte) (string, string, error) {
+			var user struct {
+				Sub           string `json:"sub"`
+				Email         string `json:"email"`
+				EmailVerified bool   `json:"email_verified"`
+			}
+			if err := json.Unmarshal(body, &user); err != nil {
+				return "", "", err
+			}
+			if user.Sub == "" {
+				return "", "", errNoSubject
+			}
+			if !user.EmailVerified {
+				user.Email = ""
+			}
+			return user.Sub, user.Email, nil
+		},
+	}
+}
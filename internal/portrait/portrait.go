@@ -0,0 +1,165 @@
+// Package portrait turns pictures into small character portraits for the
+// terminal. A picture is fetched from a URL, shrunk to a few dozen pixels,
+// and kept as raw colors; the TUI draws two pixels per cell with half
+// blocks.
+package portrait
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // decoders for image.Decode
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// Portrait sizes in pixels. Two pixels stack in each terminal cell, so the
+// largest portrait is MaxWidth columns by MaxHeight/2 rows.
+const (
+	MaxWidth  = 24
+	MaxHeight = 24
+)
+
+// Limits on what's fetched, so a huge or hostile file can't tie up the
+// server
+const (
+	maxBytes  = 5 << 20
+	maxPixels = 25_000_000
+)
+
+var (
+	ErrUnsupported = errors.New("only PNG, JPEG, and GIF images are supported")
+	ErrTooLarge    = errors.New("image is too large")
+	ErrBadURL      = errors.New("enter an http or https URL")
+	errPrivate     = errors.New("private addresses are not allowed")
+)
+
+// Portrait is a shrunk picture: Width*Height pixels of red, green, and
+// blue, row by row from the top left
+type Portrait struct {
+	Width  int
+	Height int
+	Pixels []byte
+}
+
+// RGB returns the color of the pixel at x, y
+func (p Portrait) RGB(x, y int) (r, g, b uint8) {
+	i := (y*p.Width + x) * 3
+	return p.Pixels[i], p.Pixels[i+1], p.Pixels[i+2]
+}
+
+// Valid reports whether the pixels match the size, as a portrait read back
+// from the database should
+func (p Portrait) Valid() bool {
+	return p.Width > 0 && p.Height > 0 && len(p.Pixels) == p.Width*p.Height*3
+}
+
+// client fetches pictures without reaching the server's own network, so a
+// URL can't be used to probe services behind it
+var client = &http.Client{
+	Timeout: 15 * time.Second,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				ip := net.ParseIP(host)
+				if ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+					return errPrivate
+				}
+				return nil
+			},
+		}).DialContext,
+	},
+}
+
+// Fetch downloads a picture and shrinks it to a portrait
+func Fetch(ctx context.Context, rawURL string) (Portrait, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Portrait{}, ErrBadURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return Portrait{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Portrait{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Portrait{}, fmt.Errorf("server returned %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return Portrait{}, err
+	}
+	if len(data) > maxBytes {
+		return Portrait{}, ErrTooLarge
+	}
+	return Decode(data)
+}
+
+// Decode shrinks a PNG, JPEG, or GIF to a portrait
+func Decode(data []byte) (Portrait, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Portrait{}, ErrUnsupported
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return Portrait{}, ErrTooLarge
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Portrait{}, ErrUnsupported
+	}
+	return FromImage(img), nil
+}
+
+// FromImage shrinks a picture to fit MaxWidth by MaxHeight, keeping its
+// shape. Each portrait pixel averages the pixels it covers; transparent
+// areas come out black.
+func FromImage(img image.Image) Portrait {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w == 0 || h == 0 {
+		return Portrait{}
+	}
+	scale := max(float64(w)/MaxWidth, float64(h)/MaxHeight, 1)
+	pw := max(int(float64(w)/scale), 1)
+	ph := max(int(float64(h)/scale), 1)
+	ph += ph % 2 // whole cells
+
+	p := Portrait{Width: pw, Height: ph, Pixels: make([]byte, 0, pw*ph*3)}
+	for y := range ph {
+		y0 := bounds.Min.Y + y*h/ph
+		y1 := max(bounds.Min.Y+(y+1)*h/ph, y0+1)
+		for x := range pw {
+			x0 := bounds.Min.X + x*w/pw
+			x1 := max(bounds.Min.X+(x+1)*w/pw, x0+1)
+
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, _ := img.At(sx, sy).RGBA()
+					r, g, b, n = r+uint64(cr), g+uint64(cg), b+uint64(cb), n+1
+				}
+			}
+			p.Pixels = append(p.Pixels, uint8(r/n>>8), uint8(g/n>>8), uint8(b/n>>8))
+		}
+	}
+	return p
+}
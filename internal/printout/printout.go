@@ -0,0 +1,437 @@
+// Package printout renders a whole character sheet as Markdown, for
+// pasting into wikis, or as plain text 80 columns wide, for printing. The
+// TUI shows it with "E" and the API serves it for dndcli.
+package printout
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+)
+
+// Format is how a printout is written
+type Format int
+
+const (
+	Markdown Format = iota
+	Text
+)
+
+// Width is how wide plain text printouts are
+const Width = 80
+
+// Sheet is what a printout shows: the character and what's loaded
+// alongside it
+type Sheet struct {
+	Character    db.Character
+	Spellcasting *db.CharacterSpellcasting // nil for non-casters
+	Spells       []db.CharacterSpell
+	Inventory    []db.CharacterInventory
+	Resources    []db.CharacterResource
+	Journal      []db.CharacterJournal
+}
+
+// Render writes out the whole sheet
+func Render(sheet Sheet, format Format) string {
+	char := sheet.Character
+	rules := character.RulesetFor(char.Ruleset)
+	profBonus := rules.ProficiencyBonus(int(char.Level))
+	d := &doc{format: format}
+
+	d.title(char.Name)
+	summary := fmt.Sprintf("Level %d %s %s", char.Level, char.Race, char.Class)
+	if char.Background.String != "" {
+		summary += ", " + char.Background.String
+	}
+	if char.Alignment.String != "" {
+		summary += ", " + char.Alignment.String
+	}
+	d.para(summary)
+
+	hp := fmt.Sprintf("%d/%d", char.CurrentHitPoints,
+		character.MaxHitPoints(rules, int(char.MaxHitPoints), int(char.MaxHitPointsBonus), int(char.Exhaustion)))
+	if char.TemporaryHitPoints > 0 {
+		hp += fmt.Sprintf(" +%d temp", char.TemporaryHitPoints)
+	}
+	speed := []string{fmt.Sprintf("%d ft", rules.ExhaustedSpeed(int(char.Speed), int(char.Exhaustion)))}
+	for _, m := range []struct {
+		name string
+		feet int32
+	}{{"fly", char.FlySpeed}, {"swim", char.SwimSpeed}, {"climb", char.ClimbSpeed}, {"burrow", char.BurrowSpeed}} {
+		if m.feet > 0 {
+			speed = append(speed, fmt.Sprintf("%s %d ft", m.name, rules.ExhaustedSpeed(int(m.feet), int(char.Exhaustion))))
+		}
+	}
+	fields := [][2]string{
+		{"Hit Points", hp},
+		{"Armor Class", fmt.Sprint(char.ArmorClass)},
+		{"Speed", strings.Join(speed, ", ")},
+		{"Proficiency Bonus", character.FormatModifierInt(profBonus)},
+		{"Experience", fmt.Sprintf("%d XP", char.ExperiencePoints)},
+	}
+	var senses []string
+	for _, s := range []struct {
+		name string
+		feet int32
+	}{{"darkvision", char.Darkvision}, {"blindsight", char.Blindsight}, {"truesight", char.Truesight}} {
+		if s.feet > 0 {
+			senses = append(senses, fmt.Sprintf("%s %d ft", s.name, s.feet))
+		}
+	}
+	if len(senses) > 0 {
+		fields = append(fields, [2]string{"Senses", strings.Join(senses, ", ")})
+	}
+	var conditions []string
+	if char.ConcentratingOn != "" {
+		conditions = append(conditions, "concentrating on "+char.ConcentratingOn)
+	}
+	if char.Exhaustion > 0 {
+		conditions = append(conditions, fmt.Sprintf("exhaustion %d", char.Exhaustion))
+	}
+	if char.Inspiration {
+		conditions = append(conditions, "inspired")
+	}
+	if len(conditions) > 0 {
+		fields = append(fields, [2]string{"Conditions", strings.Join(conditions, ", ")})
+	}
+	d.fields(fields)
+
+	// Ability scores and saving throws
+	scores := map[string]int32{
+		"Strength": char.Strength, "Dexterity": char.Dexterity, "Constitution": char.Constitution,
+		"Intelligence": char.Intelligence, "Wisdom": char.Wisdom, "Charisma": char.Charisma,
+	}
+	d.heading("Abilities")
+	var rows [][]string
+	for _, ability := range character.Abilities {
+		mod := character.AbilityModifier(int(scores[ability]))
+		save, mark := mod, ""
+		for _, p := range char.SavingThrowProficiencies {
+			if strings.EqualFold(p, ability) {
+				save, mark = mod+profBonus, " *"
+			}
+		}
+		rows = append(rows, []string{ability, fmt.Sprint(scores[ability]), character.FormatModifierInt(mod), character.FormatModifierInt(save) + mark})
+	}
+	d.table([]string{"Ability", "Score", "Mod", "Save"}, rows)
+	d.para("Saving throws marked * are proficient.")
+
+	// Every skill, marking proficiency
+	d.heading("Skills")
+	rows = nil
+	for _, skill := range character.SkillList {
+		ability := character.Skills[skill]
+		p := character.SkillProficiency(skill, char.SkillProficiencies, char.SkillExpertise, char.SkillHalfProficiencies)
+		bonus := character.AbilityModifier(int(scores[strings.ToUpper(ability[:1])+ability[1:]])) + p.Bonus(profBonus)
+		rows = append(rows, []string{skill, strings.ToUpper(ability[:3]), character.FormatModifierInt(bonus), proficiencyNames[p]})
+	}
+	d.table([]string{"Skill", "Ability", "Bonus", "Proficiency"}, rows)
+
+	if len(char.Languages) > 0 || len(char.ToolProficiencies) > 0 {
+		d.heading("Proficiencies")
+		d.fields([][2]string{
+			{"Languages", orNone(char.Languages)},
+			{"Tools", orNone(char.ToolProficiencies)},
+		})
+	}
+
+	// Attacks with equipped weapons
+	var attacks []string
+	for _, item := range sheet.Inventory {
+		w, magic, ok := character.FindWeapon(item.Name)
+		if !ok || !item.Equipped {
+			continue
+		}
+		a := character.CalculateAttack(w, magic, character.AttackInput{
+			Class:            char.Class,
+			Strength:         int(char.Strength),
+			Dexterity:        int(char.Dexterity),
+			ProficiencyBonus: profBonus,
+		})
+		attack := fmt.Sprintf("%s: %s to hit, %s", item.Name, character.FormatModifierInt(a.AttackBonus), a.Damage())
+		if v := a.VersatileDamage(); v != "" {
+			attack += " (" + v + " two-handed)"
+		}
+		if m := rules.WeaponMastery(char.Class, w); m != "" {
+			attack += ", " + m + " mastery"
+		}
+		attacks = append(attacks, attack)
+	}
+	if len(attacks) > 0 {
+		d.heading("Attacks")
+		d.list(attacks)
+	}
+
+	if len(sheet.Resources) > 0 {
+		d.heading("Resources")
+		var resources []string
+		for _, r := range sheet.Resources {
+			resources = append(resources, fmt.Sprintf("%s: %d/%d, regained on a %s rest", r.Name, r.MaxUses-r.Used, r.MaxUses, r.Recharge))
+		}
+		d.list(resources)
+	}
+
+	if sc := sheet.Spellcasting; sc != nil {
+		d.heading("Spellcasting")
+		fields := [][2]string{
+			{"Ability", sc.SpellcastingAbility},
+			{"Save DC", fmt.Sprint(sc.SpellSaveDc)},
+			{"Spell Attack", character.FormatModifierInt(int(sc.SpellAttackBonus))},
+		}
+		var slots []string
+		for i, total := range sc.SlotsMax {
+			if total > 0 && i < len(sc.SlotsUsed) {
+				slots = append(slots, fmt.Sprintf("%s %d/%d", ordinal(i+1), total-sc.SlotsUsed[i], total))
+			}
+		}
+		if len(slots) > 0 {
+			fields = append(fields, [2]string{"Slots", strings.Join(slots, ", ")})
+		}
+		d.fields(fields)
+
+		var spells []string
+		for _, spell := range sheet.Spells {
+			level := "cantrip"
+			if spell.Level > 0 {
+				level = "level " + fmt.Sprint(spell.Level)
+			}
+			name := fmt.Sprintf("%s (%s)", spell.Name, level)
+			if spell.Prepared {
+				name += ", prepared"
+			}
+			if spell.Concentration {
+				name += ", concentration"
+			}
+			spells = append(spells, name)
+		}
+		if len(spells) > 0 {
+			d.list(spells)
+		}
+	}
+
+	if len(sheet.Inventory) > 0 {
+		d.heading("Inventory")
+		var items []string
+		for _, item := range sheet.Inventory {
+			name := item.Name
+			if item.Quantity != 1 {
+				name += fmt.Sprintf(" x%d", item.Quantity)
+			}
+			if item.Equipped {
+				name += " (equipped)"
+			}
+			if item.Location != "" {
+				name += " (in " + item.Location + ")"
+			}
+			items = append(items, name)
+		}
+		d.list(items)
+	}
+
+	if strings.TrimSpace(char.FeaturesTraits) != "" {
+		d.heading("Features and Traits")
+		d.text(char.FeaturesTraits)
+	}
+	if len(sheet.Journal) > 0 {
+		d.heading("Journal")
+		for _, entry := range sheet.Journal {
+			d.subheading(entry.Title)
+			if strings.TrimSpace(entry.Body) != "" {
+				d.text(entry.Body)
+			}
+		}
+	}
+
+	return strings.TrimRight(d.b.String(), "\n") + "\n"
+}
+
+// proficiencyNames label skill proficiencies in the skills table
+var proficiencyNames = map[character.Proficiency]string{
+	character.NotProficient:  "",
+	character.HalfProficient: "half",
+	character.Proficient:     "proficient",
+	character.Expertise:      "expertise",
+}
+
+func orNone(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
+func ordinal(n int) string {
+	switch n {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	}
+	return fmt.Sprintf("%dth", n)
+}
+
+// doc writes the parts of a printout in either format
+type doc struct {
+	b      strings.Builder
+	format Format
+}
+
+func (d *doc) title(s string) {
+	if d.format == Markdown {
+		d.b.WriteString("# " + s + "\n\n")
+		return
+	}
+	d.b.WriteString(s + "\n" + strings.Repeat("=", width(s)) + "\n\n")
+}
+
+func (d *doc) heading(s string) {
+	if d.format == Markdown {
+		d.b.WriteString("## " + s + "\n\n")
+		return
+	}
+	d.b.WriteString(s + "\n" + strings.Repeat("-", width(s)) + "\n\n")
+}
+
+func (d *doc) subheading(s string) {
+	if d.format == Markdown {
+		d.b.WriteString("### " + s + "\n\n")
+		return
+	}
+	d.b.WriteString(s + "\n" + strings.Repeat("~", width(s)) + "\n\n")
+}
+
+// para writes a paragraph, wrapped in plain text
+func (d *doc) para(s string) {
+	if d.format == Markdown {
+		d.b.WriteString(s + "\n\n")
+		return
+	}
+	d.b.WriteString(wrap(s, Width, "") + "\n\n")
+}
+
+// text writes what the player typed, which is already Markdown, keeping
+// its lines
+func (d *doc) text(s string) {
+	s = strings.TrimRight(s, " \n")
+	if d.format == Markdown {
+		d.b.WriteString(s + "\n\n")
+		return
+	}
+	for _, line := range strings.Split(s, "\n") {
+		indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+		d.b.WriteString(wrap(line, Width, indent) + "\n")
+	}
+	d.b.WriteString("\n")
+}
+
+// fields writes labelled values, one to a line
+func (d *doc) fields(fields [][2]string) {
+	labelWidth := 0
+	for _, f := range fields {
+		labelWidth = max(labelWidth, width(f[0]))
+	}
+	for _, f := range fields {
+		if d.format == Markdown {
+			// Two trailing spaces keep the lines apart
+			d.b.WriteString("**" + f[0] + ":** " + f[1] + "  \n")
+			continue
+		}
+		// Values line up after the longest label, wrapping under themselves
+		label := f[0] + ":" + strings.Repeat(" ", labelWidth-width(f[0])+1)
+		value := wrap(f[1], Width-labelWidth-2, "")
+		d.b.WriteString(label + strings.ReplaceAll(value, "\n", "\n"+strings.Repeat(" ", labelWidth+2)) + "\n")
+	}
+	d.b.WriteString("\n")
+}
+
+func (d *doc) list(items []string) {
+	for _, item := range items {
+		if d.format == Markdown {
+			d.b.WriteString("- " + item + "\n")
+			continue
+		}
+		d.b.WriteString(wrap("- "+item, Width, "  ") + "\n")
+	}
+	d.b.WriteString("\n")
+}
+
+// table writes rows under a header, as a pipe table in Markdown and in
+// padded columns in plain text
+func (d *doc) table(header []string, rows [][]string) {
+	if d.format == Markdown {
+		escape := strings.NewReplacer("|", `\|`)
+		line := func(cells []string) {
+			for i, cell := range cells {
+				cells[i] = escape.Replace(cell)
+			}
+			d.b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		}
+		line(append([]string(nil), header...))
+		rule := make([]string, len(header))
+		for i := range rule {
+			rule[i] = "---"
+		}
+		line(rule)
+		for _, row := range rows {
+			line(row)
+		}
+		d.b.WriteString("\n")
+		return
+	}
+
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = width(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], width(cell))
+		}
+	}
+	line := func(cells []string) {
+		var b strings.Builder
+		for i, cell := range cells {
+			b.WriteString(cell + strings.Repeat(" ", widths[i]-width(cell)+2))
+		}
+		d.b.WriteString(strings.TrimRight(b.String(), " ") + "\n")
+	}
+	line(header)
+	rule := make([]string, len(header))
+	for i := range rule {
+		rule[i] = strings.Repeat("-", widths[i])
+	}
+	line(rule)
+	for _, row := range rows {
+		line(row)
+	}
+	d.b.WriteString("\n")
+}
+
+func width(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// wrap breaks s into lines of at most limit characters at spaces, keeping
+// its own indentation and starting the lines after the first with indent.
+// Words longer than a line are left whole.
+func wrap(s string, limit int, indent string) string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		switch {
+		case line == "" && len(lines) == 0:
+			line = s[:len(s)-len(strings.TrimLeft(s, " "))] + word
+		case width(line)+1+width(word) <= limit:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = indent + word
+		}
+	}
+	return strings.Join(append(lines, line), "\n")
+}
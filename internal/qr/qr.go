@@ -0,0 +1,434 @@
+// Package qr encodes short strings, such as URLs, as QR codes and renders
+// them with block characters for the terminal. It supports byte mode at
+// error correction level L in versions 1 to 6, enough for about 130 bytes.
+package qr
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrTooLong = errors.New("text is too long for a QR code")
+
+// version is the layout of one QR code version at error correction level L
+type version struct {
+	blocks     int   // error correction blocks
+	dataPer    int   // data codewords per block
+	ecPer      int   // error correction codewords per block
+	alignments []int // alignment pattern centers, empty for version 1
+}
+
+// versions lists versions 1 to 6. Later versions add version information
+// blocks and uneven block sizes, which aren't needed for links.
+var versions = []version{
+	{1, 19, 7, nil},
+	{1, 34, 10, []int{6, 18}},
+	{1, 55, 15, []int{6, 22}},
+	{1, 80, 20, []int{6, 26}},
+	{1, 108, 26, []int{6, 30}},
+	{2, 68, 18, []int{6, 34}},
+}
+
+// Code is an encoded QR code. Modules are indexed [y][x]; true is dark.
+type Code struct {
+	Size    int
+	Modules [][]bool
+
+	function [][]bool // finder, timing, alignment, and format areas
+}
+
+// Encode builds the smallest QR code that holds text
+func Encode(text string) (*Code, error) {
+	for i, v := range versions {
+		// Mode and 8-bit length take 12 bits, rounded up with the terminator
+		if len(text)+2 <= v.blocks*v.dataPer {
+			c := newCode(i + 1)
+			c.drawFunctionPatterns(v)
+			c.drawData(codewords(text, v))
+			c.applyBestMask()
+			return c, nil
+		}
+	}
+	return nil, ErrTooLong
+}
+
+func newCode(ver int) *Code {
+	size := ver*4 + 17
+	c := &Code{Size: size, Modules: make([][]bool, size), function: make([][]bool, size)}
+	for y := range size {
+		c.Modules[y] = make([]bool, size)
+		c.function[y] = make([]bool, size)
+	}
+	return c
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.Modules[y][x] = dark
+	c.function[y][x] = true
+}
+
+func (c *Code) drawFunctionPatterns(v version) {
+	// Timing patterns
+	for i := range c.Size {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	// Finder patterns with their separators
+	c.drawFinder(3, 3)
+	c.drawFinder(c.Size-4, 3)
+	c.drawFinder(3, c.Size-4)
+
+	// Alignment patterns, skipping the three finder corners
+	n := len(v.alignments)
+	for i, x := range v.alignments {
+		for j, y := range v.alignments {
+			if (i == 0 && j == 0) || (i == 0 && j == n-1) || (i == n-1 && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// Reserve the format areas; the real bits are drawn with the mask
+	c.drawFormat(0)
+}
+
+func (c *Code) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || x >= c.Size || y < 0 || y >= c.Size {
+				continue
+			}
+			d := max(abs(dx), abs(dy))
+			c.setFunction(x, y, d != 2 && d != 4)
+		}
+	}
+}
+
+// drawFormat writes the error correction level and mask, twice
+func (c *Code) drawFormat(mask int) {
+	const levelL = 1
+	data := levelL<<3 | mask
+	rem := data
+	for range 10 {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return bits>>i&1 != 0 }
+
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(i))
+	}
+	c.setFunction(8, 7, bit(6))
+	c.setFunction(8, 8, bit(7))
+	c.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.Size-15+i, bit(i))
+	}
+	c.setFunction(8, c.Size-8, true) // always dark
+}
+
+// drawData places the codewords in the zigzag column order
+func (c *Code) drawData(data []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		upward := (right+1)&2 == 0
+		for vert := range c.Size {
+			y := vert
+			if upward {
+				y = c.Size - 1 - vert
+			}
+			for j := range 2 {
+				x := right - j
+				if c.function[y][x] || i >= len(data)*8 {
+					continue
+				}
+				c.Modules[y][x] = data[i>>3]>>(7-i&7)&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+var masks = []func(x, y int) bool{
+	func(x, y int) bool { return (x+y)%2 == 0 },
+	func(x, y int) bool { return y%2 == 0 },
+	func(x, y int) bool { return x%3 == 0 },
+	func(x, y int) bool { return (x+y)%3 == 0 },
+	func(x, y int) bool { return (x/3+y/2)%2 == 0 },
+	func(x, y int) bool { return x*y%2+x*y%3 == 0 },
+	func(x, y int) bool { return (x*y%2+x*y%3)%2 == 0 },
+	func(x, y int) bool { return ((x+y)%2+x*y%3)%2 == 0 },
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := range c.Size {
+		for x := range c.Size {
+			if !c.function[y][x] && masks[mask](x, y) {
+				c.Modules[y][x] = !c.Modules[y][x]
+			}
+		}
+	}
+}
+
+// applyBestMask tries every mask and keeps the one easiest to scan
+func (c *Code) applyBestMask() {
+	best, bestPenalty := 0, -1
+	for mask := range masks {
+		c.applyMask(mask)
+		c.drawFormat(mask)
+		if p := c.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		c.applyMask(mask) // masking twice undoes it
+	}
+	c.applyMask(best)
+	c.drawFormat(best)
+}
+
+// penalty scores the code using the four rules from the QR specification
+func (c *Code) penalty() int {
+	p := 0
+	at := func(x, y int, transpose bool) bool {
+		if transpose {
+			return c.Modules[x][y]
+		}
+		return c.Modules[y][x]
+	}
+
+	// Runs of five or more, and finder-like patterns, in rows and columns
+	finder := []bool{true, false, true, true, true, false, true}
+	for _, transpose := range []bool{false, true} {
+		for y := range c.Size {
+			run := 1
+			for x := 1; x <= c.Size; x++ {
+				if x < c.Size && at(x, y, transpose) == at(x-1, y, transpose) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					p += run - 2
+				}
+				run = 1
+			}
+			for x := 0; x+7 <= c.Size; x++ {
+				match := true
+				for i, dark := range finder {
+					if at(x+i, y, transpose) != dark {
+						match = false
+						break
+					}
+				}
+				if match && (c.lightRun(x-4, x, y, transpose) || c.lightRun(x+7, x+11, y, transpose)) {
+					p += 40
+				}
+			}
+		}
+	}
+
+	// 2x2 blocks of one color
+	for y := 0; y < c.Size-1; y++ {
+		for x := 0; x < c.Size-1; x++ {
+			m := c.Modules[y][x]
+			if m == c.Modules[y][x+1] && m == c.Modules[y+1][x] && m == c.Modules[y+1][x+1] {
+				p += 3
+			}
+		}
+	}
+
+	// Balance of dark and light
+	dark := 0
+	for y := range c.Size {
+		for x := range c.Size {
+			if c.Modules[y][x] {
+				dark++
+			}
+		}
+	}
+	percent := dark * 100 / (c.Size * c.Size)
+	p += abs(percent-50) / 5 * 10
+	return p
+}
+
+// lightRun reports whether modules from..to-1 on a line are all light,
+// counting positions outside the code as light
+func (c *Code) lightRun(from, to, y int, transpose bool) bool {
+	for x := from; x < to; x++ {
+		if x < 0 || x >= c.Size {
+			continue
+		}
+		if (transpose && c.Modules[x][y]) || (!transpose && c.Modules[y][x]) {
+			return false
+		}
+	}
+	return true
+}
+
+// codewords encodes text in byte mode, pads it to the version's capacity,
+// and interleaves the data and error correction blocks
+func codewords(text string, v version) []byte {
+	capacity := v.blocks * v.dataPer
+	var bits bitBuffer
+	bits.append(0b0100, 4) // byte mode
+	bits.append(len(text), 8)
+	for i := 0; i < len(text); i++ {
+		bits.append(int(text[i]), 8)
+	}
+	bits.append(0, min(4, capacity*8-bits.n))
+	bits.append(0, (8-bits.n%8)%8)
+	data := bits.bytes()
+	for pad := byte(0xEC); len(data) < capacity; pad ^= 0xEC ^ 0x11 {
+		data = append(data, pad)
+	}
+
+	blocks := make([][]byte, v.blocks)
+	ecc := make([][]byte, v.blocks)
+	gen := generator(v.ecPer)
+	for i := range blocks {
+		blocks[i] = data[i*v.dataPer : (i+1)*v.dataPer]
+		ecc[i] = remainder(blocks[i], gen)
+	}
+
+	var out []byte
+	for i := range v.dataPer {
+		for _, b := range blocks {
+			out = append(out, b[i])
+		}
+	}
+	for i := range v.ecPer {
+		for _, e := range ecc {
+			out = append(out, e[i])
+		}
+	}
+	return out
+}
+
+type bitBuffer struct {
+	data []byte
+	n    int
+}
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		if b.n%8 == 0 {
+			b.data = append(b.data, 0)
+		}
+		if value>>i&1 != 0 {
+			b.data[b.n/8] |= 0x80 >> (b.n % 8)
+		}
+		b.n++
+	}
+}
+
+func (b *bitBuffer) bytes() []byte {
+	return b.data
+}
+
+// gfMul multiplies in GF(256) with the QR polynomial x^8+x^4+x^3+x^2+1
+func gfMul(a, b byte) byte {
+	var p byte
+	for b > 0 {
+		if b&1 != 0 {
+			p ^= a
+		}
+		carry := a & 0x80
+		a <<= 1
+		if carry != 0 {
+			a ^= 0x1D
+		}
+		b >>= 1
+	}
+	return p
+}
+
+// generator returns the Reed-Solomon generator polynomial of a degree,
+// highest coefficient first with the leading 1 dropped
+func generator(degree int) []byte {
+	gen := make([]byte, degree)
+	gen[degree-1] = 1
+	root := byte(1)
+	for range degree {
+		for j := range gen {
+			gen[j] = gfMul(gen[j], root)
+			if j+1 < len(gen) {
+				gen[j] ^= gen[j+1]
+			}
+		}
+		root = gfMul(root, 2)
+	}
+	return gen
+}
+
+// remainder returns the error correction codewords for a block
+func remainder(data, gen []byte) []byte {
+	rem := make([]byte, len(gen))
+	for _, b := range data {
+		factor := b ^ rem[0]
+		copy(rem, rem[1:])
+		rem[len(rem)-1] = 0
+		for i, g := range gen {
+			rem[i] ^= gfMul(g, factor)
+		}
+	}
+	return rem
+}
+
+// quietZone is the light border scanners need around the code, in modules
+const quietZone = 2
+
+// String renders the code with half-block characters, two rows of modules
+// per line. Dark modules are drawn as spaces and light ones as blocks, so
+// the result scans when shown light-on-dark; style it with a white
+// foreground on a black background.
+func (c *Code) String() string {
+	light := func(x, y int) bool {
+		if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+			return true
+		}
+		return !c.Modules[y][x]
+	}
+
+	var b strings.Builder
+	for y := -quietZone; y < c.Size+quietZone; y += 2 {
+		for x := -quietZone; x < c.Size+quietZone; x++ {
+			top, bottom := light(x, y), light(x, y+1)
+			switch {
+			case top && bottom:
+				b.WriteRune('█')
+			case top:
+				b.WriteRune('▀')
+			case bottom:
+				b.WriteRune('▄')
+			default:
+				b.WriteRune(' ')
+			}
+		}
+		if y+2 < c.Size+quietZone {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,82 @@
+package retention
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// DefaultHPHistory is how long HP history is kept when not configured
+const DefaultHPHistory = 90 * 24 * time.Hour
+
+// Policy sets how long each kind of log data is kept. A zero duration
+// keeps that data forever.
+type Policy struct {
+	HPHistory time.Duration
+	Events    time.Duration
+}
+
+// Result reports how many rows a prune removed
+type Result struct {
+	HPHistory int64
+	Events    int64
+}
+
+// Pruner deletes log data older than its policy allows
+type Pruner struct {
+	queries *db.Queries
+	policy  Policy
+}
+
+// NewPruner creates a new pruner
+func NewPruner(queries *db.Queries, policy Policy) *Pruner {
+	return &Pruner{
+		queries: queries,
+		policy:  policy,
+	}
+}
+
+// Prune deletes everything older than the policy's retention windows
+func (p *Pruner) Prune(ctx context.Context) (Result, error) {
+	var result Result
+
+	if p.policy.HPHistory > 0 {
+		n, err := p.queries.PruneHPHistory(ctx, cutoff(p.policy.HPHistory))
+		if err != nil {
+			return result, err
+		}
+		result.HPHistory = n
+	}
+
+	if p.policy.Events > 0 {
+		n, err := p.queries.PruneCharacterEvents(ctx, cutoff(p.policy.Events))
+		if err != nil {
+			return result, err
+		}
+		result.Events = n
+	}
+
+	return result, nil
+}
+
+// Job prunes old data and logs what was removed, for use with the scheduler
+func (p *Pruner) Job(ctx context.Context) error {
+	result, err := p.Prune(ctx)
+	if err != nil {
+		return err
+	}
+	if result.HPHistory > 0 {
+		log.Printf("Retention pruned %d HP history rows", result.HPHistory)
+	}
+	if result.Events > 0 {
+		log.Printf("Retention pruned %d character event rows", result.Events)
+	}
+	return nil
+}
+
+func cutoff(age time.Duration) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: time.Now().Add(-age), Valid: true}
+}
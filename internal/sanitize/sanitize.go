@@ -0,0 +1,116 @@
+// Package sanitize cleans user-supplied text before it is stored. Text is
+// shown in other users' terminals, so escape sequences and control
+// characters are stripped rather than trusted to the renderer.
+package sanitize
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Length limits in characters, matching the database columns
+const (
+	MaxShort = 50   // classes, races, backgrounds, alignments
+	MaxName  = 100  // character, token, subclass, feat, and item names
+	MaxEmail = 255  // email addresses
+	MaxText  = 5000 // notes and features
+)
+
+// Line cleans single-line text: escape sequences and control characters
+// are removed, tabs and newlines become spaces, and the result is trimmed,
+// NFC-normalized, and cut to max characters.
+func Line(s string, max int) string {
+	s = clean(s, false)
+	return truncate(strings.TrimSpace(s), max)
+}
+
+// Text cleans multi-line text like Line, but keeps newlines and tabs and
+// normalizes line endings to "\n".
+func Text(s string, max int) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = clean(s, true)
+	return truncate(strings.TrimRightFunc(s, unicode.IsSpace), max)
+}
+
+// clean strips terminal escape sequences, control characters, and bidi
+// overrides, then NFC-normalizes what is left
+func clean(s string, multiline bool) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\x1b':
+			i = skipEscape(runes, i)
+		case r == '\u009b':
+			// 8-bit CSI
+			i = skipCSI(runes, i+1)
+		case r == '\n' || r == '\t':
+			if multiline {
+				b.WriteRune(r)
+			} else {
+				b.WriteRune(' ')
+			}
+		case r == '\r':
+			if multiline {
+				b.WriteRune('\n')
+			} else {
+				b.WriteRune(' ')
+			}
+		case unicode.IsControl(r), unicode.Is(unicode.Bidi_Control, r):
+			// Dropped
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return norm.NFC.String(b.String())
+}
+
+// skipEscape returns the index of the last rune of the escape sequence
+// starting at runes[i], which is ESC
+func skipEscape(runes []rune, i int) int {
+	if i+1 >= len(runes) {
+		return i
+	}
+	switch runes[i+1] {
+	case '[':
+		return skipCSI(runes, i+2)
+	case ']', 'P', 'X', '^', '_':
+		// OSC, DCS, SOS, PM, and APC run to BEL or ST (ESC \)
+		for j := i + 2; j < len(runes); j++ {
+			if runes[j] == '\a' {
+				return j
+			}
+			if runes[j] == '\x1b' && j+1 < len(runes) && runes[j+1] == '\\' {
+				return j + 1
+			}
+		}
+		return len(runes) - 1
+	default:
+		// Two-character sequence such as ESC c
+		return i + 1
+	}
+}
+
+// skipCSI returns the index of the final byte of a control sequence whose
+// parameters start at runes[i]
+func skipCSI(runes []rune, i int) int {
+	for j := i; j < len(runes); j++ {
+		if runes[j] >= 0x40 && runes[j] <= 0x7e {
+			return j
+		}
+	}
+	return len(runes) - 1
+}
+
+// truncate cuts s to at most max runes
+func truncate(s string, max int) string {
+	if runes := []rune(s); len(runes) > max {
+		return strings.TrimRightFunc(string(runes[:max]), unicode.IsSpace)
+	}
+	return s
+}
@@ -0,0 +1,162 @@
+// Package sessions keeps track of the SSH sessions connected to this
+// server, so admins can see who's on and the server can sign everyone out
+// cleanly when it shuts down.
+package sessions
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DrainMsg tells a session the server is shutting down, so it can say so
+// and disconnect
+type DrainMsg struct {
+	Message string
+}
+
+// Info describes a connected session
+type Info struct {
+	ID          uint64
+	RemoteAddr  string
+	Account     string // "" until signed in
+	Screen      string
+	ConnectedAt time.Time
+	LastActive  time.Time
+}
+
+// Registry holds the sessions connected to this server. Other servers
+// sharing the database keep their own.
+type Registry struct {
+	mu       sync.Mutex
+	nextID   uint64
+	sessions map[uint64]*Session
+}
+
+// NewRegistry creates a new registry
+func NewRegistry() *Registry {
+	return &Registry{sessions: make(map[uint64]*Session)}
+}
+
+// Session is one connected session's entry in the registry
+type Session struct {
+	registry *Registry
+	program  *tea.Program
+	info     Info
+}
+
+// Add registers a session, which stays listed until it's closed
+func (r *Registry) Add(remoteAddr string, program *tea.Program) *Session {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	now := time.Now()
+	s := &Session{
+		registry: r,
+		program:  program,
+		info: Info{
+			ID:          r.nextID,
+			RemoteAddr:  remoteAddr,
+			ConnectedAt: now,
+			LastActive:  now,
+		},
+	}
+	r.sessions[s.info.ID] = s
+	return s
+}
+
+// List returns the connected sessions, longest connected first
+func (r *Registry) List() []Info {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := make([]Info, 0, len(r.sessions))
+	for _, s := range r.sessions {
+		list = append(list, s.info)
+	}
+	slices.SortFunc(list, func(a, b Info) int {
+		return a.ConnectedAt.Compare(b.ConnectedAt)
+	})
+	return list
+}
+
+// Count returns how many sessions are connected
+func (r *Registry) Count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.sessions)
+}
+
+// Send delivers a message to every connected session
+func (r *Registry) Send(msg tea.Msg) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	programs := make([]*tea.Program, 0, len(r.sessions))
+	for _, s := range r.sessions {
+		programs = append(programs, s.program)
+	}
+	r.mu.Unlock()
+
+	// Send blocks until the program takes the message, so one slow session
+	// shouldn't hold up the rest
+	for _, p := range programs {
+		go p.Send(msg)
+	}
+}
+
+// Drain tells every session the server is shutting down and waits for them
+// to disconnect, or for ctx to be done. It returns how many were still
+// connected when it gave up.
+func (r *Registry) Drain(ctx context.Context, message string) int {
+	r.Send(DrainMsg{Message: message})
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		n := r.Count()
+		if n == 0 {
+			return 0
+		}
+		select {
+		case <-ctx.Done():
+			return n
+		case <-ticker.C:
+		}
+	}
+}
+
+// Update records the account signed in to the session and the screen it's
+// on
+func (s *Session) Update(account, screen string) {
+	if s == nil {
+		return
+	}
+	s.registry.mu.Lock()
+	defer s.registry.mu.Unlock()
+	s.info.Account = account
+	s.info.Screen = screen
+}
+
+// Touch records that the player pressed a key or used the mouse
+func (s *Session) Touch() {
+	if s == nil {
+		return
+	}
+	s.registry.mu.Lock()
+	defer s.registry.mu.Unlock()
+	s.info.LastActive = time.Now()
+}
+
+// Close removes the session from the registry
+func (s *Session) Close() {
+	s.registry.mu.Lock()
+	defer s.registry.mu.Unlock()
+	delete(s.registry.sessions, s.info.ID)
+}
@@ -0,0 +1,352 @@
+// Package share serves read-only character sheets for share links, so a
+// player can follow their character on a phone at the table
+package share
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/auth"
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+)
+
+// refreshSeconds is how often the page reloads to pick up changes
+const refreshSeconds = 30
+
+// Handler serves GET /share/{token} and the printable spell cards at
+// GET /share/{token}/spells
+type Handler struct {
+	auth    *auth.Service
+	queries *db.Queries
+	mux     *http.ServeMux
+}
+
+// NewHandler creates the share link handler
+func NewHandler(authService *auth.Service, queries *db.Queries) *Handler {
+	h := &Handler{auth: authService, queries: queries, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /share/{token}", h.sheet)
+	h.mux.HandleFunc("GET /share/{token}/spells", h.spellCards)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// sheetView is what the sheet page shows
+type sheetView struct {
+	Refresh    int
+	Name       string
+	Summary    string
+	HP         string
+	AC         int32
+	Speed      int32
+	Movement   []string // other speeds and senses, like "fly 60 ft"
+	Conditions []string
+	Abilities  []abilityView
+	Skills     []string
+	Languages  []string
+	Tools      []string
+	Attacks    []string
+	Slots      string
+	Spells     []string
+	Items      []string
+	CardsURL   string
+}
+
+// spellCard is one printable spell card
+type spellCard struct {
+	Name         string
+	Kind         string
+	CastingTime  string
+	Range        string
+	Components   string
+	Duration     string
+	Description  string
+	HigherLevels string
+}
+
+type spellCardsView struct {
+	Name  string
+	Cards []spellCard
+}
+
+type abilityView struct {
+	Name  string
+	Score int32
+	Mod   string
+	Save  string
+}
+
+func (h *Handler) sheet(w http.ResponseWriter, r *http.Request) {
+	char, ok := h.sharedCharacter(w, r)
+	if !ok {
+		return
+	}
+	items, _ := h.queries.GetCharacterInventory(r.Context(), char.ID)
+
+	rules := character.RulesetFor(char.Ruleset)
+	level := int(char.Level)
+	profBonus := rules.ProficiencyBonus(level)
+	hp := character.HitPoints{
+		Current: int(char.CurrentHitPoints),
+		Temp:    int(char.TemporaryHitPoints),
+		Max:     character.MaxHitPoints(rules, int(char.MaxHitPoints), int(char.MaxHitPointsBonus), int(char.Exhaustion)),
+	}
+
+	v := sheetView{
+		Refresh:   refreshSeconds,
+		Name:      char.Name,
+		Summary:   fmt.Sprintf("Level %d %s %s", char.Level, char.Race, char.Class),
+		HP:        fmt.Sprintf("%d / %d", hp.Current, hp.Max),
+		AC:        char.ArmorClass,
+		Speed:     int32(rules.ExhaustedSpeed(int(char.Speed), int(char.Exhaustion))),
+		Languages: char.Languages,
+		Tools:     char.ToolProficiencies,
+	}
+	if hp.Temp > 0 {
+		v.HP += fmt.Sprintf(" (+%d temp)", hp.Temp)
+	}
+	for _, m := range []struct {
+		name  string
+		feet  int32
+		speed bool
+	}{
+		{"fly", char.FlySpeed, true}, {"swim", char.SwimSpeed, true},
+		{"climb", char.ClimbSpeed, true}, {"burrow", char.BurrowSpeed, true},
+		{"darkvision", char.Darkvision, false}, {"blindsight", char.Blindsight, false},
+		{"truesight", char.Truesight, false},
+	} {
+		feet := int(m.feet)
+		if m.speed {
+			feet = rules.ExhaustedSpeed(feet, int(char.Exhaustion))
+		}
+		if m.feet > 0 {
+			v.Movement = append(v.Movement, fmt.Sprintf("%s %d ft", m.name, feet))
+		}
+	}
+	if char.ConcentratingOn != "" {
+		v.Conditions = append(v.Conditions, "Concentrating on "+char.ConcentratingOn)
+	}
+	if char.Exhaustion > 0 {
+		v.Conditions = append(v.Conditions, fmt.Sprintf("Exhaustion %d", char.Exhaustion))
+	}
+	if char.Inspiration {
+		v.Conditions = append(v.Conditions, "Inspired")
+	}
+
+	scores := map[string]int32{
+		"Strength": char.Strength, "Dexterity": char.Dexterity, "Constitution": char.Constitution,
+		"Intelligence": char.Intelligence, "Wisdom": char.Wisdom, "Charisma": char.Charisma,
+	}
+	for _, ability := range character.Abilities {
+		mod := character.AbilityModifier(int(scores[ability]))
+		save := mod
+		for _, p := range char.SavingThrowProficiencies {
+			if strings.EqualFold(p, ability) {
+				save += profBonus
+			}
+		}
+		v.Abilities = append(v.Abilities, abilityView{
+			Name:  ability[:3],
+			Score: scores[ability],
+			Mod:   character.FormatModifierInt(mod),
+			Save:  character.FormatModifierInt(save),
+		})
+	}
+
+	for _, skill := range character.SkillList {
+		p := character.SkillProficiency(skill, char.SkillProficiencies, char.SkillExpertise, char.SkillHalfProficiencies)
+		if p == character.NotProficient {
+			continue
+		}
+		score := scores[strings.ToUpper(character.Skills[skill][:1])+character.Skills[skill][1:]]
+		bonus := character.AbilityModifier(int(score)) + p.Bonus(profBonus)
+		v.Skills = append(v.Skills, fmt.Sprintf("%s %s", skill, character.FormatModifierInt(bonus)))
+	}
+
+	for _, item := range items {
+		name := item.Name
+		if item.Quantity != 1 {
+			name += fmt.Sprintf(" ×%d", item.Quantity)
+		}
+		if item.Equipped {
+			name += " (equipped)"
+		}
+		if item.Location != "" {
+			name += " (in " + item.Location + ")"
+		}
+		v.Items = append(v.Items, name)
+
+		if w, magic, ok := character.FindWeapon(item.Name); ok && item.Equipped {
+			a := character.CalculateAttack(w, magic, character.AttackInput{
+				Class:            char.Class,
+				Strength:         int(char.Strength),
+				Dexterity:        int(char.Dexterity),
+				ProficiencyBonus: profBonus,
+			})
+			attack := fmt.Sprintf("%s %s to hit, %s", item.Name, character.FormatModifierInt(a.AttackBonus), a.Damage())
+			if m := rules.WeaponMastery(char.Class, w); m != "" {
+				attack += " (" + m + ": " + character.WeaponMasteries[m] + ")"
+			}
+			v.Attacks = append(v.Attacks, attack)
+		}
+	}
+
+	if sc, err := h.queries.GetCharacterSpellcasting(r.Context(), char.ID); err == nil {
+		var slots []string
+		for i, total := range sc.SlotsMax {
+			if total > 0 && i < len(sc.SlotsUsed) {
+				slots = append(slots, fmt.Sprintf("%s: %d/%d", ordinal(i+1), total-sc.SlotsUsed[i], total))
+			}
+		}
+		v.Slots = fmt.Sprintf("Save DC %d, attack %s", sc.SpellSaveDc, character.FormatModifierInt(int(sc.SpellAttackBonus)))
+		if len(slots) > 0 {
+			v.Slots += " • " + strings.Join(slots, ", ")
+		}
+		spells, _ := h.queries.GetCharacterSpells(r.Context(), char.ID)
+		for _, spell := range spells {
+			if spell.Prepared {
+				v.Spells = append(v.Spells, spell.Name)
+			}
+		}
+		if len(v.Spells) > 0 {
+			v.CardsURL = r.URL.Path + "/spells"
+		}
+	}
+
+	render(w, http.StatusOK, sheetPage, v)
+}
+
+// spellCards shows the character's prepared spells as cards sized to cut
+// out after printing
+func (h *Handler) spellCards(w http.ResponseWriter, r *http.Request) {
+	char, ok := h.sharedCharacter(w, r)
+	if !ok {
+		return
+	}
+	spells, _ := h.queries.GetCharacterSpells(r.Context(), char.ID)
+
+	v := spellCardsView{Name: char.Name}
+	for _, spell := range spells {
+		if !spell.Prepared {
+			continue
+		}
+		card := spellCard{Name: spell.Name, Kind: spellKind(int(spell.Level), "")}
+		// Spells outside the SRD list only have a name and level
+		if info, ok := character.FindSpell(spell.Name); ok {
+			card.Kind = spellKind(info.Level, info.School)
+			card.CastingTime = info.CastingTime
+			if info.Ritual {
+				card.CastingTime += " (ritual)"
+			}
+			card.Range = info.Range
+			card.Components = info.Components
+			card.Duration = info.Duration
+			if info.Concentration {
+				card.Duration = "Concentration, " + strings.ToLower(info.Duration[:1]) + info.Duration[1:]
+			}
+			card.Description = info.Description
+			card.HigherLevels = info.HigherLevels
+		}
+		v.Cards = append(v.Cards, card)
+	}
+
+	render(w, http.StatusOK, spellCardsPage, v)
+}
+
+// sharedCharacter loads the character for the link in the path, writing an
+// error page if the link is unknown or expired
+func (h *Handler) sharedCharacter(w http.ResponseWriter, r *http.Request) (db.Character, bool) {
+	// Links are bearer secrets; keep them out of caches and referrers
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+
+	char, err := h.auth.SharedCharacter(r.Context(), r.PathValue("token"))
+	if err != nil {
+		render(w, http.StatusNotFound, errorPage, "This link has expired. Create a new one from the character sheet.")
+		return db.Character{}, false
+	}
+	return char, true
+}
+
+// spellKind describes a spell as "Cantrip" or "1st-level evocation"
+func spellKind(level int, school string) string {
+	if level == 0 {
+		return strings.TrimSpace(school + " cantrip")
+	}
+	return strings.TrimSpace(ordinal(level) + "-level " + strings.ToLower(school))
+}
+
+func ordinal(n int) string {
+	switch n {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	}
+	return fmt.Sprintf("%dth", n)
+}
+
+func render(w http.ResponseWriter, status int, page *template.Template, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_ = page.Execute(w, data)
+}
+
+const pageHead = `<!DOCTYPE html><html><head><meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">`
+
+const pageStyle = `<title>D&amp;D Character Tracker</title>
+<style>body{font-family:monospace;max-width:32rem;margin:1rem auto;padding:0 1rem}
+table{border-collapse:collapse}td,th{padding:.1rem .6rem;text-align:right}
+ul{padding-left:1.2rem}h2{margin-bottom:.3rem}.muted{color:#777}</style></head><body>`
+
+var sheetPage = template.Must(template.New("sheet").Parse(pageHead + `
+<meta http-equiv="refresh" content="{{.Refresh}}">` + pageStyle + `
+<h1>{{.Name}}</h1>
+<p>{{.Summary}}</p>
+<p><b>HP</b> {{.HP}} &nbsp; <b>AC</b> {{.AC}} &nbsp; <b>Speed</b> {{.Speed}} ft{{range .Movement}}, {{.}}{{end}}</p>
+{{if .Conditions}}<p>{{range $i, $c := .Conditions}}{{if $i}} • {{end}}{{$c}}{{end}}</p>{{end}}
+<table><tr><th></th><th>Score</th><th>Mod</th><th>Save</th></tr>
+{{range .Abilities}}<tr><th>{{.Name}}</th><td>{{.Score}}</td><td>{{.Mod}}</td><td>{{.Save}}</td></tr>{{end}}
+</table>
+{{if .Attacks}}<h2>Attacks</h2><ul>{{range .Attacks}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{if .Skills}}<h2>Skills</h2><ul>{{range .Skills}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{if .Languages}}<p><b>Languages</b> {{range $i, $l := .Languages}}{{if $i}}, {{end}}{{$l}}{{end}}</p>{{end}}
+{{if .Tools}}<p><b>Tools</b> {{range $i, $t := .Tools}}{{if $i}}, {{end}}{{$t}}{{end}}</p>{{end}}
+{{if .Slots}}<h2>Spells</h2><p>{{.Slots}}</p><ul>{{range .Spells}}<li>{{.}}</li>{{end}}</ul>
+{{if .CardsURL}}<p><a href="{{.CardsURL}}">Printable spell cards</a></p>{{end}}{{end}}
+{{if .Items}}<h2>Inventory</h2><ul>{{range .Items}}<li>{{.}}</li>{{end}}</ul>{{end}}
+<p class="muted">Read-only. Updates every {{.Refresh}} seconds.</p>
+</body></html>`))
+
+var spellCardsPage = template.Must(template.New("spells").Parse(pageHead + `
+<title>{{.Name}} - Spell Cards</title>
+<style>body{font-family:Georgia,serif;margin:1rem}
+.cards{display:grid;grid-template-columns:repeat(auto-fill,2.5in);gap:.25in}
+.card{width:2.5in;min-height:3.5in;box-sizing:border-box;border:2px solid #333;border-radius:.15in;padding:.15in;font-size:9pt;break-inside:avoid}
+.card h2{font-size:12pt;margin:0}.kind{font-style:italic;margin:0 0 .1in}
+dl{display:grid;grid-template-columns:auto 1fr;gap:0 .1in;margin:0 0 .1in;border-bottom:1px solid #333;padding-bottom:.1in}
+dt{font-weight:bold}dd{margin:0}p{margin:.05in 0}
+@media print{.noprint{display:none}body{margin:0}}</style></head><body>
+<p class="noprint">{{.Name}}'s prepared spells. Print this page and cut along the borders.</p>
+<div class="cards">
+{{range .Cards}}<div class="card"><h2>{{.Name}}</h2><p class="kind">{{.Kind}}</p>
+{{if .CastingTime}}<dl><dt>Casting Time</dt><dd>{{.CastingTime}}</dd><dt>Range</dt><dd>{{.Range}}</dd>
+<dt>Components</dt><dd>{{.Components}}</dd><dt>Duration</dt><dd>{{.Duration}}</dd></dl>{{end}}
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+{{if .HigherLevels}}<p><b>At Higher Levels.</b> {{.HigherLevels}}</p>{{end}}
+</div>{{else}}<p>No prepared spells.</p>{{end}}
+</div>
+</body></html>`))
+
+var errorPage = template.Must(template.New("error").Parse(pageHead + pageStyle + `
+<h1>Link expired</h1>
+<p>{{.}}</p>
+</body></html>`))
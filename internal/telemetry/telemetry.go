@@ -0,0 +1,138 @@
+// Package telemetry counts how often features are used and how often they
+// fail, for players who opt in, and periodically sends the totals to a URL
+// chosen by the server operator. Only counts are kept: no names, notes,
+// character details, or anything identifying a player or server.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// sendInterval is how often the totals are sent
+const sendInterval = time.Hour
+
+// Report is the whole of what's sent for each interval
+type Report struct {
+	Start    time.Time        `json:"start"`
+	End      time.Time        `json:"end"`
+	Features map[string]int64 `json:"features"` // e.g. "screen.sheet" or "edit.hp"
+	Errors   map[string]int64 `json:"errors"`
+}
+
+// Recorder collects counts in memory and sends them. A nil Recorder, used
+// when the operator hasn't set a URL, ignores everything.
+type Recorder struct {
+	url    string
+	client *http.Client
+
+	mu       sync.Mutex
+	start    time.Time
+	features map[string]int64
+	errors   map[string]int64
+}
+
+// NewRecorder returns a recorder sending to url, or nil if url is empty
+func NewRecorder(url string) *Recorder {
+	if url == "" {
+		return nil
+	}
+	return &Recorder{
+		url:      url,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		start:    time.Now(),
+		features: map[string]int64{},
+		errors:   map[string]int64{},
+	}
+}
+
+// Enabled reports whether the server collects usage statistics at all
+func (r *Recorder) Enabled() bool {
+	return r != nil
+}
+
+// Count records one use of a feature
+func (r *Recorder) Count(feature string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.features[feature]++
+	r.mu.Unlock()
+}
+
+// Error records one failure of a feature
+func (r *Recorder) Error(feature string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.errors[feature]++
+	r.mu.Unlock()
+}
+
+// Run sends the totals every interval until ctx is done
+func (r *Recorder) Run(ctx context.Context) {
+	if r == nil {
+		return
+	}
+	ticker := time.NewTicker(sendInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.send(ctx); err != nil {
+				log.Printf("Failed to send usage statistics: %v", err)
+			}
+		}
+	}
+}
+
+// take returns the totals so far and starts counting afresh
+func (r *Recorder) take() Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	report := Report{Start: r.start, End: now, Features: r.features, Errors: r.errors}
+	r.start = now
+	r.features = map[string]int64{}
+	r.errors = map[string]int64{}
+	return report
+}
+
+func (r *Recorder) send(ctx context.Context) error {
+	report := r.take()
+	if len(report.Features) == 0 && len(report.Errors) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
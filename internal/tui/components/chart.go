@@ -0,0 +1,147 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sparkBlocks are the eighth-height block characters used by sparklines
+var sparkBlocks = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// Sparkline renders values as a single line of block characters scaled
+// between their min and max. When there are more values than width, the
+// most recent width values are shown.
+func Sparkline(values []int, width int, style lipgloss.Style) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+	return SparklineRange(values, lo, hi, width, style)
+}
+
+// SparklineRange renders a sparkline against a fixed scale, e.g. 0 to max HP
+func SparklineRange(values []int, lo, hi, width int, style lipgloss.Style) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		idx := len(sparkBlocks) - 1
+		if hi > lo {
+			v = min(max(v, lo), hi)
+			idx = (v - lo) * (len(sparkBlocks) - 1) / (hi - lo)
+		}
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return style.Render(b.String())
+}
+
+// Bar is a single labeled value in a bar chart
+type Bar struct {
+	Label string
+	Value int
+}
+
+// BarChart renders horizontal bars scaled so the largest value fills width
+func BarChart(bars []Bar, width int, barStyle, labelStyle lipgloss.Style) string {
+	if len(bars) == 0 || width <= 0 {
+		return ""
+	}
+
+	labelWidth, peak := 0, 0
+	for _, bar := range bars {
+		labelWidth = max(labelWidth, lipgloss.Width(bar.Label))
+		peak = max(peak, bar.Value)
+	}
+
+	var b strings.Builder
+	for i, bar := range bars {
+		length := 0
+		if peak > 0 && bar.Value > 0 {
+			length = max(1, bar.Value*width/peak)
+		}
+		b.WriteString(labelStyle.Render(fmt.Sprintf("%-*s", labelWidth, bar.Label)))
+		b.WriteString(" ")
+		b.WriteString(barStyle.Render(strings.Repeat("█", length)))
+		b.WriteString(fmt.Sprintf(" %d", bar.Value))
+		if i < len(bars)-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// Histogram renders vertical columns of the given height with labels
+// underneath. Each column is as wide as its widest label.
+func Histogram(bars []Bar, height int, barStyle, labelStyle lipgloss.Style) string {
+	if len(bars) == 0 || height <= 0 {
+		return ""
+	}
+
+	peak, colWidth := 0, 1
+	for _, bar := range bars {
+		peak = max(peak, bar.Value)
+		colWidth = max(colWidth, lipgloss.Width(bar.Label))
+	}
+
+	// Column heights in eighths of a row for smooth tops
+	eighths := make([]int, len(bars))
+	for i, bar := range bars {
+		if peak > 0 {
+			eighths[i] = bar.Value * height * 8 / peak
+		}
+	}
+
+	var b strings.Builder
+	for row := height - 1; row >= 0; row-- {
+		for i := range bars {
+			fill := eighths[i] - row*8
+			cell := " "
+			switch {
+			case fill >= 8:
+				cell = "█"
+			case fill > 0:
+				cell = string(sparkBlocks[fill-1])
+			}
+			b.WriteString(barStyle.Render(strings.Repeat(cell, colWidth)))
+			if i < len(bars)-1 {
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	labels := make([]string, len(bars))
+	for i, bar := range bars {
+		labels[i] = labelStyle.Render(fmt.Sprintf("%*s", colWidth, bar.Label))
+	}
+	b.WriteString(strings.Join(labels, " "))
+	return b.String()
+}
+
+// ProgressBar renders value out of total as a bar width cells long, the
+// filled part in fill and the rest in empty. Values past total fill the bar.
+func ProgressBar(value, total float64, width int, fill, empty lipgloss.Style) string {
+	if width <= 0 {
+		return ""
+	}
+	filled := width
+	if total > 0 && value < total {
+		filled = int(max(value, 0) / total * float64(width))
+	}
+	if value > 0 && filled == 0 {
+		filled = 1
+	}
+	return fill.Render(strings.Repeat("█", filled)) + empty.Render(strings.Repeat("░", width-filled))
+}
@@ -0,0 +1,70 @@
+package components
+
+import (
+	"io"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Notification is a way of getting a player's attention, such as when an
+// announcement arrives
+type Notification struct {
+	Name  string // stored per user
+	Label string // shown in settings
+}
+
+// DefaultNotification is used before login
+const DefaultNotification = "bell"
+
+// Notifications are the ways a player can choose to be alerted, in the
+// order settings lists them
+var Notifications = []Notification{
+	{Name: "bell", Label: "Terminal bell"},
+	{Name: "flash", Label: "Screen flash"},
+	{Name: "silent", Label: "Silent"},
+}
+
+// NotificationLabel returns how settings shows a notification, falling back
+// to the default for names it doesn't know
+func NotificationLabel(name string) string {
+	for _, n := range Notifications {
+		if n.Name == name {
+			return n.Label
+		}
+	}
+	return NotificationLabel(DefaultNotification)
+}
+
+// NotifyMsg asks for the player to be alerted the way they chose. Screens
+// send it for things that need the player's attention.
+type NotifyMsg struct{}
+
+// flashTime is how long the screen stays inverted for a flash
+const flashTime = 150 * time.Millisecond
+
+// Notify alerts the player on their terminal. A bell rings the terminal's
+// bell, which many terminals show as a sound or a bounce in the dock; a
+// flash briefly inverts the screen's colors instead, for players in rooms
+// where a beep isn't welcome. The escape codes go straight to the terminal,
+// around the program's own drawing.
+func Notify(out io.Writer, name string) tea.Cmd {
+	if out == nil {
+		return nil
+	}
+	switch name {
+	case "silent":
+		return nil
+	case "flash":
+		return func() tea.Msg {
+			_, _ = io.WriteString(out, "\x1b[?5h")
+			time.Sleep(flashTime)
+			_, _ = io.WriteString(out, "\x1b[?5l")
+			return nil
+		}
+	}
+	return func() tea.Msg {
+		_, _ = io.WriteString(out, "\a")
+		return nil
+	}
+}
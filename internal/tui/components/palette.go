@@ -0,0 +1,192 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// paletteLimit caps how many commands the palette lists at once
+const paletteLimit = 10
+
+// Command is one action offered by a Palette
+type Command struct {
+	Name string // e.g. "Take long rest"
+	Key  string // the key that does the same thing, shown as a hint
+	Run  func() tea.Cmd
+}
+
+// PaletteStyles are the styles a Palette renders with
+type PaletteStyles struct {
+	Title    lipgloss.Style
+	Input    lipgloss.Style
+	Selected lipgloss.Style
+	Normal   lipgloss.Style
+	Muted    lipgloss.Style
+}
+
+// Palette is a ctrl+k command list filtered by fuzzy matching what's typed
+type Palette struct {
+	input    textinput.Model
+	commands []Command
+	cursor   int
+}
+
+func NewPalette() Palette {
+	input := textinput.New()
+	input.Placeholder = "type a command"
+	input.Width = 40
+	input.CharLimit = 60
+	return Palette{input: input}
+}
+
+// Open resets the palette to list commands
+func (p *Palette) Open(commands []Command) tea.Cmd {
+	p.commands = commands
+	p.cursor = 0
+	p.input.SetValue("")
+	p.input.Focus()
+	return textinput.Blink
+}
+
+// Update handles a key while the palette is open. closed is true once the
+// palette should be dismissed, with chosen set if enter picked a command.
+// The caller runs the chosen command after leaving its palette mode, so
+// commands can switch to another mode.
+func (p *Palette) Update(msg tea.KeyMsg) (chosen *Command, closed bool, cmd tea.Cmd) {
+	matches := p.matches()
+	switch msg.String() {
+	case "up", "ctrl+p":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+		return nil, false, nil
+	case "down", "ctrl+n":
+		if p.cursor < min(len(matches), paletteLimit)-1 {
+			p.cursor++
+		}
+		return nil, false, nil
+	case "enter":
+		p.input.Blur()
+		if p.cursor >= len(matches) {
+			return nil, true, nil
+		}
+		return &matches[p.cursor], true, nil
+	case "esc", "ctrl+k":
+		p.input.Blur()
+		return nil, true, nil
+	}
+
+	p.input, cmd = p.input.Update(msg)
+	p.cursor = 0
+	return nil, false, cmd
+}
+
+// Click handles a click on a part of the palette, returning the command
+// clicked, which closes the palette like enter
+func (p *Palette) Click(zone string) *Command {
+	i, ok := Index(zone, "command")
+	matches := p.matches()
+	if !ok || i >= len(matches) {
+		return nil
+	}
+	p.input.Blur()
+	return &matches[i]
+}
+
+// matches returns the commands matching the query, best first. With no
+// query every command is listed in its original order.
+func (p *Palette) matches() []Command {
+	query := strings.TrimSpace(p.input.Value())
+	if query == "" {
+		return p.commands
+	}
+
+	type scored struct {
+		command Command
+		score   int
+	}
+	var found []scored
+	for _, c := range p.commands {
+		if score, ok := FuzzyScore(query, c.Name); ok {
+			found = append(found, scored{c, score})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].score > found[j].score })
+
+	matches := make([]Command, len(found))
+	for i, f := range found {
+		matches[i] = f.command
+	}
+	return matches
+}
+
+// FuzzyScore reports whether the letters of query appear in order in
+// target, ignoring case and spaces, and how good a match it is. Runs of
+// consecutive letters and letters starting a word score higher, so "lr"
+// ranks "Long rest" above "Roll Perception".
+func FuzzyScore(query, target string) (int, bool) {
+	q := []rune(strings.ToLower(strings.ReplaceAll(query, " ", "")))
+	t := []rune(strings.ToLower(target))
+
+	score, qi, last := 0, 0, -1
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+		score++
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 8 // start of a word
+		}
+		if last >= 0 && ti == last+1 {
+			score += 5 // follows the previous match
+		}
+		if last >= 0 {
+			score -= min(ti-last-1, 3) // small penalty for gaps
+		}
+		last = ti
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	return score, true
+}
+
+// View renders the search box and the best matching commands
+func (p *Palette) View(title string, st PaletteStyles) string {
+	var b strings.Builder
+	b.WriteString(st.Title.Render(title))
+	b.WriteString("\n\n")
+	b.WriteString(st.Input.Render(p.input.View()))
+	b.WriteString("\n\n")
+
+	matches := p.matches()
+	if len(matches) == 0 {
+		b.WriteString(st.Muted.Render("No matching commands."))
+	}
+	for i, c := range matches[:min(len(matches), paletteLimit)] {
+		cursor, style := "  ", st.Normal
+		if i == p.cursor {
+			cursor, style = "> ", st.Selected
+		}
+		line := style.Render(fmt.Sprintf("%s%-34s", cursor, c.Name))
+		if c.Key != "" {
+			line += st.Muted.Render(" " + c.Key)
+		}
+		b.WriteString(Mark(Item("command", i), line))
+		b.WriteString("\n")
+	}
+	if len(matches) > paletteLimit {
+		b.WriteString(st.Muted.Render(fmt.Sprintf("…and %d more", len(matches)-paletteLimit)))
+	}
+
+	return lipgloss.NewStyle().
+		Align(lipgloss.Left).
+		Render(strings.TrimSuffix(b.String(), "\n"))
+}
@@ -0,0 +1,148 @@
+package components
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Zones let mouse clicks find what was clicked. Views wrap clickable parts,
+// such as tabs, list rows, buttons, and form fields, in zero-width markers
+// with Mark; the finished view is passed through ScanZones, which notes where
+// each marked part ended up and strips the markers before it's drawn.
+//
+// Markers are escape sequences of the form ESC [ n z, which lipgloss counts
+// as taking no space, so marked text can be styled, padded, and placed like
+// any other.
+
+// ClickMsg is sent to the current screen when a marked part of its view is
+// clicked
+type ClickMsg struct {
+	Zone string // e.g. "row:3"
+}
+
+// ScrollZone marks text that scrolls on its own, apart from the list beside
+// it. The scroll wheel sends a ScrollMsg over it instead of the arrow keys.
+const ScrollZone = "scroll"
+
+// WheelLines is how far one turn of the scroll wheel scrolls
+const WheelLines = 3
+
+// ScrollMsg is sent to the current screen when the scroll wheel turns over
+// its ScrollZone
+type ScrollMsg struct {
+	Lines int // down if positive, up if negative
+}
+
+// zoneNumbers numbers zone names for their markers, which can only hold
+// digits. Names are shared by every session; views use a small, fixed set.
+var zoneNumbers = struct {
+	sync.Mutex
+	ids   map[string]int
+	names []string
+}{ids: map[string]int{}}
+
+var zoneMarker = regexp.MustCompile("\x1b\\[([0-9]+)z")
+
+// Mark makes s a zone for clicks
+func Mark(zone, s string) string {
+	zoneNumbers.Lock()
+	id, ok := zoneNumbers.ids[zone]
+	if !ok {
+		id = len(zoneNumbers.names)
+		zoneNumbers.ids[zone] = id
+		zoneNumbers.names = append(zoneNumbers.names, zone)
+	}
+	zoneNumbers.Unlock()
+
+	marker := fmt.Sprintf("\x1b[%dz", id)
+	return marker + s + marker
+}
+
+// Item names a zone for the i'th of a kind of thing, such as Item("row", 3)
+func Item(kind string, i int) string {
+	return kind + ":" + strconv.Itoa(i)
+}
+
+// Index returns i for a zone named Item(kind, i)
+func Index(zone, kind string) (int, bool) {
+	rest, ok := strings.CutPrefix(zone, kind+":")
+	if !ok {
+		return 0, false
+	}
+	i, err := strconv.Atoi(rest)
+	return i, err == nil
+}
+
+// zoneArea is the screen area a zone covers, from its top-left cell to its
+// bottom-right one
+type zoneArea struct {
+	x0, y0, x1, y1 int
+}
+
+// Zones are the areas of the marked parts of a view
+type Zones struct {
+	names []string
+	areas []zoneArea
+}
+
+// ScanZones finds the marked parts of a view, returning the view without
+// its markers. A part whose end was cut off, such as by a clipped view,
+// can't be clicked.
+func ScanZones(view string) (string, Zones) {
+	var zones Zones
+	if !strings.Contains(view, "\x1b[") {
+		return view, zones
+	}
+
+	open := map[string][2]int{}
+	lines := strings.Split(view, "\n")
+	for y, line := range lines {
+		var out strings.Builder
+		last := 0
+		for _, m := range zoneMarker.FindAllStringSubmatchIndex(line, -1) {
+			out.WriteString(line[last:m[0]])
+			last = m[1]
+
+			id, _ := strconv.Atoi(line[m[2]:m[3]])
+			zoneNumbers.Lock()
+			if id >= len(zoneNumbers.names) {
+				zoneNumbers.Unlock()
+				continue
+			}
+			name := zoneNumbers.names[id]
+			zoneNumbers.Unlock()
+
+			x := lipgloss.Width(out.String())
+			start, ok := open[name]
+			if !ok {
+				open[name] = [2]int{x, y}
+				continue
+			}
+			delete(open, name)
+			zones.names = append(zones.names, name)
+			zones.areas = append(zones.areas, zoneArea{start[0], start[1], max(x-1, start[0]), y})
+		}
+		if last > 0 {
+			out.WriteString(line[last:])
+			lines[y] = out.String()
+		}
+	}
+	return strings.Join(lines, "\n"), zones
+}
+
+// At returns the zone under a cell. Zones spanning lines, like a bordered
+// box, cover the rectangle between their first and last cells; where zones
+// overlap, the innermost wins.
+func (z Zones) At(x, y int) (string, bool) {
+	for i, a := range z.areas {
+		if x >= a.x0 && x <= a.x1 && y >= a.y0 && y <= a.y1 {
+			return z.names[i], true
+		}
+	}
+	return "", false
+}
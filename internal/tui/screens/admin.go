@@ -0,0 +1,403 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brady1408/dnd/internal/announce"
+	"github.com/brady1408/dnd/internal/auth"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/sessions"
+	"github.com/brady1408/dnd/internal/tui/components"
+	"github.com/brady1408/dnd/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type AdminMode int
+
+const (
+	ModeAdminList AdminMode = iota
+	ModeAdminDisable
+	ModeAdminBroadcast
+)
+
+// broadcastDurations are how long a broadcast from the admin screen can
+// show, cycled with tab
+var broadcastDurations = []struct {
+	label    string
+	duration time.Duration
+}{
+	{"10 minutes", announce.DefaultDuration},
+	{"1 hour", time.Hour},
+	{"1 day", 24 * time.Hour},
+}
+
+// AdminScreen lets admins see every account and who's connected, disable
+// abusive accounts, and broadcast a message to everyone connected
+type AdminScreen struct {
+	ctx         context.Context
+	authService *auth.Service
+	user        *db.User
+	sessions    *sessions.Registry
+	styles      *styles.Styles
+
+	mode          AdminMode
+	showSessions  bool // list this server's sessions instead of accounts
+	users         []db.ListUsersWithCharacterCountsRow
+	selectedIndex int
+	messageInput  textinput.Model
+	durationIndex int
+	status        string
+	err           string
+	width         int
+	height        int
+}
+
+type NavigateToAdminMsg struct{}
+
+type AdminUsersLoadedMsg struct {
+	Users []db.ListUsersWithCharacterCountsRow
+}
+
+type adminStatusMsg struct {
+	Status string
+}
+
+// AdminChangeMsg is sent after an admin broadcasts or disables an account,
+// so the server's sessions can pick it up right away
+type AdminChangeMsg struct{}
+
+type adminErrorMsg struct {
+	Err error
+}
+
+func NewAdminScreen(ctx context.Context, authService *auth.Service, user *db.User, registry *sessions.Registry, s *styles.Styles) *AdminScreen {
+	messageInput := textinput.New()
+	messageInput.Placeholder = "Server restarting at 9pm for an update"
+	messageInput.CharLimit = announce.MaxMessage
+	messageInput.Width = 50
+
+	return &AdminScreen{
+		ctx:          ctx,
+		authService:  authService,
+		user:         user,
+		sessions:     registry,
+		styles:       s,
+		mode:         ModeAdminList,
+		messageInput: messageInput,
+		width:        80,
+		height:       24,
+	}
+}
+
+func (a *AdminScreen) Init() tea.Cmd {
+	return a.loadUsers()
+}
+
+func (a *AdminScreen) loadUsers() tea.Cmd {
+	return func() tea.Msg {
+		users, err := a.authService.ListUsers(a.ctx, a.user.ID)
+		if err != nil {
+			return adminErrorMsg{Err: err}
+		}
+		return AdminUsersLoadedMsg{Users: users}
+	}
+}
+
+func (a *AdminScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		a.width = msg.Width
+		a.height = msg.Height
+
+	case AdminUsersLoadedMsg:
+		a.users = msg.Users
+		if a.selectedIndex >= len(a.users) {
+			a.selectedIndex = len(a.users) - 1
+		}
+		if a.selectedIndex < 0 {
+			a.selectedIndex = 0
+		}
+
+	case adminStatusMsg:
+		a.status = msg.Status
+		return a, tea.Batch(a.loadUsers(), func() tea.Msg { return AdminChangeMsg{} })
+
+	case adminErrorMsg:
+		a.err = msg.Err.Error()
+		a.mode = ModeAdminList
+
+	case components.ClickMsg:
+		if i, ok := components.Index(msg.Zone, "user"); ok && a.mode == ModeAdminList && !a.showSessions && i < len(a.users) {
+			a.selectedIndex = i
+		}
+
+	case tea.KeyMsg:
+		a.err = ""
+		a.status = ""
+		switch a.mode {
+		case ModeAdminList:
+			return a.updateList(msg)
+		case ModeAdminDisable:
+			return a.updateDisable(msg)
+		case ModeAdminBroadcast:
+			return a.updateBroadcast(msg)
+		}
+	}
+
+	return a, nil
+}
+
+func (a *AdminScreen) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if !a.showSessions && a.selectedIndex > 0 {
+			a.selectedIndex--
+		}
+	case "down", "j":
+		if !a.showSessions && a.selectedIndex < len(a.users)-1 {
+			a.selectedIndex++
+		}
+	case "tab":
+		a.showSessions = !a.showSessions
+	case "d":
+		if a.showSessions || a.selectedIndex >= len(a.users) {
+			return a, nil
+		}
+		u := a.users[a.selectedIndex]
+		if u.DisabledAt.Valid {
+			// Re-enabling is harmless, so it doesn't ask first
+			return a, a.setDisabled(u, false)
+		}
+		a.mode = ModeAdminDisable
+	case "b":
+		a.mode = ModeAdminBroadcast
+		a.messageInput.SetValue("")
+		a.messageInput.Focus()
+		return a, textinput.Blink
+	case "r":
+		return a, a.loadUsers()
+	case "esc", "q":
+		return a, func() tea.Msg { return NavigateBackMsg{} }
+	}
+	return a, nil
+}
+
+func (a *AdminScreen) updateDisable(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		a.mode = ModeAdminList
+		if a.selectedIndex < len(a.users) {
+			return a, a.setDisabled(a.users[a.selectedIndex], true)
+		}
+	case "n", "N", "esc":
+		a.mode = ModeAdminList
+	}
+	return a, nil
+}
+
+func (a *AdminScreen) updateBroadcast(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		message := strings.TrimSpace(a.messageInput.Value())
+		if message == "" {
+			a.err = "Message is required"
+			return a, nil
+		}
+		a.messageInput.Blur()
+		a.mode = ModeAdminList
+		duration := broadcastDurations[a.durationIndex]
+		return a, func() tea.Msg {
+			if err := a.authService.Broadcast(a.ctx, a.user.ID, message, duration.duration); err != nil {
+				return adminErrorMsg{Err: err}
+			}
+			return adminStatusMsg{Status: "Broadcasting to everyone for " + duration.label + "."}
+		}
+	case "tab":
+		a.durationIndex = (a.durationIndex + 1) % len(broadcastDurations)
+		return a, nil
+	case "esc":
+		a.messageInput.Blur()
+		a.mode = ModeAdminList
+		return a, nil
+	}
+
+	var cmd tea.Cmd
+	a.messageInput, cmd = a.messageInput.Update(msg)
+	return a, cmd
+}
+
+func (a *AdminScreen) setDisabled(u db.ListUsersWithCharacterCountsRow, disabled bool) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := a.authService.SetDisabled(a.ctx, a.user.ID, u.ID, disabled); err != nil {
+			return adminErrorMsg{Err: err}
+		}
+		if disabled {
+			return adminStatusMsg{Status: "Disabled " + accountName(u) + "."}
+		}
+		return adminStatusMsg{Status: "Enabled " + accountName(u) + "."}
+	}
+}
+
+// accountName is how an account shows in the list: its email, or for
+// accounts registered by SSH key, the start of the key
+func accountName(u db.ListUsersWithCharacterCountsRow) string {
+	if u.Email.Valid {
+		return u.Email.String
+	}
+	key := u.PublicKey.String
+	if len(key) > 30 {
+		key = key[:30] + "..."
+	}
+	return key
+}
+
+func (a *AdminScreen) View() string {
+	var b strings.Builder
+
+	b.WriteString(a.styles.Title.Render("Admin"))
+	b.WriteString("\n")
+	b.WriteString(a.styles.Subtitle.Render(fmt.Sprintf("%d accounts • %d connected to this server", len(a.users), len(a.sessions.List()))))
+	b.WriteString("\n\n")
+
+	switch {
+	case a.mode == ModeAdminBroadcast:
+		b.WriteString("Message for everyone connected:\n")
+		b.WriteString(a.styles.FocusedInput.Render(a.messageInput.View()))
+		b.WriteString("\n\n")
+		b.WriteString("Show for: " + a.styles.Selected.Render(broadcastDurations[a.durationIndex].label))
+	case a.showSessions:
+		b.WriteString(a.viewSessions())
+	default:
+		b.WriteString(a.viewList())
+	}
+
+	if a.mode == ModeAdminDisable && a.selectedIndex < len(a.users) {
+		b.WriteString("\n")
+		b.WriteString(a.styles.WarningText.Render(fmt.Sprintf(
+			"Disable %s? They'll be signed out and can't sign back in. (y/n)",
+			accountName(a.users[a.selectedIndex]),
+		)))
+	}
+
+	if a.status != "" {
+		b.WriteString("\n")
+		b.WriteString(a.styles.SuccessText.Render(a.status))
+	}
+	if a.err != "" {
+		b.WriteString("\n")
+		b.WriteString(a.styles.ErrorText.Render("Error: " + a.err))
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(a.styles.Help.Render(a.getHelp()))
+
+	return lipgloss.Place(a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		b.String())
+}
+
+func (a *AdminScreen) viewList() string {
+	var b strings.Builder
+
+	if len(a.users) == 0 {
+		b.WriteString(a.styles.Muted.Render("No accounts yet."))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	// Show the rows around the selection that fit on screen
+	rows := max(a.height-14, 5)
+	start := max(a.selectedIndex-rows/2, 0)
+	end := min(start+rows, len(a.users))
+	start = max(end-rows, 0)
+
+	b.WriteString(a.styles.Muted.Render(fmt.Sprintf("  %-34s %-7s %5s  %-10s  %s", "Account", "Role", "Chars", "Joined", "Status")))
+	b.WriteString("\n")
+	for i := start; i < end; i++ {
+		u := a.users[i]
+		cursor := "  "
+		style := a.styles.Unselected
+		if i == a.selectedIndex {
+			cursor = "> "
+			style = a.styles.Selected
+		}
+
+		joined := ""
+		if u.CreatedAt.Valid {
+			joined = u.CreatedAt.Time.Format("2006-01-02")
+		}
+		status := a.styles.SuccessText.Render("active")
+		if u.DisabledAt.Valid {
+			status = a.styles.ErrorText.Render("disabled " + u.DisabledAt.Time.Format("2006-01-02"))
+		}
+
+		b.WriteString(components.Mark(components.Item("user", i),
+			style.Render(fmt.Sprintf("%s%-34s %-7s %5d  %-10s  ", cursor, accountName(u), u.Role, u.CharacterCount, joined))+status))
+		b.WriteString("\n")
+	}
+	if len(a.users) > rows {
+		b.WriteString(a.styles.Muted.Render(fmt.Sprintf("  %d–%d of %d", start+1, end, len(a.users))))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// viewSessions lists the sessions connected to this server, with what
+// they're doing
+func (a *AdminScreen) viewSessions() string {
+	var b strings.Builder
+
+	list := a.sessions.List()
+	rows := max(a.height-14, 5)
+	b.WriteString(a.styles.Muted.Render(fmt.Sprintf("  %-30s %-21s %-9s %9s  %s", "Account", "From", "Screen", "Connected", "Idle")))
+	b.WriteString("\n")
+	for i, s := range list {
+		if i == rows {
+			b.WriteString(a.styles.Muted.Render(fmt.Sprintf("  and %d more", len(list)-rows)))
+			b.WriteString("\n")
+			break
+		}
+		account := s.Account
+		if account == "" {
+			account = "(signing in)"
+		}
+		if len(account) > 30 {
+			account = account[:27] + "..."
+		}
+		b.WriteString(fmt.Sprintf("  %-30s %-21s %-9s %9s  %s\n", account, s.RemoteAddr, s.Screen,
+			sessionDuration(time.Since(s.ConnectedAt)), sessionDuration(time.Since(s.LastActive))))
+	}
+
+	return b.String()
+}
+
+// sessionDuration formats a duration to the minute, or in seconds under a
+// minute
+func sessionDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func (a *AdminScreen) getHelp() string {
+	switch a.mode {
+	case ModeAdminDisable:
+		return "y: confirm disable • n: cancel"
+	case ModeAdminBroadcast:
+		return "enter: broadcast • tab: change how long • esc: cancel"
+	}
+	if a.showSessions {
+		return "tab: accounts • b: broadcast • esc: back"
+	}
+	return "↑/↓: navigate • d: disable/enable • tab: sessions • b: broadcast • r: refresh • esc: back"
+}
@@ -0,0 +1,129 @@
+package screens
+
+import (
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/tui/components"
+	"github.com/brady1408/dnd/internal/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Rows of the color and icon picker
+const (
+	appearanceAccent = iota
+	appearanceIcon
+)
+
+// appearancePicker chooses a character's accent color and icon. Index 0 of
+// each is none; the rest follow styles.Accents and styles.CharacterIcons.
+type appearancePicker struct {
+	accent int
+	icon   int
+	row    int
+}
+
+type appearanceSavedMsg struct {
+	Status string
+}
+
+func newAppearancePicker(char db.Character) appearancePicker {
+	var p appearancePicker
+	for i, a := range styles.Accents {
+		if a.Name == char.Accent {
+			p.accent = i + 1
+		}
+	}
+	for i, icon := range styles.CharacterIcons {
+		if icon == char.Icon {
+			p.icon = i + 1
+		}
+	}
+	return p
+}
+
+// values returns the chosen accent name and icon, "" for none
+func (p appearancePicker) values() (accent, icon string) {
+	if p.accent > 0 {
+		accent = styles.Accents[p.accent-1].Name
+	}
+	if p.icon > 0 {
+		icon = styles.CharacterIcons[p.icon-1]
+	}
+	return accent, icon
+}
+
+func (h *HomeScreen) updateAppearance(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := &h.appearance
+	step := 0
+	switch msg.String() {
+	case "esc":
+		h.styling = false
+		return h, nil
+	case "up", "k", "shift+tab":
+		p.row = appearanceAccent
+	case "down", "j", "tab":
+		p.row = appearanceIcon
+	case "left", "h":
+		step = -1
+	case "right", "l", " ":
+		step = 1
+	case "enter":
+		if h.selectedIndex >= len(h.characters) {
+			h.styling = false
+			return h, nil
+		}
+		char := h.characters[h.selectedIndex]
+		accent, icon := p.values()
+		return h, func() tea.Msg {
+			_, err := h.queries.UpdateCharacterAppearance(h.ctx, db.UpdateCharacterAppearanceParams{
+				ID:     char.ID,
+				Accent: accent,
+				Icon:   icon,
+			})
+			if err != nil {
+				return homeErrorMsg{Err: err}
+			}
+			return appearanceSavedMsg{Status: "Updated " + char.Name + "'s color and icon."}
+		}
+	}
+
+	if step != 0 {
+		switch p.row {
+		case appearanceAccent:
+			count := len(styles.Accents) + 1
+			p.accent = (p.accent + step + count) % count
+		case appearanceIcon:
+			count := len(styles.CharacterIcons) + 1
+			p.icon = (p.icon + step + count) % count
+		}
+	}
+	return h, nil
+}
+
+// viewAppearance shows the picker for the selected character, with a
+// preview of how it will look in the list
+func (h *HomeScreen) viewAppearance() string {
+	p := h.appearance
+	char := h.characters[h.selectedIndex]
+	accent, icon := p.values()
+
+	accentLabel, iconLabel := "None", "None"
+	if a, ok := styles.FindAccent(accent); ok {
+		accentLabel = h.styles.Base.Foreground(h.styles.AccentColor(a)).Render(a.Label)
+	}
+	if icon != "" {
+		iconLabel = icon
+	}
+
+	row := func(i int, label, value string) string {
+		cursor, style := "  ", h.styles.NotProficient
+		if i == p.row {
+			cursor, style = "> ", h.styles.Cursor
+		}
+		arrows := components.Mark(components.Item("less", i), "◀") + " " + value + " " + components.Mark(components.Item("more", i), "▶")
+		return components.Mark(components.Item("appearance", i), style.Render(cursor+label)) + arrows + "\n"
+	}
+
+	return "Color and icon for " + h.styles.CharacterMark(accent, icon) + char.Name + "\n" +
+		row(appearanceAccent, "Color  ", accentLabel) +
+		row(appearanceIcon, "Icon   ", iconLabel)
+}
@@ -0,0 +1,115 @@
+package screens
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// errNotEnoughCoins is returned when a character's coins don't cover a cost
+var errNotEnoughCoins = errors.New("not enough coins")
+
+// coinIndex is which of character.CoinNames an item is, if any
+func coinIndex(item db.CharacterInventory) (int, bool) {
+	for i, name := range character.CoinNames {
+		if strings.EqualFold(item.Name, name) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// purseOf counts the coins carried in an inventory
+func purseOf(inventory []db.CharacterInventory) character.Purse {
+	var purse character.Purse
+	for _, item := range inventory {
+		if i, ok := coinIndex(item); ok {
+			purse[i] += int(item.Quantity)
+		}
+	}
+	return purse
+}
+
+// coinPayment is what paying did to a character's coin rows, so it can be
+// taken back
+type coinPayment struct {
+	taken map[pgtype.UUID]int32 // coins taken from each row, negative for change
+	added []pgtype.UUID         // rows made to hold change
+}
+
+// payCoins takes cost copper pieces' worth of coins from a character's
+// inventory, reading the coins afresh so the queries should share a
+// transaction with whatever is being paid for
+func payCoins(ctx context.Context, queries *db.Queries, characterID pgtype.UUID, cost int) (coinPayment, error) {
+	payment := coinPayment{taken: make(map[pgtype.UUID]int32)}
+	inventory, err := queries.GetCharacterInventory(ctx, characterID)
+	if err != nil {
+		return payment, err
+	}
+	purse := purseOf(inventory)
+	after, ok := purse.Pay(cost)
+	if !ok {
+		return payment, errNotEnoughCoins
+	}
+
+	var rows [len(purse)][]db.CharacterInventory
+	for _, item := range inventory {
+		if i, ok := coinIndex(item); ok {
+			rows[i] = append(rows[i], item)
+		}
+	}
+	for i := range purse {
+		change := int32(after[i] - purse[i])
+		switch {
+		case change < 0:
+			for _, row := range rows[i] {
+				n := min(row.Quantity, -change)
+				if n == 0 {
+					continue
+				}
+				if _, err := queries.AddInventoryQuantity(ctx, db.AddInventoryQuantityParams{ID: row.ID, Quantity: -n}); err != nil {
+					return payment, err
+				}
+				payment.taken[row.ID] += n
+				if change += n; change == 0 {
+					break
+				}
+			}
+		case change > 0 && len(rows[i]) > 0:
+			if _, err := queries.AddInventoryQuantity(ctx, db.AddInventoryQuantityParams{ID: rows[i][0].ID, Quantity: change}); err != nil {
+				return payment, err
+			}
+			payment.taken[rows[i][0].ID] -= change
+		case change > 0:
+			item, err := queries.AddInventoryItem(ctx, db.AddInventoryItemParams{
+				CharacterID: characterID,
+				Name:        character.CoinNames[i],
+				Quantity:    change,
+			})
+			if err != nil {
+				return payment, err
+			}
+			payment.added = append(payment.added, item.ID)
+		}
+	}
+	return payment, nil
+}
+
+// refund puts back the coins a payment took
+func (p coinPayment) refund(ctx context.Context, queries *db.Queries) error {
+	for id, n := range p.taken {
+		if _, err := queries.AddInventoryQuantity(ctx, db.AddInventoryQuantityParams{ID: id, Quantity: n}); err != nil {
+			return err
+		}
+	}
+	for _, id := range p.added {
+		if err := queries.DeleteInventoryItem(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
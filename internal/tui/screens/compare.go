@@ -0,0 +1,423 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/tui/components"
+	"github.com/brady1408/dnd/internal/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CompareScreen shows two characters side by side, highlighting where they
+// differ
+type CompareScreen struct {
+	ctx     context.Context
+	queries *db.Queries
+	left    compareSide
+	right   compareSide
+	styles  *styles.Styles
+
+	tab    int // 0=stats, 1=proficiencies, 2=spells, 3=equipment
+	width  int
+	height int
+}
+
+// compareSide is one character and its spellcasting data and inventory
+type compareSide struct {
+	char         db.Character
+	spellcasting *db.CharacterSpellcasting
+	spells       []db.CharacterSpell
+	inventory    []db.CharacterInventory
+}
+
+// CompareCharactersMsg opens the compare view for two characters
+type CompareCharactersMsg struct {
+	Left  db.Character
+	Right db.Character
+}
+
+type compareSpellsLoadedMsg struct {
+	Left  SpellsLoadedMsg
+	Right SpellsLoadedMsg
+}
+
+type compareInventoryLoadedMsg struct {
+	Left  []db.CharacterInventory
+	Right []db.CharacterInventory
+}
+
+// compareTabs are the compare view sections
+var compareTabs = []string{"Stats", "Proficiencies", "Spells", "Equipment"}
+
+// Column widths for the compare tables
+const (
+	compareLabelWidth = 16
+	compareValueWidth = 20
+)
+
+func NewCompareScreen(ctx context.Context, queries *db.Queries, left, right db.Character, s *styles.Styles) *CompareScreen {
+	return &CompareScreen{
+		ctx:     ctx,
+		queries: queries,
+		left:    compareSide{char: left},
+		right:   compareSide{char: right},
+		styles:  s,
+		width:   80,
+		height:  24,
+	}
+}
+
+func (c *CompareScreen) Init() tea.Cmd {
+	loadSpells := func() tea.Msg {
+		return compareSpellsLoadedMsg{
+			Left:  c.loadSpells(c.left.char.ID),
+			Right: c.loadSpells(c.right.char.ID),
+		}
+	}
+	loadInventory := func() tea.Msg {
+		left, _ := c.queries.GetCharacterInventory(c.ctx, c.left.char.ID)
+		right, _ := c.queries.GetCharacterInventory(c.ctx, c.right.char.ID)
+		return compareInventoryLoadedMsg{Left: left, Right: right}
+	}
+	return tea.Batch(loadSpells, loadInventory)
+}
+
+func (c *CompareScreen) loadSpells(id pgtype.UUID) SpellsLoadedMsg {
+	msg := SpellsLoadedMsg{}
+	sc, err := c.queries.GetCharacterSpellcasting(c.ctx, id)
+	if err != nil {
+		return msg
+	}
+	msg.Spellcasting = &sc
+	spells, err := c.queries.GetCharacterSpells(c.ctx, id)
+	if err == nil {
+		msg.Spells = spells
+	}
+	return msg
+}
+
+func (c *CompareScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		c.width = msg.Width
+		c.height = msg.Height
+
+	case compareSpellsLoadedMsg:
+		c.left.spellcasting, c.left.spells = msg.Left.Spellcasting, msg.Left.Spells
+		c.right.spellcasting, c.right.spells = msg.Right.Spellcasting, msg.Right.Spells
+
+	case compareInventoryLoadedMsg:
+		c.left.inventory, c.right.inventory = msg.Left, msg.Right
+
+	case components.ClickMsg:
+		if i, ok := components.Index(msg.Zone, "tab"); ok && i < len(compareTabs) {
+			c.tab = i
+		}
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "tab", "right", "l":
+			c.tab = (c.tab + 1) % len(compareTabs)
+		case "shift+tab", "left", "h":
+			c.tab = (c.tab + len(compareTabs) - 1) % len(compareTabs)
+		case "x":
+			c.left, c.right = c.right, c.left
+		case "esc", "q":
+			return c, func() tea.Msg { return NavigateBackMsg{} }
+		}
+	}
+
+	return c, nil
+}
+
+func (c *CompareScreen) View() string {
+	var b strings.Builder
+
+	b.WriteString(c.styles.Title.Render("Compare Characters"))
+	b.WriteString("\n\n")
+
+	// Tab bar
+	tabBar := ""
+	for i, t := range compareTabs {
+		style := c.styles.Button
+		if i == c.tab {
+			style = c.styles.FocusedButton
+		}
+		tabBar += components.Mark(components.Item("tab", i), style.Render(" "+t+" "))
+	}
+	b.WriteString(tabBar)
+	b.WriteString("\n\n")
+
+	// Column headings
+	b.WriteString(fmt.Sprintf("%-*s ", compareLabelWidth, ""))
+	b.WriteString(c.styles.Cursor.Render(fmt.Sprintf("%-*s", compareValueWidth, truncate(c.left.char.Name, compareValueWidth))))
+	b.WriteString(" ")
+	b.WriteString(c.styles.Cursor.Render(truncate(c.right.char.Name, compareValueWidth)))
+	b.WriteString("\n\n")
+
+	var content string
+	switch c.tab {
+	case 0:
+		content = c.viewStats()
+	case 1:
+		content = c.viewProficiencies()
+	case 2:
+		content = c.viewSpells()
+	case 3:
+		content = c.viewEquipment()
+	}
+	b.WriteString(lipgloss.NewStyle().Align(lipgloss.Left).Render(content))
+
+	b.WriteString("\n\n")
+	b.WriteString(c.styles.Help.Render("tab/←/→: switch tabs • x: swap sides • esc: back"))
+
+	return lipgloss.Place(c.width, c.height,
+		lipgloss.Center, lipgloss.Center,
+		b.String())
+}
+
+// row renders one label with both values, highlighting the right value when
+// it differs from the left
+func (c *CompareScreen) row(label, left, right string) string {
+	style := c.styles.Base
+	if left != right {
+		style = c.styles.WarningText
+	}
+	return fmt.Sprintf("%-*s %-*s %s\n",
+		compareLabelWidth, label,
+		compareValueWidth, truncate(left, compareValueWidth),
+		style.Render(truncate(right, compareValueWidth)))
+}
+
+// numRow is row for numbers, showing the change on the right
+func (c *CompareScreen) numRow(label string, left, right int) string {
+	value := fmt.Sprintf("%d", right)
+	if right != left {
+		value += fmt.Sprintf(" (%s)", character.FormatModifierInt(right-left))
+	}
+	return c.row(label, fmt.Sprintf("%d", left), value)
+}
+
+func (c *CompareScreen) viewStats() string {
+	var b strings.Builder
+	l, r := c.left.char, c.right.char
+
+	b.WriteString(c.row("Class", l.Class, r.Class))
+	b.WriteString(c.numRow("Level", int(l.Level), int(r.Level)))
+	b.WriteString(c.row("Race", l.Race, r.Race))
+	b.WriteString(c.row("Background", l.Background.String, r.Background.String))
+	b.WriteString(c.row("Alignment", l.Alignment.String, r.Alignment.String))
+	b.WriteString(c.numRow("Experience", int(l.ExperiencePoints), int(r.ExperiencePoints)))
+
+	b.WriteString("\n")
+	b.WriteString(c.styles.Header.Render("Ability Scores"))
+	b.WriteString("\n")
+	abilities := []struct {
+		name        string
+		left, right int32
+	}{
+		{"Strength", l.Strength, r.Strength},
+		{"Dexterity", l.Dexterity, r.Dexterity},
+		{"Constitution", l.Constitution, r.Constitution},
+		{"Intelligence", l.Intelligence, r.Intelligence},
+		{"Wisdom", l.Wisdom, r.Wisdom},
+		{"Charisma", l.Charisma, r.Charisma},
+	}
+	for _, a := range abilities {
+		b.WriteString(c.numRow(a.name, int(a.left), int(a.right)))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(c.styles.Header.Render("Combat"))
+	b.WriteString("\n")
+	b.WriteString(c.numRow("Max HP", int(l.MaxHitPoints), int(r.MaxHitPoints)))
+	b.WriteString(c.numRow("Armor Class", int(l.ArmorClass), int(r.ArmorClass)))
+	b.WriteString(c.numRow("Speed", int(l.Speed), int(r.Speed)))
+	b.WriteString(c.row("Initiative",
+		character.FormatModifierInt(character.Initiative(int(l.Dexterity))),
+		character.FormatModifierInt(character.Initiative(int(r.Dexterity)))))
+	b.WriteString(c.row("Proficiency",
+		character.FormatModifierInt(character.RulesetFor(l.Ruleset).ProficiencyBonus(int(l.Level))),
+		character.FormatModifierInt(character.RulesetFor(r.Ruleset).ProficiencyBonus(int(r.Level)))))
+
+	return b.String()
+}
+
+func (c *CompareScreen) viewProficiencies() string {
+	var b strings.Builder
+
+	b.WriteString(c.styles.Header.Render("Saving Throws"))
+	b.WriteString("\n")
+	b.WriteString(c.listDiff(c.left.char.SavingThrowProficiencies, c.right.char.SavingThrowProficiencies))
+
+	b.WriteString("\n")
+	b.WriteString(c.styles.Header.Render("Skills"))
+	b.WriteString("\n")
+	b.WriteString(c.listDiff(c.left.char.SkillProficiencies, c.right.char.SkillProficiencies))
+
+	return b.String()
+}
+
+func (c *CompareScreen) viewSpells() string {
+	var b strings.Builder
+	l, r := c.left, c.right
+
+	if l.spellcasting == nil && r.spellcasting == nil {
+		b.WriteString(c.styles.Muted.Render("Neither character has spellcasting."))
+		return b.String()
+	}
+
+	b.WriteString(c.row("Ability", spellcastingAbility(l.spellcasting), spellcastingAbility(r.spellcasting)))
+	b.WriteString(c.row("Save DC", spellSaveDC(l.spellcasting), spellSaveDC(r.spellcasting)))
+	b.WriteString(c.row("Spell Attack", spellAttack(l.spellcasting), spellAttack(r.spellcasting)))
+	for level := 0; level < 9; level++ {
+		left, right := slotCount(l.spellcasting, level), slotCount(r.spellcasting, level)
+		if left == 0 && right == 0 {
+			continue
+		}
+		b.WriteString(c.numRow(fmt.Sprintf("Level %d Slots", level+1), left, right))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(c.styles.Header.Render("Spells"))
+	b.WriteString("\n")
+	b.WriteString(c.listDiff(spellNames(l.spells), spellNames(r.spells)))
+
+	return b.String()
+}
+
+func (c *CompareScreen) viewEquipment() string {
+	return c.listDiff(itemNames(c.left.inventory), itemNames(c.right.inventory))
+}
+
+// listDiff renders two lists in columns: shared entries muted, entries
+// only one side has highlighted
+func (c *CompareScreen) listDiff(left, right []string) string {
+	onlyLeft, onlyRight, shared := diffLists(left, right)
+	if len(onlyLeft)+len(onlyRight)+len(shared) == 0 {
+		return c.styles.Muted.Render("None.") + "\n"
+	}
+
+	var b strings.Builder
+	for i := 0; i < max(len(onlyLeft), len(onlyRight)); i++ {
+		var l, r string
+		if i < len(onlyLeft) {
+			l = "- " + onlyLeft[i]
+		}
+		if i < len(onlyRight) {
+			r = "+ " + onlyRight[i]
+		}
+		b.WriteString(fmt.Sprintf("%-*s ", compareLabelWidth, ""))
+		b.WriteString(c.styles.ErrorText.Render(fmt.Sprintf("%-*s", compareValueWidth, truncate(l, compareValueWidth))))
+		b.WriteString(" ")
+		b.WriteString(c.styles.SuccessText.Render(truncate(r, compareValueWidth)))
+		b.WriteString("\n")
+	}
+	for _, s := range shared {
+		b.WriteString(c.styles.Muted.Render(fmt.Sprintf("%-*s %-*s %s",
+			compareLabelWidth, "",
+			compareValueWidth, truncate("  "+s, compareValueWidth),
+			truncate("  "+s, compareValueWidth))))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// diffLists splits two lists into entries only on the left, only on the
+// right, and on both. Duplicates count, so two daggers against one leaves a
+// dagger on the left.
+func diffLists(left, right []string) (onlyLeft, onlyRight, shared []string) {
+	counts := make(map[string]int)
+	for _, s := range right {
+		counts[s]++
+	}
+	for _, s := range left {
+		if counts[s] > 0 {
+			counts[s]--
+			shared = append(shared, s)
+		} else {
+			onlyLeft = append(onlyLeft, s)
+		}
+	}
+	for _, s := range right {
+		if counts[s] > 0 {
+			counts[s]--
+			onlyRight = append(onlyRight, s)
+		}
+	}
+
+	sort.Strings(onlyLeft)
+	sort.Strings(onlyRight)
+	sort.Strings(shared)
+	return onlyLeft, onlyRight, shared
+}
+
+// spellNames lists spells as "Name (level)"
+func spellNames(spells []db.CharacterSpell) []string {
+	names := make([]string, 0, len(spells))
+	for _, spell := range spells {
+		level := "cantrip"
+		if spell.Level > 0 {
+			level = fmt.Sprintf("L%d", spell.Level)
+		}
+		names = append(names, fmt.Sprintf("%s (%s)", spell.Name, level))
+	}
+	return names
+}
+
+// itemNames lists inventory items with their quantities
+func itemNames(items []db.CharacterInventory) []string {
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+		if item.Quantity != 1 {
+			names[i] += fmt.Sprintf(" ×%d", item.Quantity)
+		}
+	}
+	return names
+}
+
+func spellcastingAbility(sc *db.CharacterSpellcasting) string {
+	if sc == nil {
+		return "-"
+	}
+	return sc.SpellcastingAbility
+}
+
+func spellSaveDC(sc *db.CharacterSpellcasting) string {
+	if sc == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", sc.SpellSaveDc)
+}
+
+func spellAttack(sc *db.CharacterSpellcasting) string {
+	if sc == nil {
+		return "-"
+	}
+	return character.FormatModifierInt(int(sc.SpellAttackBonus))
+}
+
+func slotCount(sc *db.CharacterSpellcasting, level int) int {
+	if sc == nil || level >= len(sc.SlotsMax) {
+		return 0
+	}
+	return int(sc.SlotsMax[level])
+}
+
+// truncate shortens s to width runes, marking the cut with an ellipsis
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
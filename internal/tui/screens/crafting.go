@@ -0,0 +1,351 @@
+package screens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/audit"
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/tui/components"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CraftingLoadedMsg struct {
+	Crafting *db.CharacterCrafting // nil when nothing is being crafted
+}
+
+func (s *SheetScreen) loadCrafting() tea.Cmd {
+	return s.cache.load(s.char.ID, partCrafting, func() tea.Msg {
+		c, err := s.queries.GetCharacterCrafting(s.ctx, s.char.ID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CraftingLoadedMsg{}
+		}
+		if err != nil {
+			return ErrorMsg{Action: "load crafting", Err: err, Retry: s.loadCrafting()}
+		}
+		return CraftingLoadedMsg{Crafting: &c}
+	})
+}
+
+// startCrafting opens the recipes, or the project already under way
+func (s *SheetScreen) startCrafting() {
+	if s.rules().CraftingPerDay() == 0 {
+		s.status = "Crafting downtime isn't tracked for " + s.rules().Name()
+		return
+	}
+	s.mode = ModeCraft
+	s.craftDays = 1
+}
+
+// craftDaysLeft is how many days of work the project still needs
+func (s *SheetScreen) craftDaysLeft() int {
+	if s.crafting == nil {
+		return 0
+	}
+	perDay := s.rules().CraftingPerDay()
+	return (int(s.crafting.Price-s.crafting.Progress) + perDay - 1) / perDay
+}
+
+// canCraft reports whether the character has the tools proficiency an item
+// needs
+func (s *SheetScreen) canCraft(e character.Equipment) bool {
+	for _, tool := range s.char.ToolProficiencies {
+		if strings.EqualFold(tool, e.Tool) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SheetScreen) updateCraft(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if s.crafting != nil {
+		return s.updateCraftProject(msg)
+	}
+
+	recipes := character.Recipes()
+	switch msg.String() {
+	case "up", "k":
+		if s.recipeCursor > 0 {
+			s.recipeCursor--
+		}
+	case "down", "j":
+		if s.recipeCursor < len(recipes)-1 {
+			s.recipeCursor++
+		}
+	case "enter":
+		e := recipes[s.recipeCursor]
+		if !s.canCraft(e) {
+			s.status = fmt.Sprintf("Crafting %s needs proficiency with %s", e.Name, e.Tool)
+			return s, nil
+		}
+		cost := character.CraftingMaterials(e)
+		if have := purseOf(s.inventory).Total(); have < cost {
+			s.status = fmt.Sprintf("Materials for %s cost %s; you have %s", e.Name, character.FormatCoins(cost), character.FormatCoins(have))
+			return s, nil
+		}
+		s.status = ""
+		return s, s.undo.run(startCraftingEdit(s.ctx, s.queries, s.userID, s.char, e))
+	case "esc":
+		s.mode = ModeView
+	}
+	return s, nil
+}
+
+func (s *SheetScreen) updateCraftProject(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "left", "h":
+		if s.craftDays > 1 {
+			s.craftDays--
+		}
+	case "right", "l":
+		if s.craftDays < s.craftDaysLeft() {
+			s.craftDays++
+		}
+	case "enter":
+		days := min(s.craftDays, s.craftDaysLeft())
+		progress := min(int(s.crafting.Progress)+days*s.rules().CraftingPerDay(), int(s.crafting.Price))
+		return s, s.undo.run(craftingWorkEdit(s.ctx, s.queries, s.userID, s.char, *s.crafting, days, int32(progress)))
+	case "x":
+		return s, s.undo.run(abandonCraftingEdit(s.ctx, s.queries, s.userID, s.char, *s.crafting))
+	case "esc":
+		s.mode = ModeView
+	}
+	return s, nil
+}
+
+// viewCraft shows the recipes, or the project under way
+func (s *SheetScreen) viewCraft() string {
+	if s.crafting != nil {
+		return s.viewCraftProject()
+	}
+
+	var b strings.Builder
+	b.WriteString(s.styles.Header.Render("Craft"))
+	b.WriteString("\n\n")
+
+	recipes := character.Recipes()
+	perDay := s.rules().CraftingPerDay()
+	const rows = 10
+	start := min(max(s.recipeCursor-rows/2, 0), max(len(recipes)-rows, 0))
+	end := min(start+rows, len(recipes))
+	for i := start; i < end; i++ {
+		e := recipes[i]
+		days := (e.Price + perDay - 1) / perDay
+		name := e.Name
+		if e.Count > 1 {
+			name += fmt.Sprintf(" ×%d", e.Count)
+		}
+		line := fmt.Sprintf("%-20s %-10s %-22s %d day", name, character.FormatCoins(character.CraftingMaterials(e)), e.Tool, days)
+		if days != 1 {
+			line += "s"
+		}
+		if s.narrow() {
+			line = fmt.Sprintf("%-20s %s", name, character.FormatCoins(character.CraftingMaterials(e)))
+		}
+		style := s.styles.Muted
+		if s.canCraft(e) {
+			style = s.styles.NotProficient
+		}
+		if i == s.recipeCursor {
+			style = s.styles.Cursor
+		}
+		b.WriteString(components.Mark(components.Item("recipe", i), style.Render(line)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(s.styles.StatLabel.Render("Coins: "))
+	b.WriteString(s.styles.StatValue.Render(character.FormatCoins(purseOf(s.inventory).Total())))
+	b.WriteString("\n\n")
+	text := lipgloss.NewStyle().Width(s.contentWidth(64))
+	b.WriteString(s.styles.Muted.Render(text.Render(fmt.Sprintf(
+		"Raw materials cost half the item's price, paid from your coins. Each day of downtime works off %s of the price, and you need proficiency with the tools listed.",
+		character.FormatCoins(perDay)))))
+
+	return lipgloss.NewStyle().
+		Align(lipgloss.Left).
+		Render(b.String())
+}
+
+// viewCraftProject shows how far along the project is and how many days
+// of work to put in
+func (s *SheetScreen) viewCraftProject() string {
+	var b strings.Builder
+	b.WriteString(s.styles.Header.Render("Crafting: " + s.crafting.Item))
+	b.WriteString("\n\n")
+
+	b.WriteString(s.styles.StatLabel.Render("Progress: "))
+	b.WriteString(s.styles.StatValue.Render(fmt.Sprintf("%s of %s",
+		character.FormatCoins(int(s.crafting.Progress)), character.FormatCoins(int(s.crafting.Price)))))
+	b.WriteString("\n")
+	b.WriteString(components.ProgressBar(float64(s.crafting.Progress), float64(s.crafting.Price), 30, s.styles.SuccessText, s.styles.Muted))
+	b.WriteString("\n\n")
+
+	left := s.craftDaysLeft()
+	b.WriteString(s.styles.StatLabel.Render("Days left: "))
+	b.WriteString(s.styles.StatValue.Render(fmt.Sprintf("%d", left)))
+	b.WriteString("\n")
+	b.WriteString(s.styles.StatLabel.Render("Work:      "))
+	b.WriteString(s.styles.StatValue.Render(fmt.Sprintf("◀ %d ▶", min(s.craftDays, left))))
+	b.WriteString(s.styles.Muted.Render(" days"))
+	b.WriteString("\n")
+
+	return lipgloss.NewStyle().
+		Align(lipgloss.Left).
+		Render(b.String())
+}
+
+// craftingSummary is the line the Inventory tab shows for a project
+func (s *SheetScreen) craftingSummary() string {
+	if s.crafting == nil {
+		return ""
+	}
+	return fmt.Sprintf("Crafting %s: %s of %s done", s.crafting.Item,
+		character.FormatCoins(int(s.crafting.Progress)), character.FormatCoins(int(s.crafting.Price)))
+}
+
+// startCraftingEdit buys the raw materials for an item and starts work on
+// it. The coins are taken in the same transaction, so they're never spent
+// without the project being started.
+func startCraftingEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, e character.Equipment) sheetEdit {
+	cost := character.CraftingMaterials(e)
+	var payment coinPayment
+	return sheetEdit{
+		label:   "start of crafting",
+		touches: partInventory | partCrafting,
+		apply: func() (db.Character, error) {
+			err := queries.InTx(ctx, func(q *db.Queries) error {
+				var err error
+				if payment, err = payCoins(ctx, q, char.ID, cost); err != nil {
+					return err
+				}
+				return q.StartCrafting(ctx, db.StartCraftingParams{
+					CharacterID: char.ID,
+					Item:        e.Name,
+					Price:       int32(e.Price),
+				})
+			})
+			if err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindCrafting,
+				fmt.Sprintf("Started crafting %s (materials %s)", e.Name, character.FormatCoins(cost)))
+			return queries.GetCharacterByID(ctx, char.ID)
+		},
+		revert: func() (db.Character, error) {
+			err := queries.InTx(ctx, func(q *db.Queries) error {
+				if err := q.DeleteCharacterCrafting(ctx, char.ID); err != nil {
+					return err
+				}
+				return payment.refund(ctx, q)
+			})
+			if err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindCrafting, "Undid starting to craft "+e.Name)
+			return queries.GetCharacterByID(ctx, char.ID)
+		},
+	}
+}
+
+// craftingWorkEdit puts days of work into a project. Finishing it moves
+// the item into the inventory.
+func craftingWorkEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, project db.CharacterCrafting, days int, progress int32) sheetEdit {
+	finished := progress >= project.Price
+	quantity := int32(1)
+	if e, ok := character.FindEquipment(project.Item); ok && e.Count > 0 {
+		quantity = e.Count
+	}
+	description := fmt.Sprintf("Worked %d day(s) crafting %s", days, project.Item)
+	if finished {
+		description = fmt.Sprintf("Worked %d day(s) and finished crafting %s", days, project.Item)
+	}
+
+	var added pgtype.UUID
+	return sheetEdit{
+		label:   "crafting work",
+		touches: partInventory | partCrafting,
+		apply: func() (db.Character, error) {
+			var err error
+			if finished {
+				err = queries.InTx(ctx, func(q *db.Queries) error {
+					if err := q.DeleteCharacterCrafting(ctx, char.ID); err != nil {
+						return err
+					}
+					item, err := q.AddInventoryItem(ctx, db.AddInventoryItemParams{
+						CharacterID: char.ID,
+						Name:        project.Item,
+						Quantity:    quantity,
+					})
+					added = item.ID
+					return err
+				})
+			} else {
+				err = queries.SetCraftingProgress(ctx, db.SetCraftingProgressParams{CharacterID: char.ID, Progress: progress})
+			}
+			if err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindCrafting, description)
+			return queries.GetCharacterByID(ctx, char.ID)
+		},
+		revert: func() (db.Character, error) {
+			var err error
+			if finished {
+				err = queries.InTx(ctx, func(q *db.Queries) error {
+					if err := q.DeleteInventoryItem(ctx, added); err != nil {
+						return err
+					}
+					return q.StartCrafting(ctx, db.StartCraftingParams{
+						CharacterID: char.ID,
+						Item:        project.Item,
+						Price:       project.Price,
+						Progress:    project.Progress,
+					})
+				})
+			} else {
+				err = queries.SetCraftingProgress(ctx, db.SetCraftingProgressParams{CharacterID: char.ID, Progress: project.Progress})
+			}
+			if err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindCrafting, "Undid crafting work on "+project.Item)
+			return queries.GetCharacterByID(ctx, char.ID)
+		},
+	}
+}
+
+// abandonCraftingEdit gives up on a project. The materials already bought
+// are lost, as they would be at the table.
+func abandonCraftingEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, project db.CharacterCrafting) sheetEdit {
+	return sheetEdit{
+		label:   "abandoned crafting",
+		touches: partCrafting,
+		apply: func() (db.Character, error) {
+			if err := queries.DeleteCharacterCrafting(ctx, char.ID); err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindCrafting, "Abandoned crafting "+project.Item)
+			return queries.GetCharacterByID(ctx, char.ID)
+		},
+		revert: func() (db.Character, error) {
+			err := queries.StartCrafting(ctx, db.StartCraftingParams{
+				CharacterID: char.ID,
+				Item:        project.Item,
+				Price:       project.Price,
+				Progress:    project.Progress,
+			})
+			if err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindCrafting, "Undid abandoning "+project.Item)
+			return queries.GetCharacterByID(ctx, char.ID)
+		},
+	}
+}
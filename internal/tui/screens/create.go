@@ -1,13 +1,16 @@
 package screens
 
 import (
+	"cmp"
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/brady1408/dnd/internal/audit"
 	"github.com/brady1408/dnd/internal/character"
 	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/sanitize"
+	"github.com/brady1408/dnd/internal/tui/components"
 	"github.com/brady1408/dnd/internal/tui/styles"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -26,6 +29,8 @@ const (
 	StepAbilityArray
 	StepAbilityPointBuy
 	StepSkills
+	StepCantrips
+	StepSpells
 	StepReview
 )
 
@@ -45,6 +50,14 @@ type CreateScreen struct {
 	backgroundInput textinput.Model
 	alignmentIndex  int
 
+	// Name suggestions: the race they were for, and the index into
+	// character.NameStyles to suggest from
+	suggestedFor string
+	nameStyle    int
+
+	// Set once a race has been picked, so names are suggested for it
+	raceChosen bool
+
 	// Race & Class
 	raceIndex  int
 	classIndex int
@@ -62,6 +75,27 @@ type CreateScreen struct {
 	selectedSkills    []string
 	skillsToSelect    int
 	skillCursor       int
+
+	// Spells (casters only)
+	availableCantrips []character.Spell
+	availableSpells   []character.Spell
+	selectedCantrips  []string
+	selectedSpells    []string
+	cantripsToSelect  int
+	spellsToSelect    int
+	spellCursor       int
+
+	// Set once the user has seen the rule deviations and chosen to keep them
+	houseRules bool
+
+	// Index into character.Rulesets()
+	rulesetIndex int
+
+	// quick skips from class straight to review, filling in the rest
+	quick bool
+
+	// random starts at review with every choice made at random
+	random bool
 }
 
 type CharacterCreatedMsg struct {
@@ -70,7 +104,9 @@ type CharacterCreatedMsg struct {
 
 type NavigateBackMsg struct{}
 
-func NewCreateScreen(ctx context.Context, queries *db.Queries, userID pgtype.UUID, s *styles.Styles) *CreateScreen {
+// NewCreateScreen starts character creation. In quick mode only the name,
+// race, and class are asked for.
+func NewCreateScreen(ctx context.Context, queries *db.Queries, userID pgtype.UUID, quick bool, s *styles.Styles) *CreateScreen {
 	nameInput := textinput.New()
 	nameInput.Placeholder = "Character Name"
 	nameInput.CharLimit = 100
@@ -91,6 +127,7 @@ func NewCreateScreen(ctx context.Context, queries *db.Queries, userID pgtype.UUI
 		nameInput:      nameInput,
 		backgroundInput: bgInput,
 		assignedScores: make(map[string]int),
+		quick:          quick,
 		width:          80,
 		height:         24,
 	}
@@ -106,6 +143,10 @@ func (c *CreateScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		c.width = msg.Width
 		c.height = msg.Height
 
+	case components.ClickMsg:
+		c.err = ""
+		return c.click(msg.Zone)
+
 	case tea.KeyMsg:
 		c.err = ""
 
@@ -139,6 +180,10 @@ func (c *CreateScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return c.updatePointBuy(msg)
 		case StepSkills:
 			return c.updateSkills(msg)
+		case StepCantrips:
+			return c.updateCantrips(msg)
+		case StepSpells:
+			return c.updateSpells(msg)
 		case StepReview:
 			return c.updateReview(msg)
 		}
@@ -167,31 +212,81 @@ func (c *CreateScreen) previousStep() {
 	case StepSkills:
 		// Go back to ability method selection
 		c.step = StepAbilityMethod
-	case StepReview:
+	case StepCantrips:
 		c.step = StepSkills
+	case StepSpells:
+		c.step = StepCantrips
+		c.spellCursor = 0
+	case StepReview:
+		c.houseRules = false
+		if c.quick {
+			c.step = StepClass
+		} else if c.isCaster() {
+			c.step = StepSpells
+			c.spellCursor = 0
+		} else {
+			c.step = StepSkills
+		}
 	}
 }
 
 func (c *CreateScreen) updateBasicInfo(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter", "tab":
-		if strings.TrimSpace(c.nameInput.Value()) == "" {
+		name := sanitize.Line(c.nameInput.Value(), sanitize.MaxName)
+		if name == "" {
 			c.err = "Name is required"
 			return c, nil
 		}
+		if err := checkName(c.ctx, c.queries, c.userID, name, pgtype.UUID{}); err != nil {
+			c.err = err.Error()
+			return c, nil
+		}
 		c.step = StepRace
 		c.nameInput.Blur()
 		return c, nil
 
+	case "ctrl+n":
+		c.suggestName()
+		return c, nil
+
+	case "ctrl+g":
+		c.nameStyle = (c.nameStyle + 1) % len(character.NameStyles)
+		c.suggestName()
+		return c, nil
+
 	case "up", "down":
 		// Toggle between name and background inputs could be added here
 	}
 
+	before := c.nameInput.Value()
 	var cmd tea.Cmd
 	c.nameInput, cmd = c.nameInput.Update(msg)
+	if c.nameInput.Value() != before {
+		c.suggestedFor = ""
+	}
 	return c, cmd
 }
 
+// suggestName fills in a made-up name for the chosen race, or for a random
+// one before a race is picked. Asking again gives a different name.
+func (c *CreateScreen) suggestName() {
+	race := character.Races[c.raceIndex]
+	if !c.raceChosen {
+		race = character.Races[randomIndex(len(character.Races))]
+	}
+	current := c.nameInput.Value()
+	name := current
+	for range 10 {
+		if name = character.SuggestName(race, character.NameStyles[c.nameStyle]); name != current {
+			break
+		}
+	}
+	c.nameInput.SetValue(name)
+	c.nameInput.CursorEnd()
+	c.suggestedFor = race
+}
+
 func (c *CreateScreen) updateRace(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "up", "k":
@@ -204,6 +299,7 @@ func (c *CreateScreen) updateRace(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case "enter":
 		c.step = StepClass
+		c.raceChosen = true
 	}
 	return c, nil
 }
@@ -219,11 +315,73 @@ func (c *CreateScreen) updateClass(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			c.classIndex++
 		}
 	case "enter":
+		if c.quick {
+			c.quickFill()
+			c.step = StepReview
+			return c, nil
+		}
 		c.step = StepAbilityMethod
 	}
 	return c, nil
 }
 
+// Randomize makes every choice at random and skips to review, for a
+// character ready to play right away. Abilities, skills, and spells still
+// suit the class; R on review rolls another.
+func (c *CreateScreen) Randomize() {
+	c.quick, c.random = true, true
+	c.raceIndex = randomIndex(len(character.Races))
+	c.classIndex = randomIndex(len(character.Classes))
+	c.alignmentIndex = randomIndex(len(character.Alignments))
+	c.backgroundInput.SetValue(character.Backgrounds[randomIndex(len(character.Backgrounds))])
+	c.raceChosen = true
+	c.nameStyle = randomIndex(len(character.NameStyles))
+	for range 5 {
+		c.suggestName()
+		if checkName(c.ctx, c.queries, c.userID, c.nameInput.Value(), pgtype.UUID{}) == nil {
+			break
+		}
+	}
+
+	c.quickFill()
+	if c.isCaster() {
+		c.selectedCantrips = character.RandomSpells(c.availableCantrips, c.cantripsToSelect)
+		c.selectedSpells = character.RandomSpells(c.availableSpells, c.spellsToSelect)
+	}
+	c.houseRules = false
+	c.nameInput.Blur()
+	c.step = StepReview
+}
+
+// randomIndex returns a random index into a list of n items
+func randomIndex(n int) int {
+	return character.RollDice(1, n)[0] - 1
+}
+
+// quickFill makes the remaining choices for quick mode: the standard array
+// assigned by class priority, then skills and spells
+func (c *CreateScreen) quickFill() {
+	className := character.Classes[c.classIndex]
+	c.abilityMethodIndex = 1
+	c.rolledScores = character.GetStandardArray()
+	c.assignedScores = character.DefaultAbilityAssignment(className)
+	c.pointBuyState = nil
+
+	scores := make(map[string]int, len(character.Abilities))
+	for _, ability := range character.Abilities {
+		scores[ability] = c.abilityScore(ability)
+	}
+	c.setupSkillSelection()
+	c.selectedSkills = character.DefaultSkills(c.availableSkills, c.skillsToSelect, scores)
+
+	c.selectedCantrips, c.selectedSpells = nil, nil
+	if c.isCaster() {
+		c.setupSpellSelection()
+		c.selectedCantrips = character.DefaultSpells(c.availableCantrips, c.cantripsToSelect)
+		c.selectedSpells = character.DefaultSpells(c.availableSpells, c.spellsToSelect)
+	}
+}
+
 func (c *CreateScreen) updateAbilityMethod(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	methods := []string{"Roll 4d6 (drop lowest)", "Standard Array", "Point Buy"}
 
@@ -244,11 +402,13 @@ func (c *CreateScreen) updateAbilityMethod(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 			copy(c.rolledScores, c.abilityRolls.Totals)
 			c.assignedScores = make(map[string]int)
 			c.assignIndex = 0
+			c.pointBuyState = nil
 			c.step = StepAbilityRoll
 		case 1:
 			c.rolledScores = character.GetStandardArray()
 			c.assignedScores = make(map[string]int)
 			c.assignIndex = 0
+			c.pointBuyState = nil
 			c.step = StepAbilityArray
 		case 2:
 			c.pointBuyState = character.NewPointBuyState()
@@ -328,6 +488,8 @@ func (c *CreateScreen) updatePointBuy(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "left", "h", "-":
 		ability := character.Abilities[c.assignIndex]
 		c.pointBuyState.Decrease(ability)
+	case "a":
+		c.pointBuyState.Recommend(character.Classes[c.classIndex])
 	case "enter":
 		c.setupSkillSelection()
 		c.step = StepSkills
@@ -374,7 +536,12 @@ func (c *CreateScreen) updateSkills(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case "enter":
 		if len(c.selectedSkills) == c.skillsToSelect {
-			c.step = StepReview
+			if c.isCaster() {
+				c.setupSpellSelection()
+				c.step = StepCantrips
+			} else {
+				c.step = StepReview
+			}
 		} else {
 			c.err = fmt.Sprintf("Please select %d skills", c.skillsToSelect)
 		}
@@ -382,26 +549,151 @@ func (c *CreateScreen) updateSkills(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return c, nil
 }
 
+// isCaster returns true if the chosen class casts spells at 1st level
+func (c *CreateScreen) isCaster() bool {
+	return character.IsSpellcaster(character.Classes[c.classIndex])
+}
+
+// abilityScore returns the score currently assigned to an ability
+func (c *CreateScreen) abilityScore(ability string) int {
+	if c.pointBuyState != nil {
+		return c.pointBuyState.Scores[ability]
+	}
+	if scoreIdx, ok := c.assignedScores[ability]; ok {
+		return c.rolledScores[scoreIdx]
+	}
+	return 0
+}
+
+func (c *CreateScreen) setupSpellSelection() {
+	className := character.Classes[c.classIndex]
+	info := character.ClassSpellcasting[className]
+
+	c.availableCantrips = character.SpellsForClass(className, 0)
+	c.availableSpells = character.SpellsForClass(className, 1)
+	c.cantripsToSelect = info.CantripsKnown
+	c.spellsToSelect = character.SpellsToChoose(className, c.abilityScore(info.Ability))
+	c.selectedCantrips = []string{}
+	c.selectedSpells = []string{}
+	c.spellCursor = 0
+}
+
+// toggleSpell adds or removes a spell from a selection, respecting the limit
+func toggleSpell(selected []string, name string, limit int) []string {
+	for i, s := range selected {
+		if s == name {
+			return append(selected[:i], selected[i+1:]...)
+		}
+	}
+	if len(selected) < limit {
+		selected = append(selected, name)
+	}
+	return selected
+}
+
+func (c *CreateScreen) updateCantrips(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if c.spellCursor > 0 {
+			c.spellCursor--
+		}
+	case "down", "j":
+		if c.spellCursor < len(c.availableCantrips)-1 {
+			c.spellCursor++
+		}
+	case " ", "x":
+		if len(c.availableCantrips) > 0 {
+			name := c.availableCantrips[c.spellCursor].Name
+			c.selectedCantrips = toggleSpell(c.selectedCantrips, name, c.cantripsToSelect)
+		}
+	case "enter":
+		if len(c.selectedCantrips) == c.cantripsToSelect {
+			c.step = StepSpells
+			c.spellCursor = 0
+		} else {
+			c.err = fmt.Sprintf("Please select %d cantrips", c.cantripsToSelect)
+		}
+	}
+	return c, nil
+}
+
+func (c *CreateScreen) updateSpells(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if c.spellCursor > 0 {
+			c.spellCursor--
+		}
+	case "down", "j":
+		if c.spellCursor < len(c.availableSpells)-1 {
+			c.spellCursor++
+		}
+	case " ", "x":
+		if len(c.availableSpells) > 0 {
+			name := c.availableSpells[c.spellCursor].Name
+			c.selectedSpells = toggleSpell(c.selectedSpells, name, c.spellsToSelect)
+		}
+	case "enter":
+		if len(c.selectedSpells) == c.spellsToSelect {
+			c.step = StepReview
+		} else {
+			c.err = fmt.Sprintf("Please select %d spells", c.spellsToSelect)
+		}
+	}
+	return c, nil
+}
+
 func (c *CreateScreen) updateReview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter", "y":
+		// Deviations from the rules need a second, explicit confirmation
+		if len(c.startingIssues()) > 0 && !c.houseRules {
+			c.houseRules = true
+			return c, nil
+		}
 		return c, c.createCharacter()
 	case "n":
+		c.houseRules = false
 		c.step = StepBasicInfo
 		c.nameInput.Focus()
+	case "r":
+		c.rulesetIndex = (c.rulesetIndex + 1) % len(character.Rulesets())
+	case "R":
+		if c.random {
+			c.Randomize()
+		}
 	}
 	return c, nil
 }
 
+// startingIssues lists where the current choices break the class and
+// background rules
+func (c *CreateScreen) startingIssues() []string {
+	className := character.Classes[c.classIndex]
+	choices := character.StartingChoices{
+		Class:      className,
+		Background: sanitize.Line(c.backgroundInput.Value(), sanitize.MaxShort),
+		Skills:     c.selectedSkills,
+		Cantrips:   c.selectedCantrips,
+		Spells:     c.selectedSpells,
+	}
+	if info, ok := character.ClassSpellcasting[className]; ok {
+		choices.SpellcastingScore = c.abilityScore(info.Ability)
+	}
+	if c.pointBuyState != nil {
+		choices.PointBuyRemaining = c.pointBuyState.PointsRemaining
+	}
+	return character.ValidateStartingChoices(choices)
+}
+
 func (c *CreateScreen) createCharacter() tea.Cmd {
 	return func() tea.Msg {
 		// Build character
 		char := character.NewCharacter()
-		char.Name = strings.TrimSpace(c.nameInput.Value())
+		char.Name = sanitize.Line(c.nameInput.Value(), sanitize.MaxName)
 		char.SetRace(character.Races[c.raceIndex])
 		char.SetClass(character.Classes[c.classIndex])
 		char.Background = character.Backgrounds[0] // Default
-		if bg := strings.TrimSpace(c.backgroundInput.Value()); bg != "" {
+		if bg := sanitize.Line(c.backgroundInput.Value(), sanitize.MaxShort); bg != "" {
 			char.Background = bg
 		}
 		char.Alignment = character.Alignments[c.alignmentIndex]
@@ -441,10 +733,9 @@ func (c *CreateScreen) createCharacter() tea.Cmd {
 
 		char.SkillProficiencies = c.selectedSkills
 		char.InitializeHP()
+		char.InitializeArmorClass()
 
 		// Save to database
-		equipmentJSON, _ := json.Marshal(char.Equipment)
-
 		dbChar, err := c.queries.CreateCharacter(c.ctx, db.CreateCharacterParams{
 			UserID:                   c.userID,
 			Name:                     char.Name,
@@ -467,24 +758,107 @@ func (c *CreateScreen) createCharacter() tea.Cmd {
 			Speed:                    int32(char.Speed),
 			SavingThrowProficiencies: char.SavingThrowProficiencies,
 			SkillProficiencies:       char.SkillProficiencies,
-			Equipment:                equipmentJSON,
 			FeaturesTraits:           char.FeaturesTraits,
 			Notes:                    char.Notes,
+			Ruleset:                  character.Rulesets()[c.rulesetIndex].ID(),
+			Darkvision:               int32(char.Darkvision),
 		})
 
 		if err != nil {
-			return nil // Handle error
+			return ErrorMsg{Action: "create the character", Err: err, Retry: c.createCharacter()}
 		}
 
-		return CharacterCreatedMsg{Character: dbChar}
+		// Starting armor is worn, matching the AC it was given. The
+		// character exists by now, so a failure here is reported on the
+		// sheet rather than stopping creation.
+		var failed error
+		for _, item := range char.Equipment {
+			_, isArmor := character.FindArmor(item)
+			_, err := c.queries.AddInventoryItem(c.ctx, db.AddInventoryItemParams{
+				CharacterID: dbChar.ID,
+				Name:        item,
+				Quantity:    1,
+				Equipped:    isArmor,
+			})
+			failed = cmp.Or(failed, err)
+		}
+		if c.isCaster() {
+			failed = cmp.Or(failed, c.createSpellcasting(dbChar))
+		}
+		failed = cmp.Or(failed, syncClassResources(c.ctx, c.queries, dbChar))
+		description := fmt.Sprintf("Created level %d %s %s", dbChar.Level, dbChar.Race, dbChar.Class)
+		if issues := c.startingIssues(); len(issues) > 0 {
+			description += " with house rules: " + strings.Join(issues, "; ")
+		}
+		audit.Record(c.ctx, c.queries, dbChar.ID, c.userID, audit.KindCreated, description)
+
+		created := CharacterCreatedMsg{Character: dbChar}
+		if failed != nil {
+			// The sheet opens first, so the error is shown on it
+			return tea.Sequence(
+				func() tea.Msg { return created },
+				func() tea.Msg {
+					return ErrorMsg{Action: "save all of the starting equipment, spells, and class resources", Err: failed}
+				},
+			)()
+		}
+		return created
 	}
 }
 
+// createSpellcasting stores the spellcasting stats and chosen spells for a
+// new caster, returning the first error
+func (c *CreateScreen) createSpellcasting(dbChar db.Character) error {
+	info := character.ClassSpellcasting[dbChar.Class]
+	score := c.abilityScore(info.Ability)
+	level := int(dbChar.Level)
+
+	_, err := c.queries.CreateCharacterSpellcasting(c.ctx, db.CreateCharacterSpellcastingParams{
+		CharacterID:         dbChar.ID,
+		SpellcastingClass:   dbChar.Class,
+		SpellcastingAbility: info.Ability,
+		SpellSaveDc:         int32(character.SpellSaveDC(score, level)),
+		SpellAttackBonus:    int32(character.SpellAttackBonus(score, level)),
+		SlotsMax:            slotCounts(character.RulesetFor(dbChar.Ruleset).SpellSlots(dbChar.Class, level)),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range c.selectedCantrips {
+		spell, _ := character.FindSpell(name)
+		_, err = c.queries.AddCharacterSpell(c.ctx, db.AddCharacterSpellParams{
+			CharacterID:   dbChar.ID,
+			Name:          name,
+			Level:         0,
+			Prepared:      true,
+			Concentration: spell.Concentration,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, name := range c.selectedSpells {
+		spell, _ := character.FindSpell(name)
+		_, err = c.queries.AddCharacterSpell(c.ctx, db.AddCharacterSpellParams{
+			CharacterID:   dbChar.ID,
+			Name:          name,
+			Level:         1,
+			Prepared:      true,
+			Concentration: spell.Concentration,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *CreateScreen) View() string {
 	var b strings.Builder
 
 	// Progress indicator
-	steps := []string{"Info", "Race", "Class", "Abilities", "Skills", "Review"}
+	steps := c.stepNames()
 	stepIdx := c.currentStepIndex()
 	progress := ""
 	for i, s := range steps {
@@ -518,6 +892,10 @@ func (c *CreateScreen) View() string {
 		b.WriteString(c.viewPointBuy())
 	case StepSkills:
 		b.WriteString(c.viewSkills())
+	case StepCantrips:
+		b.WriteString(c.viewSpellSelection("Cantrips", c.availableCantrips, c.selectedCantrips, c.cantripsToSelect))
+	case StepSpells:
+		b.WriteString(c.viewSpellSelection(c.spellStepTitle(), c.availableSpells, c.selectedSpells, c.spellsToSelect))
 	case StepReview:
 		b.WriteString(c.viewReview())
 	}
@@ -537,6 +915,18 @@ func (c *CreateScreen) View() string {
 		b.String())
 }
 
+// stepNames returns the progress labels, including spells for casters
+func (c *CreateScreen) stepNames() []string {
+	if c.quick {
+		return []string{"Info", "Race", "Class", "Review"}
+	}
+	steps := []string{"Info", "Race", "Class", "Abilities", "Skills"}
+	if c.isCaster() {
+		steps = append(steps, "Spells")
+	}
+	return append(steps, "Review")
+}
+
 func (c *CreateScreen) currentStepIndex() int {
 	switch c.step {
 	case StepBasicInfo:
@@ -549,8 +939,10 @@ func (c *CreateScreen) currentStepIndex() int {
 		return 3
 	case StepSkills:
 		return 4
-	case StepReview:
+	case StepCantrips, StepSpells:
 		return 5
+	case StepReview:
+		return len(c.stepNames()) - 1
 	}
 	return 0
 }
@@ -559,10 +951,25 @@ func (c *CreateScreen) viewBasicInfo() string {
 	var b strings.Builder
 
 	b.WriteString(c.styles.Title.Render("Create Your Character"))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if c.quick {
+		b.WriteString(c.styles.Muted.Render("Quick create: pick a race and class, and the rest is filled in for you."))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	b.WriteString("Name:\n")
 	b.WriteString(c.styles.FocusedInput.Render(c.nameInput.View()))
+	b.WriteString("\n")
+	style := character.NameStyles[c.nameStyle]
+	if style == character.NamesAny {
+		style = "any"
+	}
+	if c.suggestedFor != "" {
+		b.WriteString(c.styles.Muted.Render(fmt.Sprintf("Suggested %s name (%s). ctrl+n for another.", c.suggestedFor, style)))
+	} else {
+		b.WriteString(c.styles.Muted.Render(fmt.Sprintf("Need a name? ctrl+n suggests one (%s).", style)))
+	}
 
 	return b.String()
 }
@@ -580,9 +987,12 @@ func (c *CreateScreen) viewRace() string {
 			cursor = "> "
 			style = c.styles.Selected
 		}
-		speed := character.RaceSpeed[race]
-		b.WriteString(c.styles.Cursor.Render(cursor))
-		b.WriteString(style.Render(fmt.Sprintf("%-12s (Speed: %d)", race, speed)))
+		traits := fmt.Sprintf("Speed: %d", character.RaceSpeed[race])
+		if dark := character.RaceDarkvision[race]; dark > 0 {
+			traits += fmt.Sprintf(", darkvision %d ft", dark)
+		}
+		b.WriteString(components.Mark(components.Item("race", i),
+			c.styles.Cursor.Render(cursor)+style.Render(fmt.Sprintf("%-12s (%s)", race, traits))))
 		b.WriteString("\n")
 	}
 
@@ -603,8 +1013,8 @@ func (c *CreateScreen) viewClass() string {
 			style = c.styles.Selected
 		}
 		hitDie := character.ClassHitDice[class]
-		b.WriteString(c.styles.Cursor.Render(cursor))
-		b.WriteString(style.Render(fmt.Sprintf("%-12s (Hit Die: d%d)", class, hitDie)))
+		b.WriteString(components.Mark(components.Item("class", i),
+			c.styles.Cursor.Render(cursor)+style.Render(fmt.Sprintf("%-12s (Hit Die: d%d)", class, hitDie))))
 		b.WriteString("\n")
 	}
 
@@ -633,10 +1043,8 @@ func (c *CreateScreen) viewAbilityMethod() string {
 			cursor = "> "
 			style = c.styles.Selected
 		}
-		b.WriteString(c.styles.Cursor.Render(cursor))
-		b.WriteString(style.Render(m.name))
-		b.WriteString("\n")
-		b.WriteString(c.styles.Muted.Render("    " + m.desc))
+		b.WriteString(components.Mark(components.Item("method", i),
+			c.styles.Cursor.Render(cursor)+style.Render(m.name)+"\n"+c.styles.Muted.Render("    "+m.desc)))
 		b.WriteString("\n")
 	}
 
@@ -663,11 +1071,11 @@ func (c *CreateScreen) viewAbilityAssignment() string {
 				break
 			}
 		}
+		chip := c.styles.SuccessText.Render(fmt.Sprintf("[%d]=%d", i+1, score))
 		if used {
-			b.WriteString(c.styles.Muted.Render(fmt.Sprintf("[%d]=%d ", i+1, score)))
-		} else {
-			b.WriteString(c.styles.SuccessText.Render(fmt.Sprintf("[%d]=%d ", i+1, score)))
+			chip = c.styles.Muted.Render(fmt.Sprintf("[%d]=%d", i+1, score))
 		}
+		b.WriteString(components.Mark(components.Item("score", i), chip) + " ")
 	}
 	b.WriteString("\n\n")
 
@@ -687,8 +1095,8 @@ func (c *CreateScreen) viewAbilityAssignment() string {
 			scoreStr = fmt.Sprintf("%2d (%s)", score, character.FormatModifierInt(mod))
 		}
 
-		b.WriteString(c.styles.Cursor.Render(cursor))
-		b.WriteString(style.Render(fmt.Sprintf("%-14s: %s", ability, scoreStr)))
+		b.WriteString(components.Mark(components.Item("ability", i),
+			c.styles.Cursor.Render(cursor)+style.Render(fmt.Sprintf("%-14s: %s", ability, scoreStr))))
 		b.WriteString("\n")
 	}
 
@@ -701,9 +1109,19 @@ func (c *CreateScreen) viewPointBuy() string {
 	b.WriteString(c.styles.Title.Render("Point Buy"))
 	b.WriteString("\n\n")
 
-	b.WriteString(fmt.Sprintf("Points remaining: %s\n\n",
+	b.WriteString(fmt.Sprintf("Points remaining: %s\n",
 		c.styles.StatValue.Render(fmt.Sprintf("%d", c.pointBuyState.PointsRemaining))))
 
+	// The class's three most important abilities
+	className := character.Classes[c.classIndex]
+	var key []string
+	for _, ability := range character.AbilityPriority(className)[:3] {
+		key = append(key, strings.ToUpper(ability[:3]))
+	}
+	b.WriteString(c.styles.Muted.Render(fmt.Sprintf("%s: %s first. Press a to spend the points that way.",
+		className, strings.Join(key, " > "))))
+	b.WriteString("\n\n")
+
 	for i, ability := range character.Abilities {
 		cursor := "  "
 		style := c.styles.Unselected
@@ -721,17 +1139,17 @@ func (c *CreateScreen) viewPointBuy() string {
 
 		arrows := ""
 		if canDec {
-			arrows += "◀ "
+			arrows += components.Mark(components.Item("less", i), "◀") + " "
 		} else {
 			arrows += "  "
 		}
 		if canInc {
-			arrows += " ▶"
+			arrows += " " + components.Mark(components.Item("more", i), "▶")
 		}
 
-		b.WriteString(c.styles.Cursor.Render(cursor))
-		b.WriteString(style.Render(fmt.Sprintf("%-14s: %2d (%s) cost:%d %s",
-			ability, score, character.FormatModifierInt(mod), cost, arrows)))
+		b.WriteString(components.Mark(components.Item("ability", i),
+			c.styles.Cursor.Render(cursor)+style.Render(fmt.Sprintf("%-14s: %2d (%s) cost:%d %s",
+				ability, score, character.FormatModifierInt(mod), cost, arrows))))
 		b.WriteString("\n")
 	}
 
@@ -763,11 +1181,98 @@ func (c *CreateScreen) viewSkills() string {
 			}
 		}
 
-		b.WriteString(c.styles.Cursor.Render(cursor))
-		b.WriteString(style.Render(fmt.Sprintf("%s %s", checkbox, skill)))
+		b.WriteString(components.Mark(components.Item("skill", i),
+			c.styles.Cursor.Render(cursor)+style.Render(fmt.Sprintf("%s %s", checkbox, skill))))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (c *CreateScreen) spellStepTitle() string {
+	className := character.Classes[c.classIndex]
+	info := character.ClassSpellcasting[className]
+	switch {
+	case className == "Wizard":
+		return "Spellbook Spells"
+	case info.Prepared:
+		return "Prepared 1st-Level Spells"
+	default:
+		return "1st-Level Spells"
+	}
+}
+
+// spellListHeight is the number of spells shown at once in the selection lists
+const spellListHeight = 12
+
+func (c *CreateScreen) viewSpellSelection(title string, spells []character.Spell, selected []string, toSelect int) string {
+	var b strings.Builder
+
+	className := character.Classes[c.classIndex]
+	b.WriteString(c.styles.Title.Render(fmt.Sprintf("Choose %d %s (%s)", toSelect, title, className)))
+	b.WriteString("\n\n")
+
+	b.WriteString(fmt.Sprintf("Selected: %d/%d\n\n", len(selected), toSelect))
+
+	// Window the list around the cursor so long spell lists fit the screen
+	start := 0
+	if c.spellCursor >= spellListHeight {
+		start = c.spellCursor - spellListHeight + 1
+	}
+	end := start + spellListHeight
+	if end > len(spells) {
+		end = len(spells)
+	}
+
+	if start > 0 {
+		b.WriteString(c.styles.Muted.Render("  ↑ more"))
+	}
+	b.WriteString("\n")
+
+	for i := start; i < end; i++ {
+		spell := spells[i]
+		cursor := "  "
+		style := c.styles.Unselected
+		if i == c.spellCursor {
+			cursor = "> "
+			style = c.styles.Selected
+		}
+
+		checkbox := "[ ]"
+		for _, s := range selected {
+			if s == spell.Name {
+				checkbox = "[x]"
+				break
+			}
+		}
+
+		tags := ""
+		if spell.Concentration {
+			tags += " (C)"
+		}
+		if spell.Ritual {
+			tags += " (R)"
+		}
+
+		b.WriteString(components.Mark(components.Item("spell", i),
+			c.styles.Cursor.Render(cursor)+style.Render(fmt.Sprintf("%s %-30s %s", checkbox, spell.Name+tags, spell.School))))
 		b.WriteString("\n")
 	}
 
+	if end < len(spells) {
+		b.WriteString(c.styles.Muted.Render("  ↓ more"))
+	}
+	b.WriteString("\n")
+
+	// Details for the highlighted spell
+	if c.spellCursor < len(spells) {
+		spell := spells[c.spellCursor]
+		b.WriteString("\n")
+		b.WriteString(c.styles.Muted.Render(fmt.Sprintf("%s • %s • %s", spell.CastingTime, spell.Range, spell.Duration)))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().Width(60).Render(spell.Description))
+	}
+
 	return b.String()
 }
 
@@ -783,22 +1288,21 @@ func (c *CreateScreen) viewReview() string {
 	b.WriteString(fmt.Sprintf("Name:       %s\n", c.nameInput.Value()))
 	b.WriteString(fmt.Sprintf("Race:       %s\n", character.Races[c.raceIndex]))
 	b.WriteString(fmt.Sprintf("Class:      %s\n", character.Classes[c.classIndex]))
+	b.WriteString(fmt.Sprintf("Rules:      %s\n", character.Rulesets()[c.rulesetIndex].Name()))
 	b.WriteString("\n")
 
 	// Abilities
 	b.WriteString(c.styles.Header.Render("Ability Scores"))
 	b.WriteString("\n")
+	if c.quick {
+		b.WriteString(c.styles.Muted.Render("Standard array, highest scores in " + strings.Join(character.AbilityPriority(character.Classes[c.classIndex])[:2], " and ")))
+		b.WriteString("\n")
+	}
 
-	for i, ability := range character.Abilities {
-		var score int
-		if c.pointBuyState != nil {
-			score = c.pointBuyState.Scores[ability]
-		} else if scoreIdx, ok := c.assignedScores[ability]; ok {
-			score = c.rolledScores[scoreIdx]
-		}
+	for _, ability := range character.Abilities {
+		score := c.abilityScore(ability)
 		mod := character.AbilityModifier(score)
 		b.WriteString(fmt.Sprintf("%-14s: %2d (%s)\n", ability, score, character.FormatModifierInt(mod)))
-		_ = i
 	}
 	b.WriteString("\n")
 
@@ -810,7 +1314,42 @@ func (c *CreateScreen) viewReview() string {
 	}
 	b.WriteString("\n")
 
-	b.WriteString(c.styles.SuccessText.Render("Create this character? (y/n)"))
+	// Spellcasting
+	if c.isCaster() {
+		className := character.Classes[c.classIndex]
+		info := character.ClassSpellcasting[className]
+		score := c.abilityScore(info.Ability)
+
+		b.WriteString(c.styles.Header.Render("Spellcasting"))
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("Save DC %d • Attack %s (%s)\n",
+			character.SpellSaveDC(score, 1),
+			character.FormatModifierInt(character.SpellAttackBonus(score, 1)),
+			info.Ability))
+		b.WriteString(fmt.Sprintf("Cantrips:  %s\n", strings.Join(c.selectedCantrips, ", ")))
+		b.WriteString(fmt.Sprintf("1st Level: %s\n", strings.Join(c.selectedSpells, ", ")))
+		b.WriteString("\n")
+	}
+
+	issues := c.startingIssues()
+	if len(issues) > 0 {
+		b.WriteString(c.styles.Header.Render("Rule Deviations"))
+		b.WriteString("\n")
+		for _, issue := range issues {
+			b.WriteString(c.styles.WarningText.Render("  ! " + issue))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	switch {
+	case len(issues) > 0 && c.houseRules:
+		b.WriteString(c.styles.WarningText.Render("Keep these as house rules and create the character? (y/n)"))
+	case len(issues) > 0:
+		b.WriteString(c.styles.WarningText.Render("Create this character anyway? (y/n)"))
+	default:
+		b.WriteString(c.styles.SuccessText.Render("Create this character? (y/n)"))
+	}
 
 	return b.String()
 }
@@ -818,7 +1357,7 @@ func (c *CreateScreen) viewReview() string {
 func (c *CreateScreen) getHelp() string {
 	switch c.step {
 	case StepBasicInfo:
-		return "enter: continue • esc: back"
+		return "enter: continue • ctrl+n: suggest name • ctrl+g: name style • esc: back"
 	case StepRace, StepClass, StepAbilityMethod:
 		return "↑/↓: select • enter: confirm • esc: back"
 	case StepAbilityRoll:
@@ -826,11 +1365,14 @@ func (c *CreateScreen) getHelp() string {
 	case StepAbilityArray:
 		return "↑/↓: select ability • 1-6: assign score • enter: confirm • esc: back"
 	case StepAbilityPointBuy:
-		return "↑/↓: select • ←/→: adjust • enter: confirm • esc: back"
-	case StepSkills:
+		return "↑/↓: select • ←/→: adjust • a: recommended • enter: confirm • esc: back"
+	case StepSkills, StepCantrips, StepSpells:
 		return "↑/↓: navigate • space: toggle • enter: confirm • esc: back"
 	case StepReview:
-		return "y: create • n: start over • esc: back"
+		if c.random {
+			return "y: create • R: reroll • r: change rules • n: start over • esc: back"
+		}
+		return "y: create • r: change rules • n: start over • esc: back"
 	}
 	return ""
 }
@@ -0,0 +1,15 @@
+package screens
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// ErrorMsg reports that something a screen did failed, usually because the
+// database is unreachable. The session shows it under the screen, whichever
+// screen sent it, and the screen keeps what it showed before.
+type ErrorMsg struct {
+	Action string // what failed, worded to follow "Couldn't"
+	Err    error
+
+	// Retry tries again, on ctrl+t or once the database is back; nil when
+	// there's nothing to retry
+	Retry tea.Cmd
+}
@@ -0,0 +1,351 @@
+package screens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/audit"
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// healthIssue is an inconsistency in a character's stored data, usually
+// left by an older version or made through the API, and how to fix it
+type healthIssue struct {
+	problem string
+	repair  func(ctx context.Context, queries *db.Queries) error
+}
+
+type healthCheckedMsg struct {
+	issues []healthIssue
+}
+
+type healthRepairedMsg struct {
+	character db.Character
+	repaired  int
+	err       error
+}
+
+// checkHealth looks for problems with the character's data when the sheet
+// opens, so they can be offered for repair
+func (s *SheetScreen) checkHealth() tea.Cmd {
+	ctx, queries, char := s.ctx, s.queries, s.char
+	return func() tea.Msg {
+		issues, err := findHealthIssues(ctx, queries, char)
+		if err != nil || len(issues) == 0 {
+			return nil
+		}
+		return healthCheckedMsg{issues: issues}
+	}
+}
+
+func findHealthIssues(ctx context.Context, queries *db.Queries, char db.Character) ([]healthIssue, error) {
+	var issues []healthIssue
+	rules := character.RulesetFor(char.Ruleset)
+	level := int(char.Level)
+
+	plan, err := queries.GetCharacterPlan(ctx, char.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Spellcasting stats are set at creation and weren't updated on level
+	// up, and characters created through the API never had them
+	sc, err := queries.GetCharacterSpellcasting(ctx, char.ID)
+	info, caster := character.ClassSpellcasting[char.Class]
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		if caster {
+			issues = append(issues, healthIssue{
+				problem: "No spellcasting stats for a " + char.Class,
+				repair: func(ctx context.Context, queries *db.Queries) error {
+					score := abilityScore(char, info.Ability)
+					_, err := queries.CreateCharacterSpellcasting(ctx, db.CreateCharacterSpellcastingParams{
+						CharacterID:         char.ID,
+						SpellcastingClass:   char.Class,
+						SpellcastingAbility: info.Ability,
+						SpellSaveDc:         int32(character.SpellSaveDC(score, level)),
+						SpellAttackBonus:    int32(character.SpellAttackBonus(score, level)),
+						SlotsMax:            slotCounts(rules.SpellSlots(char.Class, classLevel(char, plan))),
+					})
+					return err
+				},
+			})
+		}
+	case err != nil:
+		return nil, err
+	default:
+		if params, stale := spellcastingForLevel(char, sc, plan); stale {
+			issues = append(issues, healthIssue{
+				problem: fmt.Sprintf("Spell slots, save DC, or attack bonus are out of date for level %d", level),
+				repair: func(ctx context.Context, queries *db.Queries) error {
+					return queries.UpdateCharacterSpellcasting(ctx, params)
+				},
+			})
+		}
+	}
+
+	if maxHP := char.MaxHitPoints + char.MaxHitPointsBonus; char.CurrentHitPoints > maxHP {
+		issues = append(issues, healthIssue{
+			problem: fmt.Sprintf("HP %d is above the maximum of %d", char.CurrentHitPoints, maxHP),
+			repair: func(ctx context.Context, queries *db.Queries) error {
+				_, err := queries.UpdateCharacterHitPoints(ctx, db.UpdateCharacterHitPointsParams{
+					ID:                 char.ID,
+					CurrentHitPoints:   maxHP,
+					TemporaryHitPoints: char.TemporaryHitPoints,
+				})
+				return err
+			},
+		})
+	}
+
+	// Class resources are kept up to date on level up, but characters
+	// created before they were tracked, or through the API, have none
+	resources, err := queries.GetCharacterResources(ctx, char.ID)
+	if err != nil {
+		return nil, err
+	}
+	if changes := classResourceChanges(char, resources); changes.stale() {
+		issues = append(issues, healthIssue{
+			problem: "Class resources such as Ki or Rage are missing or out of date",
+			repair:  changes.apply,
+		})
+	}
+
+	items, err := queries.GetCharacterInventory(ctx, char.ID)
+	if err != nil {
+		return nil, err
+	}
+	layout := newInventoryLayout(items)
+	for _, item := range items {
+		if strings.TrimSpace(item.Location) == "" || layout.location(item) != "" {
+			continue
+		}
+		issues = append(issues, healthIssue{
+			problem: fmt.Sprintf("%s is stored in a %s the character doesn't have", item.Name, item.Location),
+			repair: func(ctx context.Context, queries *db.Queries) error {
+				_, err := queries.SetInventoryItemLocation(ctx, db.SetInventoryItemLocationParams{ID: item.ID, Location: ""})
+				return err
+			},
+		})
+	}
+
+	if ac := armorClass(char, items).Total; int32(ac) != char.ArmorClass {
+		issues = append(issues, healthIssue{
+			problem: fmt.Sprintf("AC %d doesn't match the %d worked out from equipped items", char.ArmorClass, ac),
+			repair: func(ctx context.Context, queries *db.Queries) error {
+				_, err := recalculateArmorClass(ctx, queries, char.ID)
+				return err
+			},
+		})
+	}
+
+	return issues, nil
+}
+
+// repairHealth fixes the issues found when the sheet opened
+func (s *SheetScreen) repairHealth() tea.Cmd {
+	ctx, queries, userID, char, issues := s.ctx, s.queries, s.userID, s.char, s.health
+	return func() tea.Msg {
+		for _, issue := range issues {
+			if err := issue.repair(ctx, queries); err != nil {
+				return healthRepairedMsg{err: err}
+			}
+		}
+		problems := make([]string, len(issues))
+		for i, issue := range issues {
+			problems[i] = issue.problem
+		}
+		audit.Record(ctx, queries, char.ID, userID, audit.KindRepaired, "Repaired: "+strings.Join(problems, "; "))
+
+		updated, err := queries.GetCharacterByID(ctx, char.ID)
+		return healthRepairedMsg{character: updated, repaired: len(issues), err: err}
+	}
+}
+
+func (s *SheetScreen) handleHealthRepaired(msg healthRepairedMsg) (tea.Model, tea.Cmd) {
+	s.mode = ModeView
+	s.health = nil
+	if msg.err != nil {
+		s.status = "Error: " + msg.err.Error()
+		return s, nil
+	}
+	s.char = msg.character
+	s.status = fmt.Sprintf("Repaired %d problem(s)", msg.repaired)
+
+	updated := msg.character
+	changed := partSpells | partEvents | partInventory | partResources
+	s.cache.invalidate(updated.ID, changed)
+	return s, tea.Batch(
+		func() tea.Msg { return CharacterUpdatedMsg{Character: updated} },
+		s.loadParts(changed),
+	)
+}
+
+// viewHealth lists the problems found and asks to repair them
+func (s *SheetScreen) viewHealth() string {
+	var b strings.Builder
+	b.WriteString(s.styles.Header.Render("Repair " + s.char.Name + "?"))
+	b.WriteString("\n\n")
+	b.WriteString("Some of this character's data doesn't fit together, probably from an\nolder version of the app:\n\n")
+	for _, issue := range s.health {
+		b.WriteString(s.styles.WarningText.Render("• " + issue.problem))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(s.styles.Muted.Render("Repairs are recorded on the History tab."))
+
+	return lipgloss.NewStyle().
+		Align(lipgloss.Left).
+		Render(b.String())
+}
+
+// resourceChanges are the class resources a character should have that are
+// missing or have the wrong uses, and the ones its class no longer has
+type resourceChanges struct {
+	characterID pgtype.UUID
+	set         []character.Resource
+	remove      []string
+}
+
+// classResourceChanges compares a character's stored resources with what its
+// class has at its level. Only the character's starting class counts, since
+// levels taken in other classes aren't recorded.
+func classResourceChanges(char db.Character, stored []db.CharacterResource) resourceChanges {
+	changes := resourceChanges{characterID: char.ID}
+	want := character.RulesetFor(char.Ruleset).ClassResources(char.Class, int(char.Level), int(char.Charisma))
+	for _, r := range want {
+		i := slices.IndexFunc(stored, func(s db.CharacterResource) bool { return s.Name == r.Name })
+		if i < 0 || stored[i].MaxUses != int32(r.Max) || stored[i].Recharge != r.Recharge {
+			changes.set = append(changes.set, r)
+		}
+	}
+	for _, s := range stored {
+		has := slices.ContainsFunc(want, func(r character.Resource) bool { return r.Name == s.Name })
+		if !has && character.IsClassResource(s.Name) {
+			changes.remove = append(changes.remove, s.Name)
+		}
+	}
+	return changes
+}
+
+func (c resourceChanges) stale() bool {
+	return len(c.set) > 0 || len(c.remove) > 0
+}
+
+// apply writes the changes, keeping uses spent so far up to the new maximum
+func (c resourceChanges) apply(ctx context.Context, queries *db.Queries) error {
+	for _, r := range c.set {
+		if _, err := queries.UpsertCharacterResource(ctx, db.UpsertCharacterResourceParams{
+			CharacterID: c.characterID,
+			Name:        r.Name,
+			MaxUses:     int32(r.Max),
+			Recharge:    r.Recharge,
+		}); err != nil {
+			return err
+		}
+	}
+	for _, name := range c.remove {
+		if err := queries.DeleteCharacterResource(ctx, db.DeleteCharacterResourceParams{CharacterID: c.characterID, Name: name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncClassResources brings a character's class resources up to its level
+func syncClassResources(ctx context.Context, queries *db.Queries, char db.Character) error {
+	stored, err := queries.GetCharacterResources(ctx, char.ID)
+	if err != nil {
+		return err
+	}
+	return classResourceChanges(char, stored).apply(ctx, queries)
+}
+
+// classLevel is how many of a character's levels are in its starting class.
+// Levels the build plan shows were taken in another class don't count; the
+// first level is always in the starting class.
+func classLevel(char db.Character, plan []db.CharacterPlanLevel) int {
+	level := int(char.Level)
+	for _, row := range plan {
+		if row.Level > 1 && row.Level <= char.Level && !strings.EqualFold(row.Class, char.Class) {
+			level--
+		}
+	}
+	return max(level, 1)
+}
+
+// spellcastingForLevel works out a character's spellcasting stats for its
+// current level, keeping the slots spent so far up to the new maximum, and
+// reports whether they differ from what's stored. Slots follow the levels
+// in the casting class, and the save DC and attack bonus the total level.
+func spellcastingForLevel(char db.Character, sc db.CharacterSpellcasting, plan []db.CharacterPlanLevel) (db.UpdateCharacterSpellcastingParams, bool) {
+	level := int(char.Level)
+	slots := character.RulesetFor(char.Ruleset).SpellSlots(sc.SpellcastingClass, classLevel(char, plan))
+	if slots == nil {
+		// The ruleset doesn't model slots, so there's nothing to compare
+		return db.UpdateCharacterSpellcastingParams{}, false
+	}
+	score := abilityScore(char, sc.SpellcastingAbility)
+	dc := int32(character.SpellSaveDC(score, level))
+	attack := int32(character.SpellAttackBonus(score, level))
+	slotsMax := slotCounts(slots)
+	if character.SpellcastingBaseClass(sc.SpellcastingClass) != char.Class {
+		// A class multiclassed into has slots for the levels taken in
+		// it, which aren't recorded
+		slotsMax = sc.SlotsMax
+	}
+
+	used := make([]int32, len(slotsMax))
+	for i := range used {
+		if i < len(sc.SlotsUsed) {
+			used[i] = min(sc.SlotsUsed[i], slotsMax[i])
+		}
+	}
+	stale := dc != sc.SpellSaveDc || attack != sc.SpellAttackBonus || !slices.Equal(slotsMax, sc.SlotsMax)
+	return db.UpdateCharacterSpellcastingParams{
+		CharacterID:      char.ID,
+		SpellSaveDc:      dc,
+		SpellAttackBonus: attack,
+		SlotsMax:         slotsMax,
+		SlotsUsed:        used,
+	}, stale
+}
+
+// slotCounts converts spell slot maxima for storage
+func slotCounts(slots []int) []int32 {
+	counts := make([]int32, 9)
+	for i, n := range slots {
+		if i < len(counts) {
+			counts[i] = int32(n)
+		}
+	}
+	return counts
+}
+
+// abilityScore returns the character's score for an ability named in any
+// case, e.g. "Wisdom" or "wisdom"
+func abilityScore(char db.Character, ability string) int {
+	switch strings.ToLower(ability) {
+	case "strength":
+		return int(char.Strength)
+	case "dexterity":
+		return int(char.Dexterity)
+	case "constitution":
+		return int(char.Constitution)
+	case "intelligence":
+		return int(char.Intelligence)
+	case "wisdom":
+		return int(char.Wisdom)
+	case "charisma":
+		return int(char.Charisma)
+	}
+	return 10
+}
@@ -2,11 +2,17 @@ package screens
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/brady1408/dnd/internal/audit"
+	"github.com/brady1408/dnd/internal/auth"
 	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/sanitize"
+	"github.com/brady1408/dnd/internal/tui/components"
 	"github.com/brady1408/dnd/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -23,9 +29,54 @@ type HomeScreen struct {
 	width         int
 	height        int
 	confirmDelete bool
+
+	// New name for the selected character, while renaming
+	renameInput textinput.Model
+	renaming    bool
+
+	// Color and icon for the selected character, while picking them
+	appearance appearancePicker
+	styling    bool
+
+	// Search, sort, and paging
+	searchInput textinput.Model
+	searching   bool
+	sortIndex   int
+	page        int
+	total       int64
+
+	// Character marked with "v" to compare against
+	compareWith *db.Character
+
+	// Drawn portraits of the listed characters that have one
+	portraits map[pgtype.UUID]string
+
+	// templates switches the list to saved templates
+	templates bool
+	status    string
+
+	// ctrl+k command palette
+	palette     components.Palette
+	paletteOpen bool
 }
 
-type NavigateToCreateMsg struct{}
+// homePageSize is how many characters are listed per page
+const homePageSize = 10
+
+// homeSortOptions are the character list orderings, cycled with "s"
+var homeSortOptions = []struct {
+	key   string
+	label string
+}{
+	{"last_played", "last played"},
+	{"name", "name"},
+	{"level", "level"},
+}
+
+type NavigateToCreateMsg struct {
+	Quick  bool // only ask for name, race, and class
+	Random bool // make every choice at random and go straight to review
+}
 type CharacterSelectedMsg struct {
 	Character db.Character
 }
@@ -34,21 +85,40 @@ type CharacterDeletedMsg struct {
 }
 type LogoutMsg struct{}
 
-func NewHomeScreen(ctx context.Context, queries *db.Queries, user *db.User, s *styles.Styles) *HomeScreen {
-	return &HomeScreen{
-		ctx:     ctx,
-		queries: queries,
-		user:    user,
-		styles:  s,
-		width:   80,
-		height:  24,
-	}
+type characterCopiedMsg struct {
+	Status string
 }
 
-func (h *HomeScreen) SetCharacters(chars []db.Character) {
-	h.characters = chars
-	if h.selectedIndex >= len(chars) && len(chars) > 0 {
-		h.selectedIndex = len(chars) - 1
+type homeErrorMsg struct {
+	Err error
+}
+
+type characterRenamedMsg struct {
+	Status string
+}
+
+func NewHomeScreen(ctx context.Context, queries *db.Queries, user *db.User, s *styles.Styles) *HomeScreen {
+	searchInput := textinput.New()
+	searchInput.Placeholder = "name, race, or class"
+	searchInput.Prompt = "/"
+	searchInput.CharLimit = 50
+	searchInput.Width = 30
+
+	renameInput := textinput.New()
+	renameInput.Placeholder = "New name"
+	renameInput.CharLimit = sanitize.MaxName
+	renameInput.Width = 30
+
+	return &HomeScreen{
+		ctx:         ctx,
+		queries:     queries,
+		user:        user,
+		styles:      s,
+		searchInput: searchInput,
+		renameInput: renameInput,
+		palette:     components.NewPalette(),
+		width:       80,
+		height:      24,
 	}
 }
 
@@ -57,17 +127,235 @@ func (h *HomeScreen) Init() tea.Cmd {
 }
 
 func (h *HomeScreen) loadCharacters() tea.Cmd {
+	search := h.searchInput.Value()
+	pattern := fuzzyPattern(search)
+	sortBy := homeSortOptions[h.sortIndex].key
+	page := h.page
+	templates := h.templates
+
+	// Retrying loads the same page, even if the search has changed since
+	var load tea.Cmd
+	load = func() tea.Msg {
+		total, err := h.queries.CountCharactersByUserID(h.ctx, db.CountCharactersByUserIDParams{
+			UserID:    h.user.ID,
+			Templates: templates,
+			Search:    pattern,
+		})
+		if err != nil {
+			return ErrorMsg{Action: "load your characters", Err: err, Retry: load}
+		}
+
+		chars, err := h.queries.GetCharactersByUserIDPaged(h.ctx, db.GetCharactersByUserIDPagedParams{
+			UserID:     h.user.ID,
+			Templates:  templates,
+			Search:     pattern,
+			SortBy:     sortBy,
+			PageLimit:  homePageSize,
+			PageOffset: int32(page * homePageSize),
+		})
+		if err != nil {
+			return ErrorMsg{Action: "load your characters", Err: err, Retry: load}
+		}
+
+		portraits, err := h.queries.GetUserCharacterPortraits(h.ctx, h.user.ID)
+		if err != nil {
+			return ErrorMsg{Action: "load your characters", Err: err, Retry: load}
+		}
+		return CharactersLoadedMsg{Characters: chars, Portraits: portraits, Total: total, Search: search, Templates: templates}
+	}
+	return load
+}
+
+// uniqueNames is set when each player's characters must have different
+// names. Templates are exempt.
+var uniqueNames bool
+
+// RequireUniqueNames stops players giving two of their characters the same
+// name, ignoring case
+func RequireUniqueNames() {
+	uniqueNames = true
+}
+
+var errNameTaken = errors.New("you already have a character with that name")
+
+// checkName returns errNameTaken if names must be unique and another of the
+// user's characters already has this one
+func checkName(ctx context.Context, queries *db.Queries, userID pgtype.UUID, name string, exclude pgtype.UUID) error {
+	if !uniqueNames {
+		return nil
+	}
+	taken, err := queries.CharacterNameTaken(ctx, db.CharacterNameTakenParams{
+		UserID:    userID,
+		Name:      name,
+		ExcludeID: exclude,
+	})
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errNameTaken
+	}
+	return nil
+}
+
+// freeName returns name, or name with a number after it if names must be
+// unique and it's already taken
+func freeName(ctx context.Context, queries *db.Queries, userID pgtype.UUID, name string) (string, error) {
+	candidate := name
+	for n := 2; ; n++ {
+		err := checkName(ctx, queries, userID, candidate, pgtype.UUID{})
+		if !errors.Is(err, errNameTaken) {
+			return candidate, err
+		}
+		candidate = sanitize.Line(fmt.Sprintf("%s (%d)", name, n), sanitize.MaxName)
+	}
+}
+
+// renameCharacter changes a character's name, recording the old one in the
+// change log
+func renameCharacter(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, name string) (db.Character, error) {
+	name = sanitize.Line(name, sanitize.MaxName)
+	if name == "" {
+		return db.Character{}, errors.New("name can't be empty")
+	}
+	if !char.IsTemplate {
+		if err := checkName(ctx, queries, char.UserID, name, char.ID); err != nil {
+			return db.Character{}, err
+		}
+	}
+	renamed, err := queries.UpdateCharacterName(ctx, db.UpdateCharacterNameParams{ID: char.ID, Name: name})
+	if err != nil {
+		return db.Character{}, err
+	}
+	audit.Record(ctx, queries, renamed.ID, userID, audit.KindRenamed, fmt.Sprintf("Renamed from %s to %s", char.Name, renamed.Name))
+	return renamed, nil
+}
+
+// copyCharacter clones a character with its spells, build plan, inventory,
+// journal, and portrait. Copies that aren't templates get a free name when
+// names must be unique.
+func copyCharacter(ctx context.Context, queries *db.Queries, src db.Character, name string, template bool) (db.Character, error) {
+	name = sanitize.Line(name, sanitize.MaxName)
+	if !template {
+		var err error
+		if name, err = freeName(ctx, queries, src.UserID, name); err != nil {
+			return db.Character{}, err
+		}
+	}
+	copied, err := queries.CopyCharacter(ctx, db.CopyCharacterParams{
+		Name:       name,
+		IsTemplate: template,
+		ID:         src.ID,
+	})
+	if err != nil {
+		return db.Character{}, err
+	}
+
+	err = queries.CopyCharacterSpellcasting(ctx, db.CopyCharacterSpellcastingParams{NewID: copied.ID, SourceID: src.ID})
+	if err == nil {
+		err = queries.CopyCharacterSpells(ctx, db.CopyCharacterSpellsParams{NewID: copied.ID, SourceID: src.ID})
+	}
+	if err == nil {
+		err = queries.CopyCharacterPlan(ctx, db.CopyCharacterPlanParams{NewID: copied.ID, SourceID: src.ID})
+	}
+	if err == nil {
+		err = queries.CopyCharacterInventory(ctx, db.CopyCharacterInventoryParams{NewID: copied.ID, SourceID: src.ID})
+	}
+	if err == nil {
+		err = queries.CopyCharacterJournal(ctx, db.CopyCharacterJournalParams{NewID: copied.ID, SourceID: src.ID})
+	}
+	if err == nil {
+		err = queries.CopyCharacterResources(ctx, db.CopyCharacterResourcesParams{NewID: copied.ID, SourceID: src.ID})
+	}
+	if err == nil {
+		err = queries.CopyCharacterPortrait(ctx, db.CopyCharacterPortraitParams{NewID: copied.ID, SourceID: src.ID})
+	}
+	if err == nil {
+		err = queries.CopyCharacterCrafting(ctx, db.CopyCharacterCraftingParams{NewID: copied.ID, SourceID: src.ID})
+	}
+	if err != nil {
+		// Don't leave a partial copy behind
+		_ = queries.DeleteCharacter(ctx, copied.ID)
+		return db.Character{}, err
+	}
+
+	description := "Copied from " + src.Name
+	if src.IsTemplate {
+		description = "Created from template " + src.Name
+	} else if template {
+		description = "Saved as a template from " + src.Name
+	}
+	audit.Record(ctx, queries, copied.ID, copied.UserID, audit.KindCopied, description)
+	return copied, nil
+}
+
+func (h *HomeScreen) copySelected(name string, template bool, status string) tea.Cmd {
+	src := h.characters[h.selectedIndex]
+	return func() tea.Msg {
+		if _, err := copyCharacter(h.ctx, h.queries, src, name, template); err != nil {
+			return homeErrorMsg{Err: err}
+		}
+		return characterCopiedMsg{Status: status}
+	}
+}
+
+// stampTemplate creates a character from the selected template and opens it
+func (h *HomeScreen) stampTemplate() tea.Cmd {
+	src := h.characters[h.selectedIndex]
 	return func() tea.Msg {
-		chars, err := h.queries.GetCharactersByUserID(h.ctx, h.user.ID)
+		char, err := copyCharacter(h.ctx, h.queries, src, src.Name, false)
 		if err != nil {
-			return nil
+			return homeErrorMsg{Err: err}
 		}
-		return CharactersLoadedMsg{Characters: chars}
+		return CharacterSelectedMsg{Character: char}
 	}
 }
 
+// fuzzyPattern turns a search into an ILIKE pattern matching its letters in
+// order, so "hlf" finds "Half-Elf"
+func fuzzyPattern(search string) string {
+	search = strings.TrimSpace(search)
+	if search == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("%")
+	for _, r := range search {
+		if r == '%' || r == '_' || r == '\\' {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+		b.WriteString("%")
+	}
+	return b.String()
+}
+
+// pageCount returns the number of pages, at least one
+// withPortrait shows the selected character's portrait to the right of
+// the list when there's room
+func (h *HomeScreen) withPortrait(list string) string {
+	if h.selectedIndex >= len(h.characters) {
+		return list
+	}
+	art := h.portraits[h.characters[h.selectedIndex].ID]
+	if art == "" || lipgloss.Width(list)+lipgloss.Width(art)+4 > h.width {
+		return list
+	}
+	list = strings.TrimSuffix(list, "\n")
+	return lipgloss.JoinHorizontal(lipgloss.Top, list, "    ", art) + "\n"
+}
+
+func (h *HomeScreen) pageCount() int {
+	return max(1, int((h.total+homePageSize-1)/homePageSize))
+}
+
 type CharactersLoadedMsg struct {
 	Characters []db.Character
+	Portraits  []db.CharacterPortrait
+	Total      int64
+	Search     string // the search the page was loaded for
+	Templates  bool
 }
 
 func (h *HomeScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -77,18 +365,151 @@ func (h *HomeScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h.height = msg.Height
 
 	case CharactersLoadedMsg:
+		// Ignore results for a search that has since changed
+		if msg.Search != h.searchInput.Value() || msg.Templates != h.templates {
+			return h, nil
+		}
 		h.characters = msg.Characters
+		h.total = msg.Total
+		h.portraits = make(map[pgtype.UUID]string)
+		for _, p := range msg.Portraits {
+			h.portraits[p.CharacterID] = h.styles.Portrait(portraitOf(p))
+		}
+		if h.page >= h.pageCount() {
+			h.page = h.pageCount() - 1
+			return h, h.loadCharacters()
+		}
+		if h.selectedIndex > h.maxIndex() {
+			h.selectedIndex = h.maxIndex()
+		}
+
+	case characterCopiedMsg:
+		h.status = msg.Status
+		return h, h.loadCharacters()
+
+	case homeErrorMsg:
+		h.status = "Error: " + msg.Err.Error()
+
+	case characterRenamedMsg:
+		h.renaming = false
+		h.renameInput.Blur()
+		h.status = msg.Status
+		return h, h.loadCharacters()
+
+	case appearanceSavedMsg:
+		h.styling = false
+		h.status = msg.Status
+		return h, h.loadCharacters()
+
+	case components.ClickMsg:
+		h.status = ""
+		return h.click(msg.Zone)
 
 	case tea.KeyMsg:
+		h.status = ""
+		if h.paletteOpen {
+			return h.handlePaletteInput(msg)
+		}
 		if h.confirmDelete {
 			return h.handleDeleteConfirm(msg)
 		}
+		if h.searching {
+			return h.handleSearchInput(msg)
+		}
+		if h.renaming {
+			return h.handleRenameInput(msg)
+		}
+		if h.styling {
+			return h.updateAppearance(msg)
+		}
 		return h.handleInput(msg)
 	}
 
+	if h.searching {
+		var cmd tea.Cmd
+		h.searchInput, cmd = h.searchInput.Update(msg)
+		return h, cmd
+	}
+
 	return h, nil
 }
 
+func (h *HomeScreen) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "down":
+		// Keep the filter and go back to the list
+		h.searching = false
+		h.searchInput.Blur()
+		return h, nil
+	case "esc":
+		h.searching = false
+		h.searchInput.Blur()
+		h.searchInput.SetValue("")
+		h.page = 0
+		h.selectedIndex = 0
+		return h, h.loadCharacters()
+	}
+
+	before := h.searchInput.Value()
+	var cmd tea.Cmd
+	h.searchInput, cmd = h.searchInput.Update(msg)
+	if h.searchInput.Value() != before {
+		h.page = 0
+		h.selectedIndex = 0
+		return h, tea.Batch(cmd, h.loadCharacters())
+	}
+	return h, cmd
+}
+
+func (h *HomeScreen) handlePaletteInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	chosen, closed, cmd := h.palette.Update(msg)
+	if !closed {
+		return h, cmd
+	}
+	h.paletteOpen = false
+	if chosen == nil {
+		return h, nil
+	}
+	return h, chosen.Run()
+}
+
+func (h *HomeScreen) handleRenameInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		if h.selectedIndex >= len(h.characters) {
+			h.renaming = false
+			return h, nil
+		}
+		char := h.characters[h.selectedIndex]
+		name := h.renameInput.Value()
+		userID := h.user.ID
+		return h, func() tea.Msg {
+			renamed, err := renameCharacter(h.ctx, h.queries, userID, char, name)
+			if err != nil {
+				return homeErrorMsg{Err: err}
+			}
+			return characterRenamedMsg{Status: "Renamed " + char.Name + " to " + renamed.Name + "."}
+		}
+	case "esc":
+		h.renaming = false
+		h.renameInput.Blur()
+		return h, nil
+	}
+
+	var cmd tea.Cmd
+	h.renameInput, cmd = h.renameInput.Update(msg)
+	return h, cmd
+}
+
+// maxIndex is the last selectable entry: the "Create New Character" option
+// after the characters, or the last template
+func (h *HomeScreen) maxIndex() int {
+	if h.templates {
+		return max(0, len(h.characters)-1)
+	}
+	return len(h.characters)
+}
+
 func (h *HomeScreen) handleInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "up", "k":
@@ -97,30 +518,153 @@ func (h *HomeScreen) handleInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case "down", "j":
-		// +1 for "Create New Character" option
-		maxIndex := len(h.characters)
-		if h.selectedIndex < maxIndex {
+		if h.selectedIndex < h.maxIndex() {
 			h.selectedIndex++
 		}
 
 	case "enter":
-		if h.selectedIndex == len(h.characters) {
+		if h.selectedIndex >= len(h.characters) {
+			if h.templates {
+				return h, nil
+			}
 			// Create new character
 			return h, func() tea.Msg { return NavigateToCreateMsg{} }
 		}
-		if h.selectedIndex < len(h.characters) {
+		if h.templates {
+			return h, h.stampTemplate()
+		}
+		char := h.characters[h.selectedIndex]
+		return h, func() tea.Msg { return CharacterSelectedMsg{Character: char} }
+
+	case "e":
+		// Open a template's sheet to edit it
+		if h.templates && h.selectedIndex < len(h.characters) {
 			char := h.characters[h.selectedIndex]
 			return h, func() tea.Msg { return CharacterSelectedMsg{Character: char} }
 		}
 
+	case "c":
+		if h.selectedIndex < len(h.characters) {
+			src := h.characters[h.selectedIndex]
+			return h, h.copySelected(src.Name+" (copy)", h.templates, "Copied "+src.Name+".")
+		}
+
+	case "T":
+		if !h.templates && h.selectedIndex < len(h.characters) {
+			src := h.characters[h.selectedIndex]
+			return h, h.copySelected(src.Name, true, "Saved "+src.Name+" as a template.")
+		}
+
+	case "tab":
+		h.templates = !h.templates
+		h.compareWith = nil
+		h.page = 0
+		h.selectedIndex = 0
+		h.characters = nil
+		return h, h.loadCharacters()
+
 	case "d", "delete":
 		if h.selectedIndex < len(h.characters) {
 			h.confirmDelete = true
 		}
 
+	case "f":
+		if !h.templates {
+			return h, func() tea.Msg { return NavigateToCreateMsg{Quick: true} }
+		}
+
+	case "R":
+		if !h.templates {
+			return h, func() tea.Msg { return NavigateToCreateMsg{Random: true} }
+		}
+
+	case "r":
+		if h.selectedIndex < len(h.characters) {
+			h.renaming = true
+			h.renameInput.SetValue(h.characters[h.selectedIndex].Name)
+			h.renameInput.CursorEnd()
+			h.renameInput.Focus()
+			return h, textinput.Blink
+		}
+
+	case "p":
+		if h.selectedIndex < len(h.characters) {
+			h.styling = true
+			h.appearance = newAppearancePicker(h.characters[h.selectedIndex])
+		}
+
+	case "v":
+		if h.selectedIndex >= len(h.characters) {
+			return h, nil
+		}
+		char := h.characters[h.selectedIndex]
+		if h.compareWith == nil {
+			h.compareWith = &char
+			return h, nil
+		}
+		if h.compareWith.ID == char.ID {
+			h.compareWith = nil
+			return h, nil
+		}
+		left := *h.compareWith
+		h.compareWith = nil
+		return h, func() tea.Msg { return CompareCharactersMsg{Left: left, Right: char} }
+
+	case "esc":
+		h.compareWith = nil
+
+	case "/":
+		h.searching = true
+		h.searchInput.Focus()
+		return h, textinput.Blink
+
+	case "s":
+		h.sortIndex = (h.sortIndex + 1) % len(homeSortOptions)
+		h.page = 0
+		h.selectedIndex = 0
+		return h, h.loadCharacters()
+
+	case "right", "pgdown", "]":
+		if h.page < h.pageCount()-1 {
+			h.page++
+			h.selectedIndex = 0
+			return h, h.loadCharacters()
+		}
+
+	case "left", "pgup", "[":
+		if h.page > 0 {
+			h.page--
+			h.selectedIndex = 0
+			return h, h.loadCharacters()
+		}
+
+	case "t":
+		return h, func() tea.Msg { return NavigateToTokensMsg{} }
+
+	case "i":
+		return h, func() tea.Msg { return NavigateToInvitesMsg{} }
+
+	case "a":
+		return h, func() tea.Msg { return NavigateToLinksMsg{} }
+
+	case "o":
+		return h, func() tea.Msg { return NavigateToSettingsMsg{} }
+
+	case "A":
+		if auth.IsAdmin(h.user) {
+			return h, func() tea.Msg { return NavigateToAdminMsg{} }
+		}
+
 	case "l":
 		return h, func() tea.Msg { return LogoutMsg{} }
 
+	case "?":
+		return h, func() tea.Msg { return NavigateToManualMsg{} }
+
+	case "ctrl+k":
+		h.paletteOpen = true
+		return h, h.palette.Open(h.homePaletteCommands())
+
 	case "q", "ctrl+c":
 		return h, tea.Quit
 	}
@@ -135,10 +679,14 @@ func (h *HomeScreen) handleDeleteConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			charID := h.characters[h.selectedIndex].ID
 			h.confirmDelete = false
 
-			return h, func() tea.Msg {
-				_ = h.queries.DeleteCharacter(h.ctx, charID)
+			var del tea.Cmd
+			del = func() tea.Msg {
+				if err := h.queries.DeleteCharacter(h.ctx, charID); err != nil {
+					return ErrorMsg{Action: "delete the character", Err: err, Retry: del}
+				}
 				return CharacterDeletedMsg{ID: charID}
 			}
+			return h, del
 		}
 
 	case "n", "N", "esc":
@@ -149,6 +697,13 @@ func (h *HomeScreen) handleDeleteConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (h *HomeScreen) View() string {
+	if h.paletteOpen {
+		return lipgloss.Place(h.width, h.height,
+			lipgloss.Center, lipgloss.Center,
+			h.palette.View("Commands", paletteStyles(h.styles))+"\n\n"+
+				h.styles.Help.Render("type to filter • ↑/↓: select • enter: run • esc: close"))
+	}
+
 	var b strings.Builder
 
 	// Header
@@ -164,13 +719,31 @@ func (h *HomeScreen) View() string {
 	b.WriteString("\n\n")
 
 	// Title
-	b.WriteString(h.styles.Title.Render("Your Characters"))
-	b.WriteString("\n\n")
+	title := "Your Characters"
+	if h.templates {
+		title = "Templates"
+	}
+	b.WriteString(h.styles.Title.Render(title))
+	b.WriteString("\n")
+	b.WriteString(h.styles.Muted.Render(fmt.Sprintf("%d total • sorted by %s", h.total, homeSortOptions[h.sortIndex].label)))
+	b.WriteString("\n")
+	if h.searching || h.searchInput.Value() != "" {
+		b.WriteString(h.searchInput.View())
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
-	// Character list
+	// Character list, with the selected character's portrait beside it
+	var list strings.Builder
 	if len(h.characters) == 0 {
-		b.WriteString(h.styles.Muted.Render("No characters yet. Create your first adventurer!"))
-		b.WriteString("\n\n")
+		if h.searchInput.Value() != "" {
+			list.WriteString(h.styles.Muted.Render("No characters match your search."))
+		} else if h.templates {
+			list.WriteString(h.styles.Muted.Render("No templates yet. Press T on a character to save one."))
+		} else {
+			list.WriteString(h.styles.Muted.Render("No characters yet. Create your first adventurer!"))
+		}
+		list.WriteString("\n\n")
 	} else {
 		for i, char := range h.characters {
 			cursor := "  "
@@ -179,31 +752,62 @@ func (h *HomeScreen) View() string {
 				cursor = "> "
 				style = h.styles.Selected
 			}
+			marker := ""
+			if h.compareWith != nil && h.compareWith.ID == char.ID {
+				marker = " ◆"
+			}
 
-			line := fmt.Sprintf("%s%s - Level %d %s %s",
-				cursor,
+			line := fmt.Sprintf("%s - Level %d %s %s%s",
 				char.Name,
 				char.Level,
 				char.Race,
 				char.Class,
+				marker,
 			)
 
-			b.WriteString(style.Render(line))
-			b.WriteString("\n")
+			list.WriteString(components.Mark(components.Item("character", i),
+				style.Render(cursor)+h.styles.CharacterMark(char.Accent, char.Icon)+style.Render(line)))
+			list.WriteString("\n")
 		}
-		b.WriteString("\n")
+		if h.pageCount() > 1 {
+			list.WriteString(h.styles.Muted.Render(fmt.Sprintf("Page %d of %d", h.page+1, h.pageCount())))
+			list.WriteString("\n")
+		}
+		list.WriteString("\n")
 	}
 
 	// Create new character option
-	createCursor := "  "
-	createStyle := h.styles.Unselected
-	if h.selectedIndex == len(h.characters) {
-		createCursor = "> "
-		createStyle = h.styles.Selected
-	}
-	b.WriteString(h.styles.Cursor.Render(createCursor))
-	b.WriteString(createStyle.Render("+ Create New Character"))
-	b.WriteString("\n")
+	if !h.templates {
+		createCursor := "  "
+		createStyle := h.styles.Unselected
+		if h.selectedIndex == len(h.characters) {
+			createCursor = "> "
+			createStyle = h.styles.Selected
+		}
+		list.WriteString(components.Mark(components.Item("character", len(h.characters)),
+			h.styles.Cursor.Render(createCursor)+createStyle.Render("+ Create New Character")))
+		list.WriteString("\n")
+	}
+
+	b.WriteString(h.withPortrait(list.String()))
+
+	if h.status != "" {
+		b.WriteString("\n")
+		if strings.HasPrefix(h.status, "Error: ") {
+			b.WriteString(h.styles.ErrorText.Render(h.status))
+		} else {
+			b.WriteString(h.styles.SuccessText.Render(h.status))
+		}
+		b.WriteString("\n")
+	}
+
+	if h.compareWith != nil {
+		b.WriteString("\n")
+		b.WriteString(h.styles.Muted.Render(fmt.Sprintf(
+			"Comparing against %s. Press v on another character, or esc to cancel.",
+			h.compareWith.Name,
+		)))
+	}
 
 	// Delete confirmation
 	if h.confirmDelete && h.selectedIndex < len(h.characters) {
@@ -215,12 +819,40 @@ func (h *HomeScreen) View() string {
 		)))
 	}
 
+	if h.renaming && h.selectedIndex < len(h.characters) {
+		b.WriteString("\n")
+		b.WriteString("Rename " + h.characters[h.selectedIndex].Name + ": ")
+		b.WriteString(h.styles.FocusedInput.Render(h.renameInput.View()))
+	}
+
+	if h.styling && h.selectedIndex < len(h.characters) {
+		b.WriteString("\n")
+		b.WriteString(h.viewAppearance())
+	}
+
 	// Help
 	b.WriteString("\n\n")
-	if h.confirmDelete {
+	switch {
+	case h.confirmDelete:
 		b.WriteString(h.styles.Help.Render("y: confirm delete • n: cancel"))
-	} else {
-		b.WriteString(h.styles.Help.Render("↑/↓: navigate • enter: select • d: delete • l: logout • q: quit"))
+	case h.renaming:
+		b.WriteString(h.styles.Help.Render("enter: rename • esc: cancel"))
+	case h.styling:
+		b.WriteString(h.styles.Help.Render("↑/↓: color or icon • ←/→: change • enter: save • esc: cancel"))
+	case h.searching:
+		b.WriteString(h.styles.Help.Render("type to filter • enter: done • esc: clear"))
+	case h.templates:
+		b.WriteString(h.styles.Help.Render("↑/↓: navigate • ←/→: page • enter: new character • e: edit • r: rename • p: color & icon • c: copy • /: search • s: sort • d: delete"))
+		b.WriteString("\n")
+		b.WriteString(h.styles.Help.Render("tab: characters • ctrl+k: commands • ?: manual • l: logout • q: quit"))
+	default:
+		b.WriteString(h.styles.Help.Render("↑/↓: navigate • ←/→: page • enter: select • f: quick create • R: random • /: search • s: sort • v: compare • r: rename • p: color & icon • c: copy • T: save template • d: delete"))
+		b.WriteString("\n")
+		admin := ""
+		if auth.IsAdmin(h.user) {
+			admin = " • A: admin"
+		}
+		b.WriteString(h.styles.Help.Render("tab: templates • t: API tokens • i: invites • a: linked accounts • o: settings" + admin + " • ctrl+k: commands • ?: manual • l: logout • q: quit"))
 	}
 
 	return lipgloss.Place(h.width, h.height,
@@ -0,0 +1,112 @@
+package screens
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+)
+
+// inventoryLayout knows which of a character's items are containers, keyed
+// by lowercased item name, so items can be grouped by where they're stored
+type inventoryLayout struct {
+	containers map[string]character.Container
+	keys       []string
+}
+
+func newInventoryLayout(items []db.CharacterInventory) inventoryLayout {
+	l := inventoryLayout{containers: map[string]character.Container{}}
+	for _, item := range items {
+		key := strings.ToLower(strings.TrimSpace(item.Name))
+		if c, ok := character.FindContainer(item.Name); ok {
+			if _, seen := l.containers[key]; !seen {
+				l.keys = append(l.keys, key)
+			}
+			l.containers[key] = c
+		}
+	}
+	return l
+}
+
+// location returns the container an item is stored in, or "" when it's
+// carried directly. Items whose container has been removed count as carried.
+func (l inventoryLayout) location(item db.CharacterInventory) string {
+	key := strings.ToLower(strings.TrimSpace(item.Location))
+	if _, ok := l.containers[key]; !ok || key == strings.ToLower(strings.TrimSpace(item.Name)) {
+		return ""
+	}
+	return key
+}
+
+// order lists carried items first, then the contents of each container
+func (l inventoryLayout) order(items []db.CharacterInventory) []db.CharacterInventory {
+	ordered := make([]db.CharacterInventory, 0, len(items))
+	for _, key := range append([]string{""}, l.keys...) {
+		for _, item := range items {
+			if l.location(item) == key {
+				ordered = append(ordered, item)
+			}
+		}
+	}
+	return ordered
+}
+
+// nextLocation returns where the M key moves an item: the next container
+// after its current one, then back out. It's false when there's nowhere
+// else to put the item.
+func (l inventoryLayout) nextLocation(item db.CharacterInventory) (string, bool) {
+	targets := []string{""}
+	for _, key := range l.keys {
+		if key != strings.ToLower(strings.TrimSpace(item.Name)) {
+			targets = append(targets, key)
+		}
+	}
+	if len(targets) == 1 {
+		return "", false
+	}
+	current := l.location(item)
+	for i, key := range targets {
+		if key == current {
+			return l.containers[targets[(i+1)%len(targets)]].Name, true
+		}
+	}
+	return "", true
+}
+
+// inventoryWeight is how much a character's items weigh, in pounds
+type inventoryWeight struct {
+	carried  float64
+	contents map[string]float64 // by container
+	unknown  int                // items not in the SRD weight tables
+}
+
+// weigh totals the items' weights. Contents of weightless containers such
+// as a bag of holding count toward the container's capacity but not the
+// carried weight.
+func (l inventoryLayout) weigh(items []db.CharacterInventory) inventoryWeight {
+	w := inventoryWeight{contents: map[string]float64{}}
+	for _, item := range items {
+		each, ok := character.ItemWeight(item.Name)
+		if !ok {
+			w.unknown++
+			continue
+		}
+		total := each * float64(item.Quantity)
+		loc := l.location(item)
+		if loc != "" {
+			w.contents[loc] += total
+			if l.containers[loc].Weightless {
+				continue
+			}
+		}
+		w.carried += total
+	}
+	return w
+}
+
+// formatWeight drops trailing zeros, e.g. "12" or "0.5"
+func formatWeight(lb float64) string {
+	return strconv.FormatFloat(math.Round(lb*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,136 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/auth"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// InvitesScreen lets a user create invite codes for new players
+type InvitesScreen struct {
+	ctx         context.Context
+	authService *auth.Service
+	user        *db.User
+	styles      *styles.Styles
+
+	invites []db.Invite
+	err     string
+	width   int
+	height  int
+}
+
+type NavigateToInvitesMsg struct{}
+
+type InvitesLoadedMsg struct {
+	Invites []db.Invite
+}
+
+type inviteErrorMsg struct {
+	Err error
+}
+
+func NewInvitesScreen(ctx context.Context, authService *auth.Service, user *db.User, s *styles.Styles) *InvitesScreen {
+	return &InvitesScreen{
+		ctx:         ctx,
+		authService: authService,
+		user:        user,
+		styles:      s,
+		width:       80,
+		height:      24,
+	}
+}
+
+func (v *InvitesScreen) Init() tea.Cmd {
+	return v.loadInvites()
+}
+
+func (v *InvitesScreen) loadInvites() tea.Cmd {
+	return func() tea.Msg {
+		invites, err := v.authService.ListInvites(v.ctx, v.user.ID)
+		if err != nil {
+			return inviteErrorMsg{Err: err}
+		}
+		return InvitesLoadedMsg{Invites: invites}
+	}
+}
+
+func (v *InvitesScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.width = msg.Width
+		v.height = msg.Height
+
+	case InvitesLoadedMsg:
+		v.invites = msg.Invites
+
+	case inviteErrorMsg:
+		v.err = msg.Err.Error()
+
+	case tea.KeyMsg:
+		v.err = ""
+		switch msg.String() {
+		case "n":
+			return v, func() tea.Msg {
+				if _, err := v.authService.CreateInvite(v.ctx, v.user.ID); err != nil {
+					return inviteErrorMsg{Err: err}
+				}
+				invites, err := v.authService.ListInvites(v.ctx, v.user.ID)
+				if err != nil {
+					return inviteErrorMsg{Err: err}
+				}
+				return InvitesLoadedMsg{Invites: invites}
+			}
+		case "esc", "q":
+			return v, func() tea.Msg { return NavigateBackMsg{} }
+		}
+	}
+
+	return v, nil
+}
+
+func (v *InvitesScreen) View() string {
+	var b strings.Builder
+
+	b.WriteString(v.styles.Title.Render("Invites"))
+	b.WriteString("\n")
+	if v.authService.InviteOnly() {
+		b.WriteString(v.styles.Subtitle.Render("New players need an invite code to register."))
+	} else {
+		b.WriteString(v.styles.Subtitle.Render("Registration is open; invite codes are not required right now."))
+	}
+	b.WriteString("\n\n")
+
+	if len(v.invites) == 0 {
+		b.WriteString(v.styles.Muted.Render("No invites yet."))
+		b.WriteString("\n")
+	}
+	for _, invite := range v.invites {
+		status := v.styles.SuccessText.Render("unused")
+		if invite.UsedBy.Valid {
+			used := "used"
+			if invite.UsedAt.Valid {
+				used = "used " + invite.UsedAt.Time.Format("2006-01-02")
+			}
+			status = v.styles.Muted.Render(used)
+		}
+		b.WriteString(fmt.Sprintf("  %-12s %s\n", invite.Code, status))
+	}
+
+	if v.err != "" {
+		b.WriteString("\n")
+		b.WriteString(v.styles.ErrorText.Render("Error: " + v.err))
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(v.styles.Help.Render("n: new invite • esc: back"))
+
+	return lipgloss.Place(v.width, v.height,
+		lipgloss.Center, lipgloss.Center,
+		b.String())
+}
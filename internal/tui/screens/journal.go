@@ -0,0 +1,155 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/sanitize"
+	"github.com/brady1408/dnd/internal/tui/components"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (s *SheetScreen) loadJournal() tea.Cmd {
+	return s.cache.load(s.char.ID, partJournal, func() tea.Msg {
+		entries, err := s.queries.GetCharacterJournal(s.ctx, s.char.ID)
+		if err != nil {
+			return ErrorMsg{Action: "load the journal", Err: err, Retry: s.loadJournal()}
+		}
+		return JournalLoadedMsg{Entries: entries}
+	})
+}
+
+// selectedEntry returns the entry under the cursor
+func (s *SheetScreen) selectedEntry() (db.CharacterJournal, bool) {
+	if s.journalCursor >= len(s.journal) {
+		return db.CharacterJournal{}, false
+	}
+	return s.journal[s.journalCursor], true
+}
+
+// titleEntry asks for the title of a new entry, or a new title for entry
+func (s *SheetScreen) titleEntry(entry db.CharacterJournal) (tea.Model, tea.Cmd) {
+	s.mode = ModeJournalTitle
+	s.editingEntry = entry
+	s.titleInput.SetValue(entry.Title)
+	s.titleInput.CursorEnd()
+	s.titleInput.Focus()
+	return s, textinput.Blink
+}
+
+// editEntry opens an entry's text for editing
+func (s *SheetScreen) editEntry(entry db.CharacterJournal) (tea.Model, tea.Cmd) {
+	s.mode = ModeEditNotes
+	s.preview = false
+	s.editingEntry = entry
+	s.notesInput.SetValue(entry.Body)
+	s.notesInput.Focus()
+	return s, textarea.Blink
+}
+
+func (s *SheetScreen) updateJournalTitle(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		title := sanitize.Line(s.titleInput.Value(), sanitize.MaxName)
+		if title == "" {
+			s.mode = ModeView
+			return s, nil
+		}
+		if !s.editingEntry.ID.Valid {
+			// A new entry gets its text before anything is saved
+			s.editingEntry.Title = title
+			return s.editEntry(s.editingEntry)
+		}
+		s.journalCursor = 0 // saving moves the entry to the top
+		return s, s.undo.run(journalEdit(s.ctx, s.queries, s.userID, s.char, s.editingEntry, title, s.editingEntry.Body))
+	case "esc":
+		s.mode = ModeView
+		return s, nil
+	}
+
+	var cmd tea.Cmd
+	s.titleInput, cmd = s.titleInput.Update(msg)
+	return s, cmd
+}
+
+func (s *SheetScreen) updateJournalEntry(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if s.journalCursor > 0 {
+			s.journalCursor--
+		}
+	case "down", "j":
+		if s.journalCursor < len(s.journal)-1 {
+			s.journalCursor++
+		}
+	case "e":
+		if entry, ok := s.selectedEntry(); ok {
+			return s.editEntry(entry)
+		}
+	case "esc", "q", "enter":
+		s.mode = ModeView
+	}
+	return s, nil
+}
+
+// viewJournal lists the entries, most recently updated first
+func (s *SheetScreen) viewJournal() string {
+	var b strings.Builder
+
+	if s.mode == ModeJournalTitle {
+		b.WriteString("Title: ")
+		b.WriteString(s.styles.FocusedInput.Render(s.titleInput.View()))
+		b.WriteString("\n\n")
+	}
+
+	if s.mode == ModeEditNotes {
+		b.WriteString(s.styles.Subtitle.Render("Editing " + s.editingEntry.Title))
+		b.WriteString("\n")
+		b.WriteString(s.viewEditor(s.notesInput))
+		return b.String()
+	}
+
+	if len(s.journal) == 0 {
+		b.WriteString(s.styles.Muted.Render("No journal entries. Press a to start one."))
+		return b.String()
+	}
+	for i, entry := range s.journal {
+		style := s.styles.NotProficient
+		if i == s.journalCursor {
+			style = s.styles.Cursor
+		}
+		b.WriteString(components.Mark(components.Item("entry", i),
+			style.Render(fmt.Sprintf("%-38s", truncate(entry.Title, 36)))+
+				s.styles.Muted.Render("updated "+entry.UpdatedAt.Time.Local().Format("Jan 2 15:04"))))
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// viewJournalEntry shows the selected entry's text
+func (s *SheetScreen) viewJournalEntry() string {
+	entry, ok := s.selectedEntry()
+	if !ok {
+		return s.styles.Muted.Render("No entry selected.")
+	}
+
+	var b strings.Builder
+	b.WriteString(s.styles.Header.Render(entry.Title))
+	b.WriteString("\n")
+	b.WriteString(s.styles.Muted.Render("Started " + entry.CreatedAt.Time.Local().Format("Jan 2, 2006") +
+		" • updated " + entry.UpdatedAt.Time.Local().Format("Jan 2, 2006 15:04")))
+	b.WriteString("\n\n")
+	if strings.TrimSpace(entry.Body) == "" {
+		b.WriteString(s.styles.Muted.Render("Nothing written yet."))
+	} else {
+		b.WriteString(s.styles.Markdown(entry.Body, s.notesWidth()))
+	}
+
+	return lipgloss.NewStyle().
+		Align(lipgloss.Left).
+		Render(b.String())
+}
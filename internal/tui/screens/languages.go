@@ -0,0 +1,149 @@
+package screens
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/audit"
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/sanitize"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// languagesEdit sets a character's languages and tool proficiencies.
+// description is recorded in the change log.
+func languagesEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, languages, tools []string, description string) sheetEdit {
+	set := func(languages, tools []string, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			updated, err := queries.UpdateCharacterLanguages(ctx, db.UpdateCharacterLanguagesParams{
+				ID:                char.ID,
+				Languages:         languages,
+				ToolProficiencies: tools,
+			})
+			if err != nil {
+				return updated, err
+			}
+			audit.Record(ctx, queries, updated.ID, userID, audit.KindProficiency, description)
+			return updated, nil
+		}
+	}
+	return sheetEdit{
+		label:  "language change",
+		apply:  set(languages, tools, description),
+		revert: set(char.Languages, char.ToolProficiencies, "Undid language change"),
+	}
+}
+
+// otherProficiencies is how many languages and tools the Stats tab lists,
+// languages first
+func (s *SheetScreen) otherProficiencies() int {
+	return len(s.char.Languages) + len(s.char.ToolProficiencies)
+}
+
+// startAddLanguage asks for a language, or a tool when tool is set,
+// suggesting the SRD ones
+func (s *SheetScreen) startAddLanguage(tool bool) tea.Cmd {
+	s.mode = ModeAddLanguage
+	s.addingTool = tool
+	s.languageInput.Placeholder = "Language"
+	s.languageInput.SetSuggestions(character.Languages)
+	if tool {
+		s.languageInput.Placeholder = "Tool"
+		s.languageInput.SetSuggestions(character.Tools)
+	}
+	s.languageInput.SetValue("")
+	s.languageInput.Focus()
+	return textinput.Blink
+}
+
+func (s *SheetScreen) updateAddLanguage(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		name := sanitize.Line(s.languageInput.Value(), sanitize.MaxName)
+		if name == "" {
+			s.mode = ModeView
+			return s, nil
+		}
+		languages, tools := s.char.Languages, s.char.ToolProficiencies
+		known := slices.ContainsFunc(append(slices.Clone(languages), tools...), func(have string) bool {
+			return strings.EqualFold(have, name)
+		})
+		if known {
+			s.mode = ModeView
+			s.status = s.char.Name + " already has " + name
+			return s, nil
+		}
+		description := "Learned " + name
+		if s.addingTool {
+			tools = append(slices.Clone(tools), name)
+			description = "Gained proficiency with " + name
+		} else {
+			languages = append(slices.Clone(languages), name)
+		}
+		return s, s.undo.run(languagesEdit(s.ctx, s.queries, s.userID, s.char, languages, tools, description))
+
+	case "esc":
+		s.mode = ModeView
+		return s, nil
+	}
+
+	var cmd tea.Cmd
+	s.languageInput, cmd = s.languageInput.Update(msg)
+	return s, cmd
+}
+
+// removeLanguage removes the language or tool under the cursor
+func (s *SheetScreen) removeLanguage() tea.Cmd {
+	languages := slices.Clone(s.char.Languages)
+	tools := slices.Clone(s.char.ToolProficiencies)
+	var name string
+	if i := s.languageCursor; i < len(languages) {
+		name = languages[i]
+		languages = slices.Delete(languages, i, i+1)
+	} else {
+		i -= len(languages)
+		name = tools[i]
+		tools = slices.Delete(tools, i, i+1)
+	}
+	s.languageCursor = max(min(s.languageCursor, s.otherProficiencies()-2), 0)
+	return s.undo.run(languagesEdit(s.ctx, s.queries, s.userID, s.char, languages, tools, "Removed "+name))
+}
+
+// viewLanguages lists the character's languages and tool proficiencies
+func (s *SheetScreen) viewLanguages() string {
+	var b strings.Builder
+	b.WriteString(s.styles.Header.Render("Proficiencies"))
+	b.WriteString("\n\n")
+
+	row := 0
+	list := func(title string, names []string) {
+		b.WriteString(title + ": ")
+		if len(names) == 0 {
+			b.WriteString(s.styles.Muted.Render("none"))
+		}
+		for i, name := range names {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			style := s.styles.NotProficient
+			if row == s.languageCursor && s.mode == ModeView {
+				style = s.styles.Cursor
+			}
+			b.WriteString(style.Render(name))
+			row++
+		}
+		b.WriteString("\n")
+	}
+	list("Languages", s.char.Languages)
+	list("Tools", s.char.ToolProficiencies)
+
+	if s.mode == ModeAddLanguage {
+		b.WriteString(s.styles.FocusedInput.Render(s.languageInput.View()))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,98 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/tui/components"
+	"github.com/brady1408/dnd/internal/tui/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The sheet is laid out for 80x24. Below that it reflows: badges move under
+// the name, the tab bar and tables shrink, the help shortens, and tabs too
+// long for the window are cut off with a note instead of scrolling the
+// terminal. Below the minimum it shows a warning instead.
+const (
+	minWidth      = 40
+	minHeight     = 12
+	compactWidth  = 80
+	compactHeight = 24
+)
+
+// tooSmall reports whether a terminal is below the minimum size
+func tooSmall(width, height int) bool {
+	return width < minWidth || height < minHeight
+}
+
+// viewTooSmall asks for a bigger terminal
+func viewTooSmall(st *styles.Styles, width, height int) string {
+	text := lipgloss.NewStyle().Width(max(width-2, 10)).Align(lipgloss.Center)
+	msg := st.WarningText.Render(text.Render("Terminal too small")) + "\n\n" +
+		text.Render(fmt.Sprintf("%dx%d, needs at least %dx%d. Enlarge the window, or press q to go back.", width, height, minWidth, minHeight))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, msg)
+}
+
+// clipLines cuts text to fit in width columns and height rows, ending with
+// a note if any rows were cut
+func clipLines(st *styles.Styles, text string, width, height int) string {
+	lines := strings.Split(text, "\n")
+	if len(lines) > height {
+		lines = append(lines[:max(height-1, 0)], st.Muted.Render(fmt.Sprintf("… %d more lines; enlarge the window to see them", len(lines)-height+1)))
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
+
+// narrow reports whether the sheet has less than its usual width
+func (s *SheetScreen) narrow() bool {
+	return s.width < compactWidth
+}
+
+// short reports whether the sheet has less than its usual height
+func (s *SheetScreen) short() bool {
+	return s.height < compactHeight
+}
+
+// contentWidth is the width available to tab content and tables, at most
+// limit
+func (s *SheetScreen) contentWidth(limit int) int {
+	return max(min(limit, s.width-4), 20)
+}
+
+// viewTabBar shows the tabs as buttons, as plain names on narrow terminals,
+// or just the focused tab when even the names don't fit
+func (s *SheetScreen) viewTabBar() string {
+	if !s.narrow() {
+		tabBar := ""
+		for i, t := range sheetTabs {
+			var tab string
+			switch {
+			case i == s.tab:
+				tab = s.styles.FocusedButton.Render(" " + t + " ")
+			case s.split() && i == s.paneTab():
+				tab = s.styles.Selected.Render(" "+t+" ") + " "
+			default:
+				tab = s.styles.Button.Render(" " + t + " ")
+			}
+			tabBar += components.Mark(components.Item("tab", i), tab)
+		}
+		return tabBar
+	}
+
+	names := make([]string, len(sheetTabs))
+	for i, t := range sheetTabs {
+		if i == s.tab {
+			names[i] = s.styles.Cursor.Render("[" + t + "]")
+		} else {
+			names[i] = s.styles.Muted.Render(t)
+		}
+		names[i] = components.Mark(components.Item("tab", i), names[i])
+	}
+	if tabBar := strings.Join(names, " "); lipgloss.Width(tabBar) <= s.width-2 {
+		return tabBar
+	}
+	// Clicking the only tab shown moves on to the next
+	next := components.Item("tab", (s.tab+1)%len(sheetTabs))
+	return components.Mark(next, s.styles.Cursor.Render("‹ "+sheetTabs[s.tab]+" ›")) +
+		s.styles.Muted.Render(fmt.Sprintf(" %d/%d", s.tab+1, len(sheetTabs)))
+}
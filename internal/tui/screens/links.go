@@ -0,0 +1,169 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/auth"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LinksScreen lets a user link Discord or Google accounts with a code from
+// the web sign-in page
+type LinksScreen struct {
+	ctx         context.Context
+	authService *auth.Service
+	user        *db.User
+	styles      *styles.Styles
+
+	identities []db.UserIdentity
+	codeInput  textinput.Model
+	err        string
+	linked     bool
+	width      int
+	height     int
+}
+
+type NavigateToLinksMsg struct{}
+
+type IdentitiesLoadedMsg struct {
+	Identities []db.UserIdentity
+}
+
+type identityLinkedMsg struct {
+	Identities []db.UserIdentity
+}
+
+type linkErrorMsg struct {
+	Err error
+}
+
+func NewLinksScreen(ctx context.Context, authService *auth.Service, user *db.User, s *styles.Styles) *LinksScreen {
+	codeInput := textinput.New()
+	codeInput.Placeholder = "ABCD-EFGH"
+	codeInput.CharLimit = 20
+	codeInput.Width = 30
+	if authService.IdentityLinking() {
+		codeInput.Focus()
+	}
+
+	return &LinksScreen{
+		ctx:         ctx,
+		authService: authService,
+		user:        user,
+		styles:      s,
+		codeInput:   codeInput,
+		width:       80,
+		height:      24,
+	}
+}
+
+func (l *LinksScreen) Init() tea.Cmd {
+	return tea.Batch(l.loadIdentities(), textinput.Blink)
+}
+
+func (l *LinksScreen) loadIdentities() tea.Cmd {
+	return func() tea.Msg {
+		identities, err := l.authService.ListIdentities(l.ctx, l.user.ID)
+		if err != nil {
+			return linkErrorMsg{Err: err}
+		}
+		return IdentitiesLoadedMsg{Identities: identities}
+	}
+}
+
+func (l *LinksScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		l.width = msg.Width
+		l.height = msg.Height
+
+	case IdentitiesLoadedMsg:
+		l.identities = msg.Identities
+
+	case identityLinkedMsg:
+		l.identities = msg.Identities
+		l.linked = true
+		l.codeInput.SetValue("")
+
+	case linkErrorMsg:
+		l.err = msg.Err.Error()
+
+	case tea.KeyMsg:
+		l.err = ""
+		l.linked = false
+		switch msg.String() {
+		case "esc":
+			return l, func() tea.Msg { return NavigateBackMsg{} }
+		case "enter":
+			if !l.authService.IdentityLinking() {
+				return l, nil
+			}
+			code := l.codeInput.Value()
+			return l, func() tea.Msg {
+				if err := l.authService.LinkIdentity(l.ctx, l.user.ID, code); err != nil {
+					return linkErrorMsg{Err: err}
+				}
+				identities, err := l.authService.ListIdentities(l.ctx, l.user.ID)
+				if err != nil {
+					return linkErrorMsg{Err: err}
+				}
+				return identityLinkedMsg{Identities: identities}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	l.codeInput, cmd = l.codeInput.Update(msg)
+	return l, cmd
+}
+
+func (l *LinksScreen) View() string {
+	var b strings.Builder
+
+	b.WriteString(l.styles.Title.Render("Linked Accounts"))
+	b.WriteString("\n\n")
+
+	if len(l.identities) == 0 {
+		b.WriteString(l.styles.Muted.Render("No linked accounts."))
+		b.WriteString("\n")
+	}
+	for _, identity := range l.identities {
+		email := ""
+		if identity.Email.Valid {
+			email = identity.Email.String
+		}
+		b.WriteString(fmt.Sprintf("  %-10s %s\n", identity.Provider, email))
+	}
+	b.WriteString("\n")
+
+	if l.authService.IdentityLinking() {
+		b.WriteString("Sign in on the web to get a link code:\n")
+		b.WriteString(l.styles.HighlightBox.Render(l.authService.SignInURL()))
+		b.WriteString("\n\nLink Code:\n")
+		b.WriteString(l.styles.FocusedInput.Render(l.codeInput.View()))
+	} else {
+		b.WriteString(l.styles.Muted.Render("Web sign-in is not configured on this server."))
+	}
+
+	if l.linked {
+		b.WriteString("\n")
+		b.WriteString(l.styles.SuccessText.Render("Account linked."))
+	}
+	if l.err != "" {
+		b.WriteString("\n")
+		b.WriteString(l.styles.ErrorText.Render("Error: " + l.err))
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(l.styles.Help.Render("enter: link • esc: back"))
+
+	return lipgloss.Place(l.width, l.height,
+		lipgloss.Center, lipgloss.Center,
+		b.String())
+}
@@ -0,0 +1,184 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/manual"
+	"github.com/brady1408/dnd/internal/tui/components"
+	"github.com/brady1408/dnd/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ManualScreen shows the in-app manual: a searchable list of topics beside
+// the selected topic's text
+type ManualScreen struct {
+	styles *styles.Styles
+
+	manual      *manual.Manual
+	err         error
+	searchInput textinput.Model
+	cursor      int
+	offset      int // first line of the topic shown
+	width       int
+	height      int
+}
+
+type NavigateToManualMsg struct{}
+
+// manualTextWidth is the width topics are wrapped to
+const manualTextWidth = 64
+
+func NewManualScreen(s *styles.Styles) *ManualScreen {
+	searchInput := textinput.New()
+	searchInput.Placeholder = "search the manual"
+	searchInput.Prompt = "/"
+	searchInput.CharLimit = 50
+	searchInput.Width = 24
+	searchInput.Focus()
+
+	m, err := manual.Load()
+	return &ManualScreen{
+		styles:      s,
+		manual:      m,
+		err:         err,
+		searchInput: searchInput,
+		width:       80,
+		height:      24,
+	}
+}
+
+func (m *ManualScreen) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// topics returns the topics matching the search
+func (m *ManualScreen) topics() []manual.Topic {
+	if m.manual == nil {
+		return nil
+	}
+	return m.manual.Search(m.searchInput.Value())
+}
+
+// topicLines renders the selected topic as lines for scrolling
+func (m *ManualScreen) topicLines() []string {
+	topics := m.topics()
+	if m.cursor >= len(topics) {
+		return nil
+	}
+	t := topics[m.cursor]
+	return strings.Split(m.styles.Header.Render(t.Title)+"\n"+m.styles.Markdown(t.Body, manualTextWidth), "\n")
+}
+
+// textRows is how many lines of a topic fit on screen
+func (m *ManualScreen) textRows() int {
+	return max(8, m.height-8)
+}
+
+func (m *ManualScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case components.ClickMsg:
+		if i, ok := components.Index(msg.Zone, "topic"); ok && i < len(m.topics()) {
+			m.cursor, m.offset = i, 0
+		}
+		return m, nil
+
+	case components.ScrollMsg:
+		m.offset = min(max(m.offset+msg.Lines, 0), max(0, len(m.topicLines())-m.textRows()))
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			if m.searchInput.Value() != "" {
+				m.searchInput.SetValue("")
+				m.cursor, m.offset = 0, 0
+				return m, nil
+			}
+			return m, func() tea.Msg { return NavigateBackMsg{} }
+		case "up":
+			if m.cursor > 0 {
+				m.cursor--
+				m.offset = 0
+			}
+			return m, nil
+		case "down":
+			if m.cursor < len(m.topics())-1 {
+				m.cursor++
+				m.offset = 0
+			}
+			return m, nil
+		case "pgdown", "ctrl+d":
+			m.offset = min(m.offset+m.textRows()/2, max(0, len(m.topicLines())-m.textRows()))
+			return m, nil
+		case "pgup", "ctrl+u":
+			m.offset = max(0, m.offset-m.textRows()/2)
+			return m, nil
+		}
+	}
+
+	before := m.searchInput.Value()
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	if m.searchInput.Value() != before {
+		m.cursor, m.offset = 0, 0
+	}
+	return m, cmd
+}
+
+func (m *ManualScreen) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render("Manual"))
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString(m.styles.ErrorText.Render("Error: " + m.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(m.styles.Help.Render("esc: back"))
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, b.String())
+	}
+
+	// Topic list, with the search box above it
+	topics := m.topics()
+	var list strings.Builder
+	list.WriteString(m.searchInput.View())
+	list.WriteString("\n\n")
+	if len(topics) == 0 {
+		list.WriteString(m.styles.Muted.Render("No topics match."))
+	}
+	for i, t := range topics {
+		style := m.styles.NotProficient
+		if i == m.cursor {
+			style = m.styles.Cursor
+		}
+		list.WriteString(components.Mark(components.Item("topic", i), style.Render(fmt.Sprintf("%-28s", truncate(t.Title, 28)))))
+		list.WriteString("\n")
+	}
+
+	// The selected topic, scrolled to offset
+	lines := m.topicLines()
+	offset := min(m.offset, max(0, len(lines)-m.textRows()))
+	end := min(len(lines), offset+m.textRows())
+	text := strings.Join(lines[offset:end], "\n")
+	if end < len(lines) {
+		text += "\n" + m.styles.Muted.Render(fmt.Sprintf("… %d more lines (pgdown)", len(lines)-end))
+	}
+
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(32).Render(list.String()),
+		components.Mark(components.ScrollZone, lipgloss.NewStyle().Align(lipgloss.Left).Render(text))))
+	b.WriteString("\n\n")
+	b.WriteString(m.styles.Help.Render("type to search • ↑/↓: topic • pgup/pgdown: scroll • esc: back"))
+
+	return lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		b.String())
+}
@@ -0,0 +1,264 @@
+package screens
+
+import (
+	"github.com/brady1408/dnd/internal/tui/components"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Clicks are handled by pressing the keys that do the same thing, so the
+// mouse never does anything the keyboard can't. Clicking a list row
+// selects it, and clicking the selected row again opens or toggles it.
+
+// Keys pressed on the player's behalf by clicks
+var (
+	keyEnter = tea.KeyMsg{Type: tea.KeyEnter}
+	keySpace = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	keyLeft  = tea.KeyMsg{Type: tea.KeyLeft}
+	keyRight = tea.KeyMsg{Type: tea.KeyRight}
+)
+
+// clickRow moves cursor to a clicked row, reporting whether it was there
+// already
+func clickRow(cursor *int, row int) (again bool) {
+	again = *cursor == row
+	*cursor = row
+	return again
+}
+
+// click handles a click on the sheet
+func (s *SheetScreen) click(zone string) (tea.Model, tea.Cmd) {
+	switch s.mode {
+	case ModeView:
+		if i, ok := components.Index(zone, "tab"); ok {
+			s.showTab(i)
+			return s, nil
+		}
+		if i, ok := components.Index(zone, "spell"); ok && i < len(s.spells) {
+			s.showTab(3)
+			if clickRow(&s.spellCursor, i) {
+				return s.updateView(keyEnter)
+			}
+		}
+		if i, ok := components.Index(zone, "entry"); ok && i < len(s.journal) {
+			s.showTab(4)
+			if clickRow(&s.journalCursor, i) {
+				return s.updateView(keyEnter)
+			}
+		}
+		if i, ok := components.Index(zone, "resource"); ok && i < len(s.resources) {
+			s.showTab(2)
+			s.resourceCursor = i
+		}
+		if i, ok := components.Index(zone, "item"); ok && i < len(s.inventory) {
+			s.showTab(6)
+			s.inventoryCursor = i
+		}
+	case ModeEditSaves, ModeEditSkills:
+		if i, ok := components.Index(zone, "prof"); ok {
+			s.profCursor = i
+			return s.updateEditProficiencies(keySpace)
+		}
+	case ModePickWeapon:
+		if i, ok := components.Index(zone, "weapon"); ok && clickRow(&s.weaponCursor, i) {
+			return s.updatePickWeapon(keyEnter)
+		}
+	case ModeCraft:
+		if i, ok := components.Index(zone, "recipe"); ok && clickRow(&s.recipeCursor, i) {
+			return s.updateCraft(keyEnter)
+		}
+	case ModeShop:
+		if i, ok := components.Index(zone, "ware"); ok && clickRow(&s.shop.cursor, i) {
+			return s.updateShop(keyEnter)
+		}
+	case ModeSearch:
+		if i, ok := components.Index(zone, "result"); ok {
+			s.searchCursor = i
+			return s.updateSearch(keyEnter)
+		}
+	case ModePalette:
+		if chosen := s.palette.Click(zone); chosen != nil {
+			s.mode = ModeView
+			return s, chosen.Run()
+		}
+	case ModeSetupSpellcasting:
+		if i, ok := components.Index(zone, "setup"); ok {
+			s.setup.row = i
+		}
+		if i, ok := components.Index(zone, "less"); ok {
+			s.setup.row = i
+			return s.updateSpellcastingSetup(keyLeft)
+		}
+		if i, ok := components.Index(zone, "more"); ok {
+			s.setup.row = i
+			return s.updateSpellcastingSetup(keyRight)
+		}
+	case ModeEditMovement:
+		if i, ok := components.Index(zone, "movement"); ok {
+			s.movement.row = i
+		}
+		if i, ok := components.Index(zone, "less"); ok {
+			s.movement.row = i
+			return s.updateEditMovement(keyLeft)
+		}
+		if i, ok := components.Index(zone, "more"); ok {
+			s.movement.row = i
+			return s.updateEditMovement(keyRight)
+		}
+	case ModePrepareSpells:
+		if i, ok := components.Index(zone, "prepare"); ok {
+			s.prepare.cursor = i
+			return s.updateSpellPreparation(keySpace)
+		}
+	}
+	return s, nil
+}
+
+// click handles a click on the character list
+func (h *HomeScreen) click(zone string) (tea.Model, tea.Cmd) {
+	if h.paletteOpen {
+		if chosen := h.palette.Click(zone); chosen != nil {
+			h.paletteOpen = false
+			return h, chosen.Run()
+		}
+		return h, nil
+	}
+	if h.styling {
+		if i, ok := components.Index(zone, "appearance"); ok {
+			h.appearance.row = i
+		}
+		if i, ok := components.Index(zone, "less"); ok {
+			h.appearance.row = i
+			return h.updateAppearance(keyLeft)
+		}
+		if i, ok := components.Index(zone, "more"); ok {
+			h.appearance.row = i
+			return h.updateAppearance(keyRight)
+		}
+		return h, nil
+	}
+	if h.confirmDelete || h.renaming {
+		return h, nil
+	}
+	if i, ok := components.Index(zone, "character"); ok && i <= h.maxIndex() && clickRow(&h.selectedIndex, i) {
+		return h.handleInput(keyEnter)
+	}
+	return h, nil
+}
+
+// click handles a click on the welcome menu or a login form
+func (w *WelcomeScreen) click(zone string) (tea.Model, tea.Cmd) {
+	switch w.mode {
+	case ModeMenu:
+		if i, ok := components.Index(zone, "menu"); ok && i < len(w.getMenuItems()) && clickRow(&w.menuIndex, i) {
+			return w.updateMenu(keyEnter)
+		}
+	case ModeLogin, ModeRegister, ModeLinkKey, ModeResetPassword:
+		if i, ok := components.Index(zone, "field"); ok && i <= w.buttonIndex() {
+			w.focusIndex = i
+			w.updateFocus()
+			if i == w.buttonIndex() {
+				return w.submitForm()
+			}
+		}
+	}
+	return w, nil
+}
+
+// click handles a click on a setting, or on the arrows that change one
+func (st *SettingsScreen) click(zone string) (tea.Model, tea.Cmd) {
+	if i, ok := components.Index(zone, "less"); ok {
+		st.cursor = i
+		return st.Update(keyLeft)
+	}
+	if i, ok := components.Index(zone, "more"); ok {
+		st.cursor = i
+		return st.Update(keyRight)
+	}
+	if i, ok := components.Index(zone, "setting"); ok && i < st.rows() && clickRow(&st.cursor, i) {
+		return st.Update(keyEnter)
+	}
+	return st, nil
+}
+
+// click handles a click on a level of the plan, or on a field of the form
+// for planning one
+func (p *PlanScreen) click(zone string) (tea.Model, tea.Cmd) {
+	switch {
+	case p.confirmUp:
+	case p.editing:
+		if i, ok := components.Index(zone, "field"); ok {
+			p.focus = planField(i)
+			return p, p.focusField()
+		}
+		if i, ok := components.Index(zone, "less"); ok {
+			p.focus = planField(i)
+			p.focusField()
+			return p.updateForm(keyLeft)
+		}
+		if i, ok := components.Index(zone, "more"); ok {
+			p.focus = planField(i)
+			p.focusField()
+			return p.updateForm(keyRight)
+		}
+	default:
+		if level, ok := components.Index(zone, "level"); ok && clickRow(&p.cursor, level) {
+			return p.updateList(keyEnter)
+		}
+	}
+	return p, nil
+}
+
+// click handles a click on a choice in the current step of character
+// creation
+func (c *CreateScreen) click(zone string) (tea.Model, tea.Cmd) {
+	switch c.step {
+	case StepRace:
+		if i, ok := components.Index(zone, "race"); ok && clickRow(&c.raceIndex, i) {
+			return c.updateRace(keyEnter)
+		}
+	case StepClass:
+		if i, ok := components.Index(zone, "class"); ok && clickRow(&c.classIndex, i) {
+			return c.updateClass(keyEnter)
+		}
+	case StepAbilityMethod:
+		if i, ok := components.Index(zone, "method"); ok && clickRow(&c.abilityMethodIndex, i) {
+			return c.updateAbilityMethod(keyEnter)
+		}
+	case StepAbilityRoll, StepAbilityArray:
+		if i, ok := components.Index(zone, "ability"); ok {
+			c.assignIndex = i
+		}
+		// Clicking a score assigns it to the selected ability
+		if i, ok := components.Index(zone, "score"); ok {
+			return c.updateAbilityAssignment(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{rune('1' + i)}})
+		}
+	case StepAbilityPointBuy:
+		if i, ok := components.Index(zone, "ability"); ok {
+			c.assignIndex = i
+		}
+		if i, ok := components.Index(zone, "less"); ok {
+			c.assignIndex = i
+			return c.updatePointBuy(keyLeft)
+		}
+		if i, ok := components.Index(zone, "more"); ok {
+			c.assignIndex = i
+			return c.updatePointBuy(keyRight)
+		}
+	case StepSkills:
+		if i, ok := components.Index(zone, "skill"); ok {
+			c.skillCursor = i
+			return c.updateSkills(keySpace)
+		}
+	case StepCantrips:
+		if i, ok := components.Index(zone, "spell"); ok {
+			c.spellCursor = i
+			return c.updateCantrips(keySpace)
+		}
+	case StepSpells:
+		if i, ok := components.Index(zone, "spell"); ok {
+			c.spellCursor = i
+			return c.updateSpells(keySpace)
+		}
+	}
+	return c, nil
+}
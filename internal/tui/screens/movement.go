@@ -0,0 +1,170 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/audit"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/tui/components"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Rows of the speeds and senses form
+const (
+	moveWalk = iota
+	moveFly
+	moveSwim
+	moveClimb
+	moveBurrow
+	senseDarkvision
+	senseBlindsight
+	senseTruesight
+	movementRows
+)
+
+// movementLabels name the form's rows
+var movementLabels = [movementRows]string{"Walk", "Fly", "Swim", "Climb", "Burrow", "Darkvision", "Blindsight", "Truesight"}
+
+// maxMovement caps any speed or sense, in feet
+const maxMovement = 500
+
+// movementForm is the form for setting a character's speeds and senses,
+// each in feet, for flying races, magic items, and the like
+type movementForm struct {
+	row    int
+	values [movementRows]int32
+}
+
+// movementValues are a character's speeds and senses in form order
+func movementValues(char db.Character) [movementRows]int32 {
+	return [movementRows]int32{
+		char.Speed, char.FlySpeed, char.SwimSpeed, char.ClimbSpeed, char.BurrowSpeed,
+		char.Darkvision, char.Blindsight, char.Truesight,
+	}
+}
+
+func (s *SheetScreen) startEditMovement() {
+	s.movement = movementForm{values: movementValues(s.char)}
+	s.mode = ModeEditMovement
+}
+
+func (s *SheetScreen) updateEditMovement(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var step int32
+	switch msg.String() {
+	case "esc":
+		s.mode = ModeView
+		return s, nil
+	case "up", "k":
+		s.movement.row = (s.movement.row + movementRows - 1) % movementRows
+	case "down", "j":
+		s.movement.row = (s.movement.row + 1) % movementRows
+	case "left", "h", "-":
+		step = -5
+	case "right", "l", "+", "=":
+		step = 5
+	case "enter":
+		if s.movement.values == movementValues(s.char) {
+			s.mode = ModeView
+			return s, nil
+		}
+		return s, s.undo.run(movementEdit(s.ctx, s.queries, s.userID, s.char, s.movement.values))
+	}
+	v := &s.movement.values[s.movement.row]
+	*v = min(max(*v+step, 0), maxMovement)
+	return s, nil
+}
+
+func (s *SheetScreen) viewEditMovement() string {
+	var b strings.Builder
+	b.WriteString(s.styles.Header.Render("Speeds and Senses"))
+	b.WriteString("\n\n")
+
+	for i, label := range movementLabels {
+		cursor, style := "  ", s.styles.NotProficient
+		if i == s.movement.row {
+			cursor, style = "> ", s.styles.Cursor
+		}
+		value := "none"
+		if v := s.movement.values[i]; v > 0 {
+			value = fmt.Sprintf("%d ft", v)
+		}
+		b.WriteString(components.Mark(components.Item("movement", i), style.Render(fmt.Sprintf("%s%-12s", cursor, label))))
+		b.WriteString(components.Mark(components.Item("less", i), "◀") + " " + value + " " + components.Mark(components.Item("more", i), "▶") + "\n")
+		if i == moveBurrow {
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+
+	text := lipgloss.NewStyle().Width(s.contentWidth(64))
+	b.WriteString(s.styles.Muted.Render(text.Render("Speeds change by 5 feet. Exhaustion slows every speed, as it does walking.")))
+
+	return lipgloss.NewStyle().Align(lipgloss.Left).Render(b.String())
+}
+
+// otherSpeeds lists the character's speeds besides walking, slowed by
+// exhaustion, like "fly 60 ft"
+func (s *SheetScreen) otherSpeeds() []string {
+	var speeds []string
+	values := movementValues(s.char)
+	for row := moveFly; row <= moveBurrow; row++ {
+		if values[row] > 0 {
+			speed := s.rules().ExhaustedSpeed(int(values[row]), int(s.char.Exhaustion))
+			speeds = append(speeds, fmt.Sprintf("%s %d ft", strings.ToLower(movementLabels[row]), speed))
+		}
+	}
+	return speeds
+}
+
+// senses lists the character's special senses, like "darkvision 60 ft"
+func senses(char db.Character) []string {
+	var senses []string
+	values := movementValues(char)
+	for row := senseDarkvision; row <= senseTruesight; row++ {
+		if values[row] > 0 {
+			senses = append(senses, fmt.Sprintf("%s %d ft", strings.ToLower(movementLabels[row]), values[row]))
+		}
+	}
+	return senses
+}
+
+// movementEdit sets a character's speeds and senses, given in form order
+func movementEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, values [movementRows]int32) sheetEdit {
+	set := func(values [movementRows]int32, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			updated, err := queries.UpdateCharacterMovement(ctx, db.UpdateCharacterMovementParams{
+				ID:          char.ID,
+				Speed:       values[moveWalk],
+				FlySpeed:    values[moveFly],
+				SwimSpeed:   values[moveSwim],
+				ClimbSpeed:  values[moveClimb],
+				BurrowSpeed: values[moveBurrow],
+				Darkvision:  values[senseDarkvision],
+				Blindsight:  values[senseBlindsight],
+				Truesight:   values[senseTruesight],
+			})
+			if err != nil {
+				return updated, err
+			}
+			audit.Record(ctx, queries, updated.ID, userID, audit.KindMovement, description)
+			return updated, nil
+		}
+	}
+
+	before := movementValues(char)
+	var changes []string
+	for i, label := range movementLabels {
+		if values[i] != before[i] {
+			changes = append(changes, fmt.Sprintf("%s %d → %d ft", strings.ToLower(label), before[i], values[i]))
+		}
+	}
+	return sheetEdit{
+		label:  "speed change",
+		apply:  set(values, "Changed "+strings.Join(changes, ", ")),
+		revert: set(before, "Undid speed change"),
+	}
+}
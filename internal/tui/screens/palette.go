@@ -0,0 +1,203 @@
+package screens
+
+import (
+	"fmt"
+
+	"github.com/brady1408/dnd/internal/auth"
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/tui/components"
+	"github.com/brady1408/dnd/internal/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func paletteStyles(st *styles.Styles) components.PaletteStyles {
+	return components.PaletteStyles{
+		Title:    st.Header,
+		Input:    st.FocusedInput,
+		Selected: st.Cursor,
+		Normal:   st.NotProficient,
+		Muted:    st.Muted,
+	}
+}
+
+// paletteCommands lists what the command palette can do on the sheet.
+// Most commands show the tab their key works on and press it, so they
+// behave exactly like the keybinding.
+func (s *SheetScreen) paletteCommands() []components.Command {
+	press := func(tab int, key string) func() tea.Cmd {
+		return func() tea.Cmd {
+			if tab >= 0 {
+				s.showTab(tab)
+			}
+			_, cmd := s.updateView(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+			return cmd
+		}
+	}
+
+	var commands []components.Command
+	for i, name := range sheetTabs {
+		tab := i
+		commands = append(commands, components.Command{Name: "Go to " + name, Key: "tab", Run: func() tea.Cmd {
+			s.showTab(tab)
+			return nil
+		}})
+	}
+
+	inspiration := "Gain inspiration"
+	if s.char.Inspiration {
+		inspiration = "Spend inspiration"
+	}
+	commands = append(commands,
+		components.Command{Name: "Switch character", Key: "q", Run: func() tea.Cmd {
+			return func() tea.Msg { return NavigateBackMsg{} }
+		}},
+		components.Command{Name: "Edit saving throws", Key: "e", Run: press(0, "e")},
+		components.Command{Name: "Add language", Key: "a", Run: press(0, "a")},
+		components.Command{Name: "Add tool proficiency", Key: "T", Run: press(0, "T")},
+		components.Command{Name: "Set portrait", Key: "I", Run: press(0, "I")},
+		components.Command{Name: "Edit skill proficiencies", Key: "e", Run: press(1, "e")},
+		components.Command{Name: "Edit HP", Key: "e", Run: press(2, "e")},
+		components.Command{Name: "Set temporary HP", Key: "t", Run: press(2, "t")},
+		components.Command{Name: "Raise max HP", Key: "m", Run: press(2, "m")},
+		components.Command{Name: "Override AC", Key: "o", Run: press(2, "o")},
+		components.Command{Name: "Set speeds and senses", Key: "v", Run: press(2, "v")},
+		components.Command{Name: "Take short rest", Key: "s", Run: press(2, "s")},
+		components.Command{Name: "Take long rest", Key: "L", Run: press(2, "L")},
+		components.Command{Name: "Add exhaustion", Key: "+", Run: press(2, "+")},
+		components.Command{Name: "Remove exhaustion", Key: "-", Run: press(2, "-")},
+		components.Command{Name: "Spend luck point", Key: "u", Run: press(2, "u")},
+		components.Command{Name: "Gain luck point", Key: "U", Run: press(2, "U")},
+		components.Command{Name: inspiration, Key: "i", Run: press(-1, "i")},
+	)
+	for i, r := range s.resources {
+		commands = append(commands, components.Command{Name: "Use " + r.Name, Key: "g", Run: func() tea.Cmd {
+			s.resourceCursor = i
+			return press(2, "g")()
+		}})
+		commands = append(commands, components.Command{Name: "Reset " + r.Name, Key: "R", Run: func() tea.Cmd {
+			s.resourceCursor = i
+			return press(2, "R")()
+		}})
+	}
+	if s.spellcasting == nil {
+		commands = append(commands, components.Command{Name: "Set up spellcasting", Key: "enter", Run: func() tea.Cmd {
+			s.showTab(3)
+			s.startSpellcastingSetup()
+			return nil
+		}})
+	}
+	if _, ok := s.preparedLimit(); ok {
+		commands = append(commands, components.Command{Name: "Change prepared spells", Key: "P", Run: press(3, "P")})
+	}
+	if s.char.ConcentratingOn != "" {
+		commands = append(commands, components.Command{Name: "End concentration on " + s.char.ConcentratingOn, Key: "x", Run: press(2, "x")})
+	}
+	commands = append(commands,
+		components.Command{Name: "New journal entry", Key: "a", Run: press(4, "a")},
+		components.Command{Name: "Edit features & traits", Key: "f", Run: press(4, "f")},
+		components.Command{Name: "Class table", Key: "c", Run: press(4, "c")},
+		components.Command{Name: "Add item", Key: "a", Run: press(6, "a")},
+		components.Command{Name: "Add weapon", Key: "w", Run: press(6, "w")},
+		components.Command{Name: "Craft item", Key: "c", Run: press(6, "c")},
+		components.Command{Name: "Buy equipment", Key: "b", Run: press(6, "b")},
+		components.Command{Name: "Award XP", Key: "X", Run: press(-1, "X")},
+		components.Command{Name: "Rename character", Key: "n", Run: press(-1, "n")},
+		components.Command{Name: "Build plan & level up", Key: "p", Run: press(-1, "p")},
+		components.Command{Name: "Share character", Key: "S", Run: press(-1, "S")},
+		components.Command{Name: "Print view (plain text or Markdown)", Key: "E", Run: press(-1, "E")},
+		components.Command{Name: "Search sheet", Key: "/", Run: press(-1, "/")},
+		components.Command{Name: "Open manual", Key: "?", Run: press(-1, "?")},
+		components.Command{Name: "Roll d20", Key: "r", Run: press(-1, "r")},
+	)
+	for _, skill := range character.SkillList {
+		commands = append(commands, components.Command{Name: "Roll " + skill, Run: func() tea.Cmd {
+			s.rollSkill(skill)
+			return nil
+		}})
+	}
+	commands = append(commands,
+		components.Command{Name: "Undo", Key: "ctrl+z", Run: func() tea.Cmd {
+			_, cmd := s.updateView(tea.KeyMsg{Type: tea.KeyCtrlZ})
+			return cmd
+		}},
+		components.Command{Name: "Redo", Key: "ctrl+y", Run: func() tea.Cmd {
+			_, cmd := s.updateView(tea.KeyMsg{Type: tea.KeyCtrlY})
+			return cmd
+		}},
+	)
+	return commands
+}
+
+// rollSkill rolls a d20 check with the character's modifier for skill
+func (s *SheetScreen) rollSkill(skill string) {
+	profBonus := s.rules().ProficiencyBonus(int(s.char.Level))
+	mod := character.AbilityModifier(abilityScore(s.char, character.Skills[skill])) + s.skillProficiency(skill).Bonus(profBonus)
+
+	roll := character.RollD20()
+	s.status = fmt.Sprintf("%s check: %d %s = %d", skill, roll, character.FormatModifierInt(mod), roll+mod) + s.natOneOffer(roll)
+}
+
+func (s *SheetScreen) updatePalette(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	chosen, closed, cmd := s.palette.Update(msg)
+	if !closed {
+		return s, cmd
+	}
+	s.mode = ModeView
+	if chosen == nil {
+		return s, nil
+	}
+	return s, chosen.Run()
+}
+
+// homePaletteCommands lists what the command palette can do on the
+// character list, including opening any character on the page
+func (h *HomeScreen) homePaletteCommands() []components.Command {
+	press := func(key string) func() tea.Cmd {
+		return func() tea.Cmd {
+			_, cmd := h.handleInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+			return cmd
+		}
+	}
+
+	var commands []components.Command
+	for _, char := range h.characters {
+		commands = append(commands, components.Command{Name: "Open " + char.Name, Run: func() tea.Cmd {
+			return func() tea.Msg { return CharacterSelectedMsg{Character: char} }
+		}})
+	}
+	if !h.templates {
+		commands = append(commands,
+			components.Command{Name: "New character", Key: "enter", Run: func() tea.Cmd {
+				return func() tea.Msg { return NavigateToCreateMsg{} }
+			}},
+			components.Command{Name: "Quick create character", Key: "f", Run: press("f")},
+			components.Command{Name: "Random character", Key: "R", Run: press("R")},
+			components.Command{Name: "Show templates", Key: "tab", Run: func() tea.Cmd {
+				_, cmd := h.handleInput(tea.KeyMsg{Type: tea.KeyTab})
+				return cmd
+			}},
+		)
+	} else {
+		commands = append(commands, components.Command{Name: "Show characters", Key: "tab", Run: func() tea.Cmd {
+			_, cmd := h.handleInput(tea.KeyMsg{Type: tea.KeyTab})
+			return cmd
+		}})
+	}
+	commands = append(commands,
+		components.Command{Name: "Search characters", Key: "/", Run: press("/")},
+		components.Command{Name: "Change sort order", Key: "s", Run: press("s")},
+		components.Command{Name: "Change color and icon", Key: "p", Run: press("p")},
+		components.Command{Name: "API tokens", Key: "t", Run: press("t")},
+		components.Command{Name: "Invites", Key: "i", Run: press("i")},
+		components.Command{Name: "Linked accounts", Key: "a", Run: press("a")},
+		components.Command{Name: "Settings", Key: "o", Run: press("o")},
+	)
+	if auth.IsAdmin(h.user) {
+		commands = append(commands, components.Command{Name: "Admin", Key: "A", Run: press("A")})
+	}
+	commands = append(commands,
+		components.Command{Name: "Open manual", Key: "?", Run: press("?")},
+		components.Command{Name: "Log out", Key: "l", Run: press("l")},
+	)
+	return commands
+}
@@ -0,0 +1,790 @@
+package screens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/audit"
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/sanitize"
+	"github.com/brady1408/dnd/internal/tui/components"
+	"github.com/brady1408/dnd/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PlanScreen lets a user sketch a character's progression through level 20
+// and apply the next planned level when the character levels up
+type PlanScreen struct {
+	ctx     context.Context
+	queries *db.Queries
+	userID  pgtype.UUID
+	char    db.Character
+	styles  *styles.Styles
+
+	plan      map[int]db.CharacterPlanLevel
+	cursor    int // level under the cursor, 1-20
+	editing   bool
+	confirmUp bool
+	err       string
+	width     int
+	height    int
+
+	// Opened to level up: the next level is edited, creating its plan if
+	// there isn't one, and applied once saved
+	levelUp bool
+
+	// Edit form for the level under the cursor
+	focus         planField
+	classIndex    int
+	asi           [2]int // index into character.Abilities, -1 for none
+	subclassInput textinput.Model
+	featInput     textinput.Model
+	notesInput    textinput.Model
+}
+
+// planField is a field of the plan edit form
+type planField int
+
+const (
+	planFieldClass planField = iota
+	planFieldSubclass
+	planFieldASI1
+	planFieldASI2
+	planFieldFeat
+	planFieldNotes
+)
+
+type NavigateToPlanMsg struct {
+	Character db.Character
+	LevelUp   bool // go straight to leveling up
+}
+
+type PlanLoadedMsg struct {
+	Levels []db.CharacterPlanLevel
+}
+
+type planSavedMsg struct {
+	Level db.CharacterPlanLevel
+}
+
+type planDeletedMsg struct {
+	Level int
+}
+
+type planErrorMsg struct {
+	Err error
+}
+
+func NewPlanScreen(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, levelUp bool, s *styles.Styles) *PlanScreen {
+	subclassInput := textinput.New()
+	subclassInput.Placeholder = "e.g. Champion"
+	subclassInput.CharLimit = 100
+	subclassInput.Width = 30
+
+	featInput := textinput.New()
+	featInput.Placeholder = "e.g. Lucky"
+	featInput.CharLimit = 100
+	featInput.Width = 30
+
+	notesInput := textinput.New()
+	notesInput.Placeholder = "Optional"
+	notesInput.CharLimit = 200
+	notesInput.Width = 40
+
+	return &PlanScreen{
+		ctx:           ctx,
+		queries:       queries,
+		userID:        userID,
+		char:          char,
+		styles:        s,
+		plan:          make(map[int]db.CharacterPlanLevel),
+		cursor:        min(int(char.Level)+1, character.MaxLevel),
+		levelUp:       levelUp && int(char.Level) < character.MaxLevel,
+		subclassInput: subclassInput,
+		featInput:     featInput,
+		notesInput:    notesInput,
+		width:         80,
+		height:        24,
+	}
+}
+
+func (p *PlanScreen) Init() tea.Cmd {
+	return func() tea.Msg {
+		levels, err := p.queries.GetCharacterPlan(p.ctx, p.char.ID)
+		if err != nil {
+			return planErrorMsg{Err: err}
+		}
+		return PlanLoadedMsg{Levels: levels}
+	}
+}
+
+// classAt returns the class taken at level: the planned class if there is
+// one, the character's class for levels already gained, or "" if unplanned
+func (p *PlanScreen) classAt(level int) string {
+	if row, ok := p.plan[level]; ok {
+		return row.Class
+	}
+	if level <= int(p.char.Level) {
+		return p.char.Class
+	}
+	return ""
+}
+
+// classLevelAt returns which level of class the character reaches at level
+func (p *PlanScreen) classLevelAt(level int, class string) int {
+	n := 1
+	for l := 1; l < level; l++ {
+		if p.classAt(l) == class {
+			n++
+		}
+	}
+	return n
+}
+
+func (p *PlanScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+		return p, nil
+
+	case PlanLoadedMsg:
+		for _, row := range msg.Levels {
+			p.plan[int(row.Level)] = row
+		}
+		if p.levelUp {
+			return p, p.startEdit()
+		}
+		return p, nil
+
+	case planSavedMsg:
+		p.plan[int(msg.Level.Level)] = msg.Level
+		p.editing = false
+		if p.levelUp && int(msg.Level.Level) == int(p.char.Level)+1 {
+			p.confirmUp = true
+		}
+		return p, nil
+
+	case planDeletedMsg:
+		delete(p.plan, msg.Level)
+		return p, nil
+
+	case planErrorMsg:
+		p.err = msg.Err.Error()
+		p.levelUp = false
+		return p, nil
+
+	case CharacterUpdatedMsg:
+		p.char = msg.Character
+		p.cursor = min(int(p.char.Level)+1, character.MaxLevel)
+		if p.levelUp {
+			// Back to the sheet with the new level
+			return p, func() tea.Msg { return NavigateBackMsg{} }
+		}
+		return p, nil
+
+	case components.ClickMsg:
+		p.err = ""
+		return p.click(msg.Zone)
+
+	case tea.KeyMsg:
+		p.err = ""
+		if p.confirmUp {
+			return p.updateConfirm(msg)
+		}
+		if p.editing {
+			return p.updateForm(msg)
+		}
+		return p.updateList(msg)
+	}
+
+	if p.editing {
+		return p.updateInputs(msg)
+	}
+	return p, nil
+}
+
+func (p *PlanScreen) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if p.cursor > 1 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < character.MaxLevel {
+			p.cursor++
+		}
+	case "enter", "e":
+		if p.cursor > int(p.char.Level) {
+			return p, p.startEdit()
+		}
+	case "d", "delete":
+		if _, ok := p.plan[p.cursor]; ok && p.cursor > int(p.char.Level) {
+			level := p.cursor
+			return p, func() tea.Msg {
+				err := p.queries.DeleteCharacterPlanLevel(p.ctx, db.DeleteCharacterPlanLevelParams{
+					CharacterID: p.char.ID,
+					Level:       int32(level),
+				})
+				if err != nil {
+					return planErrorMsg{Err: err}
+				}
+				return planDeletedMsg{Level: level}
+			}
+		}
+	case "u":
+		next := int(p.char.Level) + 1
+		if next > character.MaxLevel {
+			p.err = "Already at the maximum level."
+		} else if _, ok := p.plan[next]; !ok {
+			p.err = fmt.Sprintf("Plan level %d before leveling up.", next)
+		} else {
+			p.confirmUp = true
+		}
+	case "esc", "q":
+		return p, func() tea.Msg { return NavigateBackMsg{} }
+	}
+
+	return p, nil
+}
+
+func (p *PlanScreen) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		p.confirmUp = false
+		return p, p.applyNextLevel()
+	case "n", "N", "esc":
+		p.confirmUp = false
+		p.levelUp = false
+	}
+	return p, nil
+}
+
+// startEdit fills the form from the plan for the level under the cursor
+func (p *PlanScreen) startEdit() tea.Cmd {
+	p.editing = true
+	p.focus = planFieldClass
+	p.asi = [2]int{-1, -1}
+
+	row, ok := p.plan[p.cursor]
+	if !ok {
+		// Default to continuing in the previous level's class
+		row = db.CharacterPlanLevel{Class: p.classAt(p.cursor - 1)}
+		if row.Class == "" {
+			row.Class = p.char.Class
+		}
+	}
+
+	p.classIndex = 0
+	for i, c := range character.Classes {
+		if c == row.Class {
+			p.classIndex = i
+		}
+	}
+	for i, inc := range row.AbilityIncreases {
+		if i < len(p.asi) {
+			p.asi[i] = abilityIndex(inc)
+		}
+	}
+	p.subclassInput.SetValue(row.Subclass)
+	p.featInput.SetValue(row.Feat)
+	p.notesInput.SetValue(row.Notes)
+	return p.focusField()
+}
+
+// formFields returns the form fields that apply to the level being edited
+func (p *PlanScreen) formFields() []planField {
+	class := character.Classes[p.classIndex]
+	classLevel := p.classLevelAt(p.cursor, class)
+
+	fields := []planField{planFieldClass}
+	if character.IsSubclassLevel(class, classLevel) {
+		fields = append(fields, planFieldSubclass)
+	}
+	if character.IsASILevel(class, classLevel) {
+		fields = append(fields, planFieldASI1, planFieldASI2, planFieldFeat)
+	}
+	return append(fields, planFieldNotes)
+}
+
+func (p *PlanScreen) hasField(field planField) bool {
+	for _, f := range p.formFields() {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// moveFocus moves the form focus by delta visible fields
+func (p *PlanScreen) moveFocus(delta int) tea.Cmd {
+	fields := p.formFields()
+	idx := 0
+	for i, f := range fields {
+		if f == p.focus {
+			idx = i
+		}
+	}
+	p.focus = fields[(idx+delta+len(fields))%len(fields)]
+	return p.focusField()
+}
+
+func (p *PlanScreen) focusField() tea.Cmd {
+	p.subclassInput.Blur()
+	p.featInput.Blur()
+	p.notesInput.Blur()
+	switch p.focus {
+	case planFieldSubclass:
+		p.subclassInput.Focus()
+	case planFieldFeat:
+		p.featInput.Focus()
+	case planFieldNotes:
+		p.notesInput.Focus()
+	default:
+		return nil
+	}
+	return textinput.Blink
+}
+
+func (p *PlanScreen) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		// Leaving the level up shows the whole plan
+		p.editing = false
+		p.levelUp = false
+		return p, nil
+	case "ctrl+s":
+		return p, p.savePlanLevel()
+	case "tab", "down", "enter":
+		if msg.String() == "enter" && p.focus == planFieldNotes {
+			return p, p.savePlanLevel()
+		}
+		return p, p.moveFocus(1)
+	case "shift+tab", "up":
+		return p, p.moveFocus(-1)
+	case "left", "right":
+		delta := 1
+		if msg.String() == "left" {
+			delta = -1
+		}
+		switch p.focus {
+		case planFieldClass:
+			p.classIndex = (p.classIndex + delta + len(character.Classes)) % len(character.Classes)
+			return p, nil
+		case planFieldASI1, planFieldASI2:
+			// Cycle through "none" (-1) and each ability
+			i := p.focus - planFieldASI1
+			n := len(character.Abilities) + 1
+			p.asi[i] = (p.asi[i]+1+delta+n)%n - 1
+			return p, nil
+		}
+	}
+
+	return p.updateInputs(msg)
+}
+
+func (p *PlanScreen) updateInputs(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch p.focus {
+	case planFieldSubclass:
+		p.subclassInput, cmd = p.subclassInput.Update(msg)
+	case planFieldFeat:
+		p.featInput, cmd = p.featInput.Update(msg)
+	case planFieldNotes:
+		p.notesInput, cmd = p.notesInput.Update(msg)
+	}
+	return p, cmd
+}
+
+func (p *PlanScreen) savePlanLevel() tea.Cmd {
+	params := db.UpsertCharacterPlanLevelParams{
+		CharacterID:      p.char.ID,
+		Level:            int32(p.cursor),
+		Class:            character.Classes[p.classIndex],
+		AbilityIncreases: []string{},
+		Notes:            sanitize.Line(p.notesInput.Value(), sanitize.MaxText),
+	}
+	if p.hasField(planFieldSubclass) {
+		params.Subclass = sanitize.Line(p.subclassInput.Value(), sanitize.MaxName)
+	}
+	if p.hasField(planFieldASI1) {
+		for _, i := range p.asi {
+			if i >= 0 {
+				params.AbilityIncreases = append(params.AbilityIncreases, character.Abilities[i])
+			}
+		}
+		params.Feat = sanitize.Line(p.featInput.Value(), sanitize.MaxName)
+		if params.Feat != "" && len(params.AbilityIncreases) > 0 {
+			p.err = "Choose ability score increases or a feat, not both."
+			return nil
+		}
+	}
+
+	return func() tea.Msg {
+		row, err := p.queries.UpsertCharacterPlanLevel(p.ctx, params)
+		if err != nil {
+			return planErrorMsg{Err: err}
+		}
+		return planSavedMsg{Level: row}
+	}
+}
+
+// applyNextLevel levels the character up using the plan for the next level
+func (p *PlanScreen) applyNextLevel() tea.Cmd {
+	char := p.char
+	row := p.plan[int(char.Level)+1]
+	if _, err := levelUpScores(char, row); err != nil {
+		p.err = err.Error() + "."
+		p.levelUp = false
+		return nil
+	}
+
+	return func() tea.Msg {
+		updated, err := levelUp(p.ctx, p.queries, p.userID, char, row)
+		if err != nil {
+			return planErrorMsg{Err: err}
+		}
+		return CharacterUpdatedMsg{Character: updated}
+	}
+}
+
+// errLevelChanged is returned when a character's level changed after the
+// plan was opened
+var errLevelChanged = errors.New("character level changed, go back and reopen the plan")
+
+// levelUpScores applies a plan level's ability score increases
+func levelUpScores(char db.Character, row db.CharacterPlanLevel) ([6]int32, error) {
+	scores := [6]int32{char.Strength, char.Dexterity, char.Constitution, char.Intelligence, char.Wisdom, char.Charisma}
+	for _, inc := range row.AbilityIncreases {
+		i := abilityIndex(inc)
+		if i < 0 {
+			continue
+		}
+		if scores[i] >= character.MaxAbilityScore {
+			return scores, fmt.Errorf("%s is already %d", inc, character.MaxAbilityScore)
+		}
+		scores[i]++
+	}
+	return scores, nil
+}
+
+// levelUp levels a character up to a plan level. Every write is made in one
+// transaction, so a failure leaves the character at its old level rather
+// than with the new level and the old slots or resources.
+func levelUp(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, row db.CharacterPlanLevel) (db.Character, error) {
+	scores, err := levelUpScores(char, row)
+	if err != nil {
+		return char, err
+	}
+
+	// A higher Constitution modifier also raises HP for every earlier level
+	oldMod := character.AbilityModifier(int(char.Constitution))
+	newMod := character.AbilityModifier(int(scores[2]))
+	gain := int32(character.LevelUpHP(row.Class, int(scores[2])) + (newMod-oldMod)*int(char.Level))
+
+	// Record the subclass and feat with the character's features
+	var gained []string
+	if row.Subclass != "" {
+		gained = append(gained, fmt.Sprintf("Subclass: %s (level %d)", row.Subclass, row.Level))
+	}
+	if row.Feat != "" {
+		gained = append(gained, fmt.Sprintf("Feat: %s (level %d)", row.Feat, row.Level))
+	}
+
+	var updated db.Character
+	err = queries.InTx(ctx, func(q *db.Queries) error {
+		var err error
+		updated, err = q.LevelUpCharacter(ctx, db.LevelUpCharacterParams{
+			ID:               char.ID,
+			Level:            row.Level,
+			MaxHitPoints:     char.MaxHitPoints + gain,
+			CurrentHitPoints: char.CurrentHitPoints + gain,
+			Strength:         scores[0],
+			Dexterity:        scores[1],
+			Constitution:     scores[2],
+			Intelligence:     scores[3],
+			Wisdom:           scores[4],
+			Charisma:         scores[5],
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errLevelChanged
+		}
+		if err != nil {
+			return err
+		}
+		err = q.AddHPHistory(ctx, db.AddHPHistoryParams{
+			CharacterID:        updated.ID,
+			CurrentHitPoints:   updated.CurrentHitPoints,
+			TemporaryHitPoints: updated.TemporaryHitPoints,
+			MaxHitPoints:       updated.MaxHitPoints,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(gained) > 0 {
+			features := strings.TrimRight(updated.FeaturesTraits, "\n")
+			if features != "" {
+				features += "\n"
+			}
+			updated, err = q.UpdateCharacterNotes(ctx, db.UpdateCharacterNotesParams{
+				ID:             updated.ID,
+				FeaturesTraits: features + strings.Join(gained, "\n"),
+				Notes:          updated.Notes,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// Higher Dexterity, or Constitution for a Barbarian's Unarmored
+		// Defense, changes AC
+		updated, err = storeArmorClass(ctx, q, updated, updated.ArmorClassOverride)
+		if err != nil {
+			return err
+		}
+
+		if err := levelUpSpellcasting(ctx, q, updated, row); err != nil {
+			return err
+		}
+		return syncClassResources(ctx, q, updated)
+	})
+	if err != nil {
+		return char, err
+	}
+
+	details := []string{row.Class, fmt.Sprintf("+%d HP", gain)}
+	for _, inc := range row.AbilityIncreases {
+		details = append(details, inc+" +1")
+	}
+	details = append(details, gained...)
+	audit.Record(ctx, queries, updated.ID, userID, audit.KindLevelUp,
+		fmt.Sprintf("Leveled up to %d (%s)", row.Level, strings.Join(details, ", ")))
+	return updated, nil
+}
+
+// levelUpSpellcasting brings spell slots, save DC, and attack bonus up to a
+// character's new level, and sets up spellcasting for a class or subclass
+// that starts casting at it
+func levelUpSpellcasting(ctx context.Context, queries *db.Queries, char db.Character, row db.CharacterPlanLevel) error {
+	plan, err := queries.GetCharacterPlan(ctx, char.ID)
+	if err != nil {
+		return err
+	}
+	sc, err := queries.GetCharacterSpellcasting(ctx, char.ID)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		// Levels in another class are set up from the sheet, which asks
+		// how many were taken
+		if row.Class != char.Class {
+			return nil
+		}
+		class := row.Class
+		for _, c := range character.SpellcastingClasses() {
+			if strings.EqualFold(c, row.Subclass) && character.SpellcastingBaseClass(c) == row.Class {
+				class = c
+			}
+		}
+		ability, ok := character.SpellcastingAbility(class)
+		slots := character.RulesetFor(char.Ruleset).SpellSlots(class, classLevel(char, plan))
+		if !ok || !slices.ContainsFunc(slots, func(n int) bool { return n > 0 }) {
+			return nil
+		}
+		score := abilityScore(char, ability)
+		_, err := queries.CreateCharacterSpellcasting(ctx, db.CreateCharacterSpellcastingParams{
+			CharacterID:         char.ID,
+			SpellcastingClass:   class,
+			SpellcastingAbility: ability,
+			SpellSaveDc:         int32(character.SpellSaveDC(score, int(char.Level))),
+			SpellAttackBonus:    int32(character.SpellAttackBonus(score, int(char.Level))),
+			SlotsMax:            slotCounts(slots),
+		})
+		return err
+	case err != nil:
+		return err
+	}
+
+	if params, stale := spellcastingForLevel(char, sc, plan); stale {
+		return queries.UpdateCharacterSpellcasting(ctx, params)
+	}
+	return nil
+}
+
+// abilityIndex returns the index of an ability in character.Abilities, or -1
+func abilityIndex(ability string) int {
+	for i, a := range character.Abilities {
+		if strings.EqualFold(a, ability) {
+			return i
+		}
+	}
+	return -1
+}
+
+// planChoices summarizes the ASIs and feat of a planned level
+func planChoices(row db.CharacterPlanLevel) string {
+	if row.Feat != "" {
+		return "Feat: " + row.Feat
+	}
+
+	var parts []string
+	counts := make(map[string]int)
+	for _, inc := range row.AbilityIncreases {
+		if counts[inc] == 0 {
+			parts = append(parts, inc)
+		}
+		counts[inc]++
+	}
+	for i, a := range parts {
+		parts[i] = fmt.Sprintf("+%d %s", counts[a], a[:3])
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (p *PlanScreen) View() string {
+	var b strings.Builder
+
+	if p.levelUp {
+		b.WriteString(p.styles.Title.Render(fmt.Sprintf("Level Up - %s", p.char.Name)))
+		b.WriteString("\n\n")
+		b.WriteString(p.styles.Muted.Render(fmt.Sprintf("Choose what level %d brings. It's saved to the build plan, then applied.", p.char.Level+1)))
+		b.WriteString("\n\n")
+	} else {
+		b.WriteString(p.styles.Title.Render(fmt.Sprintf("Build Plan - %s", p.char.Name)))
+		b.WriteString("\n\n")
+	}
+
+	if p.editing {
+		b.WriteString(p.viewForm())
+	} else {
+		b.WriteString(p.viewList())
+	}
+
+	if xpLevel := character.LevelFromXP(int(p.char.ExperiencePoints)); xpLevel > int(p.char.Level) && !p.editing {
+		b.WriteString("\n")
+		b.WriteString(p.styles.SuccessText.Render(fmt.Sprintf(
+			"%d XP is enough for level %d. Press u to level up.", p.char.ExperiencePoints, xpLevel,
+		)))
+	}
+	if p.confirmUp {
+		next := int(p.char.Level) + 1
+		b.WriteString("\n")
+		b.WriteString(p.styles.WarningText.Render(fmt.Sprintf(
+			"Level up to %d as %s? (y/n)", next, p.plan[next].Class,
+		)))
+	}
+	if p.err != "" {
+		b.WriteString("\n")
+		b.WriteString(p.styles.ErrorText.Render("Error: " + p.err))
+	}
+
+	b.WriteString("\n\n")
+	switch {
+	case p.confirmUp:
+		b.WriteString(p.styles.Help.Render("y: level up • n: cancel"))
+	case p.editing && p.levelUp:
+		b.WriteString(p.styles.Help.Render("tab/↑↓: move • ←/→: change • ctrl+s: save and level up • esc: whole plan"))
+	case p.editing:
+		b.WriteString(p.styles.Help.Render("tab/↑↓: move • ←/→: change • ctrl+s: save • esc: cancel"))
+	default:
+		b.WriteString(p.styles.Help.Render("↑/↓: navigate • enter: plan level • d: clear • u: level up • esc: back"))
+	}
+
+	return lipgloss.Place(p.width, p.height,
+		lipgloss.Center, lipgloss.Center,
+		b.String())
+}
+
+func (p *PlanScreen) viewList() string {
+	var b strings.Builder
+
+	b.WriteString(p.styles.Muted.Render(fmt.Sprintf("  %-3s %-13s %-18s %s", "Lv", "Class", "Subclass", "ASI / Feat")))
+	b.WriteString("\n")
+
+	for level := 1; level <= character.MaxLevel; level++ {
+		cursor := "  "
+		if level == p.cursor {
+			cursor = "> "
+		}
+
+		class := p.classAt(level)
+		classLabel := "-"
+		if class != "" {
+			classLabel = fmt.Sprintf("%s %d", class, p.classLevelAt(level, class))
+		}
+		row := p.plan[level]
+		line := fmt.Sprintf("%s%-3d %-13s %-18s %s",
+			cursor,
+			level,
+			classLabel,
+			truncate(row.Subclass, 18),
+			planChoices(row),
+		)
+
+		style := p.styles.Unselected
+		switch {
+		case level == p.cursor:
+			style = p.styles.Selected
+		case level <= int(p.char.Level):
+			style = p.styles.Muted
+		case class == "":
+			style = p.styles.NotProficient
+		}
+		b.WriteString(components.Mark(components.Item("level", level), style.Render(line)))
+		b.WriteString("\n")
+	}
+
+	if row, ok := p.plan[p.cursor]; ok && row.Notes != "" {
+		b.WriteString("\n")
+		b.WriteString(p.styles.Muted.Render(row.Notes))
+	}
+
+	return lipgloss.NewStyle().Align(lipgloss.Left).Render(b.String())
+}
+
+func (p *PlanScreen) viewForm() string {
+	var b strings.Builder
+
+	class := character.Classes[p.classIndex]
+	b.WriteString(p.styles.Header.Render(fmt.Sprintf("Level %d (%s %d)", p.cursor, class, p.classLevelAt(p.cursor, class))))
+	b.WriteString("\n\n")
+
+	for _, field := range p.formFields() {
+		label, value := "", ""
+		switch field {
+		case planFieldClass:
+			label, value = "Class", components.Mark(components.Item("less", int(field)), "<")+" "+class+" "+components.Mark(components.Item("more", int(field)), ">")
+		case planFieldSubclass:
+			label, value = "Subclass", p.subclassInput.View()
+		case planFieldASI1, planFieldASI2:
+			label = "Ability +1"
+			ability := "none"
+			if i := p.asi[field-planFieldASI1]; i >= 0 {
+				ability = character.Abilities[i]
+			}
+			value = components.Mark(components.Item("less", int(field)), "<") + " " + ability + " " + components.Mark(components.Item("more", int(field)), ">")
+		case planFieldFeat:
+			label, value = "or Feat", p.featInput.View()
+		case planFieldNotes:
+			label, value = "Notes", p.notesInput.View()
+		}
+
+		style := p.styles.Unselected
+		cursor := "  "
+		if field == p.focus {
+			style = p.styles.Selected
+			cursor = "> "
+		}
+		b.WriteString(components.Mark(components.Item("field", int(field)), style.Render(fmt.Sprintf("%s%-12s", cursor, label))+" "+value))
+		b.WriteString("\n")
+	}
+
+	return lipgloss.NewStyle().Align(lipgloss.Left).Render(b.String())
+}
@@ -0,0 +1,178 @@
+package screens
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/audit"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/portrait"
+	"github.com/brady1408/dnd/internal/sanitize"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxPortraitURL caps the length of a portrait's picture URL
+const maxPortraitURL = 2000
+
+type PortraitLoadedMsg struct {
+	Portrait *db.CharacterPortrait // nil without one
+}
+
+// portraitFetchedMsg is sent when a picture has been fetched and shrunk
+type portraitFetchedMsg struct {
+	URL      string
+	Portrait portrait.Portrait
+	Err      error
+}
+
+// portraitOf reads a stored portrait back
+func portraitOf(p db.CharacterPortrait) portrait.Portrait {
+	return portrait.Portrait{Width: int(p.Width), Height: int(p.Height), Pixels: p.Pixels}
+}
+
+func (s *SheetScreen) loadPortrait() tea.Cmd {
+	return s.cache.load(s.char.ID, partPortrait, func() tea.Msg {
+		p, err := s.queries.GetCharacterPortrait(s.ctx, s.char.ID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return PortraitLoadedMsg{}
+		}
+		if err != nil {
+			return ErrorMsg{Action: "load the portrait", Err: err, Retry: s.loadPortrait()}
+		}
+		return PortraitLoadedMsg{Portrait: &p}
+	})
+}
+
+func (s *SheetScreen) handlePortraitLoaded(msg PortraitLoadedMsg) {
+	s.portrait = msg.Portrait
+	s.portraitArt = ""
+	if msg.Portrait != nil {
+		s.portraitArt = s.styles.Portrait(portraitOf(*msg.Portrait))
+	}
+}
+
+// startSetPortrait asks for the URL of a picture to draw the portrait from
+func (s *SheetScreen) startSetPortrait() tea.Cmd {
+	s.mode = ModeSetPortrait
+	s.portraitInput.SetValue("")
+	if s.portrait != nil {
+		s.portraitInput.SetValue(s.portrait.SourceUrl)
+	}
+	s.portraitInput.Focus()
+	return textinput.Blink
+}
+
+func (s *SheetScreen) updateSetPortrait(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		s.mode = ModeView
+		url := sanitize.Line(s.portraitInput.Value(), maxPortraitURL)
+		if url == "" {
+			if s.portrait == nil {
+				return s, nil
+			}
+			return s, s.undo.run(portraitEdit(s.ctx, s.queries, s.userID, s.char, s.portrait, nil))
+		}
+		s.status = "Fetching portrait..."
+		ctx := s.ctx
+		return s, func() tea.Msg {
+			p, err := portrait.Fetch(ctx, url)
+			return portraitFetchedMsg{URL: url, Portrait: p, Err: err}
+		}
+
+	case "esc":
+		s.mode = ModeView
+		return s, nil
+	}
+
+	var cmd tea.Cmd
+	s.portraitInput, cmd = s.portraitInput.Update(msg)
+	return s, cmd
+}
+
+func (s *SheetScreen) handlePortraitFetched(msg portraitFetchedMsg) tea.Cmd {
+	if msg.Err != nil {
+		s.status = "Couldn't fetch the portrait: " + msg.Err.Error()
+		return nil
+	}
+	s.status = ""
+	after := &db.CharacterPortrait{
+		CharacterID: s.char.ID,
+		Width:       int32(msg.Portrait.Width),
+		Height:      int32(msg.Portrait.Height),
+		Pixels:      msg.Portrait.Pixels,
+		SourceUrl:   msg.URL,
+	}
+	return s.undo.run(portraitEdit(s.ctx, s.queries, s.userID, s.char, s.portrait, after))
+}
+
+// viewSetPortrait shows the current portrait and asks for a new one
+func (s *SheetScreen) viewSetPortrait() string {
+	var b strings.Builder
+	b.WriteString(s.styles.Header.Render("Portrait"))
+	b.WriteString("\n\n")
+	if s.portraitArt != "" {
+		b.WriteString(s.portraitArt)
+		b.WriteString("\n\n")
+	}
+	b.WriteString("Picture URL: ")
+	b.WriteString(s.styles.FocusedInput.Render(s.portraitInput.View()))
+	b.WriteString("\n\n")
+
+	text := lipgloss.NewStyle().Width(s.contentWidth(64))
+	b.WriteString(s.styles.Muted.Render(text.Render("Link a PNG, JPEG, or GIF. It's shrunk to a few dozen pixels and drawn with block characters, so bold, simple pictures work best. Leave the URL blank to remove the portrait.")))
+	return b.String()
+}
+
+// withPortrait shows the portrait to the right of text when there's room
+func (s *SheetScreen) withPortrait(text string) string {
+	width := s.width - 8
+	if s.split() {
+		width /= 2
+	}
+	if s.portraitArt == "" || lipgloss.Width(text)+lipgloss.Width(s.portraitArt)+4 > width {
+		return text
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, text, "    ", s.portraitArt)
+}
+
+// portraitEdit replaces a character's portrait; a nil portrait removes it
+func portraitEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, before, after *db.CharacterPortrait) sheetEdit {
+	set := func(p *db.CharacterPortrait, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			var err error
+			if p == nil {
+				err = queries.DeleteCharacterPortrait(ctx, char.ID)
+			} else {
+				err = queries.UpsertCharacterPortrait(ctx, db.UpsertCharacterPortraitParams{
+					CharacterID: char.ID,
+					Width:       p.Width,
+					Height:      p.Height,
+					Pixels:      p.Pixels,
+					SourceUrl:   p.SourceUrl,
+				})
+			}
+			if err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindPortrait, description)
+			return queries.GetCharacterByID(ctx, char.ID)
+		}
+	}
+
+	description := "Removed portrait"
+	if after != nil {
+		description = "Set portrait from " + after.SourceUrl
+	}
+	return sheetEdit{
+		label:   "portrait change",
+		touches: partPortrait,
+		apply:   set(after, description),
+		revert:  set(before, "Undid portrait change"),
+	}
+}
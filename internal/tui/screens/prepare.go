@@ -0,0 +1,259 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/audit"
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/tui/components"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// preparableSpell is a leveled spell a prepared caster could prepare
+type preparableSpell struct {
+	name          string
+	level         int32
+	concentration bool
+}
+
+// spellPreparation is the list for choosing a new set of prepared spells
+type spellPreparation struct {
+	spells []preparableSpell
+	chosen map[string]bool
+	limit  int
+	cursor int
+}
+
+// preparedLimit returns how many spells the character prepares, or false if
+// their casting class knows a fixed list of spells instead
+func (s *SheetScreen) preparedLimit() (int, bool) {
+	if s.spellcasting == nil {
+		return 0, false
+	}
+	sc := s.spellcasting
+	return character.PreparedSpellLimit(sc.SpellcastingClass, abilityScore(s.char, sc.SpellcastingAbility), int(s.char.Level))
+}
+
+// startSpellPreparation opens the list of spells the character could
+// prepare, with the ones prepared now already chosen. Wizards prepare from
+// their spellbook; other prepared casters from their whole class list, up to
+// the highest level they have slots for.
+func (s *SheetScreen) startSpellPreparation() bool {
+	limit, ok := s.preparedLimit()
+	if !ok {
+		return false
+	}
+	prep := spellPreparation{chosen: map[string]bool{}, limit: limit}
+	for _, spell := range s.spells {
+		if spell.Level == 0 {
+			continue
+		}
+		prep.spells = append(prep.spells, preparableSpell{spell.Name, spell.Level, spell.Concentration})
+		if spell.Prepared {
+			prep.chosen[spell.Name] = true
+		}
+	}
+
+	class := s.spellcasting.SpellcastingClass
+	if class != "Wizard" {
+		for level, total := range s.spellcasting.SlotsMax {
+			if total == 0 {
+				continue
+			}
+			for _, spell := range character.SpellsForClass(class, level+1) {
+				known := slices.ContainsFunc(prep.spells, func(p preparableSpell) bool { return p.name == spell.Name })
+				if !known {
+					prep.spells = append(prep.spells, preparableSpell{spell.Name, int32(spell.Level), spell.Concentration})
+				}
+			}
+		}
+	}
+	slices.SortFunc(prep.spells, func(a, b preparableSpell) int {
+		if a.level != b.level {
+			return int(a.level - b.level)
+		}
+		return strings.Compare(a.name, b.name)
+	})
+
+	s.prepare = prep
+	s.mode = ModePrepareSpells
+	return true
+}
+
+func (s *SheetScreen) updateSpellPreparation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	prep := &s.prepare
+	switch msg.String() {
+	case "esc":
+		s.mode = ModeView
+	case "up", "k":
+		if prep.cursor > 0 {
+			prep.cursor--
+		}
+	case "down", "j":
+		if prep.cursor < len(prep.spells)-1 {
+			prep.cursor++
+		}
+	case " ":
+		if prep.cursor >= len(prep.spells) {
+			return s, nil
+		}
+		name := prep.spells[prep.cursor].name
+		if !prep.chosen[name] && len(prep.chosen) >= prep.limit {
+			s.status = fmt.Sprintf("Already preparing %d spells; unprepare one first", prep.limit)
+			return s, nil
+		}
+		s.status = ""
+		if prep.chosen[name] {
+			delete(prep.chosen, name)
+		} else {
+			prep.chosen[name] = true
+		}
+	case "enter":
+		before := map[string]bool{}
+		for _, spell := range s.spells {
+			if spell.Level > 0 && spell.Prepared {
+				before[spell.Name] = true
+			}
+		}
+		return s, s.undo.run(preparedSpellsEdit(s.ctx, s.queries, s.userID, s.char,
+			s.spellcasting.SpellcastingClass == "Wizard", prep.spells, before, prep.chosen))
+	}
+	return s, nil
+}
+
+// viewSpellPreparation lists the spells that could be prepared around the
+// cursor, marking the chosen ones
+func (s *SheetScreen) viewSpellPreparation() string {
+	var b strings.Builder
+	prep := s.prepare
+
+	b.WriteString(s.styles.Header.Render("Change Prepared Spells"))
+	b.WriteString("\n\n")
+
+	count := s.styles.StatValue.UnsetWidth().Render(fmt.Sprintf("%d of %d", len(prep.chosen), prep.limit))
+	b.WriteString(count + " prepared\n")
+
+	if len(prep.spells) == 0 {
+		b.WriteString("\n")
+		b.WriteString(s.styles.Muted.Render("No spells to prepare."))
+		return b.String()
+	}
+
+	rows := 12
+	if s.short() {
+		rows = 6
+	}
+	start := min(max(prep.cursor-rows/2, 0), max(len(prep.spells)-rows, 0))
+	end := min(start+rows, len(prep.spells))
+	level := int32(-1)
+	for i := start; i < end; i++ {
+		spell := prep.spells[i]
+		if spell.level != level {
+			level = spell.level
+			b.WriteString("\n")
+			b.WriteString(s.styles.Header.Render(fmt.Sprintf("Level %d", level)))
+			b.WriteString("\n")
+		}
+		mark, style := "○ ", s.styles.NotProficient
+		if prep.chosen[spell.name] {
+			mark, style = "● ", s.styles.Proficient
+		}
+		cursor := "  "
+		if i == prep.cursor {
+			cursor, style = "> ", s.styles.Cursor
+		}
+		name := spell.name
+		if spell.concentration {
+			name += " (C)"
+		}
+		b.WriteString(components.Mark(components.Item("prepare", i), style.Render(cursor+mark+name)))
+		b.WriteString("\n")
+	}
+	if len(prep.spells) > rows {
+		b.WriteString(s.styles.Muted.Render(fmt.Sprintf("%d–%d of %d", start+1, end, len(prep.spells))))
+		b.WriteString("\n")
+	}
+
+	return lipgloss.NewStyle().
+		Align(lipgloss.Left).
+		Render(b.String())
+}
+
+// preparedSpellsEdit replaces the character's prepared spells. Wizards keep
+// unprepared spells in their spellbook; other casters drop them from the
+// sheet, since they can prepare them again from their class list.
+func preparedSpellsEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, wizard bool, spells []preparableSpell, before, after map[string]bool) sheetEdit {
+	before, after = maps.Clone(before), maps.Clone(after)
+	set := func(chosen map[string]bool, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			current, err := queries.GetCharacterSpells(ctx, char.ID)
+			if err != nil {
+				return char, err
+			}
+			have := map[string]bool{}
+			for _, spell := range current {
+				if spell.Level == 0 {
+					continue
+				}
+				have[spell.Name] = true
+				switch {
+				case chosen[spell.Name] == spell.Prepared:
+				case chosen[spell.Name] || wizard:
+					err = queries.SetCharacterSpellPrepared(ctx, db.SetCharacterSpellPreparedParams{ID: spell.ID, Prepared: chosen[spell.Name]})
+				default:
+					err = queries.DeleteCharacterSpell(ctx, spell.ID)
+				}
+				if err != nil {
+					return char, err
+				}
+			}
+			for _, spell := range spells {
+				if !chosen[spell.name] || have[spell.name] {
+					continue
+				}
+				_, err := queries.AddCharacterSpell(ctx, db.AddCharacterSpellParams{
+					CharacterID:   char.ID,
+					Name:          spell.name,
+					Level:         spell.level,
+					Prepared:      true,
+					Concentration: spell.concentration,
+				})
+				if err != nil {
+					return char, err
+				}
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindSpellcasting, description)
+			return queries.GetCharacterByID(ctx, char.ID)
+		}
+	}
+
+	var added, dropped []string
+	for _, spell := range spells {
+		switch {
+		case after[spell.name] && !before[spell.name]:
+			added = append(added, spell.name)
+		case before[spell.name] && !after[spell.name]:
+			dropped = append(dropped, spell.name)
+		}
+	}
+	description := fmt.Sprintf("Prepared %d spells", len(after))
+	if len(added) > 0 {
+		description += ", added " + strings.Join(added, ", ")
+	}
+	if len(dropped) > 0 {
+		description += ", dropped " + strings.Join(dropped, ", ")
+	}
+	return sheetEdit{
+		label:   "prepared spells",
+		touches: partSpells,
+		apply:   set(after, description),
+		revert:  set(before, "Undid change of prepared spells"),
+	}
+}
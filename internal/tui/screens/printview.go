@@ -0,0 +1,76 @@
+package screens
+
+import (
+	"strings"
+
+	"github.com/brady1408/dnd/internal/printout"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// printView is the whole sheet as plain text or Markdown, shown without
+// styling so it can be selected and copied from the terminal
+type printView struct {
+	markdown bool
+	offset   int // first line shown
+}
+
+// printout renders the sheet as the print view shows it
+func (s *SheetScreen) printout() string {
+	format := printout.Text
+	if s.print.markdown {
+		format = printout.Markdown
+	}
+	return printout.Render(printout.Sheet{
+		Character:    s.char,
+		Spellcasting: s.spellcasting,
+		Spells:       s.spells,
+		Inventory:    s.inventory,
+		Resources:    s.resources,
+		Journal:      s.journal,
+	}, format)
+}
+
+// printRows is how many lines of the printout fit above the help line
+func (s *SheetScreen) printRows() int {
+	return max(s.height-2, 1)
+}
+
+func (s *SheetScreen) updatePrintView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	lines := strings.Count(s.printout(), "\n")
+	last := max(lines-s.printRows(), 0)
+	switch msg.String() {
+	case "up", "k":
+		s.print.offset--
+	case "down", "j":
+		s.print.offset++
+	case "pgup", "b":
+		s.print.offset -= s.printRows()
+	case "pgdown", " ", "f":
+		s.print.offset += s.printRows()
+	case "home", "g":
+		s.print.offset = 0
+	case "end", "G":
+		s.print.offset = last
+	case "m":
+		s.print.markdown = !s.print.markdown
+		s.print.offset = 0
+	case "esc", "q", "E":
+		s.mode = ModeView
+		return s, tea.EnableMouseCellMotion
+	}
+	s.print.offset = min(max(s.print.offset, 0), last)
+	return s, nil
+}
+
+// viewPrint fills the screen with the printout and nothing else, so what's
+// copied is what would be printed
+func (s *SheetScreen) viewPrint() string {
+	lines := strings.Split(strings.TrimSuffix(s.printout(), "\n"), "\n")
+	end := min(s.print.offset+s.printRows(), len(lines))
+	start := min(s.print.offset, end)
+	shown := lines[start:end]
+	for len(shown) < s.printRows() {
+		shown = append(shown, "")
+	}
+	return strings.Join(shown, "\n") + "\n\n" + s.styles.Help.Render(s.getHelp())
+}
@@ -0,0 +1,170 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/tui/components"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// searchLimit caps how many results the search shows
+const searchLimit = 12
+
+// searchResult is one match for a sheet search: where it is and a line of
+// context
+type searchResult struct {
+	tab    int
+	row    int // spell, journal entry, or item index on the tab, or -1
+	kind   string
+	name   string
+	detail string
+}
+
+// search finds query in the character's spells, features, journal, and
+// inventory, ignoring case
+func (s *SheetScreen) search(query string) []searchResult {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+	matches := func(text string) bool {
+		return strings.Contains(strings.ToLower(text), query)
+	}
+
+	var results []searchResult
+	for i, spell := range s.spells {
+		detail := ""
+		if info, ok := character.FindSpell(spell.Name); ok {
+			detail = snippet(info.Description, query)
+		}
+		if matches(spell.Name) || detail != "" {
+			results = append(results, searchResult{tab: 3, row: i, kind: "Spell", name: spell.Name, detail: detail})
+		}
+	}
+	for _, line := range strings.Split(s.char.FeaturesTraits, "\n") {
+		if matches(line) {
+			results = append(results, searchResult{tab: 4, row: -1, kind: "Feature", name: truncate(strings.TrimSpace(line), 40)})
+		}
+	}
+	for i, entry := range s.journal {
+		if detail := snippet(entry.Body, query); matches(entry.Title) || detail != "" {
+			results = append(results, searchResult{tab: 4, row: i, kind: "Journal", name: entry.Title, detail: detail})
+		}
+	}
+	for i, item := range s.inventory {
+		if matches(item.Name) || matches(item.Location) {
+			detail := ""
+			if item.Location != "" {
+				detail = "in " + item.Location
+			}
+			results = append(results, searchResult{tab: 6, row: i, kind: "Item", name: item.Name, detail: detail})
+		}
+	}
+	return results
+}
+
+// snippet returns a short piece of text around the first match of query,
+// which must be lowercase, or "" if there's no match
+func snippet(text, query string) string {
+	for _, line := range strings.Split(text, "\n") {
+		lower := strings.ToLower(line)
+		i := strings.Index(lower, query)
+		if i < 0 {
+			continue
+		}
+		// Start a little before the match so it has some context
+		runes := []rune(line)
+		start := min(max(0, len([]rune(lower[:i]))-15), len(runes))
+		out := string(runes[start:])
+		if start > 0 {
+			out = "…" + out
+		}
+		return truncate(strings.TrimSpace(out), 50)
+	}
+	return ""
+}
+
+func (s *SheetScreen) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	results := s.search(s.searchInput.Value())
+	switch msg.String() {
+	case "up", "ctrl+p":
+		if s.searchCursor > 0 {
+			s.searchCursor--
+		}
+		return s, nil
+	case "down", "ctrl+n":
+		if s.searchCursor < min(len(results), searchLimit)-1 {
+			s.searchCursor++
+		}
+		return s, nil
+	case "enter":
+		if s.searchCursor >= len(results) {
+			return s, nil
+		}
+		return s, s.jumpTo(results[s.searchCursor])
+	case "esc":
+		s.mode = ModeView
+		return s, nil
+	}
+
+	var cmd tea.Cmd
+	s.searchInput, cmd = s.searchInput.Update(msg)
+	s.searchCursor = 0
+	return s, cmd
+}
+
+// jumpTo shows the tab a result is on with it selected
+func (s *SheetScreen) jumpTo(r searchResult) tea.Cmd {
+	s.mode = ModeView
+	s.showTab(r.tab)
+	switch {
+	case r.tab == 3:
+		s.spellCursor = r.row
+		return s.showSpellDetail()
+	case r.tab == 4 && r.row >= 0:
+		s.journalCursor = r.row
+		s.mode = ModeJournalEntry
+	case r.tab == 6:
+		s.inventoryCursor = r.row
+	}
+	return nil
+}
+
+// viewSearch shows the search box and what matches so far
+func (s *SheetScreen) viewSearch() string {
+	var b strings.Builder
+	b.WriteString(s.styles.Header.Render("Search " + s.char.Name))
+	b.WriteString("\n\n")
+	b.WriteString(s.styles.FocusedInput.Render(s.searchInput.View()))
+	b.WriteString("\n\n")
+
+	results := s.search(s.searchInput.Value())
+	switch {
+	case strings.TrimSpace(s.searchInput.Value()) == "":
+		b.WriteString(s.styles.Muted.Render("Type to search spells, features, the journal, and inventory."))
+	case len(results) == 0:
+		b.WriteString(s.styles.Muted.Render("No matches."))
+	}
+	for i, r := range results[:min(len(results), searchLimit)] {
+		style := s.styles.NotProficient
+		if i == s.searchCursor {
+			style = s.styles.Cursor
+		}
+		line := style.Render(fmt.Sprintf("%-8s %-30s", r.kind, truncate(r.name, 30)))
+		if r.detail != "" {
+			line += s.styles.Muted.Render(" " + r.detail)
+		}
+		b.WriteString(components.Mark(components.Item("result", i), line))
+		b.WriteString("\n")
+	}
+	if len(results) > searchLimit {
+		b.WriteString(s.styles.Muted.Render(fmt.Sprintf("…and %d more; keep typing to narrow them down", len(results)-searchLimit)))
+	}
+
+	return lipgloss.NewStyle().
+		Align(lipgloss.Left).
+		Render(strings.TrimSuffix(b.String(), "\n"))
+}
@@ -0,0 +1,245 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/auth"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/tui/components"
+	"github.com/brady1408/dnd/internal/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Rows on the settings screen
+const (
+	settingTheme = iota
+	settingNotifications
+	settingUsageStats
+)
+
+// SettingsScreen holds a player's preferences: their color theme, how
+// they're alerted, and, on servers that collect them, whether to share
+// usage statistics
+type SettingsScreen struct {
+	ctx         context.Context
+	authService *auth.Service
+	user        *db.User
+	styles      *styles.Styles
+
+	cursor int
+	err    string
+	width  int
+	height int
+}
+
+type NavigateToSettingsMsg struct{}
+
+// UserUpdatedMsg is sent when the player changes their settings
+type UserUpdatedMsg struct {
+	User *db.User
+}
+
+type settingsErrorMsg struct {
+	Err error
+}
+
+func NewSettingsScreen(ctx context.Context, authService *auth.Service, user *db.User, s *styles.Styles) *SettingsScreen {
+	return &SettingsScreen{
+		ctx:         ctx,
+		authService: authService,
+		user:        user,
+		styles:      s,
+		width:       80,
+		height:      24,
+	}
+}
+
+func (st *SettingsScreen) Init() tea.Cmd {
+	return nil
+}
+
+// rows is how many settings are listed
+func (st *SettingsScreen) rows() int {
+	if usageStats {
+		return 3
+	}
+	return 2
+}
+
+func (st *SettingsScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		st.width = msg.Width
+		st.height = msg.Height
+
+	case UserUpdatedMsg:
+		st.user = msg.User
+
+	case settingsErrorMsg:
+		st.err = msg.Err.Error()
+
+	case components.ClickMsg:
+		st.err = ""
+		return st.click(msg.Zone)
+
+	case tea.KeyMsg:
+		st.err = ""
+		switch msg.String() {
+		case "esc", "q":
+			return st, func() tea.Msg { return NavigateBackMsg{} }
+		case "up", "k":
+			if st.cursor > 0 {
+				st.cursor--
+			}
+		case "down", "j":
+			if st.cursor < st.rows()-1 {
+				st.cursor++
+			}
+		case "left", "h":
+			switch st.cursor {
+			case settingTheme:
+				return st, st.cycleTheme(-1)
+			case settingNotifications:
+				return st, st.cycleNotifications(-1)
+			}
+		case "right", "l", " ", "enter":
+			switch st.cursor {
+			case settingTheme:
+				return st, st.cycleTheme(1)
+			case settingNotifications:
+				return st, st.cycleNotifications(1)
+			case settingUsageStats:
+				return st, st.toggleUsageStats()
+			}
+		}
+	}
+	return st, nil
+}
+
+// cycleTheme switches to the next or previous theme, applying it at once
+// and saving it for the next login
+func (st *SettingsScreen) cycleTheme(step int) tea.Cmd {
+	themes := styles.Themes()
+	current := 0
+	for i, t := range themes {
+		if t.Name == st.styles.Theme().Name {
+			current = i
+		}
+	}
+	theme := themes[(current+step+len(themes))%len(themes)]
+	st.styles.Apply(theme)
+
+	userID := st.user.ID
+	return func() tea.Msg {
+		user, err := st.authService.SetTheme(st.ctx, userID, theme.Name)
+		if err != nil {
+			return settingsErrorMsg{Err: err}
+		}
+		return UserUpdatedMsg{User: user}
+	}
+}
+
+// cycleNotifications switches to the next or previous way of being alerted.
+// The new one is shown once so the player knows what to expect.
+func (st *SettingsScreen) cycleNotifications(step int) tea.Cmd {
+	current := 0
+	for i, n := range components.Notifications {
+		if n.Name == st.user.Notifications {
+			current = i
+		}
+	}
+	count := len(components.Notifications)
+	mode := components.Notifications[(current+step+count)%count].Name
+
+	userID := st.user.ID
+	save := func() tea.Msg {
+		user, err := st.authService.SetNotifications(st.ctx, userID, mode)
+		if err != nil {
+			return settingsErrorMsg{Err: err}
+		}
+		return UserUpdatedMsg{User: user}
+	}
+	return tea.Sequence(save, func() tea.Msg { return components.NotifyMsg{} })
+}
+
+func (st *SettingsScreen) toggleUsageStats() tea.Cmd {
+	userID, enabled := st.user.ID, !st.user.UsageStats
+	return func() tea.Msg {
+		user, err := st.authService.SetUsageStats(st.ctx, userID, enabled)
+		if err != nil {
+			return settingsErrorMsg{Err: err}
+		}
+		return UserUpdatedMsg{User: user}
+	}
+}
+
+func (st *SettingsScreen) View() string {
+	var b strings.Builder
+
+	b.WriteString(st.styles.Title.Render("Settings"))
+	b.WriteString("\n\n")
+
+	row := func(i int, label, value string) {
+		cursor, style := "  ", st.styles.NotProficient
+		if i == st.cursor {
+			cursor, style = "> ", st.styles.Cursor
+		}
+		b.WriteString(components.Mark(components.Item("setting", i), style.Render(fmt.Sprintf("%s%-18s", cursor, label))+value))
+		b.WriteString("\n")
+	}
+	arrows := func(i int, value string) string {
+		return components.Mark(components.Item("less", i), "◀") + " " + value + " " + components.Mark(components.Item("more", i), "▶")
+	}
+	row(settingTheme, "Theme", arrows(settingTheme, st.styles.Theme().Label))
+	row(settingNotifications, "Notifications", arrows(settingNotifications, components.NotificationLabel(st.user.Notifications)))
+	if usageStats {
+		value := st.styles.Muted.Render("Off")
+		if st.user.UsageStats {
+			value = st.styles.SuccessText.Render("On")
+		}
+		row(settingUsageStats, "Usage statistics", value)
+	}
+	b.WriteString("\n")
+
+	text := lipgloss.NewStyle().Width(64)
+	switch st.cursor {
+	case settingTheme:
+		b.WriteString(text.Render("Colors for every screen. Changes apply right away and are saved for your next visit. Your terminal shows " + st.styles.ColorSupport() + "."))
+		b.WriteString("\n\n")
+		b.WriteString(st.styles.Header.Render("Preview"))
+		b.WriteString("\n")
+		b.WriteString(st.styles.StatValue.Render("16") + st.styles.StatMod.Render("+3") + " ")
+		b.WriteString(st.styles.Proficient.Render("● Proficient") + "  ")
+		b.WriteString(st.styles.HPCurrent.Render("HP 24") + st.styles.HPMax.Render("/31") + "  ")
+		b.WriteString(st.styles.HPLow.Render("Low") + "  ")
+		b.WriteString(st.styles.HPCritical.Render("Critical"))
+		b.WriteString("\n")
+		b.WriteString(st.styles.FocusedButton.Render(" Selected ") + st.styles.Button.Render(" Tab "))
+		b.WriteString(st.styles.Selected.Render(" Highlighted row "))
+	case settingNotifications:
+		b.WriteString(text.Render("How you're alerted to things that need your attention, such as server announcements. The terminal bell beeps or bounces your terminal's icon; a screen flash briefly inverts the screen's colors without a sound, for noisy rooms or quiet ones. Silent shows them without any alert."))
+	case settingUsageStats:
+		b.WriteString(text.Render("Sharing usage statistics helps the maintainers decide what to work on. When it's on, the server counts which screens you open, which kinds of changes you make (such as \"HP change\" or \"long rest\"), and how often they fail, and every hour sends the totals to an address chosen by the server operator."))
+		b.WriteString("\n\n")
+		b.WriteString(text.Render("Nothing you type is collected: no names, notes, journal entries, or character details. The totals are combined across players and aren't linked to your account. It's off unless you turn it on."))
+	}
+
+	if st.err != "" {
+		b.WriteString("\n\n")
+		b.WriteString(st.styles.ErrorText.Render("Error: " + st.err))
+	}
+
+	help := "↑/↓: select • ←/→: change"
+	if usageStats {
+		help += " • space: turn usage statistics on/off"
+	}
+	b.WriteString("\n\n")
+	b.WriteString(st.styles.Help.Render(help + " • esc: back"))
+
+	return lipgloss.Place(st.width, st.height,
+		lipgloss.Center, lipgloss.Center,
+		lipgloss.NewStyle().Align(lipgloss.Left).Render(b.String()))
+}
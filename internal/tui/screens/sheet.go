@@ -2,16 +2,27 @@ package screens
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/bits"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/brady1408/dnd/internal/audit"
+	"github.com/brady1408/dnd/internal/auth"
 	"github.com/brady1408/dnd/internal/character"
 	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/qr"
+	"github.com/brady1408/dnd/internal/sanitize"
+	"github.com/brady1408/dnd/internal/tui/components"
 	"github.com/brady1408/dnd/internal/tui/styles"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type SheetMode int
@@ -19,38 +30,242 @@ type SheetMode int
 const (
 	ModeView SheetMode = iota
 	ModeEditHP
+	ModeEditTempHP
 	ModeEditNotes
 	ModeEditFeatures
+	ModeClassTable
+	ModeConcentrationSave
+	ModeConfirmLongRest
+	ModeEditSaves
+	ModeEditSkills
+	ModeAddItem
+	ModeEditAC
+	ModePickWeapon
+	ModeEditMaxHPBonus
+	ModeRename
+	ModeAwardXP
+	ModeShare
+	ModeSpellDetail
+	ModeJournalEntry
+	ModeJournalTitle
+	ModeSearch
+	ModePalette
+	ModeConfirmRepair
+	ModeSetupSpellcasting
+	ModePrepareSpells
+	ModeRollLoot
+	ModeConfirmShortRest
+	ModeAddLanguage
+	ModeEditMovement
+	ModeSetPortrait
+	ModePrint
+	ModeCraft
+	ModeShop
 )
 
 type SheetScreen struct {
-	ctx     context.Context
-	queries *db.Queries
-	char    db.Character
-	styles  *styles.Styles
+	ctx         context.Context
+	queries     *db.Queries
+	authService *auth.Service
+	userID      pgtype.UUID
+	char        db.Character
+	styles      *styles.Styles
+
+	// What this session's sheets loaded, shared so reopening is quick
+	cache *SheetCache
 
 	mode       SheetMode
-	tab        int // 0=stats, 1=skills, 2=combat, 3=notes
+	tab        int // 0=stats, 1=skills, 2=combat, 3=spells, 4=notes, 5=history, 6=inventory
 	width      int
 	height     int
 
+	// Spellcasting data (nil/empty for non-casters)
+	spellcasting *db.CharacterSpellcasting
+	spells       []db.CharacterSpell
+
+	// HP changes, newest first
+	hpHistory []db.CharacterHpHistory
+
+	// Class resources such as Ki, and the one under the cursor on the
+	// Combat tab
+	resources      []db.CharacterResource
+	resourceCursor int
+
+	// Carried items and the one under the cursor on the Inventory tab
+	inventory       []db.CharacterInventory
+	inventoryCursor int
+
+	// SRD weapon under the cursor when adding a weapon, and its magic bonus
+	weaponCursor int
+	weaponMagic  int
+
+	// Treasure being rolled on the Inventory tab: the challenge rating band,
+	// whether it's a hoard, and the last roll
+	lootBand  int
+	lootHoard bool
+	loot      character.Treasure
+
+	// Spell whose details are being looked up online
+	lookingUp string
+
+	// Change log, newest first, and the first entry shown
+	events        []db.GetCharacterEventsRow
+	historyOffset int
+
 	// Edit mode inputs
 	hpInput       textinput.Model
+	tempHPInput   textinput.Model
 	notesInput    textarea.Model
 	featuresInput textarea.Model
+	itemInput     textinput.Model
+	acInput       textinput.Model
+	bonusInput    textinput.Model
+	nameInput     textinput.Model
+	xpInput       textinput.Model
 	editCursor    int
+
+	// Show the notes or features being edited as rendered markdown
+	preview bool
+
+	// Journal entries on the Notes tab, the one under the cursor, and the
+	// entry being titled or edited
+	journal       []db.CharacterJournal
+	journalCursor int
+	editingEntry  db.CharacterJournal
+	titleInput    textinput.Model
+
+	// Search across the sheet, and the result under the cursor
+	searchInput  textinput.Model
+	searchCursor int
+
+	// Languages and tools on the Stats tab, the one under the cursor, and
+	// whether a tool is being added rather than a language
+	languageInput  textinput.Model
+	languageCursor int
+	addingTool     bool
+
+	// Speeds and senses being edited on the Combat tab
+	movement movementForm
+
+	// Type of the damage being entered on the Combat tab; empty for untyped
+	damageType string
+
+	// Portrait on the Stats tab, nil without one, as stored and as drawn
+	portrait      *db.CharacterPortrait
+	portraitArt   string
+	portraitInput textinput.Model
+
+	// Item being crafted, nil when there isn't one; the recipe under the
+	// cursor and how many days of work to put in
+	crafting     *db.CharacterCrafting
+	recipeCursor int
+	craftDays    int
+
+	// SRD equipment being bought on the Inventory tab
+	shop shop
+
+	// ctrl+k command palette
+	palette components.Palette
+
+	// Problems with the character's data found when the sheet opened
+	health []healthIssue
+
+	// Spellcasting being set up
+	setup spellcastingSetup
+
+	// Prepared spells being changed
+	prepare spellPreparation
+
+	// First level shown in the class table
+	classTableOffset int
+
+	// Spell under the cursor on the Spells tab
+	spellCursor int
+
+	// Proficiencies being edited on the Stats and Skills tabs, and the row
+	// under the cursor
+	editSaves  map[string]bool
+	editSkills map[string]character.Proficiency
+	profCursor int
+
+	// DC of the concentration save owed for damage being applied or just taken
+	concentrationDC int
+
+	// On wide terminals a second tab is shown beside the focused one.
+	// Switching panes swaps it with tab, so keys always act on tab.
+	otherTab   int
+	focusRight bool
+
+	// Read-only link being shown, with its QR code
+	share *shareLinkMsg
+
+	// Whole sheet shown for copying or printing
+	print printView
+
+	// Edits made this session, for ctrl+z / ctrl+y
+	undo   undoStack
+	status string
 }
 
 type CharacterUpdatedMsg struct {
 	Character db.Character
 }
 
-func NewSheetScreen(ctx context.Context, queries *db.Queries, char db.Character, s *styles.Styles) *SheetScreen {
+type HPHistoryLoadedMsg struct {
+	History []db.CharacterHpHistory
+}
+
+// hpHistoryLimit caps how many HP changes are loaded for the history graph
+const hpHistoryLimit = 100
+
+// hpSessionGap is the idle time after which HP changes belong to a new session
+const hpSessionGap = 4 * time.Hour
+
+type EventsLoadedMsg struct {
+	Events []db.GetCharacterEventsRow
+}
+
+// eventLimit caps how many change log entries are loaded for the History tab
+const eventLimit = 200
+
+type SpellsLoadedMsg struct {
+	Spellcasting *db.CharacterSpellcasting
+	Spells       []db.CharacterSpell
+}
+
+type InventoryLoadedMsg struct {
+	Items []db.CharacterInventory
+}
+
+type JournalLoadedMsg struct {
+	Entries []db.CharacterJournal
+}
+
+type ResourcesLoadedMsg struct {
+	Resources []db.CharacterResource
+}
+
+type shareLinkMsg struct {
+	URL     string
+	Expires time.Time
+	Code    string
+}
+
+type shareErrorMsg struct {
+	Err error
+}
+
+func NewSheetScreen(ctx context.Context, queries *db.Queries, authService *auth.Service, userID pgtype.UUID, char db.Character, cache *SheetCache, s *styles.Styles) *SheetScreen {
 	hpInput := textinput.New()
 	hpInput.Placeholder = "HP"
 	hpInput.Width = 10
 	hpInput.CharLimit = 5
 
+	tempHPInput := textinput.New()
+	tempHPInput.Placeholder = "Temp"
+	tempHPInput.Width = 10
+	tempHPInput.CharLimit = 4
+
 	notesInput := textarea.New()
 	notesInput.Placeholder = "Enter notes here..."
 	notesInput.SetWidth(50)
@@ -65,22 +280,222 @@ func NewSheetScreen(ctx context.Context, queries *db.Queries, char db.Character,
 	featuresInput.CharLimit = 5000
 	featuresInput.ShowLineNumbers = false
 
+	itemInput := textinput.New()
+	itemInput.Placeholder = "Item name"
+	itemInput.Width = 30
+	itemInput.CharLimit = sanitize.MaxName
+
+	acInput := textinput.New()
+	acInput.Placeholder = "blank to calculate"
+	acInput.Width = 20
+	acInput.CharLimit = 2
+
+	bonusInput := textinput.New()
+	bonusInput.Placeholder = "0"
+	bonusInput.Width = 10
+	bonusInput.CharLimit = 3
+
+	nameInput := textinput.New()
+	nameInput.Placeholder = "Character name"
+	nameInput.Width = 30
+	nameInput.CharLimit = sanitize.MaxName
+
+	xpInput := textinput.New()
+	xpInput.Placeholder = "XP to add"
+	xpInput.Width = 12
+	xpInput.CharLimit = 7
+
+	titleInput := textinput.New()
+	titleInput.Placeholder = "Entry title"
+	titleInput.Width = 30
+	titleInput.CharLimit = sanitize.MaxName
+
+	searchInput := textinput.New()
+	searchInput.Placeholder = "spells, features, items, journal"
+	searchInput.Width = 30
+	searchInput.CharLimit = sanitize.MaxName
+
+	languageInput := textinput.New()
+	languageInput.Width = 30
+	languageInput.CharLimit = sanitize.MaxName
+	languageInput.ShowSuggestions = true
+
+	portraitInput := textinput.New()
+	portraitInput.Placeholder = "https://..."
+	portraitInput.Width = 40
+	portraitInput.CharLimit = maxPortraitURL
+
 	return &SheetScreen{
 		ctx:           ctx,
 		queries:       queries,
+		authService:   authService,
+		userID:        userID,
 		char:          char,
 		styles:        s,
+		cache:         cache,
 		mode:          ModeView,
+		otherTab:      2,
 		hpInput:       hpInput,
+		tempHPInput:   tempHPInput,
 		notesInput:    notesInput,
 		featuresInput: featuresInput,
+		itemInput:     itemInput,
+		acInput:       acInput,
+		bonusInput:    bonusInput,
+		nameInput:     nameInput,
+		xpInput:       xpInput,
+		titleInput:    titleInput,
+		searchInput:   searchInput,
+		languageInput: languageInput,
+		portraitInput: portraitInput,
+		palette:       components.NewPalette(),
 		width:         80,
 		height:        24,
 	}
 }
 
 func (s *SheetScreen) Init() tea.Cmd {
-	return nil
+	return tea.Batch(s.Reload(), s.markPlayed(), s.checkHealth())
+}
+
+// Reload loads everything shown alongside the character, from the cache
+// where it hasn't changed
+func (s *SheetScreen) Reload() tea.Cmd {
+	return s.loadParts(partAll)
+}
+
+// loadParts loads some of what's shown alongside the character. When more
+// than one part has to be queried, everything is loaded in one round trip.
+func (s *SheetScreen) loadParts(parts sheetPart) tea.Cmd {
+	loaders := map[sheetPart]func() tea.Cmd{
+		partSpells:    s.loadSpells,
+		partHPHistory: s.loadHPHistory,
+		partEvents:    s.loadEvents,
+		partInventory: s.loadInventory,
+		partJournal:   s.loadJournal,
+		partResources: s.loadResources,
+		partPortrait:  s.loadPortrait,
+		partCrafting:  s.loadCrafting,
+	}
+	if bits.OnesCount(uint(s.cache.missing(s.char.ID, parts))) > 1 {
+		return s.loadSheetData()
+	}
+
+	var cmds []tea.Cmd
+	for part, load := range loaders {
+		if parts&part != 0 {
+			cmds = append(cmds, load())
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// sheetDataLoadedMsg carries every part of the sheet, loaded together
+type sheetDataLoadedMsg struct {
+	parts map[sheetPart]tea.Msg
+}
+
+func (s *SheetScreen) loadSheetData() tea.Cmd {
+	return s.cache.loadAll(s.char.ID, func() tea.Msg {
+		data, err := s.queries.GetSheetData(s.ctx, db.GetSheetDataParams{
+			CharacterID:    s.char.ID,
+			HPHistoryLimit: hpHistoryLimit,
+			EventLimit:     eventLimit,
+		})
+		if err != nil {
+			return ErrorMsg{Action: "load the character sheet", Err: err, Retry: s.loadSheetData()}
+		}
+		return sheetDataLoadedMsg{parts: map[sheetPart]tea.Msg{
+			partSpells:    SpellsLoadedMsg{Spellcasting: data.Spellcasting, Spells: data.Spells},
+			partHPHistory: HPHistoryLoadedMsg{History: data.HPHistory},
+			partEvents:    EventsLoadedMsg{Events: data.Events},
+			partInventory: InventoryLoadedMsg{Items: data.Inventory},
+			partJournal:   JournalLoadedMsg{Entries: data.Journal},
+			partResources: ResourcesLoadedMsg{Resources: data.Resources},
+			partPortrait:  PortraitLoadedMsg{Portrait: data.Portrait},
+			partCrafting:  CraftingLoadedMsg{Crafting: data.Crafting},
+		}}
+	})
+}
+
+// markPlayed records that the sheet was opened, for sorting the character list
+func (s *SheetScreen) markPlayed() tea.Cmd {
+	return func() tea.Msg {
+		_ = s.queries.UpdateCharacterLastPlayed(s.ctx, s.char.ID)
+		return nil
+	}
+}
+
+func (s *SheetScreen) loadHPHistory() tea.Cmd {
+	return s.cache.load(s.char.ID, partHPHistory, func() tea.Msg {
+		history, err := s.queries.GetRecentHPHistory(s.ctx, db.GetRecentHPHistoryParams{
+			CharacterID: s.char.ID,
+			Limit:       hpHistoryLimit,
+		})
+		if err != nil {
+			return ErrorMsg{Action: "load hit point history", Err: err, Retry: s.loadHPHistory()}
+		}
+		return HPHistoryLoadedMsg{History: history}
+	})
+}
+
+func (s *SheetScreen) loadEvents() tea.Cmd {
+	return s.cache.load(s.char.ID, partEvents, func() tea.Msg {
+		events, err := s.queries.GetCharacterEvents(s.ctx, db.GetCharacterEventsParams{
+			CharacterID: s.char.ID,
+			Limit:       eventLimit,
+		})
+		if err != nil {
+			return ErrorMsg{Action: "load the change log", Err: err, Retry: s.loadEvents()}
+		}
+		return EventsLoadedMsg{Events: events}
+	})
+}
+
+func (s *SheetScreen) loadSpells() tea.Cmd {
+	return s.cache.load(s.char.ID, partSpells, func() tea.Msg {
+		msg := SpellsLoadedMsg{}
+		sc, err := s.queries.GetCharacterSpellcasting(s.ctx, s.char.ID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Not a spellcaster
+			return msg
+		}
+		if err != nil {
+			return ErrorMsg{Action: "load spells", Err: err, Retry: s.loadSpells()}
+		}
+		msg.Spellcasting = &sc
+		spells, err := s.queries.GetCharacterSpells(s.ctx, s.char.ID)
+		if err != nil {
+			return ErrorMsg{Action: "load spells", Err: err, Retry: s.loadSpells()}
+		}
+		msg.Spells = spells
+		return msg
+	})
+}
+
+func (s *SheetScreen) loadInventory() tea.Cmd {
+	return s.cache.load(s.char.ID, partInventory, func() tea.Msg {
+		items, err := s.queries.GetCharacterInventory(s.ctx, s.char.ID)
+		if err != nil {
+			return ErrorMsg{Action: "load inventory", Err: err, Retry: s.loadInventory()}
+		}
+		return InventoryLoadedMsg{Items: items}
+	})
+}
+
+func (s *SheetScreen) loadResources() tea.Cmd {
+	return s.cache.load(s.char.ID, partResources, func() tea.Msg {
+		resources, err := s.queries.GetCharacterResources(s.ctx, s.char.ID)
+		if err != nil {
+			return ErrorMsg{Action: "load class resources", Err: err, Retry: s.loadResources()}
+		}
+		return ResourcesLoadedMsg{Resources: resources}
+	})
+}
+
+// rules returns the ruleset the character's sheet follows
+func (s *SheetScreen) rules() character.Ruleset {
+	return character.RulesetFor(s.char.Ruleset)
 }
 
 // SetCharacter updates the character data without resetting the view state
@@ -88,11 +503,96 @@ func (s *SheetScreen) SetCharacter(char db.Character) {
 	s.char = char
 }
 
+// ReloadAfterPlan reloads what leveling up from the build plan changes:
+// the HP history, change log, spellcasting, and class resources
+func (s *SheetScreen) ReloadAfterPlan() tea.Cmd {
+	changed := partHPHistory | partEvents | partSpells | partResources
+	s.cache.invalidate(s.char.ID, changed)
+	return s.loadParts(changed)
+}
+
 func (s *SheetScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		s.width = msg.Width
 		s.height = msg.Height
+		s.notesInput.SetWidth(s.contentWidth(50))
+		s.featuresInput.SetWidth(s.contentWidth(50))
+
+	case SpellsLoadedMsg:
+		s.spellcasting = msg.Spellcasting
+		s.spells = msg.Spells
+		return s, nil
+
+	case HPHistoryLoadedMsg:
+		s.hpHistory = msg.History
+		return s, nil
+
+	case EventsLoadedMsg:
+		s.events = msg.Events
+		s.historyOffset = 0
+		return s, nil
+
+	case InventoryLoadedMsg:
+		s.inventory = newInventoryLayout(msg.Items).order(msg.Items)
+		s.inventoryCursor = min(s.inventoryCursor, max(len(s.inventory)-1, 0))
+		return s, nil
+
+	case JournalLoadedMsg:
+		s.journal = msg.Entries
+		s.journalCursor = min(s.journalCursor, max(len(s.journal)-1, 0))
+		return s, nil
+
+	case spellLookedUpMsg:
+		s.handleSpellLookedUp(msg)
+		return s, nil
+
+	case ResourcesLoadedMsg:
+		s.resources = msg.Resources
+		s.resourceCursor = min(s.resourceCursor, max(len(s.resources)-1, 0))
+		return s, nil
+
+	case PortraitLoadedMsg:
+		s.handlePortraitLoaded(msg)
+		return s, nil
+
+	case portraitFetchedMsg:
+		return s, s.handlePortraitFetched(msg)
+
+	case CraftingLoadedMsg:
+		s.crafting = msg.Crafting
+		return s, nil
+
+	case sheetDataLoadedMsg:
+		for _, part := range msg.parts {
+			s.Update(part)
+		}
+		return s, nil
+
+	case editAppliedMsg:
+		return s.handleEditApplied(msg)
+
+	case healthCheckedMsg:
+		s.health = msg.issues
+		if s.mode == ModeView {
+			s.mode = ModeConfirmRepair
+		}
+		return s, nil
+
+	case healthRepairedMsg:
+		return s.handleHealthRepaired(msg)
+
+	case shareLinkMsg:
+		s.share = &msg
+		s.mode = ModeShare
+		return s, nil
+
+	case shareErrorMsg:
+		s.status = "Error: " + msg.Err.Error()
+		return s, nil
+
+	case components.ClickMsg:
+		return s.click(msg.Zone)
 	}
 
 	// Handle mode-specific updates
@@ -105,450 +605,2511 @@ func (s *SheetScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
 			return s.updateEditHP(keyMsg)
 		}
+	case ModeEditTempHP:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateEditTempHP(keyMsg)
+		}
 	case ModeEditNotes:
 		return s.updateEditNotes(msg)
 	case ModeEditFeatures:
 		return s.updateEditFeatures(msg)
+	case ModeClassTable:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateClassTable(keyMsg)
+		}
+	case ModeConcentrationSave:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateConcentrationSave(keyMsg)
+		}
+	case ModeEditSaves, ModeEditSkills:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateEditProficiencies(keyMsg)
+		}
+	case ModeAddLanguage:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateAddLanguage(keyMsg)
+		}
+	case ModeEditMovement:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateEditMovement(keyMsg)
+		}
+	case ModeSetPortrait:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateSetPortrait(keyMsg)
+		}
+	case ModePrint:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updatePrintView(keyMsg)
+		}
+	case ModeCraft:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateCraft(keyMsg)
+		}
+	case ModeShop:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateShop(keyMsg)
+		}
+	case ModeAddItem:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateAddItem(keyMsg)
+		}
+	case ModeEditAC:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateEditAC(keyMsg)
+		}
+	case ModePickWeapon:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updatePickWeapon(keyMsg)
+		}
+	case ModeRollLoot:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateRollLoot(keyMsg)
+		}
+	case ModeEditMaxHPBonus:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateEditMaxHPBonus(keyMsg)
+		}
+	case ModeRename:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateRename(keyMsg)
+		}
+	case ModeAwardXP:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateAwardXP(keyMsg)
+		}
+	case ModeSpellDetail:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateSpellDetail(keyMsg)
+		}
+	case ModeJournalEntry:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateJournalEntry(keyMsg)
+		}
+	case ModeJournalTitle:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateJournalTitle(keyMsg)
+		}
+	case ModeSearch:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateSearch(keyMsg)
+		}
+	case ModePalette:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updatePalette(keyMsg)
+		}
+	case ModeSetupSpellcasting:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateSpellcastingSetup(keyMsg)
+		}
+	case ModePrepareSpells:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return s.updateSpellPreparation(keyMsg)
+		}
+	case ModeShare:
+		if _, ok := msg.(tea.KeyMsg); ok {
+			s.mode = ModeView
+			s.share = nil
+		}
+	case ModeConfirmRepair:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			switch keyMsg.String() {
+			case "y", "enter":
+				return s, s.repairHealth()
+			case "n", "esc":
+				s.mode = ModeView
+				s.health = nil
+			}
+		}
+	case ModeConfirmLongRest:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			switch keyMsg.String() {
+			case "y", "enter":
+				return s, s.undo.run(longRestEdit(s.ctx, s.queries, s.userID, s.char, s.spellcasting, s.resources))
+			case "n", "esc":
+				s.mode = ModeView
+			}
+		}
+	case ModeConfirmShortRest:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			switch keyMsg.String() {
+			case "y", "enter":
+				return s, s.undo.run(shortRestEdit(s.ctx, s.queries, s.userID, s.char, s.spellcasting, s.resources))
+			case "n", "esc":
+				s.mode = ModeView
+			}
+		}
 	}
 
 	return s, nil
 }
 
 func (s *SheetScreen) updateView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s.status = ""
 	switch msg.String() {
 	case "tab", "right", "l":
-		s.tab = (s.tab + 1) % 4
+		s.tab = (s.tab + 1) % 7
+		if s.split() && s.tab == s.paneTab() {
+			s.tab = (s.tab + 1) % 7
+		}
 	case "shift+tab", "left", "h":
-		s.tab = (s.tab + 3) % 4
+		s.tab = (s.tab + 6) % 7
+		if s.split() && s.tab == s.paneTab() {
+			s.tab = (s.tab + 6) % 7
+		}
+	case "|":
+		if s.split() {
+			s.tab, s.otherTab = s.paneTab(), s.tab
+			s.focusRight = !s.focusRight
+		}
+
+	case "up", "k":
+		if s.tab == 0 && s.languageCursor > 0 {
+			s.languageCursor--
+		}
+		if s.tab == 2 && s.resourceCursor > 0 {
+			s.resourceCursor--
+		}
+		if s.tab == 3 && s.spellCursor > 0 {
+			s.spellCursor--
+		}
+		if s.tab == 4 && s.journalCursor > 0 {
+			s.journalCursor--
+		}
+		if s.tab == 5 && s.historyOffset > 0 {
+			s.historyOffset--
+		}
+		if s.tab == 6 && s.inventoryCursor > 0 {
+			s.inventoryCursor--
+		}
+	case "down", "j":
+		if s.tab == 0 && s.languageCursor < s.otherProficiencies()-1 {
+			s.languageCursor++
+		}
+		if s.tab == 2 && s.resourceCursor < len(s.resources)-1 {
+			s.resourceCursor++
+		}
+		if s.tab == 3 && s.spellCursor < len(s.spells)-1 {
+			s.spellCursor++
+		}
+		if s.tab == 4 && s.journalCursor < len(s.journal)-1 {
+			s.journalCursor++
+		}
+		if s.tab == 5 && s.historyOffset < len(s.events)-s.historyRows() {
+			s.historyOffset++
+		}
+		if s.tab == 6 && s.inventoryCursor < len(s.inventory)-1 {
+			s.inventoryCursor++
+		}
 
 	case "e":
+		if s.tab == 0 || s.tab == 1 { // Stats or Skills tab - edit proficiencies
+			s.startEditProficiencies()
+			return s, nil
+		}
 		if s.tab == 2 { // Combat tab - edit HP
 			s.mode = ModeEditHP
+			s.damageType = ""
 			s.hpInput.SetValue(fmt.Sprintf("%d", s.char.CurrentHitPoints))
 			s.hpInput.Focus()
 			return s, textinput.Blink
-		} else if s.tab == 3 { // Notes tab - edit notes
-			s.mode = ModeEditNotes
-			s.notesInput.SetValue(s.char.Notes)
-			s.notesInput.Focus()
-			return s, textarea.Blink
+		} else if s.tab == 4 { // Notes tab - edit the selected journal entry
+			if entry, ok := s.selectedEntry(); ok {
+				return s.editEntry(entry)
+			}
 		}
 
-	case "f":
-		if s.tab == 3 { // Notes tab - edit features & traits
-			s.mode = ModeEditFeatures
-			s.featuresInput.SetValue(s.char.FeaturesTraits)
-			s.featuresInput.Focus()
-			return s, textarea.Blink
+	case "t":
+		if s.tab == 2 { // Combat tab - set temp HP
+			s.mode = ModeEditTempHP
+			s.tempHPInput.SetValue(fmt.Sprintf("%d", s.char.TemporaryHitPoints))
+			s.tempHPInput.Focus()
+			return s, textinput.Blink
 		}
 
-	case "r":
-		// Roll a d20
-		roll := character.RollD20()
-		// Display would need a message system
-		_ = roll
-
-	case "esc", "q":
-		return s, func() tea.Msg { return NavigateBackMsg{} }
-	}
-
-	return s, nil
-}
-
-func (s *SheetScreen) updateEditHP(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
-		var hp int
-		fmt.Sscanf(s.hpInput.Value(), "%d", &hp)
-		if hp < 0 {
-			hp = 0
+	case "+", "=":
+		if s.tab == 2 && s.char.Exhaustion < character.MaxExhaustion {
+			return s, s.undo.run(exhaustionEdit(s.ctx, s.queries, s.userID, s.char, s.char.Exhaustion+1))
 		}
-		if hp > int(s.char.MaxHitPoints) {
-			hp = int(s.char.MaxHitPoints)
+	case "-":
+		if s.tab == 2 && s.char.Exhaustion > 0 {
+			return s, s.undo.run(exhaustionEdit(s.ctx, s.queries, s.userID, s.char, s.char.Exhaustion-1))
 		}
 
-		return s, s.updateHP(int32(hp))
+	case "L":
+		if s.tab == 2 {
+			s.mode = ModeConfirmLongRest
+		}
 
-	case "esc":
-		s.mode = ModeView
-		return s, nil
-	}
+	case "s":
+		if s.tab == 2 {
+			s.mode = ModeConfirmShortRest
+		}
 
-	var cmd tea.Cmd
-	s.hpInput, cmd = s.hpInput.Update(msg)
-	return s, cmd
-}
+	case "g", "G":
+		if s.tab == 2 && s.resourceCursor < len(s.resources) {
+			r := s.resources[s.resourceCursor]
+			if msg.String() == "G" {
+				return s, s.setResourceUsed(r.Used - 1)
+			}
+			return s, s.setResourceUsed(r.Used + 1)
+		}
 
-func (s *SheetScreen) updateEditNotes(msg tea.Msg) (tea.Model, tea.Cmd) {
-	// Handle special keys first
-	if keyMsg, ok := msg.(tea.KeyMsg); ok {
-		switch keyMsg.String() {
-		case "ctrl+s":
-			return s, s.updateNotes(s.notesInput.Value())
-		case "esc":
-			s.mode = ModeView
+	case "m":
+		if s.tab == 2 { // Combat tab - raise max HP for Aid and similar
+			s.mode = ModeEditMaxHPBonus
+			s.bonusInput.SetValue(fmt.Sprintf("%d", s.char.MaxHitPointsBonus))
+			s.bonusInput.Focus()
+			return s, textinput.Blink
+		}
+
+	case "v":
+		if s.tab == 2 { // Combat tab - set speeds and senses
+			s.startEditMovement()
 			return s, nil
 		}
-	}
 
-	// Pass all other messages to textarea
+	case "o":
+		if s.tab == 2 { // Combat tab - set AC by hand
+			s.mode = ModeEditAC
+			s.acInput.SetValue("")
+			if s.char.ArmorClassOverride.Valid {
+				s.acInput.SetValue(fmt.Sprintf("%d", s.char.ArmorClassOverride.Int32))
+			}
+			s.acInput.Focus()
+			return s, textinput.Blink
+		}
+
+	case "a":
+		if s.tab == 0 { // Stats tab - add a language
+			return s, s.startAddLanguage(false)
+		}
+		if s.tab == 4 { // Notes tab - start a journal entry
+			return s.titleEntry(db.CharacterJournal{})
+		}
+		if s.tab == 6 { // Inventory tab - add an item
+			s.mode = ModeAddItem
+			s.itemInput.SetValue("")
+			s.itemInput.Focus()
+			return s, textinput.Blink
+		}
+
+	case "w":
+		if s.tab == 6 { // Inventory tab - add an SRD weapon
+			s.mode = ModePickWeapon
+			s.weaponMagic = 0
+		}
+
+	case "b":
+		if s.tab == 6 { // Inventory tab - buy equipment
+			s.startShopping()
+		}
+
+	case "$":
+		if s.tab == 6 { // Inventory tab - roll treasure
+			s.mode = ModeRollLoot
+			s.loot = character.RollTreasure(s.lootBand, s.lootHoard)
+		}
+
+	case " ", "enter":
+		if msg.String() == " " && s.tab == 2 && s.resourceCursor < len(s.resources) {
+			return s, s.setResourceUsed(s.resources[s.resourceCursor].Used + 1)
+		}
+		if msg.String() == "enter" && s.tab == 3 && s.spellcasting == nil {
+			s.startSpellcastingSetup()
+			return s, nil
+		}
+		if msg.String() == "enter" && s.tab == 3 && s.spellCursor < len(s.spells) {
+			return s, s.showSpellDetail()
+		}
+		if msg.String() == "enter" && s.tab == 4 && s.journalCursor < len(s.journal) {
+			s.mode = ModeJournalEntry
+			return s, nil
+		}
+		if s.tab == 6 && s.inventoryCursor < len(s.inventory) {
+			item := s.inventory[s.inventoryCursor]
+			return s, s.undo.run(equipEdit(s.ctx, s.queries, s.userID, s.char, item, !item.Equipped))
+		}
+
+	case "M":
+		if s.tab == 6 && s.inventoryCursor < len(s.inventory) {
+			item := s.inventory[s.inventoryCursor]
+			location, ok := newInventoryLayout(s.inventory).nextLocation(item)
+			if !ok {
+				s.status = "Add a container, such as a Backpack, to store items in"
+				return s, nil
+			}
+			return s, s.undo.run(moveItemEdit(s.ctx, s.queries, s.userID, s.char, item, location))
+		}
+
+	case "T":
+		if s.tab == 0 { // Stats tab - add a tool proficiency
+			return s, s.startAddLanguage(true)
+		}
+
+	case "I":
+		if s.tab == 0 { // Stats tab - set the portrait
+			return s, s.startSetPortrait()
+		}
+
+	case "d", "delete":
+		if s.tab == 0 && s.languageCursor < s.otherProficiencies() {
+			return s, s.removeLanguage()
+		}
+		if entry, ok := s.selectedEntry(); ok && s.tab == 4 {
+			return s, s.undo.run(deleteJournalEdit(s.ctx, s.queries, s.userID, s.char, entry))
+		}
+		if s.tab == 6 && s.inventoryCursor < len(s.inventory) {
+			return s, s.undo.run(removeItemEdit(s.ctx, s.queries, s.userID, s.char, s.inventory[s.inventoryCursor]))
+		}
+
+	case "i":
+		// Inspiration is all or nothing, so the key grants or spends it
+		if s.char.Inspiration {
+			return s, s.setInspiration(false, s.char.LuckPoints, "Spent inspiration")
+		}
+		return s, s.setInspiration(true, s.char.LuckPoints, "Gained inspiration")
+
+	case "u":
+		if s.tab == 2 {
+			if s.char.LuckPoints == 0 {
+				s.status = "No luck points to spend"
+				return s, nil
+			}
+			return s, s.setInspiration(s.char.Inspiration, s.char.LuckPoints-1,
+				fmt.Sprintf("Spent a luck point (%d left)", s.char.LuckPoints-1))
+		}
+	case "U":
+		if s.tab == 2 {
+			return s, s.setInspiration(s.char.Inspiration, s.char.LuckPoints+1,
+				fmt.Sprintf("Gained a luck point (%d total)", s.char.LuckPoints+1))
+		}
+
+	case "x":
+		if (s.tab == 2 || s.tab == 3) && s.char.ConcentratingOn != "" {
+			return s, s.setConcentration("", "Ended concentration on "+s.char.ConcentratingOn)
+		}
+
+	case "C":
+		if s.tab == 3 && s.spellCursor < len(s.spells) {
+			return s, s.castRitual(s.spells[s.spellCursor])
+		}
+
+	case "P":
+		if s.tab == 3 && s.spellcasting != nil && !s.startSpellPreparation() {
+			s.status = s.spellcasting.SpellcastingClass + "s know their spells instead of preparing them"
+		}
+
+	case "c":
+		if s.tab == 3 && s.spellCursor < len(s.spells) {
+			spell := s.spells[s.spellCursor]
+			switch {
+			case !spell.Concentration:
+				s.status = spell.Name + " doesn't require concentration"
+			case spell.Name == s.char.ConcentratingOn:
+				s.status = "Already concentrating on " + spell.Name
+			default:
+				// Starting a new concentration spell ends the old one
+				return s, s.setConcentration(spell.Name, "Concentrating on "+spell.Name)
+			}
+		}
+		if s.tab == 4 { // Notes tab - class progression table
+			s.mode = ModeClassTable
+			// Start just above the current level so the next level is visible
+			s.classTableOffset = max(1, int(s.char.Level)-1)
+		}
+		if s.tab == 6 { // Inventory tab - craft an item
+			s.startCrafting()
+		}
+
+	case "f":
+		if s.tab == 4 { // Notes tab - edit features & traits
+			s.mode = ModeEditFeatures
+			s.preview = false
+			s.featuresInput.SetValue(s.char.FeaturesTraits)
+			s.featuresInput.Focus()
+			return s, textarea.Blink
+		}
+
+	case "R":
+		if s.tab == 2 && s.resourceCursor < len(s.resources) { // Combat tab - get every use back
+			return s, s.setResourceUsed(0)
+		}
+		if entry, ok := s.selectedEntry(); ok && s.tab == 4 { // Notes tab - retitle a journal entry
+			return s.titleEntry(entry)
+		}
+
+	case "/":
+		s.mode = ModeSearch
+		s.searchInput.SetValue("")
+		s.searchInput.Focus()
+		s.searchCursor = 0
+		return s, textinput.Blink
+
+	case "ctrl+k":
+		s.mode = ModePalette
+		return s, s.palette.Open(s.paletteCommands())
+
+	case "r":
+		roll := character.RollD20()
+		s.status = fmt.Sprintf("Rolled a d20: %d", roll) + s.natOneOffer(roll)
+
+	case "X":
+		s.showTab(0) // XP is shown on the Stats tab
+		s.mode = ModeAwardXP
+		s.xpInput.SetValue("")
+		s.xpInput.Focus()
+		return s, textinput.Blink
+
+	case "n":
+		s.mode = ModeRename
+		s.nameInput.SetValue(s.char.Name)
+		s.nameInput.CursorEnd()
+		s.nameInput.Focus()
+		return s, textinput.Blink
+
+	case "p":
+		// With enough XP, go straight to leveling up
+		char, levelUp := s.char, s.levelUpAvailable()
+		return s, func() tea.Msg { return NavigateToPlanMsg{Character: char, LevelUp: levelUp} }
+
+	case "?":
+		return s, func() tea.Msg { return NavigateToManualMsg{} }
+
+	case "S":
+		if !s.authService.Sharing() {
+			s.status = "Sharing needs api.port and oauth.base_url in the server config"
+			return s, nil
+		}
+		return s, s.createShareLink()
+
+	case "E":
+		// Without mouse reporting the terminal can select the text
+		s.mode = ModePrint
+		s.print.offset = 0
+		return s, tea.DisableMouse
+
+	case "ctrl+z":
+		if cmd := s.undo.undo(); cmd != nil {
+			return s, cmd
+		}
+		s.status = "Nothing to undo"
+
+	case "ctrl+y":
+		if cmd := s.undo.redo(); cmd != nil {
+			return s, cmd
+		}
+		s.status = "Nothing to redo"
+
+	case "esc", "q":
+		return s, func() tea.Msg { return NavigateBackMsg{} }
+	}
+
+	return s, nil
+}
+
+func (s *SheetScreen) updateEditHP(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		// "-N" is damage, "+N" is healing, and a plain number sets HP
+		value := strings.TrimSpace(s.hpInput.Value())
+		var n int
+		fmt.Sscanf(strings.TrimLeft(value, "+-"), "%d", &n)
+
+		hp := s.hitPoints()
+		s.concentrationDC = 0
+		note := ""
+		switch {
+		case strings.HasPrefix(value, "-"):
+			taken, resisted := character.DamageTaken(s.char.Race, s.damageType, n)
+			hp = hp.Damage(taken)
+			if taken > 0 && s.char.ConcentratingOn != "" {
+				s.concentrationDC = character.ConcentrationDC(taken)
+			}
+			if s.damageType != "" {
+				note = fmt.Sprintf(" (%d %s damage", taken, s.damageType)
+				if resisted {
+					note += fmt.Sprintf(", resisted from %d", n)
+					s.status = fmt.Sprintf("%s resists %s damage: took %d of %d", s.char.Name, s.damageType, taken, n)
+				}
+				note += ")"
+			}
+		case strings.HasPrefix(value, "+"):
+			hp = hp.Heal(n)
+		default:
+			hp = hp.Set(n)
+		}
+
+		return s, s.updateHP(int32(hp.Current), int32(hp.Temp), note)
+
+	case "up", "down":
+		// Cycle the damage type, with untyped before the first
+		types := append([]string{""}, character.DamageTypes...)
+		step := 1
+		if msg.String() == "up" {
+			step = len(types) - 1
+		}
+		s.damageType = types[(slices.Index(types, s.damageType)+step)%len(types)]
+		return s, nil
+
+	case "esc":
+		s.mode = ModeView
+		return s, nil
+	}
+
 	var cmd tea.Cmd
-	s.notesInput, cmd = s.notesInput.Update(msg)
+	s.hpInput, cmd = s.hpInput.Update(msg)
 	return s, cmd
 }
 
-func (s *SheetScreen) updateHP(hp int32) tea.Cmd {
-	return func() tea.Msg {
-		updated, err := s.queries.UpdateCharacterHitPoints(s.ctx, db.UpdateCharacterHitPointsParams{
-			ID:                 s.char.ID,
-			CurrentHitPoints:   hp,
-			TemporaryHitPoints: s.char.TemporaryHitPoints,
-		})
-		if err != nil {
-			return nil
+func (s *SheetScreen) updateEditMaxHPBonus(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		var bonus int
+		fmt.Sscanf(strings.TrimSpace(s.bonusInput.Value()), "%d", &bonus)
+		bonus = max(bonus, 0)
+		if bonus == int(s.char.MaxHitPointsBonus) {
+			s.mode = ModeView
+			return s, nil
+		}
+		hp := s.hitPoints().ChangeMaxBonus(bonus - int(s.char.MaxHitPointsBonus))
+		return s, s.undo.run(maxHPBonusEdit(s.ctx, s.queries, s.userID, s.char, int32(bonus), int32(hp.Current)))
+
+	case "esc":
+		s.mode = ModeView
+		return s, nil
+	}
+
+	var cmd tea.Cmd
+	s.bonusInput, cmd = s.bonusInput.Update(msg)
+	return s, cmd
+}
+
+func (s *SheetScreen) updateRename(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		name := sanitize.Line(s.nameInput.Value(), sanitize.MaxName)
+		if name == "" || name == s.char.Name {
+			s.mode = ModeView
+			return s, nil
+		}
+		return s, s.undo.run(renameEdit(s.ctx, s.queries, s.userID, s.char, name))
+
+	case "esc":
+		s.mode = ModeView
+		return s, nil
+	}
+
+	var cmd tea.Cmd
+	s.nameInput, cmd = s.nameInput.Update(msg)
+	return s, cmd
+}
+
+func (s *SheetScreen) updateAwardXP(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		// A negative amount corrects an earlier award
+		var n int
+		fmt.Sscanf(strings.TrimSpace(s.xpInput.Value()), "%d", &n)
+		xp := max(int(s.char.ExperiencePoints)+n, 0)
+		if xp == int(s.char.ExperiencePoints) {
+			s.mode = ModeView
+			return s, nil
 		}
-		s.char = updated
+		return s, s.undo.run(xpEdit(s.ctx, s.queries, s.userID, s.char, int32(xp)))
+
+	case "esc":
 		s.mode = ModeView
-		return CharacterUpdatedMsg{Character: updated}
+		return s, nil
 	}
+
+	var cmd tea.Cmd
+	s.xpInput, cmd = s.xpInput.Update(msg)
+	return s, cmd
 }
 
-func (s *SheetScreen) updateNotes(notes string) tea.Cmd {
+// createShareLink issues a read-only link to the sheet and encodes it as a
+// QR code for a phone to scan
+func (s *SheetScreen) createShareLink() tea.Cmd {
+	userID, charID := s.userID, s.char.ID
 	return func() tea.Msg {
-		updated, err := s.queries.UpdateCharacterNotes(s.ctx, db.UpdateCharacterNotesParams{
-			ID:             s.char.ID,
-			FeaturesTraits: s.char.FeaturesTraits,
-			Notes:          notes,
-		})
+		url, expires, err := s.authService.CreateShareLink(s.ctx, userID, charID)
 		if err != nil {
-			return nil
+			return shareErrorMsg{Err: err}
+		}
+		code, err := qr.Encode(url)
+		if err != nil {
+			return shareErrorMsg{Err: err}
+		}
+		return shareLinkMsg{URL: url, Expires: expires, Code: code.String()}
+	}
+}
+
+// setResourceUsed spends or regains uses of the resource under the cursor
+func (s *SheetScreen) setResourceUsed(used int32) tea.Cmd {
+	r := s.resources[s.resourceCursor]
+	if used == r.Used || used < 0 || used > r.MaxUses {
+		s.status = fmt.Sprintf("%s is at %d of %d", r.Name, r.MaxUses-r.Used, r.MaxUses)
+		return nil
+	}
+	return s.undo.run(resourceEdit(s.ctx, s.queries, s.userID, s.char, r, used))
+}
+
+// levelUpAvailable reports whether the character has enough XP for a higher
+// level than they are
+func (s *SheetScreen) levelUpAvailable() bool {
+	return s.char.Level < character.MaxLevel &&
+		character.LevelFromXP(int(s.char.ExperiencePoints)) > int(s.char.Level)
+}
+
+// hitPoints returns the character's hit points with the effective maximum
+func (s *SheetScreen) hitPoints() character.HitPoints {
+	return character.HitPoints{
+		Current: int(s.char.CurrentHitPoints),
+		Temp:    int(s.char.TemporaryHitPoints),
+		Max:     character.MaxHitPoints(s.rules(), int(s.char.MaxHitPoints), int(s.char.MaxHitPointsBonus), int(s.char.Exhaustion)),
+	}
+}
+
+func (s *SheetScreen) updateAddItem(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		name := sanitize.Line(s.itemInput.Value(), sanitize.MaxName)
+		if name == "" {
+			s.mode = ModeView
+			return s, nil
 		}
-		s.char = updated
+		return s, s.undo.run(addItemEdit(s.ctx, s.queries, s.userID, s.char, name, false))
+
+	case "esc":
 		s.mode = ModeView
-		return CharacterUpdatedMsg{Character: updated}
+		return s, nil
 	}
+
+	var cmd tea.Cmd
+	s.itemInput, cmd = s.itemInput.Update(msg)
+	return s, cmd
 }
 
-func (s *SheetScreen) updateEditFeatures(msg tea.Msg) (tea.Model, tea.Cmd) {
+func (s *SheetScreen) updatePickWeapon(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if s.weaponCursor > 0 {
+			s.weaponCursor--
+		}
+	case "down", "j":
+		if s.weaponCursor < len(character.WeaponTable)-1 {
+			s.weaponCursor++
+		}
+	case "+", "=":
+		if s.weaponMagic < 3 {
+			s.weaponMagic++
+		}
+	case "-":
+		if s.weaponMagic > 0 {
+			s.weaponMagic--
+		}
+	case "enter":
+		// New weapons are equipped so they show up as attacks
+		return s, s.undo.run(addItemEdit(s.ctx, s.queries, s.userID, s.char, s.pickedWeaponName(), true))
+	case "esc":
+		s.mode = ModeView
+	}
+	return s, nil
+}
+
+func (s *SheetScreen) updateRollLoot(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "left", "h":
+		if s.lootBand > 0 {
+			s.lootBand--
+		}
+	case "right", "l":
+		if s.lootBand < len(character.TreasureBands)-1 {
+			s.lootBand++
+		}
+	case "tab":
+		s.lootHoard = !s.lootHoard
+	case "r":
+		s.loot = character.RollTreasure(s.lootBand, s.lootHoard)
+	case "enter":
+		if s.loot.Empty() {
+			s.mode = ModeView
+			return s, nil
+		}
+		return s, s.undo.run(lootEdit(s.ctx, s.queries, s.userID, s.char, s.inventory, s.loot))
+	case "esc":
+		s.mode = ModeView
+	}
+	return s, nil
+}
+
+// pickedWeaponName is the item name for the weapon being added, with its
+// magic bonus, e.g. "Longsword +1"
+func (s *SheetScreen) pickedWeaponName() string {
+	name := character.WeaponTable[s.weaponCursor].Name
+	if s.weaponMagic > 0 {
+		name += fmt.Sprintf(" +%d", s.weaponMagic)
+	}
+	return name
+}
+
+func (s *SheetScreen) updateEditAC(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		// A blank or zero value goes back to the calculated AC
+		var n int
+		fmt.Sscanf(strings.TrimSpace(s.acInput.Value()), "%d", &n)
+		var override pgtype.Int4
+		if n > 0 {
+			override = pgtype.Int4{Int32: int32(n), Valid: true}
+		}
+		if override == s.char.ArmorClassOverride {
+			s.mode = ModeView
+			return s, nil
+		}
+		return s, s.undo.run(armorClassOverrideEdit(s.ctx, s.queries, s.userID, s.char, override))
+
+	case "esc":
+		s.mode = ModeView
+		return s, nil
+	}
+
+	var cmd tea.Cmd
+	s.acInput, cmd = s.acInput.Update(msg)
+	return s, cmd
+}
+
+func (s *SheetScreen) updateEditTempHP(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		var temp int
+		fmt.Sscanf(s.tempHPInput.Value(), "%d", &temp)
+		hp := s.hitPoints().SetTemp(temp)
+		return s, s.updateHP(int32(hp.Current), int32(hp.Temp), "")
+
+	case "esc":
+		s.mode = ModeView
+		return s, nil
+	}
+
+	var cmd tea.Cmd
+	s.tempHPInput, cmd = s.tempHPInput.Update(msg)
+	return s, cmd
+}
+
+func (s *SheetScreen) updateEditNotes(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Handle special keys first
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
 		switch keyMsg.String() {
 		case "ctrl+s":
-			return s, s.updateFeatures(s.featuresInput.Value())
+			body := sanitize.Text(s.notesInput.Value(), sanitize.MaxText)
+			s.journalCursor = 0 // saving moves the entry to the top
+			return s, s.undo.run(journalEdit(s.ctx, s.queries, s.userID, s.char, s.editingEntry, s.editingEntry.Title, body))
+		case "ctrl+r":
+			s.preview = !s.preview
+			return s, nil
 		case "esc":
 			s.mode = ModeView
 			return s, nil
 		}
+		if s.preview {
+			return s, nil
+		}
 	}
 
 	// Pass all other messages to textarea
 	var cmd tea.Cmd
-	s.featuresInput, cmd = s.featuresInput.Update(msg)
+	s.notesInput, cmd = s.notesInput.Update(msg)
 	return s, cmd
 }
 
-func (s *SheetScreen) updateFeatures(features string) tea.Cmd {
-	return func() tea.Msg {
-		updated, err := s.queries.UpdateCharacterNotes(s.ctx, db.UpdateCharacterNotesParams{
-			ID:             s.char.ID,
-			FeaturesTraits: features,
-			Notes:          s.char.Notes,
-		})
-		if err != nil {
-			return nil
-		}
-		s.char = updated
-		s.mode = ModeView
-		return CharacterUpdatedMsg{Character: updated}
+// updateHP sets hit points; note, such as the type of damage taken, is
+// added to the change log
+func (s *SheetScreen) updateHP(hp, temp int32, note string) tea.Cmd {
+	return s.undo.run(hpEdit(s.ctx, s.queries, s.userID, s.char, hp, temp, note))
+}
+
+func (s *SheetScreen) setInspiration(inspiration bool, luck int32, description string) tea.Cmd {
+	return s.undo.run(inspirationEdit(s.ctx, s.queries, s.userID, s.char, inspiration, luck, description))
+}
+
+func (s *SheetScreen) setConcentration(spell, description string) tea.Cmd {
+	return s.undo.run(concentrationEdit(s.ctx, s.queries, s.userID, s.char, spell, description))
+}
+
+func (s *SheetScreen) updateSpellDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s.status = ""
+	switch msg.String() {
+	case "up", "k":
+		if s.spellCursor > 0 {
+			s.spellCursor--
+		}
+	case "down", "j":
+		if s.spellCursor < len(s.spells)-1 {
+			s.spellCursor++
+		}
+	case "C":
+		return s, s.castRitual(s.spells[s.spellCursor])
+	case "esc", "q", "enter":
+		s.mode = ModeView
+	}
+	return s, nil
+}
+
+// castRitual casts a spell as a ritual, which spends no slot. Only
+// concentration changes the sheet; other rituals are just logged.
+func (s *SheetScreen) castRitual(spell db.CharacterSpell) tea.Cmd {
+	info, ok := character.FindSpell(spell.Name)
+	switch {
+	case !ok:
+		s.status = "No details for " + spell.Name + ", so it can't be cast as a ritual"
+		return nil
+	case !info.Ritual:
+		s.status = spell.Name + " isn't a ritual"
+		return nil
+	case !character.CanCastAsRitual(s.char.Class, info, spell.Prepared):
+		if spell.Prepared {
+			s.status = s.char.Class + "s can't cast rituals"
+		} else {
+			s.status = spell.Name + " must be prepared to cast it as a ritual"
+		}
+		return nil
+	}
+
+	description := "Cast " + spell.Name + " as a ritual"
+	if info.Concentration {
+		return s.setConcentration(spell.Name, description+", concentrating")
+	}
+	s.status = description + " (10 minutes longer, no slot spent)"
+	char, userID := s.char, s.userID
+	return func() tea.Msg {
+		audit.Record(s.ctx, s.queries, char.ID, userID, audit.KindCast, description)
+		return s.loadEvents()()
+	}
+}
+
+// natOneOffer reminds the player to take inspiration when the ruleset
+// grants it for a roll, since it's their choice to record it
+func (s *SheetScreen) natOneOffer(roll int) string {
+	if s.char.Inspiration || !s.rules().InspirationOnRoll(roll) {
+		return ""
+	}
+	return ". Natural 1! Press i to take Heroic Inspiration"
+}
+
+// concentrationSaveBonus returns the character's Constitution saving throw
+func (s *SheetScreen) concentrationSaveBonus() int {
+	return character.SavingThrow(int(s.char.Constitution), int(s.char.Level), s.saveProficient("Constitution"))
+}
+
+func (s *SheetScreen) updateConcentrationSave(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	dc := s.concentrationDC
+	spell := s.char.ConcentratingOn
+
+	switch msg.String() {
+	case "r":
+		roll := character.RollD20()
+		bonus := s.concentrationSaveBonus()
+		total := roll + bonus
+		s.mode = ModeView
+		s.concentrationDC = 0
+		result := fmt.Sprintf("Concentration save: %d %s = %d vs DC %d", roll, character.FormatModifierInt(bonus), total, dc)
+		if total >= dc {
+			s.status = result + ", kept " + spell + s.natOneOffer(roll)
+			return s, nil
+		}
+		s.status = result + ", lost " + spell + s.natOneOffer(roll)
+		return s, s.setConcentration("", fmt.Sprintf("Lost concentration on %s (rolled %d vs DC %d)", spell, total, dc))
+
+	case "s", "esc":
+		// Rolled at the table and made it
+		s.mode = ModeView
+		s.concentrationDC = 0
+
+	case "f":
+		s.mode = ModeView
+		s.concentrationDC = 0
+		return s, s.setConcentration("", fmt.Sprintf("Lost concentration on %s (failed DC %d save)", spell, dc))
+	}
+
+	return s, nil
+}
+
+// startEditProficiencies copies the character's proficiencies into the
+// working set edited on the Stats or Skills tab
+func (s *SheetScreen) startEditProficiencies() {
+	s.editSaves = make(map[string]bool)
+	for _, ability := range character.Abilities {
+		s.editSaves[ability] = s.saveProficient(ability)
+	}
+	s.editSkills = make(map[string]character.Proficiency)
+	for _, skill := range character.SkillList {
+		s.editSkills[skill] = s.skillProficiency(skill)
+	}
+	s.profCursor = 0
+	s.mode = ModeEditSaves
+	if s.tab == 1 {
+		s.mode = ModeEditSkills
+	}
+}
+
+func (s *SheetScreen) updateEditProficiencies(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rows := len(character.Abilities)
+	if s.mode == ModeEditSkills {
+		rows = len(character.SkillList)
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if s.profCursor > 0 {
+			s.profCursor--
+		}
+	case "down", "j":
+		if s.profCursor < rows-1 {
+			s.profCursor++
+		}
+	case " ", "x":
+		if s.mode == ModeEditSaves {
+			ability := character.Abilities[s.profCursor]
+			s.editSaves[ability] = !s.editSaves[ability]
+		} else {
+			skill := character.SkillList[s.profCursor]
+			s.editSkills[skill] = s.editSkills[skill].Next()
+		}
+	case "enter", "ctrl+s":
+		return s, s.saveProficiencies()
+	case "esc":
+		s.mode = ModeView
+	}
+	return s, nil
+}
+
+// saveProficiencies writes the edited proficiencies, or leaves edit mode if
+// nothing changed
+func (s *SheetScreen) saveProficiencies() tea.Cmd {
+	var saves, changes []string
+	for _, ability := range character.Abilities {
+		if s.editSaves[ability] {
+			saves = append(saves, ability)
+		}
+		if s.editSaves[ability] != s.saveProficient(ability) {
+			sign := "-"
+			if s.editSaves[ability] {
+				sign = "+"
+			}
+			changes = append(changes, sign+ability+" save")
+		}
+	}
+	for _, skill := range character.SkillList {
+		if p := s.editSkills[skill]; p != s.skillProficiency(skill) {
+			changes = append(changes, skill+" → "+proficiencyName(p))
+		}
+	}
+	if len(changes) == 0 {
+		s.mode = ModeView
+		return nil
+	}
+
+	if saves == nil {
+		saves = []string{}
+	}
+	skills, expertise, half := character.SkillLists(s.editSkills)
+	return s.undo.run(proficiencyEdit(s.ctx, s.queries, s.userID, s.char, saves, skills, expertise, half,
+		"Proficiencies: "+strings.Join(changes, ", ")))
+}
+
+// saveProficient reports whether the character is proficient in an
+// ability's saving throws
+func (s *SheetScreen) saveProficient(ability string) bool {
+	for _, p := range s.char.SavingThrowProficiencies {
+		if strings.EqualFold(p, ability) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SheetScreen) skillProficiency(skill string) character.Proficiency {
+	return character.SkillProficiency(skill, s.char.SkillProficiencies, s.char.SkillExpertise, s.char.SkillHalfProficiencies)
+}
+
+// proficiencyName describes a proficiency in the change log
+func proficiencyName(p character.Proficiency) string {
+	switch p {
+	case character.HalfProficient:
+		return "half proficient"
+	case character.Proficient:
+		return "proficient"
+	case character.Expertise:
+		return "expertise"
+	}
+	return "not proficient"
+}
+
+// proficiencyMark is the marker shown before a proficient save or skill
+func proficiencyMark(p character.Proficiency) string {
+	switch p {
+	case character.HalfProficient:
+		return "◐ "
+	case character.Proficient:
+		return "● "
+	case character.Expertise:
+		return "◆ "
+	}
+	return "  "
+}
+
+// handleEditApplied updates the sheet after an edit, undo, or redo is
+// written to the database
+func (s *SheetScreen) handleEditApplied(msg editAppliedMsg) (tea.Model, tea.Cmd) {
+	s.undo.finish(msg)
+	if msg.Err != nil {
+		s.concentrationDC = 0
+		if msg.Action != editDo || errors.Is(msg.Err, errNameTaken) || errors.Is(msg.Err, errNotEnoughCoins) || errors.Is(msg.Err, errEditPending) {
+			s.status = "Error: " + msg.Err.Error()
+		}
+		return s, nil
+	}
+
+	inspired := msg.Character.Inspiration && !s.char.Inspiration
+	s.char = msg.Character
+	s.mode = ModeView
+	switch msg.Action {
+	case editUndo:
+		s.status = "Undid " + msg.Edit.label
+	case editRedo:
+		s.status = "Redid " + msg.Edit.label
+	}
+	if msg.Edit.label == "XP" && msg.Action != editUndo && s.levelUpAvailable() {
+		s.status = "Level up available! Press p to level up."
+	}
+
+	updated := msg.Character
+	// Every edit is logged, so the change log is always reloaded
+	changed := msg.Edit.touches | partEvents
+	s.cache.invalidate(updated.ID, changed)
+	cmds := []tea.Cmd{
+		func() tea.Msg { return CharacterUpdatedMsg{Character: updated} },
+		s.loadParts(changed),
+	}
+	if msg.Edit.label == "long rest" {
+		if inspired && msg.Action != editUndo {
+			s.status = "Rested and gained Heroic Inspiration"
+		}
+		// Prepared casters choose their spells for the new day
+		if msg.Action == editDo && s.startSpellPreparation() {
+			s.showTab(3)
+		}
+	}
+
+	// Damage while concentrating calls for a save, unless it dropped the
+	// character to 0 HP, which ends concentration outright
+	if s.concentrationDC > 0 && msg.Action == editDo && updated.ConcentratingOn != "" {
+		if updated.CurrentHitPoints == 0 {
+			s.concentrationDC = 0
+			s.status = "Concentration on " + updated.ConcentratingOn + " ended at 0 HP"
+			cmds = append(cmds, s.setConcentration("", "Lost concentration on "+updated.ConcentratingOn+" (dropped to 0 HP)"))
+		} else {
+			s.mode = ModeConcentrationSave
+		}
+	}
+
+	return s, tea.Batch(cmds...)
+}
+
+func (s *SheetScreen) updateEditFeatures(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Handle special keys first
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "ctrl+s":
+			return s, s.updateFeatures(sanitize.Text(s.featuresInput.Value(), sanitize.MaxText))
+		case "ctrl+r":
+			s.preview = !s.preview
+			return s, nil
+		case "esc":
+			s.mode = ModeView
+			return s, nil
+		}
+		if s.preview {
+			return s, nil
+		}
+	}
+
+	// Pass all other messages to textarea
+	var cmd tea.Cmd
+	s.featuresInput, cmd = s.featuresInput.Update(msg)
+	return s, cmd
+}
+
+func (s *SheetScreen) updateFeatures(features string) tea.Cmd {
+	return s.undo.run(notesEdit(s.ctx, s.queries, s.userID, s.char, audit.KindFeatures, features, s.char.Notes))
+}
+
+// sheetTabs are the sheet's tab names, in tab order
+var sheetTabs = []string{"Stats", "Skills", "Combat", "Spells", "Notes", "History", "Inventory"}
+
+func (s *SheetScreen) View() string {
+	if tooSmall(s.width, s.height) {
+		return viewTooSmall(s.styles, s.width, s.height)
+	}
+	if s.mode == ModeShare && s.share != nil {
+		return s.viewShare()
+	}
+	if s.mode == ModePrint {
+		return s.viewPrint()
+	}
+
+	var b strings.Builder
+
+	// Header with character name, and badges beside it or, on narrow
+	// terminals, under it
+	header := fmt.Sprintf("%s - Level %d %s %s",
+		s.char.Name, s.char.Level, s.char.Race, s.char.Class)
+	title := s.styles.CharacterMark(s.char.Accent, s.char.Icon) + s.styles.Title.Render(header)
+	if s.mode == ModeRename {
+		title = s.styles.FocusedInput.Render(s.nameInput.View())
+	}
+	if badges := s.viewBadges(); badges != "" {
+		switch {
+		case !s.narrow():
+			title = lipgloss.JoinHorizontal(lipgloss.Top, title, "  ", badges)
+		case !s.short():
+			title = s.styles.Title.UnsetMarginBottom().Render(header) + "\n" + badges + "\n"
+		}
+	}
+	b.WriteString(title)
+	b.WriteString("\n")
+	b.WriteString(s.viewVitals())
+	b.WriteString("\n\n")
+
+	// Tab bar, with the unfocused pane's tab marked too on wide terminals
+	b.WriteString(s.viewTabBar())
+	b.WriteString("\n\n")
+
+	// Tab content, or search results or the command palette covering it
+	var body string
+	if s.mode == ModeSearch {
+		body = s.viewSearch()
+	} else if s.mode == ModePalette {
+		body = s.palette.View("Commands", paletteStyles(s.styles))
+	} else if s.mode == ModeConfirmRepair {
+		body = s.viewHealth()
+	} else if s.split() {
+		body = s.viewPanes()
+	} else {
+		body = s.viewTab(s.tab)
+	}
+
+	var footer strings.Builder
+	if s.status != "" {
+		footer.WriteString("\n\n")
+		if strings.HasPrefix(s.status, "Error: ") {
+			footer.WriteString(s.styles.ErrorText.Render(s.status))
+		} else {
+			footer.WriteString(s.styles.SuccessText.Render(s.status))
+		}
+	}
+
+	// Help
+	footer.WriteString("\n\n")
+	help := s.styles.Help
+	if lipgloss.Width(s.getHelp()) > s.width-2 {
+		help = help.Width(s.width - 2)
+	}
+	footer.WriteString(help.Render(s.getHelp()))
+
+	rows := s.height - lipgloss.Height(b.String()) - lipgloss.Height(footer.String()) + 2
+	b.WriteString(clipLines(s.styles, body, s.width-2, rows))
+	b.WriteString(footer.String())
+
+	return lipgloss.Place(s.width, s.height,
+		lipgloss.Center, lipgloss.Center,
+		lipgloss.NewStyle().MaxWidth(s.width).Render(b.String()))
+}
+
+// splitWidth is the terminal width at which two tabs are shown side by side
+const splitWidth = 140
+
+// split reports whether the terminal is wide enough for two panes
+func (s *SheetScreen) split() bool {
+	return s.width >= splitWidth
+}
+
+// paneTab returns the tab in the unfocused pane, which is never the
+// focused tab even if the terminal was narrow when that was chosen
+func (s *SheetScreen) paneTab() int {
+	if s.otherTab == s.tab {
+		return (s.tab + 1) % 7
+	}
+	return s.otherTab
+}
+
+// showTab focuses a tab, swapping panes if it's showing in the other one
+func (s *SheetScreen) showTab(tab int) {
+	if s.split() && s.paneTab() == tab {
+		s.tab, s.otherTab = tab, s.tab
+		s.focusRight = !s.focusRight
+		return
+	}
+	s.tab = tab
+}
+
+// viewPanes shows the focused tab and the other tab side by side, the
+// focused one highlighted
+func (s *SheetScreen) viewPanes() string {
+	width := (s.width - 8) / 2
+	focused := s.styles.HighlightBox.Width(width).Render(s.viewTab(s.tab))
+	other := s.styles.Box.Width(width).Render(s.viewTab(s.paneTab()))
+	if s.focusRight {
+		return lipgloss.JoinHorizontal(lipgloss.Top, other, " ", focused)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, focused, " ", other)
+}
+
+// viewTab renders one tab's content
+func (s *SheetScreen) viewTab(tab int) string {
+	switch tab {
+	case 0:
+		if s.mode == ModeSetPortrait {
+			return s.viewSetPortrait()
+		}
+		return s.viewStats()
+	case 1:
+		return s.viewSkills()
+	case 2:
+		if s.mode == ModeEditMovement {
+			return s.viewEditMovement()
+		}
+		return s.viewCombat()
+	case 3:
+		if s.mode == ModeSpellDetail {
+			return s.viewSpellDetail()
+		}
+		if s.mode == ModeSetupSpellcasting {
+			return s.viewSpellcastingSetup()
+		}
+		if s.mode == ModePrepareSpells {
+			return s.viewSpellPreparation()
+		}
+		return s.viewSpells()
+	case 4:
+		if s.mode == ModeClassTable {
+			return s.viewClassTable()
+		}
+		if s.mode == ModeJournalEntry {
+			return s.viewJournalEntry()
+		}
+		return s.viewNotes()
+	case 5:
+		return s.viewHistory()
+	case 6:
+		if s.mode == ModePickWeapon {
+			return s.viewWeaponPicker()
+		}
+		if s.mode == ModeRollLoot {
+			return s.viewLoot()
+		}
+		if s.mode == ModeCraft {
+			return s.viewCraft()
+		}
+		if s.mode == ModeShop {
+			return s.viewShop()
+		}
+		return s.viewInventory()
+	}
+	return ""
+}
+
+// viewShare shows the QR code and URL for a share link
+func (s *SheetScreen) viewShare() string {
+	var b strings.Builder
+	b.WriteString(s.styles.Title.Render("Share " + s.char.Name))
+	b.WriteString("\n\n")
+	b.WriteString(s.styles.QRCode.Render(s.share.Code))
+	b.WriteString("\n\n")
+	b.WriteString(s.share.URL)
+	b.WriteString("\n\n")
+	if s.spellcasting != nil {
+		b.WriteString("Printable spell cards: " + s.share.URL + "/spells")
+		b.WriteString("\n\n")
+	}
+	b.WriteString(s.styles.Muted.Render(fmt.Sprintf("Read-only. Expires at %s.", s.share.Expires.Format("15:04"))))
+	b.WriteString("\n\n")
+	b.WriteString(s.styles.Help.Render(s.getHelp()))
+
+	return lipgloss.Place(s.width, s.height,
+		lipgloss.Center, lipgloss.Center,
+		b.String())
+}
+
+// viewBadges shows inspiration and luck points next to the character name
+func (s *SheetScreen) viewBadges() string {
+	var badges []string
+	if s.char.Inspiration {
+		badges = append(badges, s.styles.WarningText.Render("★ Inspired"))
+	}
+	if s.char.LuckPoints > 0 {
+		badges = append(badges, s.styles.SuccessText.Render(fmt.Sprintf("☘ Luck %d", s.char.LuckPoints)))
+	}
+	if s.levelUpAvailable() {
+		badges = append(badges, s.styles.SuccessText.Render("▲ Level up available! (p)"))
+	}
+	return strings.Join(badges, "  ")
+}
+
+// viewVitals is the line under the title on every tab with what matters
+// mid-fight: HP, AC, exhaustion, and concentration
+func (s *SheetScreen) viewVitals() string {
+	hp := s.hitPoints()
+	vitals := []string{"HP " + s.hpStyle(hp).Render(fmt.Sprintf("%d/%d", hp.Current, hp.Max))}
+	if hp.Temp > 0 {
+		vitals[0] += s.styles.Muted.Render(fmt.Sprintf(" +%d temp", hp.Temp))
+	}
+	vitals = append(vitals, fmt.Sprintf("AC %d", armorClass(s.char, s.inventory).Total))
+	if s.char.Exhaustion > 0 {
+		vitals = append(vitals, s.styles.WarningText.Render(fmt.Sprintf("Exhaustion %d", s.char.Exhaustion)))
+	}
+	if s.char.ConcentratingOn != "" {
+		vitals = append(vitals, s.styles.WarningText.Render("Concentrating on "+s.char.ConcentratingOn))
+	}
+	return strings.Join(vitals, s.styles.Muted.Render(" • "))
+}
+
+// hpStyle colors hit points by how hurt the character is
+func (s *SheetScreen) hpStyle(hp character.HitPoints) lipgloss.Style {
+	pct := float64(hp.Current) / float64(hp.Max)
+	switch {
+	case pct < 0.25:
+		return s.styles.HPCritical
+	case pct < 0.5:
+		return s.styles.HPLow
+	}
+	return s.styles.HPCurrent
+}
+
+func (s *SheetScreen) viewStats() string {
+	var b strings.Builder
+
+	// Ability scores
+	abilities := []struct {
+		name  string
+		score int32
+	}{
+		{"Strength", s.char.Strength},
+		{"Dexterity", s.char.Dexterity},
+		{"Constitution", s.char.Constitution},
+		{"Intelligence", s.char.Intelligence},
+		{"Wisdom", s.char.Wisdom},
+		{"Charisma", s.char.Charisma},
+	}
+
+	profBonus := s.rules().ProficiencyBonus(int(s.char.Level))
+
+	b.WriteString(s.styles.Header.Render("Ability Scores"))
+	b.WriteString("\n\n")
+
+	// Use fixed-width columns for alignment
+	labelWidth := 14
+	scoreWidth := 3
+	modWidth := 4
+
+	for _, a := range abilities {
+		mod := character.AbilityModifier(int(a.score))
+		// Pad the name manually before styling
+		paddedName := fmt.Sprintf("%-*s", labelWidth, a.name)
+		paddedScore := fmt.Sprintf("%*d", scoreWidth, a.score)
+		paddedMod := fmt.Sprintf("%*s", modWidth, character.FormatModifierInt(mod))
+
+		b.WriteString(s.styles.Muted.Render(paddedName))
+		b.WriteString("  ")
+		b.WriteString(s.styles.StatValue.Render(paddedScore))
+		b.WriteString("  ")
+		b.WriteString(s.styles.StatMod.Render(paddedMod))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(s.styles.Header.Render("Saving Throws"))
+	b.WriteString("\n\n")
+
+	for i, a := range abilities {
+		proficient := s.saveProficient(a.name)
+		if s.mode == ModeEditSaves {
+			proficient = s.editSaves[a.name]
+		}
+
+		mod := character.AbilityModifier(int(a.score))
+		profMark := "  "
+		style := s.styles.NotProficient
+		if proficient {
+			mod += profBonus
+			profMark = "● "
+			style = s.styles.Proficient
+		}
+		if s.mode == ModeEditSaves && i == s.profCursor {
+			style = s.styles.Cursor
+		}
+		paddedName := fmt.Sprintf("%-*s", labelWidth, a.name)
+		paddedMod := fmt.Sprintf("%*s", modWidth, character.FormatModifierInt(mod))
+		row := style.Render(profMark + paddedName + "  " + paddedMod)
+		if s.mode == ModeEditSaves {
+			row = components.Mark(components.Item("prof", i), row)
+		}
+		b.WriteString(row)
+		b.WriteString("\n")
+	}
+
+	// The portrait sits beside the scores when there's room
+	scores := s.withPortrait(strings.TrimSuffix(b.String(), "\n"))
+	b.Reset()
+	b.WriteString(scores)
+	b.WriteString("\n\n")
+	b.WriteString("Experience: ")
+	if s.mode == ModeAwardXP {
+		b.WriteString(fmt.Sprintf("%d + ", s.char.ExperiencePoints))
+		b.WriteString(s.styles.FocusedInput.Render(s.xpInput.View()))
+	} else {
+		b.WriteString(s.styles.StatValue.UnsetWidth().Render(fmt.Sprintf("%d XP", s.char.ExperiencePoints)))
+		if s.char.Level < character.MaxLevel && !s.levelUpAvailable() {
+			next := character.XPThresholds[int(s.char.Level)+1] - int(s.char.ExperiencePoints)
+			b.WriteString(s.styles.Muted.Render(fmt.Sprintf(" (%d to level %d)", next, s.char.Level+1)))
+		}
+		if s.char.Level < character.MaxLevel {
+			// Progress through the current level, full once a level up is available
+			from := character.XPThresholds[int(s.char.Level)]
+			to := character.XPThresholds[int(s.char.Level)+1]
+			b.WriteString("\n            ")
+			b.WriteString(components.ProgressBar(float64(int(s.char.ExperiencePoints)-from), float64(to-from), 20, s.styles.SuccessText, s.styles.Muted))
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString("Proficiency Bonus: ")
+	b.WriteString(s.styles.StatValue.Render(character.FormatModifierInt(profBonus)))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("Carrying Capacity: %s\n", s.rules().CarryingCapacity(int(s.char.Strength))))
+	b.WriteString(s.styles.Muted.Render("Rules: " + s.rules().Name()))
+	b.WriteString("\n\n")
+	b.WriteString(s.viewLanguages())
+	if s.short() {
+		return b.String()
+	}
+	b.WriteString("\n")
+	for _, note := range s.rules().Reference() {
+		b.WriteString(s.styles.Muted.Render("• " + note))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (s *SheetScreen) viewSkills() string {
+	var b strings.Builder
+
+	b.WriteString(s.styles.Header.Render("Skills"))
+	b.WriteString("\n\n")
+
+	abilities := map[string]int32{
+		"strength":     s.char.Strength,
+		"dexterity":    s.char.Dexterity,
+		"constitution": s.char.Constitution,
+		"intelligence": s.char.Intelligence,
+		"wisdom":       s.char.Wisdom,
+		"charisma":     s.char.Charisma,
+	}
+
+	skillWidth := 18
+	modWidth := 4
+
+	profBonus := s.rules().ProficiencyBonus(int(s.char.Level))
+
+	for i, skill := range character.SkillList {
+		abilityName := character.Skills[skill]
+		abilityScore := abilities[abilityName]
+
+		proficiency := s.skillProficiency(skill)
+		if s.mode == ModeEditSkills {
+			proficiency = s.editSkills[skill]
+		}
+
+		mod := character.AbilityModifier(int(abilityScore)) + proficiency.Bonus(profBonus)
+		profMark := proficiencyMark(proficiency)
+		style := s.styles.NotProficient
+		if proficiency != character.NotProficient {
+			style = s.styles.Proficient
+		}
+		if s.mode == ModeEditSkills && i == s.profCursor {
+			style = s.styles.Cursor
+		}
+
+		// Abbreviate ability name
+		abilityAbbr := strings.ToUpper(abilityName[:3])
+
+		paddedSkill := fmt.Sprintf("%-*s", skillWidth, skill)
+		paddedMod := fmt.Sprintf("%*s", modWidth, character.FormatModifierInt(mod))
+
+		row := style.Render(profMark + paddedSkill + "  " + paddedMod + "  (" + abilityAbbr + ")")
+		if s.mode == ModeEditSkills {
+			row = components.Mark(components.Item("prof", i), row)
+		}
+		b.WriteString(row)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (s *SheetScreen) viewCombat() string {
+	var b strings.Builder
+
+	b.WriteString(s.styles.Header.Render("Combat"))
+	b.WriteString("\n\n")
+
+	// HP display
+	hp := s.hitPoints()
+	hpStyle := s.hpStyle(hp)
+
+	// Right-align labels to align on the colon
+	labelWidth := 14
+
+	if s.mode == ModeEditHP {
+		b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Hit Points:"))
+		b.WriteString(s.styles.FocusedInput.Render(s.hpInput.View()))
+		b.WriteString(fmt.Sprintf(" / %d", hp.Max))
+	} else {
+		b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Hit Points:"))
+		b.WriteString(hpStyle.Render(fmt.Sprintf("%d", hp.Current)))
+		b.WriteString(" / ")
+		b.WriteString(s.styles.HPMax.Render(fmt.Sprintf("%d", hp.Max)))
+	}
+
+	if s.mode == ModeEditTempHP {
+		b.WriteString(" temp: ")
+		b.WriteString(s.styles.FocusedInput.Render(s.tempHPInput.View()))
+	} else if s.char.TemporaryHitPoints > 0 {
+		b.WriteString(fmt.Sprintf(" (+%d temp)", s.char.TemporaryHitPoints))
+	}
+	if s.mode != ModeEditHP && s.mode != ModeEditTempHP {
+		b.WriteString("  ")
+		b.WriteString(components.ProgressBar(float64(hp.Current), float64(hp.Max), 20, hpStyle, s.styles.Muted))
+	}
+	b.WriteString("\n")
+	if s.mode == ModeEditHP && strings.HasPrefix(strings.TrimSpace(s.hpInput.Value()), "-") {
+		b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Damage Type:"))
+		damageType := s.damageType
+		if damageType == "" {
+			damageType = "untyped"
+		}
+		b.WriteString("◀ " + s.styles.StatValue.UnsetWidth().Render(damageType) + " ▶")
+		if _, resisted := character.DamageTaken(s.char.Race, s.damageType, 2); resisted {
+			b.WriteString(s.styles.Muted.Render(" (resisted, halved)"))
+		}
+		b.WriteString("\n")
+	}
+
+	if s.mode == ModeEditMaxHPBonus {
+		b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Max Bonus:"))
+		b.WriteString(s.styles.FocusedInput.Render(s.bonusInput.View()))
+		b.WriteString("\n")
+	} else if s.char.MaxHitPointsBonus > 0 {
+		b.WriteString(fmt.Sprintf("%*s ", labelWidth, ""))
+		b.WriteString(s.styles.Muted.Render(fmt.Sprintf("Maximum raised by %d (Aid or similar)", s.char.MaxHitPointsBonus)))
+		b.WriteString("\n")
+	}
+
+	if raised := int(s.char.MaxHitPoints + s.char.MaxHitPointsBonus); hp.Max < raised {
+		b.WriteString(fmt.Sprintf("%*s ", labelWidth, ""))
+		b.WriteString(s.styles.WarningText.Render(fmt.Sprintf("Maximum halved to %d by exhaustion", hp.Max)))
+		b.WriteString("\n")
+	}
+
+	if s.mode == ModeConfirmLongRest {
+		b.WriteString("\n")
+		b.WriteString(s.styles.WarningText.Render("Take a long rest? HP, spell slots, class resources, and one level of exhaustion are restored. (y/n)"))
+		b.WriteString("\n")
+	}
+
+	if s.mode == ModeConfirmShortRest {
+		b.WriteString("\n")
+		b.WriteString(s.styles.WarningText.Render("Take a short rest? Short-rest class resources and Pact Magic slots are restored. (y/n)"))
+		b.WriteString("\n")
+	}
+
+	if s.char.ConcentratingOn != "" {
+		b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Concentrating:"))
+		b.WriteString(s.styles.WarningText.Render(s.char.ConcentratingOn))
+		b.WriteString("\n")
+	}
+
+	if s.mode == ModeConcentrationSave {
+		b.WriteString("\n")
+		b.WriteString(s.styles.WarningText.Render(fmt.Sprintf(
+			"Concentration save! DC %d Constitution (%s) to keep %s",
+			s.concentrationDC, character.FormatModifierInt(s.concentrationSaveBonus()), s.char.ConcentratingOn)))
+		b.WriteString("\n")
+	}
+
+	// Other combat stats
+	initiative := character.Initiative(int(s.char.Dexterity))
+
+	ac := armorClass(s.char, s.inventory)
+	b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Armor Class:"))
+	if s.mode == ModeEditAC {
+		b.WriteString(s.styles.FocusedInput.Render(s.acInput.View()))
+	} else {
+		b.WriteString(s.styles.StatValue.Render(fmt.Sprintf("%d", ac.Total)))
+		b.WriteString(s.styles.Muted.Render(" (" + ac.Breakdown() + ")"))
+	}
+	b.WriteString("\n")
+	for _, note := range ac.Notes {
+		b.WriteString(fmt.Sprintf("%*s ", labelWidth, ""))
+		b.WriteString(s.styles.Muted.Render("• " + note))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Initiative:"))
+	b.WriteString(s.styles.StatValue.Render(character.FormatModifierInt(initiative)))
+	b.WriteString("\n")
+
+	exhaustion := int(s.char.Exhaustion)
+	b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Speed:"))
+	b.WriteString(s.styles.StatValue.Render(fmt.Sprintf("%d", s.rules().ExhaustedSpeed(int(s.char.Speed), exhaustion))))
+	b.WriteString(" ft")
+	if speeds := s.otherSpeeds(); len(speeds) > 0 {
+		b.WriteString(s.styles.Muted.Render(" (" + strings.Join(speeds, ", ") + ")"))
+	}
+	b.WriteString("\n")
+	if senses := senses(s.char); len(senses) > 0 {
+		b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Senses:"))
+		b.WriteString(strings.Join(senses, ", "))
+		b.WriteString("\n")
+	}
+
+	// Exhaustion, with its cumulative effects
+	b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Exhaustion:"))
+	pips := strings.Repeat("●", exhaustion) + strings.Repeat("○", character.MaxExhaustion-exhaustion)
+	switch {
+	case exhaustion >= character.MaxExhaustion:
+		b.WriteString(s.styles.HPCritical.Render(pips + " DEAD"))
+	case exhaustion > 0:
+		b.WriteString(s.styles.WarningText.Render(fmt.Sprintf("%s %d", pips, exhaustion)))
+	default:
+		b.WriteString(s.styles.Muted.Render(pips))
+	}
+	b.WriteString("\n")
+	if exhaustion > 0 && exhaustion < character.MaxExhaustion {
+		for _, effect := range s.rules().ExhaustionEffects(exhaustion) {
+			b.WriteString(fmt.Sprintf("%*s ", labelWidth, ""))
+			b.WriteString(s.styles.Muted.Render("• " + effect))
+			b.WriteString("\n")
+		}
+	}
+
+	// Hit dice
+	hitDie := character.ClassHitDice[s.char.Class]
+	b.WriteString(fmt.Sprintf("%*s %dd%d\n", labelWidth, "Hit Dice:", s.char.Level, hitDie))
+
+	// Class resources, as pips when there are few enough to count at a glance
+	for i, r := range s.resources {
+		left := int(r.MaxUses - r.Used)
+		label := truncate(r.Name, labelWidth-1) + ":"
+		if i == s.resourceCursor {
+			label = s.styles.Cursor.Render(fmt.Sprintf("%*s", labelWidth, label))
+		} else {
+			label = fmt.Sprintf("%*s", labelWidth, label)
+		}
+		value := fmt.Sprintf("%d / %d", left, r.MaxUses)
+		if r.MaxUses <= 10 {
+			value = strings.Repeat("●", left) + strings.Repeat("○", int(r.Used))
+		}
+		style := s.styles.StatValue
+		if left == 0 {
+			style = s.styles.Muted
+		}
+		b.WriteString(components.Mark(components.Item("resource", i), label+" "+style.Render(value)))
+		b.WriteString(s.styles.Muted.Render(" " + r.Recharge + " rest"))
+		b.WriteString("\n")
+	}
+
+	// HP over the current session
+	if session := s.sessionHPHistory(); len(session) > 0 {
+		values := make([]int, len(session))
+		for i, h := range session {
+			values[i] = int(h.CurrentHitPoints)
+		}
+		b.WriteString(fmt.Sprintf("%*s ", labelWidth, "HP History:"))
+		b.WriteString(components.SparklineRange(values, 0, int(s.char.MaxHitPoints+s.char.MaxHitPointsBonus), 30, s.styles.HPCurrent))
+		b.WriteString(s.styles.Muted.Render(fmt.Sprintf(" %d changes since %s",
+			len(session), session[0].CreatedAt.Time.Local().Format("15:04"))))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(s.styles.Header.Render("Attacks"))
+	b.WriteString("\n\n")
+
+	attacks := s.attacks()
+	var masteries []string
+	for _, a := range attacks {
+		name := a.Weapon.Name
+		if a.Magic > 0 {
+			name += fmt.Sprintf(" +%d", a.Magic)
+		}
+		b.WriteString(fmt.Sprintf("%*s ", labelWidth, truncate(name, labelWidth-1)+":"))
+		b.WriteString(s.styles.StatValue.Render(character.FormatModifierInt(a.AttackBonus)))
+		b.WriteString(" to hit, " + a.Damage())
+		if v := a.VersatileDamage(); v != "" {
+			b.WriteString(s.styles.Muted.Render(" (" + v + " two-handed)"))
+		}
+		if !a.Proficient {
+			b.WriteString(s.styles.WarningText.Render(" not proficient"))
+		}
+		if m := s.rules().WeaponMastery(s.char.Class, a.Weapon); m != "" {
+			b.WriteString(s.styles.SuccessText.Render(" " + m))
+			if !slices.Contains(masteries, m) {
+				masteries = append(masteries, m)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	// Explain each mastery once, for the moment the attack lands or misses
+	for _, m := range masteries {
+		b.WriteString(s.styles.Muted.Width(s.contentWidth(70)).Render(m + ": " + character.WeaponMasteries[m]))
+		b.WriteString("\n")
+	}
+
+	if len(attacks) == 0 {
+		// No weapons equipped, so show the generic bonuses
+		strMod := character.AbilityModifier(int(s.char.Strength))
+		dexMod := character.AbilityModifier(int(s.char.Dexterity))
+		profBonus := s.rules().ProficiencyBonus(int(s.char.Level))
+
+		b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Melee Attack:"))
+		b.WriteString(s.styles.StatValue.Render(character.FormatModifierInt(strMod + profBonus)))
+		b.WriteString(" (STR + Prof)\n")
+
+		b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Ranged Attack:"))
+		b.WriteString(s.styles.StatValue.Render(character.FormatModifierInt(dexMod + profBonus)))
+		b.WriteString(" (DEX + Prof)\n")
+		b.WriteString(s.styles.Muted.Render("Equip weapons on the Inventory tab to list their attacks."))
+		b.WriteString("\n")
+	}
+
+	// Wrap in a left-aligned box so the colon alignment works
+	return lipgloss.NewStyle().
+		Align(lipgloss.Left).
+		Render(b.String())
+}
+
+// sessionHPHistory returns this session's HP changes, oldest first. A session
+// ends at the first gap longer than hpSessionGap.
+func (s *SheetScreen) sessionHPHistory() []db.CharacterHpHistory {
+	var session []db.CharacterHpHistory
+	for i, h := range s.hpHistory {
+		if i > 0 && s.hpHistory[i-1].CreatedAt.Time.Sub(h.CreatedAt.Time) > hpSessionGap {
+			break
+		}
+		session = append(session, h)
+	}
+	if len(session) > 0 && time.Since(session[0].CreatedAt.Time) > hpSessionGap {
+		return nil
+	}
+
+	for i, j := 0, len(session)-1; i < j; i, j = i+1, j-1 {
+		session[i], session[j] = session[j], session[i]
+	}
+	return session
+}
+
+func (s *SheetScreen) viewSpells() string {
+	var b strings.Builder
+
+	b.WriteString(s.styles.Header.Render("Spellcasting"))
+	b.WriteString("\n\n")
+
+	if s.spellcasting == nil {
+		b.WriteString(s.styles.Muted.Render("This character has no spellcasting. Press enter to set it up."))
+		return b.String()
+	}
+
+	sc := s.spellcasting
+	labelWidth := 14
+
+	b.WriteString(fmt.Sprintf("%*s %s (%s)\n", labelWidth, "Ability:", sc.SpellcastingAbility, sc.SpellcastingClass))
+	b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Save DC:"))
+	b.WriteString(s.styles.StatValue.Render(fmt.Sprintf("%d", sc.SpellSaveDc)))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Spell Attack:"))
+	b.WriteString(s.styles.StatValue.Render(character.FormatModifierInt(int(sc.SpellAttackBonus))))
+	b.WriteString("\n")
+
+	// Spell slots
+	var slots []string
+	for i, total := range sc.SlotsMax {
+		if total == 0 {
+			continue
+		}
+		used := int32(0)
+		if i < len(sc.SlotsUsed) {
+			used = sc.SlotsUsed[i]
+		}
+		slots = append(slots, fmt.Sprintf("%d: %d/%d", i+1, total-used, total))
+	}
+	if len(slots) > 0 {
+		b.WriteString(fmt.Sprintf("%*s %s\n", labelWidth, "Slots:", strings.Join(slots, "  ")))
+	}
+
+	// Spells grouped by level
+	currentLevel := int32(-1)
+	for i, spell := range s.spells {
+		if spell.Level != currentLevel {
+			currentLevel = spell.Level
+			b.WriteString("\n")
+			label := "Cantrips"
+			if spell.Level > 0 {
+				label = fmt.Sprintf("Level %d", spell.Level)
+			}
+			b.WriteString(s.styles.Header.Render(label))
+			b.WriteString("\n")
+		}
+
+		profMark := "  "
+		style := s.styles.NotProficient
+		if spell.Prepared {
+			profMark = "● "
+			style = s.styles.Proficient
+		}
+		name := spell.Name
+		if spell.Concentration {
+			name += " (C)"
+		}
+		line := profMark + name
+		if info, ok := character.FindSpell(spell.Name); ok {
+			line = fmt.Sprintf("%s%-30s %s", profMark, name, info.CastingTime)
+		}
+		if spell.Name == s.char.ConcentratingOn {
+			style = s.styles.WarningText
+		}
+		if i == s.spellCursor {
+			style = s.styles.Cursor
+		}
+		b.WriteString(components.Mark(components.Item("spell", i), style.Render(line)))
+		b.WriteString("\n")
+	}
+
+	if len(s.spells) == 0 {
+		b.WriteString("\n")
+		b.WriteString(s.styles.Muted.Render("No spells known."))
+	}
+
+	return lipgloss.NewStyle().
+		Align(lipgloss.Left).
+		Render(b.String())
+}
+
+// viewSpellDetail shows everything known about the spell under the cursor
+func (s *SheetScreen) viewSpellDetail() string {
+	var b strings.Builder
+	spell := s.spells[s.spellCursor]
+
+	b.WriteString(s.styles.Header.Render(spell.Name))
+	b.WriteString("\n\n")
+
+	info, ok := character.FindSpell(spell.Name)
+	if !ok {
+		kind := "Cantrip"
+		if spell.Level > 0 {
+			kind = fmt.Sprintf("Level %d", spell.Level)
+		}
+		b.WriteString(s.styles.Subtitle.Render(kind))
+		b.WriteString("\n\n")
+		if s.lookingUp == spell.Name {
+			b.WriteString(s.styles.Muted.Render("Looking up details on Open5e..."))
+		} else {
+			b.WriteString(s.styles.Muted.Render("No details for spells outside the SRD."))
+		}
+		return b.String()
+	}
+
+	kind := info.School + " cantrip"
+	if info.Level > 0 {
+		kind = fmt.Sprintf("Level %d %s", info.Level, strings.ToLower(info.School))
+	}
+	var tags []string
+	if info.Ritual {
+		tags = append(tags, "ritual")
+	}
+	if info.Concentration {
+		tags = append(tags, "concentration")
+	}
+	if spell.Prepared {
+		tags = append(tags, "prepared")
+	}
+	if len(tags) > 0 {
+		kind += " (" + strings.Join(tags, ", ") + ")"
+	}
+	b.WriteString(s.styles.Subtitle.Render(kind))
+	b.WriteString("\n\n")
+
+	labelWidth := 14
+	b.WriteString(fmt.Sprintf("%*s %s\n", labelWidth, "Casting Time:", info.CastingTime))
+	b.WriteString(fmt.Sprintf("%*s %s\n", labelWidth, "Range:", info.Range))
+	b.WriteString(fmt.Sprintf("%*s %s\n", labelWidth, "Components:", info.Components))
+	b.WriteString(fmt.Sprintf("%*s %s\n", labelWidth, "Duration:", info.Duration))
+	b.WriteString("\n")
+
+	width := s.contentWidth(70)
+	b.WriteString(lipgloss.NewStyle().Width(width).Render(info.Description))
+	if info.HigherLevels != "" {
+		b.WriteString("\n\n")
+		b.WriteString(lipgloss.NewStyle().Width(width).Render("At Higher Levels. " + info.HigherLevels))
 	}
+	if character.CanCastAsRitual(s.char.Class, info, spell.Prepared) {
+		b.WriteString("\n\n")
+		b.WriteString(s.styles.SuccessText.Render("Can be cast as a ritual: 10 minutes longer, no slot."))
+	}
+
+	return lipgloss.NewStyle().
+		Align(lipgloss.Left).
+		Render(b.String())
 }
 
-func (s *SheetScreen) View() string {
+func (s *SheetScreen) viewNotes() string {
 	var b strings.Builder
 
-	// Header with character name
-	header := fmt.Sprintf("%s - Level %d %s %s",
-		s.char.Name, s.char.Level, s.char.Race, s.char.Class)
-	b.WriteString(s.styles.Title.Render(header))
+	b.WriteString(s.styles.Header.Render("Features & Traits"))
 	b.WriteString("\n\n")
 
-	// Tab bar
-	tabs := []string{"Stats", "Skills", "Combat", "Notes"}
-	tabBar := ""
-	for i, t := range tabs {
-		if i == s.tab {
-			tabBar += s.styles.FocusedButton.Render(" " + t + " ")
-		} else {
-			tabBar += s.styles.Button.Render(" " + t + " ")
-		}
+	if s.mode == ModeEditFeatures {
+		b.WriteString(s.viewEditor(s.featuresInput))
+	} else if s.char.FeaturesTraits != "" {
+		b.WriteString(s.styles.Markdown(s.char.FeaturesTraits, s.notesWidth()))
+	} else {
+		b.WriteString(s.styles.Muted.Render("No features or traits recorded."))
 	}
-	b.WriteString(tabBar)
 	b.WriteString("\n\n")
 
-	// Tab content
-	switch s.tab {
-	case 0:
-		b.WriteString(s.viewStats())
-	case 1:
-		b.WriteString(s.viewSkills())
-	case 2:
-		b.WriteString(s.viewCombat())
-	case 3:
-		b.WriteString(s.viewNotes())
-	}
-
-	// Help
+	b.WriteString(s.styles.Header.Render("Journal"))
 	b.WriteString("\n\n")
-	b.WriteString(s.styles.Help.Render(s.getHelp()))
+	b.WriteString(s.viewJournal())
 
-	return lipgloss.Place(s.width, s.height,
-		lipgloss.Center, lipgloss.Center,
-		b.String())
+	return b.String()
 }
 
-func (s *SheetScreen) viewStats() string {
-	var b strings.Builder
+// notesWidth is the width notes and features are wrapped to
+func (s *SheetScreen) notesWidth() int {
+	return s.contentWidth(60)
+}
 
-	// Ability scores
-	abilities := []struct {
-		name  string
-		score int32
-	}{
-		{"Strength", s.char.Strength},
-		{"Dexterity", s.char.Dexterity},
-		{"Constitution", s.char.Constitution},
-		{"Intelligence", s.char.Intelligence},
-		{"Wisdom", s.char.Wisdom},
-		{"Charisma", s.char.Charisma},
+// viewEditor shows a notes textarea, or what it will look like once saved
+// while previewing
+func (s *SheetScreen) viewEditor(input textarea.Model) string {
+	if !s.preview {
+		return s.styles.FocusedInput.Render(input.View())
 	}
+	text := s.styles.Markdown(input.Value(), s.notesWidth()-2)
+	if strings.TrimSpace(input.Value()) == "" {
+		text = s.styles.Muted.Render("Nothing to preview.")
+	}
+	return s.styles.FocusedInput.Width(s.notesWidth()).Render(text)
+}
 
-	profBonus := character.ProficiencyBonus(int(s.char.Level))
-
-	b.WriteString(s.styles.Header.Render("Ability Scores"))
-	b.WriteString("\n\n")
+// historyRows is how many change log entries fit on the History tab
+func (s *SheetScreen) historyRows() int {
+	return max(5, s.height-15)
+}
 
-	// Use fixed-width columns for alignment
-	labelWidth := 14
-	scoreWidth := 3
-	modWidth := 4
+func (s *SheetScreen) viewHistory() string {
+	var b strings.Builder
 
-	for _, a := range abilities {
-		mod := character.AbilityModifier(int(a.score))
-		// Pad the name manually before styling
-		paddedName := fmt.Sprintf("%-*s", labelWidth, a.name)
-		paddedScore := fmt.Sprintf("%*d", scoreWidth, a.score)
-		paddedMod := fmt.Sprintf("%*s", modWidth, character.FormatModifierInt(mod))
+	b.WriteString(s.styles.Header.Render("Change History"))
+	b.WriteString("\n\n")
 
-		b.WriteString(s.styles.Muted.Render(paddedName))
-		b.WriteString("  ")
-		b.WriteString(s.styles.StatValue.Render(paddedScore))
-		b.WriteString("  ")
-		b.WriteString(s.styles.StatMod.Render(paddedMod))
-		b.WriteString("\n")
+	if len(s.events) == 0 {
+		b.WriteString(s.styles.Muted.Render("No changes recorded yet."))
+		return b.String()
 	}
 
-	b.WriteString("\n")
-	b.WriteString(s.styles.Header.Render("Saving Throws"))
-	b.WriteString("\n\n")
-
-	for _, a := range abilities {
-		proficient := false
-		for _, p := range s.char.SavingThrowProficiencies {
-			if strings.EqualFold(p, a.name) {
-				proficient = true
-				break
-			}
+	end := min(len(s.events), s.historyOffset+s.historyRows())
+	for i := s.historyOffset; i < end; i++ {
+		e := s.events[i]
+		// Mark where one play session ends and an earlier one starts
+		if i > s.historyOffset && s.events[i-1].CreatedAt.Time.Sub(e.CreatedAt.Time) > hpSessionGap {
+			b.WriteString(s.styles.Muted.Render("── earlier session ──"))
+			b.WriteString("\n")
 		}
-
-		mod := character.SavingThrow(int(a.score), int(s.char.Level), proficient)
-		profMark := "  "
-		style := s.styles.NotProficient
-		if proficient {
-			profMark = "● "
-			style = s.styles.Proficient
+		when := e.CreatedAt.Time.Local().Format("Jan 2 15:04")
+		if s.narrow() {
+			b.WriteString(s.styles.Muted.Render(fmt.Sprintf("%-12s ", when)))
+		} else {
+			b.WriteString(s.styles.Muted.Render(fmt.Sprintf("%-12s %-16s ", when, truncate(s.eventActor(e), 16))))
 		}
-		paddedName := fmt.Sprintf("%-*s", labelWidth, a.name)
-		paddedMod := fmt.Sprintf("%*s", modWidth, character.FormatModifierInt(mod))
-		b.WriteString(style.Render(profMark + paddedName + "  " + paddedMod))
+		b.WriteString(e.Description)
 		b.WriteString("\n")
 	}
 
-	b.WriteString("\n")
-	b.WriteString("Proficiency Bonus: ")
-	b.WriteString(s.styles.StatValue.Render(character.FormatModifierInt(profBonus)))
-	b.WriteString("\n")
+	if len(s.events) > s.historyRows() {
+		b.WriteString(s.styles.Muted.Render(fmt.Sprintf("\n%d-%d of %d", s.historyOffset+1, end, len(s.events))))
+	}
 
 	return b.String()
 }
 
-func (s *SheetScreen) viewSkills() string {
+// eventActor names who made a change on the History tab
+func (s *SheetScreen) viewInventory() string {
 	var b strings.Builder
 
-	b.WriteString(s.styles.Header.Render("Skills"))
+	b.WriteString(s.styles.Header.Render("Inventory"))
 	b.WriteString("\n\n")
 
-	abilities := map[string]int32{
-		"strength":     s.char.Strength,
-		"dexterity":    s.char.Dexterity,
-		"constitution": s.char.Constitution,
-		"intelligence": s.char.Intelligence,
-		"wisdom":       s.char.Wisdom,
-		"charisma":     s.char.Charisma,
-	}
-
-	skillWidth := 18
-	modWidth := 4
-
-	for _, skill := range character.SkillList {
-		abilityName := character.Skills[skill]
-		abilityScore := abilities[abilityName]
-
-		proficient := false
-		for _, p := range s.char.SkillProficiencies {
-			if strings.EqualFold(p, skill) {
-				proficient = true
-				break
+	layout := newInventoryLayout(s.inventory)
+	weights := layout.weigh(s.inventory)
+	group := ""
+	for i, item := range s.inventory {
+		if loc := layout.location(item); loc != group {
+			group = loc
+			c := layout.containers[loc]
+			heading := fmt.Sprintf("%s  %s/%s lb", c.Name, formatWeight(weights.contents[loc]), formatWeight(c.Capacity))
+			style := s.styles.Muted
+			if weights.contents[loc] > c.Capacity {
+				heading += " (over capacity)"
+				style = s.styles.WarningText
 			}
+			b.WriteString("\n")
+			b.WriteString(style.Render(heading))
+			b.WriteString("\n")
 		}
 
-		mod := character.SkillBonus(int(abilityScore), int(s.char.Level), proficient)
-		profMark := "  "
+		mark := "  "
+		if group != "" {
+			mark = "    "
+		}
 		style := s.styles.NotProficient
-		if proficient {
-			profMark = "● "
+		if item.Equipped {
+			mark = strings.TrimSuffix(mark, "  ") + "● "
 			style = s.styles.Proficient
 		}
+		line := mark + item.Name
+		if item.Quantity != 1 {
+			line += fmt.Sprintf(" ×%d", item.Quantity)
+		}
+		if armor, ok := character.FindArmor(item.Name); ok {
+			detail := fmt.Sprintf("AC %d", armor.BaseAC)
+			if armor.Type == character.ShieldArmor {
+				detail = fmt.Sprintf("AC +%d", armor.BaseAC)
+			}
+			line = fmt.Sprintf("%-34s %s", line, detail)
+		} else if w, _, ok := character.FindWeapon(item.Name); ok {
+			line = fmt.Sprintf("%-34s %s %s", line, w.Damage, w.DamageType)
+		}
+		if i == s.inventoryCursor {
+			style = s.styles.Cursor
+		}
+		b.WriteString(components.Mark(components.Item("item", i), style.Render(line)))
+		b.WriteString("\n")
+	}
 
-		// Abbreviate ability name
-		abilityAbbr := strings.ToUpper(abilityName[:3])
-
-		paddedSkill := fmt.Sprintf("%-*s", skillWidth, skill)
-		paddedMod := fmt.Sprintf("%*s", modWidth, character.FormatModifierInt(mod))
+	if len(s.inventory) == 0 {
+		b.WriteString(s.styles.Muted.Render("Nothing carried."))
+		b.WriteString("\n")
+	} else {
+		b.WriteString("\n")
+		carried := fmt.Sprintf("Carried: %s lb (capacity %s)", formatWeight(weights.carried), s.rules().CarryingCapacity(int(s.char.Strength)))
+		switch {
+		case weights.unknown == 1:
+			carried += ", 1 item of unknown weight"
+		case weights.unknown > 1:
+			carried += fmt.Sprintf(", %d items of unknown weight", weights.unknown)
+		}
+		b.WriteString(s.styles.Muted.Render(carried))
+		b.WriteString("\n")
+		if limit := s.rules().CarryingLimit(int(s.char.Strength)); limit > 0 {
+			style := s.styles.SuccessText
+			switch {
+			case weights.carried > limit:
+				style = s.styles.ErrorText
+			case weights.carried > limit*2/3:
+				style = s.styles.WarningText
+			}
+			b.WriteString(components.ProgressBar(weights.carried, limit, 30, style, s.styles.Muted))
+			b.WriteString("\n")
+		}
+	}
+	if summary := s.craftingSummary(); summary != "" {
+		b.WriteString("\n")
+		b.WriteString(s.styles.Muted.Render(summary))
+		b.WriteString("\n")
+	}
 
-		b.WriteString(style.Render(profMark + paddedSkill + "  " + paddedMod + "  (" + abilityAbbr + ")"))
+	if s.mode == ModeAddItem {
+		b.WriteString("\n")
+		b.WriteString(s.styles.FocusedInput.Render(s.itemInput.View()))
 		b.WriteString("\n")
 	}
 
-	return b.String()
+	return lipgloss.NewStyle().
+		Align(lipgloss.Left).
+		Render(b.String())
 }
 
-func (s *SheetScreen) viewCombat() string {
+// attacks works out the attacks for the character's equipped weapons. They
+// are calculated on every draw, so they follow ability and level changes.
+func (s *SheetScreen) attacks() []character.Attack {
+	var attacks []character.Attack
+	for _, item := range s.inventory {
+		if !item.Equipped {
+			continue
+		}
+		if w, magic, ok := character.FindWeapon(item.Name); ok {
+			attacks = append(attacks, character.CalculateAttack(w, magic, character.AttackInput{
+				Class:            s.char.Class,
+				Strength:         int(s.char.Strength),
+				Dexterity:        int(s.char.Dexterity),
+				ProficiencyBonus: s.rules().ProficiencyBonus(int(s.char.Level)),
+			}))
+		}
+	}
+	return attacks
+}
+
+// viewWeaponPicker lists the SRD weapons around the cursor
+func (s *SheetScreen) viewWeaponPicker() string {
 	var b strings.Builder
 
-	b.WriteString(s.styles.Header.Render("Combat"))
+	b.WriteString(s.styles.Header.Render("Add Weapon"))
 	b.WriteString("\n\n")
 
-	// HP display
-	hpPct := float64(s.char.CurrentHitPoints) / float64(s.char.MaxHitPoints)
-	hpStyle := s.styles.HPCurrent
-	if hpPct < 0.25 {
-		hpStyle = s.styles.HPCritical
-	} else if hpPct < 0.5 {
-		hpStyle = s.styles.HPLow
-	}
-
-	// Right-align labels to align on the colon
-	labelWidth := 14
-
-	if s.mode == ModeEditHP {
-		b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Hit Points:"))
-		b.WriteString(s.styles.FocusedInput.Render(s.hpInput.View()))
-		b.WriteString(fmt.Sprintf(" / %d", s.char.MaxHitPoints))
-	} else {
-		b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Hit Points:"))
-		b.WriteString(hpStyle.Render(fmt.Sprintf("%d", s.char.CurrentHitPoints)))
-		b.WriteString(" / ")
-		b.WriteString(s.styles.HPMax.Render(fmt.Sprintf("%d", s.char.MaxHitPoints)))
+	const rows = 10
+	start := min(max(s.weaponCursor-rows/2, 0), max(len(character.WeaponTable)-rows, 0))
+	end := min(start+rows, len(character.WeaponTable))
+	for i := start; i < end; i++ {
+		w := character.WeaponTable[i]
+		category := "Simple"
+		if w.Martial {
+			category = "Martial"
+		}
+		properties := w.Properties
+		if m := s.rules().WeaponMastery(s.char.Class, w); m != "" {
+			properties = append([]string{"Mastery: " + m}, properties...)
+		}
+		line := fmt.Sprintf("%-16s %-8s %-5s %-12s %s",
+			w.Name, category, w.Damage, w.DamageType, strings.Join(properties, ", "))
+		if s.narrow() {
+			line = fmt.Sprintf("%-16s %-5s %s", w.Name, w.Damage, w.DamageType)
+		}
+		style := s.styles.NotProficient
+		if i == s.weaponCursor {
+			style = s.styles.Cursor
+		}
+		b.WriteString(components.Mark(components.Item("weapon", i), style.Render(line)))
+		b.WriteString("\n")
 	}
 
-	if s.char.TemporaryHitPoints > 0 {
-		b.WriteString(fmt.Sprintf(" (+%d temp)", s.char.TemporaryHitPoints))
-	}
 	b.WriteString("\n")
-
-	// Other combat stats
-	initiative := character.Initiative(int(s.char.Dexterity))
-
-	b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Armor Class:"))
-	b.WriteString(s.styles.StatValue.Render(fmt.Sprintf("%d", s.char.ArmorClass)))
+	b.WriteString("Adding: ")
+	b.WriteString(s.styles.StatValue.Render(s.pickedWeaponName()))
 	b.WriteString("\n")
 
-	b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Initiative:"))
-	b.WriteString(s.styles.StatValue.Render(character.FormatModifierInt(initiative)))
-	b.WriteString("\n")
+	return lipgloss.NewStyle().
+		Align(lipgloss.Left).
+		Render(b.String())
+}
 
-	b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Speed:"))
-	b.WriteString(s.styles.StatValue.Render(fmt.Sprintf("%d", s.char.Speed)))
-	b.WriteString(" ft\n")
+// viewLoot shows the treasure just rolled, before it's added to the
+// inventory
+func (s *SheetScreen) viewLoot() string {
+	var b strings.Builder
 
-	// Hit dice
-	hitDie := character.ClassHitDice[s.char.Class]
-	b.WriteString(fmt.Sprintf("%*s %dd%d\n", labelWidth, "Hit Dice:", s.char.Level, hitDie))
+	b.WriteString(s.styles.Header.Render("Roll Treasure"))
+	b.WriteString("\n\n")
 
+	kind := "Individual"
+	if s.lootHoard {
+		kind = "Hoard"
+	}
+	b.WriteString(s.styles.StatLabel.Render("Challenge: "))
+	b.WriteString(s.styles.StatValue.Render("◀ " + character.TreasureBands[s.lootBand] + " ▶"))
 	b.WriteString("\n")
-	b.WriteString(s.styles.Header.Render("Quick Rolls"))
+	b.WriteString(s.styles.StatLabel.Render("Treasure:  "))
+	b.WriteString(s.styles.StatValue.Render(kind))
 	b.WriteString("\n\n")
 
-	// Attack bonus examples
-	strMod := character.AbilityModifier(int(s.char.Strength))
-	dexMod := character.AbilityModifier(int(s.char.Dexterity))
-	profBonus := character.ProficiencyBonus(int(s.char.Level))
-
-	b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Melee Attack:"))
-	b.WriteString(s.styles.StatValue.Render(character.FormatModifierInt(strMod + profBonus)))
-	b.WriteString(" (STR + Prof)\n")
-
-	b.WriteString(fmt.Sprintf("%*s ", labelWidth, "Ranged Attack:"))
-	b.WriteString(s.styles.StatValue.Render(character.FormatModifierInt(dexMod + profBonus)))
-	b.WriteString(" (DEX + Prof)\n")
+	if s.loot.Empty() {
+		b.WriteString(s.styles.Muted.Render("Nothing this time."))
+		b.WriteString("\n")
+	}
+	for i := len(s.loot.Coins) - 1; i >= 0; i-- {
+		if n := s.loot.Coins[i]; n > 0 {
+			b.WriteString(fmt.Sprintf("%6d  %s\n", n, character.CoinNames[i]))
+		}
+	}
+	for _, item := range s.loot.Items {
+		b.WriteString("     •  " + item + "\n")
+	}
 
-	// Wrap in a left-aligned box so the colon alignment works
 	return lipgloss.NewStyle().
 		Align(lipgloss.Left).
 		Render(b.String())
 }
 
-func (s *SheetScreen) viewNotes() string {
+// armorClass works out a character's AC from its equipped items
+func armorClass(char db.Character, items []db.CharacterInventory) character.ArmorClass {
+	var equipped []string
+	for _, item := range items {
+		if item.Equipped {
+			equipped = append(equipped, item.Name)
+		}
+	}
+	override := 0
+	if char.ArmorClassOverride.Valid {
+		override = int(char.ArmorClassOverride.Int32)
+	}
+	return character.CalculateArmorClass(character.ArmorClassInput{
+		Class:        char.Class,
+		Strength:     int(char.Strength),
+		Dexterity:    int(char.Dexterity),
+		Constitution: int(char.Constitution),
+		Wisdom:       int(char.Wisdom),
+		Equipped:     equipped,
+		Override:     override,
+	})
+}
+
+func (s *SheetScreen) eventActor(e db.GetCharacterEventsRow) string {
+	switch {
+	case !e.UserID.Valid:
+		return "deleted user"
+	case e.UserID == s.userID:
+		return "you"
+	case e.Email.Valid:
+		return e.Email.String
+	default:
+		return "another user"
+	}
+}
+
+func (s *SheetScreen) updateClassTable(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if s.classTableOffset > 1 {
+			s.classTableOffset--
+		}
+	case "down", "j":
+		if s.classTableOffset+s.classTableRows() <= character.MaxLevel {
+			s.classTableOffset++
+		}
+	case "esc", "q", "c":
+		s.mode = ModeView
+	}
+	return s, nil
+}
+
+// classTableRows is how many levels of the class table fit on screen
+func (s *SheetScreen) classTableRows() int {
+	return min(character.MaxLevel, max(5, s.height-15))
+}
+
+func (s *SheetScreen) viewClassTable() string {
 	var b strings.Builder
 
-	b.WriteString(s.styles.Header.Render("Features & Traits"))
+	table, ok := character.ClassTables[s.char.Class]
+	b.WriteString(s.styles.Header.Render(s.char.Class + " Progression"))
 	b.WriteString("\n\n")
+	if !ok {
+		b.WriteString(s.styles.Muted.Render("No table for this class."))
+		return b.String()
+	}
 
-	if s.mode == ModeEditFeatures {
-		b.WriteString(s.styles.FocusedInput.Render(s.featuresInput.View()))
-	} else if s.char.FeaturesTraits != "" {
-		b.WriteString(s.char.FeaturesTraits)
-	} else {
-		b.WriteString(s.styles.Muted.Render("No features or traits recorded."))
+	// Every spellcasting class has slots by 20th level
+	casts := formatSlots(s.rules().SpellSlots(s.char.Class, character.MaxLevel)) != "-"
+
+	// Features are cut shorter on narrow terminals
+	featuresWidth := 40
+	if s.narrow() {
+		featuresWidth = 16
 	}
-	b.WriteString("\n\n")
+	header := fmt.Sprintf("  %-3s %-3s %-*s", "Lv", "PB", featuresWidth, "Features")
+	for _, col := range table.Columns {
+		header += fmt.Sprintf(" %-*s", max(len(col), 5), col)
+	}
+	if casts {
+		header += " Slots"
+	}
+	b.WriteString(s.styles.Muted.Render(header))
+	b.WriteString("\n")
 
-	b.WriteString(s.styles.Header.Render("Notes"))
-	b.WriteString("\n\n")
+	last := min(character.MaxLevel, s.classTableOffset+s.classTableRows()-1)
+	for level := s.classTableOffset; level <= last; level++ {
+		row := table.Levels[level-1]
 
-	if s.mode == ModeEditNotes {
-		b.WriteString(s.styles.FocusedInput.Render(s.notesInput.View()))
-	} else if s.char.Notes != "" {
-		b.WriteString(s.char.Notes)
-	} else {
-		b.WriteString(s.styles.Muted.Render("No notes recorded."))
+		features := "-"
+		if len(row.Features) > 0 {
+			features = strings.Join(row.Features, ", ")
+		}
+		marker := "  "
+		style := s.styles.Unselected
+		switch {
+		case level == int(s.char.Level):
+			marker = "> "
+			style = s.styles.Selected
+		case level == int(s.char.Level)+1:
+			marker = "+ "
+			style = s.styles.SuccessText
+		case level < int(s.char.Level):
+			style = s.styles.Muted
+		}
+
+		line := fmt.Sprintf("%s%-3d %-3s %-*s", marker, level,
+			character.FormatModifierInt(s.rules().ProficiencyBonus(level)),
+			featuresWidth, truncate(features, featuresWidth))
+		for i, col := range table.Columns {
+			line += fmt.Sprintf(" %-*s", max(len(col), 5), row.Columns[i])
+		}
+		if casts {
+			line += " " + formatSlots(s.rules().SpellSlots(s.char.Class, level))
+		}
+		b.WriteString(style.Render(line))
+		b.WriteString("\n")
 	}
 
-	return b.String()
+	return lipgloss.NewStyle().Align(lipgloss.Left).Render(b.String())
+}
+
+// formatSlots shows slot counts from 1st level up, e.g. "4 3 2". Pact Magic
+// slots, all of one higher level, show as count and level, e.g. "2 × L3".
+func formatSlots(slots []int) string {
+	used := 0
+	for _, n := range slots {
+		if n > 0 {
+			used++
+		}
+	}
+	if used == 1 && slots[0] == 0 {
+		for i, n := range slots {
+			if n > 0 {
+				return fmt.Sprintf("%d × L%d", n, i+1)
+			}
+		}
+	}
+
+	var parts []string
+	for _, n := range slots {
+		parts = append(parts, fmt.Sprintf("%d", n))
+	}
+	// Drop trailing spell levels with no slots
+	for len(parts) > 0 && parts[len(parts)-1] == "0" {
+		parts = parts[:len(parts)-1]
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
 }
 
 func (s *SheetScreen) getHelp() string {
 	switch s.mode {
 	case ModeEditHP:
+		return "-N: damage • ↑/↓: damage type • +N: heal • N: set • enter: save • esc: cancel"
+	case ModeEditTempHP, ModeEditMaxHPBonus, ModeRename:
 		return "enter: save • esc: cancel"
+	case ModeAwardXP:
+		return "N: add XP • -N: remove XP • enter: save • esc: cancel"
 	case ModeEditNotes, ModeEditFeatures:
-		return "ctrl+s: save • esc: cancel"
+		if s.preview {
+			return "ctrl+r: back to editing • ctrl+s: save • esc: cancel"
+		}
+		return "markdown supported • ctrl+r: preview • ctrl+s: save • esc: cancel"
+	case ModeClassTable:
+		return "↑/↓: scroll • esc: close"
+	case ModeConcentrationSave:
+		return "r: roll save • s: made it • f: failed"
+	case ModeConfirmLongRest, ModeConfirmShortRest:
+		return "y: rest • n: cancel"
+	case ModeEditSaves:
+		return "↑/↓: select • space: toggle proficiency • enter: save • esc: cancel"
+	case ModeEditSkills:
+		return "↑/↓: select • space: none → proficient → expertise → half • enter: save • esc: cancel"
+	case ModeAddItem:
+		return "enter: add • esc: cancel"
+	case ModeAddLanguage:
+		return "tab: complete • enter: add • esc: cancel"
+	case ModeEditMovement:
+		return "↑/↓: select • ←/→: change • enter: save • esc: cancel"
+	case ModeSetPortrait:
+		return "enter: fetch (blank to remove) • esc: cancel"
+	case ModePrint:
+		if s.print.markdown {
+			return "↑/↓/pgup/pgdn: scroll • m: plain text • esc: close"
+		}
+		return "↑/↓/pgup/pgdn: scroll • m: Markdown • esc: close"
+	case ModeCraft:
+		if s.crafting != nil {
+			return "←/→: days • enter: work • x: abandon • esc: close"
+		}
+		return "↑/↓: select • enter: buy materials and start • esc: cancel"
+	case ModeShop:
+		return "↑/↓: select • +/-: quantity • ←/→: prices • enter: buy • esc: close"
+	case ModeEditAC:
+		return "enter: save (blank to calculate) • esc: cancel"
+	case ModePickWeapon:
+		return "↑/↓: select • +/-: magic bonus • enter: add • esc: cancel"
+	case ModeRollLoot:
+		return "←/→: challenge rating • tab: individual/hoard • r: reroll • enter: add to inventory • esc: cancel"
+	case ModeShare:
+		return "scan with a phone camera • any key: close"
+	case ModeSpellDetail:
+		return "↑/↓: previous/next spell • C: cast as ritual • esc: close"
+	case ModeJournalEntry:
+		return "↑/↓: previous/next entry • e: edit • esc: close"
+	case ModeJournalTitle:
+		return "enter: continue • esc: cancel"
+	case ModeSearch:
+		return "↑/↓: select • enter: go to result • esc: close"
+	case ModePalette:
+		return "type to filter • ↑/↓: select • enter: run • esc: close"
+	case ModeConfirmRepair:
+		return "y: repair • n: not now"
+	case ModeSetupSpellcasting:
+		return "↑/↓: select • ←/→: change • enter: save • esc: cancel"
+	case ModePrepareSpells:
+		return "↑/↓: select • space: prepare/unprepare • enter: save • esc: keep current spells"
 	default:
-		help := "tab/←→: switch tabs • q/esc: back"
-		if s.tab == 2 {
-			help += " • e: edit HP"
+		// Small terminals get only the keys for finding the rest
+		if s.short() {
+			return "tab/←→: switch tabs • ctrl+k: commands • ?: manual • q/esc: back"
+		}
+		help := "tab/←→: switch tabs • p: build plan • n: rename • X: award XP • S: share • E: print view • r: roll d20 • i: inspiration • /: search • ctrl+k: commands • ctrl+z/ctrl+y: undo/redo • ?: manual • q/esc: back"
+		if s.split() {
+			help += " • |: switch pane"
+		}
+		if s.tab == 0 {
+			help += " • e: edit saving throws • a: add language • T: add tool • ↑/↓: select • d: remove • I: portrait"
+		} else if s.tab == 1 {
+			help += " • e: edit proficiencies"
+		} else if s.tab == 2 {
+			help += " • e: edit HP • t: temp HP • m: max HP bonus • o: override AC • v: speeds and senses • +/-: exhaustion • u/U: spend/gain luck • s: short rest • L: long rest"
+			if len(s.resources) > 0 {
+				help += " • ↑/↓: select resource • g/space: use • G: regain • R: reset"
+			}
+			if s.char.ConcentratingOn != "" {
+				help += " • x: end concentration"
+			}
+		} else if s.tab == 3 && s.spellcasting == nil {
+			help += " • enter: set up spellcasting"
 		} else if s.tab == 3 {
-			help += " • e: edit notes • f: edit features"
+			help += " • ↑/↓: select • enter: details • c: concentrate • C: cast as ritual"
+			if _, ok := s.preparedLimit(); ok {
+				help += " • P: change prepared spells"
+			}
+			if s.char.ConcentratingOn != "" {
+				help += " • x: end concentration"
+			}
+		} else if s.tab == 4 {
+			help += " • ↑/↓: select • enter: read • a: new entry • e: edit • R: retitle • d: delete • f: edit features • c: class table"
+		} else if s.tab == 5 {
+			help += " • ↑/↓: scroll"
+		} else if s.tab == 6 {
+			help += " • ↑/↓: select • a: add item • w: add weapon • b: shop • $: roll treasure • c: craft • space: equip/unequip • M: move to container • d: remove"
 		}
 		return help
 	}
@@ -0,0 +1,144 @@
+package screens
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// sheetPart is one of the things a sheet loads alongside the character.
+// Parts combine as a set.
+type sheetPart int
+
+const (
+	partSpells sheetPart = 1 << iota
+	partHPHistory
+	partEvents
+	partInventory
+	partJournal
+	partResources
+	partPortrait
+	partCrafting
+
+	partAll = partSpells | partHPHistory | partEvents | partInventory | partJournal | partResources | partPortrait | partCrafting
+)
+
+// sheetCacheTTL is how long a loaded part is reused. Edits made on the sheet
+// replace what they change right away; this bounds how long changes made
+// elsewhere, such as through the API, go unseen.
+const sheetCacheTTL = 2 * time.Minute
+
+// SheetCache keeps what sheets loaded during a session, by character, so
+// reopening a sheet or making an edit only queries what changed
+type SheetCache struct {
+	mu      sync.Mutex
+	entries map[pgtype.UUID]map[sheetPart]sheetCached
+
+	// generation counts invalidations, so a load that started before one
+	// doesn't store what it read
+	generation uint64
+}
+
+type sheetCached struct {
+	msg      tea.Msg
+	loadedAt time.Time
+}
+
+// NewSheetCache creates an empty cache
+func NewSheetCache() *SheetCache {
+	return &SheetCache{entries: make(map[pgtype.UUID]map[sheetPart]sheetCached)}
+}
+
+// load returns a command answering from the cache when it can, and
+// otherwise running query and caching what it returns. Failures aren't
+// cached. A nil cache always queries.
+func (c *SheetCache) load(characterID pgtype.UUID, part sheetPart, query tea.Cmd) tea.Cmd {
+	if c == nil {
+		return query
+	}
+	return func() tea.Msg {
+		c.mu.Lock()
+		cached, ok := c.entries[characterID][part]
+		generation := c.generation
+		c.mu.Unlock()
+		if ok && time.Since(cached.loadedAt) < sheetCacheTTL {
+			return cached.msg
+		}
+
+		msg := query()
+		if _, failed := msg.(ErrorMsg); failed {
+			return msg
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.generation == generation {
+			if c.entries[characterID] == nil {
+				c.entries[characterID] = make(map[sheetPart]sheetCached)
+			}
+			c.entries[characterID][part] = sheetCached{msg: msg, loadedAt: time.Now()}
+		}
+		return msg
+	}
+}
+
+// loadAll returns a command running query, which loads every part at once
+// as a sheetDataLoadedMsg, and caching the parts it returns
+func (c *SheetCache) loadAll(characterID pgtype.UUID, query tea.Cmd) tea.Cmd {
+	if c == nil {
+		return query
+	}
+	return func() tea.Msg {
+		c.mu.Lock()
+		generation := c.generation
+		c.mu.Unlock()
+
+		msg := query()
+		loaded, ok := msg.(sheetDataLoadedMsg)
+		if !ok {
+			return msg
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.generation == generation {
+			entry := make(map[sheetPart]sheetCached, len(loaded.parts))
+			for part, msg := range loaded.parts {
+				entry[part] = sheetCached{msg: msg, loadedAt: time.Now()}
+			}
+			c.entries[characterID] = entry
+		}
+		return msg
+	}
+}
+
+// missing returns which of these parts of a character's sheet have to be
+// queried
+func (c *SheetCache) missing(characterID pgtype.UUID, parts sheetPart) sheetPart {
+	if c == nil {
+		return parts
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for part, cached := range c.entries[characterID] {
+		if time.Since(cached.loadedAt) < sheetCacheTTL {
+			parts &^= part
+		}
+	}
+	return parts
+}
+
+// invalidate drops the parts of a character's sheet that were just changed
+func (c *SheetCache) invalidate(characterID pgtype.UUID, parts sheetPart) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for part := range c.entries[characterID] {
+		if part&parts != 0 {
+			delete(c.entries[characterID], part)
+		}
+	}
+}
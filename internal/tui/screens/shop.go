@@ -0,0 +1,200 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/audit"
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/tui/components"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Limits on the shop's price adjustment, in percent of the SRD price, and
+// on how many of an item are bought at once
+const (
+	minShopPrice    = 50
+	maxShopPrice    = 300
+	maxShopQuantity = 99
+)
+
+// shop is the state of the shop on the Inventory tab
+type shop struct {
+	cursor   int
+	quantity int // how many of the item, or bundles of ammunition
+	price    int // percent of the SRD price the shop charges
+}
+
+// startShopping opens the shop at list price
+func (s *SheetScreen) startShopping() {
+	s.mode = ModeShop
+	s.shop.quantity = 1
+	if s.shop.price == 0 {
+		s.shop.price = 100
+	}
+}
+
+// shopPrice is what the shop charges for an item, rounded up to a copper
+func (s *SheetScreen) shopPrice(e character.Equipment) int {
+	return (e.Price*s.shop.price + 99) / 100
+}
+
+func (s *SheetScreen) updateShop(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if s.shop.cursor > 0 {
+			s.shop.cursor--
+		}
+	case "down", "j":
+		if s.shop.cursor < len(character.EquipmentTable)-1 {
+			s.shop.cursor++
+		}
+	case "+", "=":
+		if s.shop.quantity < maxShopQuantity {
+			s.shop.quantity++
+		}
+	case "-":
+		if s.shop.quantity > 1 {
+			s.shop.quantity--
+		}
+	case "left", "h":
+		s.shop.price = max(s.shop.price-10, minShopPrice)
+	case "right", "l":
+		s.shop.price = min(s.shop.price+10, maxShopPrice)
+	case "enter":
+		e := character.EquipmentTable[s.shop.cursor]
+		cost := s.shopPrice(e) * s.shop.quantity
+		if have := purseOf(s.inventory).Total(); have < cost {
+			s.status = fmt.Sprintf("%s costs %s; you have %s", e.Name, character.FormatCoins(cost), character.FormatCoins(have))
+			return s, nil
+		}
+		s.status = ""
+		return s, s.undo.run(buyEdit(s.ctx, s.queries, s.userID, s.char, s.inventory, e, s.shop.quantity, cost))
+	case "esc":
+		s.mode = ModeView
+	}
+	return s, nil
+}
+
+// viewShop lists the SRD equipment around the cursor with the shop's
+// prices, dimming what the character can't afford
+func (s *SheetScreen) viewShop() string {
+	var b strings.Builder
+	b.WriteString(s.styles.Header.Render("Shop"))
+	b.WriteString("\n\n")
+
+	have := purseOf(s.inventory).Total()
+	const rows = 10
+	start := min(max(s.shop.cursor-rows/2, 0), max(len(character.EquipmentTable)-rows, 0))
+	end := min(start+rows, len(character.EquipmentTable))
+	for i := start; i < end; i++ {
+		e := character.EquipmentTable[i]
+		name := e.Name
+		if e.Count > 1 {
+			name += fmt.Sprintf(" ×%d", e.Count)
+		}
+		line := fmt.Sprintf("%-22s %s", name, character.FormatCoins(s.shopPrice(e)))
+		style := s.styles.NotProficient
+		if s.shopPrice(e) > have {
+			style = s.styles.Muted
+		}
+		if i == s.shop.cursor {
+			style = s.styles.Cursor
+		}
+		b.WriteString(components.Mark(components.Item("ware", i), style.Render(line)))
+		b.WriteString("\n")
+	}
+
+	e := character.EquipmentTable[s.shop.cursor]
+	cost := s.shopPrice(e) * s.shop.quantity
+	b.WriteString("\n")
+	b.WriteString(s.styles.StatLabel.Render("Prices:   "))
+	b.WriteString(s.styles.StatValue.Render(fmt.Sprintf("◀ %d%% ▶", s.shop.price)))
+	b.WriteString("\n")
+	b.WriteString(s.styles.StatLabel.Render("Buying:   "))
+	b.WriteString(s.styles.StatValue.Render(fmt.Sprintf("%d × %s for %s", s.shop.quantity, e.Name, character.FormatCoins(cost))))
+	b.WriteString("\n")
+	b.WriteString(s.styles.StatLabel.Render("Coins:    "))
+	style := s.styles.StatValue
+	if cost > have {
+		style = s.styles.WarningText
+	}
+	b.WriteString(style.Render(character.FormatCoins(have)))
+	b.WriteString("\n")
+
+	return lipgloss.NewStyle().
+		Align(lipgloss.Left).
+		Render(b.String())
+}
+
+// buyEdit pays for some of an item and adds it to the inventory, in one
+// transaction so coins are never spent on nothing
+func buyEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, inventory []db.CharacterInventory, e character.Equipment, quantity, cost int) sheetEdit {
+	count := int32(quantity)
+	if e.Count > 0 {
+		count *= e.Count
+	}
+	// Bought items join a stack already carried, the way loot does
+	var existing pgtype.UUID
+	for _, item := range inventory {
+		if strings.EqualFold(item.Name, e.Name) {
+			existing = item.ID
+			break
+		}
+	}
+	description := fmt.Sprintf("Bought %s ×%d for %s", e.Name, count, character.FormatCoins(cost))
+
+	var payment coinPayment
+	var added pgtype.UUID
+	return sheetEdit{
+		label:   "purchase",
+		touches: partInventory,
+		apply: func() (db.Character, error) {
+			err := queries.InTx(ctx, func(q *db.Queries) error {
+				var err error
+				if payment, err = payCoins(ctx, q, char.ID, cost); err != nil {
+					return err
+				}
+				if existing.Valid {
+					_, err = q.AddInventoryQuantity(ctx, db.AddInventoryQuantityParams{ID: existing, Quantity: count})
+					return err
+				}
+				item, err := q.AddInventoryItem(ctx, db.AddInventoryItemParams{
+					CharacterID: char.ID,
+					Name:        e.Name,
+					Quantity:    count,
+				})
+				added = item.ID
+				return err
+			})
+			if err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindInventory, description)
+			return queries.GetCharacterByID(ctx, char.ID)
+		},
+		revert: func() (db.Character, error) {
+			err := queries.InTx(ctx, func(q *db.Queries) error {
+				var err error
+				if existing.Valid {
+					_, err = q.AddInventoryQuantity(ctx, db.AddInventoryQuantityParams{ID: existing, Quantity: -count})
+				} else {
+					err = q.DeleteInventoryItem(ctx, added)
+				}
+				if err != nil {
+					return err
+				}
+				return payment.refund(ctx, q)
+			})
+			if err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindInventory, "Undid purchase: "+description)
+			return queries.GetCharacterByID(ctx, char.ID)
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package screens
+
+import (
+	"errors"
+
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/compendium"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// spellLookup finds spells missing from the local list online; nil when
+// the server doesn't look them up
+var spellLookup *compendium.Open5e
+
+// EnableSpellLookup looks up spells without local details on Open5e when
+// their details are opened
+func EnableSpellLookup(lookup *compendium.Open5e) {
+	spellLookup = lookup
+}
+
+type spellLookedUpMsg struct {
+	Name string
+	Err  error
+}
+
+// showSpellDetail opens the details of the spell under the cursor, looking
+// it up online when they aren't known locally
+func (s *SheetScreen) showSpellDetail() tea.Cmd {
+	s.mode = ModeSpellDetail
+	name := s.spells[s.spellCursor].Name
+	if _, ok := character.FindSpell(name); ok || !spellLookup.Enabled() {
+		return nil
+	}
+	s.lookingUp = name
+	ctx := s.ctx
+	return func() tea.Msg {
+		_, err := spellLookup.Spell(ctx, name)
+		return spellLookedUpMsg{Name: name, Err: err}
+	}
+}
+
+func (s *SheetScreen) handleSpellLookedUp(msg spellLookedUpMsg) {
+	if msg.Name == s.lookingUp {
+		s.lookingUp = ""
+	}
+	switch {
+	case errors.Is(msg.Err, compendium.ErrNotFound):
+		s.status = msg.Name + " isn't on Open5e either"
+	case msg.Err != nil:
+		s.status = "Couldn't look up " + msg.Name + ": " + msg.Err.Error()
+	}
+}
@@ -0,0 +1,167 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/audit"
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/tui/components"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// spellcastingSetup is the form for giving a character spellcasting, for
+// casters created without it and characters multiclassing into a casting
+// class
+type spellcastingSetup struct {
+	row     int
+	class   string
+	ability string
+	level   int // levels in the casting class, which set the spell slots
+}
+
+// Rows of the spellcasting setup form
+const (
+	setupClass = iota
+	setupAbility
+	setupLevel
+	setupRows
+)
+
+// startSpellcastingSetup opens the form, starting from the character's own
+// class if it casts spells
+func (s *SheetScreen) startSpellcastingSetup() {
+	class := s.char.Class
+	if _, ok := character.SpellcastingAbility(class); !ok {
+		class = character.SpellcastingClasses()[0]
+	}
+	s.setup = spellcastingSetup{}
+	s.setSetupClass(class)
+	s.mode = ModeSetupSpellcasting
+}
+
+// setSetupClass picks the casting class, with its usual ability, and all
+// the character's levels if it's their class or one if they multiclassed
+func (s *SheetScreen) setSetupClass(class string) {
+	s.setup.class = class
+	s.setup.ability, _ = character.SpellcastingAbility(class)
+	s.setup.level = 1
+	if class == s.char.Class {
+		s.setup.level = int(s.char.Level)
+	}
+}
+
+// setupStats works out what the form would save. The save DC and attack
+// bonus use the character's total level; the slots use levels in the
+// casting class.
+func (s *SheetScreen) setupStats() (dc, attack int, slots []int) {
+	score := abilityScore(s.char, s.setup.ability)
+	level := int(s.char.Level)
+	return character.SpellSaveDC(score, level),
+		character.SpellAttackBonus(score, level),
+		s.rules().SpellSlots(s.setup.class, s.setup.level)
+}
+
+func (s *SheetScreen) updateSpellcastingSetup(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	step := 0
+	switch msg.String() {
+	case "esc":
+		s.mode = ModeView
+		return s, nil
+	case "up", "k":
+		s.setup.row = (s.setup.row + setupRows - 1) % setupRows
+	case "down", "j":
+		s.setup.row = (s.setup.row + 1) % setupRows
+	case "left", "h", "-":
+		step = -1
+	case "right", "l", "+", "=":
+		step = 1
+	case "enter":
+		dc, attack, slots := s.setupStats()
+		return s, s.undo.run(spellcastingSetupEdit(s.ctx, s.queries, s.userID, s.char, db.CreateCharacterSpellcastingParams{
+			CharacterID:         s.char.ID,
+			SpellcastingClass:   s.setup.class,
+			SpellcastingAbility: s.setup.ability,
+			SpellSaveDc:         int32(dc),
+			SpellAttackBonus:    int32(attack),
+			SlotsMax:            slotCounts(slots),
+		}))
+	}
+	if step == 0 {
+		return s, nil
+	}
+
+	switch s.setup.row {
+	case setupClass:
+		classes := character.SpellcastingClasses()
+		i := slices.Index(classes, s.setup.class)
+		s.setSetupClass(classes[(i+step+len(classes))%len(classes)])
+	case setupAbility:
+		abilities := character.Abilities
+		i := slices.Index(abilities, s.setup.ability)
+		s.setup.ability = abilities[(i+step+len(abilities))%len(abilities)]
+	case setupLevel:
+		s.setup.level = min(max(s.setup.level+step, 1), int(s.char.Level))
+	}
+	return s, nil
+}
+
+func (s *SheetScreen) viewSpellcastingSetup() string {
+	var b strings.Builder
+	b.WriteString(s.styles.Header.Render("Set Up Spellcasting"))
+	b.WriteString("\n\n")
+
+	row := func(i int, label, value string) {
+		cursor, style := "  ", s.styles.NotProficient
+		if i == s.setup.row {
+			cursor, style = "> ", s.styles.Cursor
+		}
+		b.WriteString(components.Mark(components.Item("setup", i), style.Render(fmt.Sprintf("%s%-14s", cursor, label))))
+		b.WriteString(components.Mark(components.Item("less", i), "◀") + " " + value + " " + components.Mark(components.Item("more", i), "▶") + "\n")
+	}
+	row(setupClass, "Class", s.setup.class)
+	row(setupAbility, "Ability", s.setup.ability)
+	row(setupLevel, "Class levels", fmt.Sprintf("%d of %d", s.setup.level, s.char.Level))
+	b.WriteString("\n")
+
+	dc, attack, slots := s.setupStats()
+	b.WriteString(fmt.Sprintf("Save DC %s • Spell attack %s • Slots %s\n",
+		s.styles.StatValue.UnsetWidth().Render(fmt.Sprintf("%d", dc)),
+		s.styles.StatValue.UnsetWidth().Render(character.FormatModifierInt(attack)),
+		s.styles.StatValue.UnsetWidth().Render(formatSlots(slots))))
+	b.WriteString("\n")
+
+	text := lipgloss.NewStyle().Width(s.contentWidth(64))
+	b.WriteString(s.styles.Muted.Render(text.Render("For a character who multiclassed, set the levels taken in the casting class; they decide the spell slots. The save DC and attack bonus use the character's total level.")))
+
+	return lipgloss.NewStyle().Align(lipgloss.Left).Render(b.String())
+}
+
+// spellcastingSetupEdit gives a character spellcasting; undoing it removes
+// the spellcasting again
+func spellcastingSetupEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, params db.CreateCharacterSpellcastingParams) sheetEdit {
+	return sheetEdit{
+		label:   "spellcasting setup",
+		touches: partSpells,
+		apply: func() (db.Character, error) {
+			if _, err := queries.CreateCharacterSpellcasting(ctx, params); err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindSpellcasting,
+				fmt.Sprintf("Set up %s spellcasting with %s, save DC %d", params.SpellcastingClass, params.SpellcastingAbility, params.SpellSaveDc))
+			return queries.GetCharacterByID(ctx, char.ID)
+		},
+		revert: func() (db.Character, error) {
+			if err := queries.DeleteCharacterSpellcasting(ctx, char.ID); err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindSpellcasting, "Removed "+params.SpellcastingClass+" spellcasting")
+			return queries.GetCharacterByID(ctx, char.ID)
+		},
+	}
+}
@@ -0,0 +1,302 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/auth"
+	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/tui/components"
+	"github.com/brady1408/dnd/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type TokensMode int
+
+const (
+	ModeTokenList TokensMode = iota
+	ModeTokenName
+	ModeTokenCreated
+	ModeTokenRevoke
+)
+
+// TokensScreen lets a user manage API tokens for the HTTP API
+type TokensScreen struct {
+	ctx         context.Context
+	authService *auth.Service
+	user        *db.User
+	styles      *styles.Styles
+
+	mode          TokensMode
+	tokens        []db.ApiToken
+	selectedIndex int
+	nameInput     textinput.Model
+	newToken      string
+	err           string
+	width         int
+	height        int
+}
+
+type NavigateToTokensMsg struct{}
+
+type TokensLoadedMsg struct {
+	Tokens []db.ApiToken
+}
+
+type tokenCreatedMsg struct {
+	Token string
+}
+
+type tokenErrorMsg struct {
+	Err error
+}
+
+func NewTokensScreen(ctx context.Context, authService *auth.Service, user *db.User, s *styles.Styles) *TokensScreen {
+	nameInput := textinput.New()
+	nameInput.Placeholder = "Token name (e.g. phone)"
+	nameInput.CharLimit = 100
+	nameInput.Width = 30
+
+	return &TokensScreen{
+		ctx:         ctx,
+		authService: authService,
+		user:        user,
+		styles:      s,
+		mode:        ModeTokenList,
+		nameInput:   nameInput,
+		width:       80,
+		height:      24,
+	}
+}
+
+func (t *TokensScreen) Init() tea.Cmd {
+	return t.loadTokens()
+}
+
+func (t *TokensScreen) loadTokens() tea.Cmd {
+	return func() tea.Msg {
+		tokens, err := t.authService.ListAPITokens(t.ctx, t.user.ID)
+		if err != nil {
+			return tokenErrorMsg{Err: err}
+		}
+		return TokensLoadedMsg{Tokens: tokens}
+	}
+}
+
+func (t *TokensScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		t.width = msg.Width
+		t.height = msg.Height
+
+	case TokensLoadedMsg:
+		t.tokens = msg.Tokens
+		if t.selectedIndex >= len(t.tokens) {
+			t.selectedIndex = len(t.tokens) - 1
+		}
+		if t.selectedIndex < 0 {
+			t.selectedIndex = 0
+		}
+
+	case tokenCreatedMsg:
+		t.newToken = msg.Token
+		t.mode = ModeTokenCreated
+		return t, t.loadTokens()
+
+	case tokenErrorMsg:
+		t.err = msg.Err.Error()
+		t.mode = ModeTokenList
+
+	case components.ClickMsg:
+		if i, ok := components.Index(msg.Zone, "token"); ok && t.mode == ModeTokenList && i < len(t.tokens) {
+			t.selectedIndex = i
+		}
+
+	case tea.KeyMsg:
+		t.err = ""
+		switch t.mode {
+		case ModeTokenList:
+			return t.updateList(msg)
+		case ModeTokenName:
+			return t.updateName(msg)
+		case ModeTokenCreated:
+			// Any key dismisses the one-time token display
+			t.newToken = ""
+			t.mode = ModeTokenList
+		case ModeTokenRevoke:
+			return t.updateRevoke(msg)
+		}
+	}
+
+	return t, nil
+}
+
+func (t *TokensScreen) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if t.selectedIndex > 0 {
+			t.selectedIndex--
+		}
+	case "down", "j":
+		if t.selectedIndex < len(t.tokens)-1 {
+			t.selectedIndex++
+		}
+	case "n":
+		t.mode = ModeTokenName
+		t.nameInput.SetValue("")
+		t.nameInput.Focus()
+		return t, textinput.Blink
+	case "d", "delete":
+		if len(t.tokens) > 0 {
+			t.mode = ModeTokenRevoke
+		}
+	case "esc", "q":
+		return t, func() tea.Msg { return NavigateBackMsg{} }
+	}
+	return t, nil
+}
+
+func (t *TokensScreen) updateName(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		name := strings.TrimSpace(t.nameInput.Value())
+		if name == "" {
+			t.err = "Name is required"
+			return t, nil
+		}
+		t.nameInput.Blur()
+		return t, t.createToken(name)
+	case "esc":
+		t.nameInput.Blur()
+		t.mode = ModeTokenList
+		return t, nil
+	}
+
+	var cmd tea.Cmd
+	t.nameInput, cmd = t.nameInput.Update(msg)
+	return t, cmd
+}
+
+func (t *TokensScreen) updateRevoke(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		t.mode = ModeTokenList
+		if t.selectedIndex < len(t.tokens) {
+			tokenID := t.tokens[t.selectedIndex].ID
+			return t, func() tea.Msg {
+				if err := t.authService.RevokeAPIToken(t.ctx, t.user.ID, tokenID); err != nil {
+					return tokenErrorMsg{Err: err}
+				}
+				tokens, err := t.authService.ListAPITokens(t.ctx, t.user.ID)
+				if err != nil {
+					return tokenErrorMsg{Err: err}
+				}
+				return TokensLoadedMsg{Tokens: tokens}
+			}
+		}
+	case "n", "N", "esc":
+		t.mode = ModeTokenList
+	}
+	return t, nil
+}
+
+func (t *TokensScreen) createToken(name string) tea.Cmd {
+	return func() tea.Msg {
+		token, _, err := t.authService.CreateAPIToken(t.ctx, t.user.ID, name)
+		if err != nil {
+			return tokenErrorMsg{Err: err}
+		}
+		return tokenCreatedMsg{Token: token}
+	}
+}
+
+func (t *TokensScreen) View() string {
+	var b strings.Builder
+
+	b.WriteString(t.styles.Title.Render("API Tokens"))
+	b.WriteString("\n")
+	b.WriteString(t.styles.Subtitle.Render("Tokens let external tools use the HTTP API as you."))
+	b.WriteString("\n\n")
+
+	switch t.mode {
+	case ModeTokenName:
+		b.WriteString("Name:\n")
+		b.WriteString(t.styles.FocusedInput.Render(t.nameInput.View()))
+	case ModeTokenCreated:
+		b.WriteString(t.styles.SuccessText.Render("Token created. Copy it now, it will not be shown again:"))
+		b.WriteString("\n\n")
+		b.WriteString(t.styles.HighlightBox.Render(t.newToken))
+	default:
+		b.WriteString(t.viewList())
+	}
+
+	if t.mode == ModeTokenRevoke && t.selectedIndex < len(t.tokens) {
+		b.WriteString("\n")
+		b.WriteString(t.styles.WarningText.Render(fmt.Sprintf(
+			"Revoke %s? Tools using it will stop working. (y/n)",
+			t.tokens[t.selectedIndex].Name,
+		)))
+	}
+
+	if t.err != "" {
+		b.WriteString("\n")
+		b.WriteString(t.styles.ErrorText.Render("Error: " + t.err))
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(t.styles.Help.Render(t.getHelp()))
+
+	return lipgloss.Place(t.width, t.height,
+		lipgloss.Center, lipgloss.Center,
+		b.String())
+}
+
+func (t *TokensScreen) viewList() string {
+	var b strings.Builder
+
+	if len(t.tokens) == 0 {
+		b.WriteString(t.styles.Muted.Render("No API tokens yet."))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	for i, token := range t.tokens {
+		cursor := "  "
+		style := t.styles.Unselected
+		if i == t.selectedIndex {
+			cursor = "> "
+			style = t.styles.Selected
+		}
+
+		lastUsed := "never used"
+		if token.LastUsedAt.Valid {
+			lastUsed = "last used " + token.LastUsedAt.Time.Format("2006-01-02")
+		}
+		created := ""
+		if token.CreatedAt.Valid {
+			created = token.CreatedAt.Time.Format("2006-01-02")
+		}
+
+		b.WriteString(components.Mark(components.Item("token", i),
+			style.Render(fmt.Sprintf("%s%-20s created %s, %s", cursor, token.Name, created, lastUsed))))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (t *TokensScreen) getHelp() string {
+	switch t.mode {
+	case ModeTokenName:
+		return "enter: create • esc: cancel"
+	case ModeTokenCreated:
+		return "any key: continue"
+	case ModeTokenRevoke:
+		return "y: confirm revoke • n: cancel"
+	default:
+		return "↑/↓: navigate • n: new token • d: revoke • esc: back"
+	}
+}
@@ -0,0 +1,897 @@
+package screens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/audit"
+	"github.com/brady1408/dnd/internal/character"
+	"github.com/brady1408/dnd/internal/db"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// undoLimit caps how many edits are kept per sheet session
+const undoLimit = 50
+
+// sheetEdit is one undoable change to a character. apply writes the new
+// state and revert writes the old one; both return the updated character.
+type sheetEdit struct {
+	label  string
+	apply  func() (db.Character, error)
+	revert func() (db.Character, error)
+
+	// What the edit changes besides the character and change log, to be
+	// reloaded after it
+	touches sheetPart
+}
+
+// undoStack records the edits made on a sheet so they can be undone and
+// redone in order
+type undoStack struct {
+	done    []sheetEdit
+	undone  []sheetEdit
+	pending bool // an edit, undo, or redo is being written
+}
+
+// errEditPending refuses an edit made while another is still being written.
+// finish moves edits between the stacks by position, so only one write can
+// be in flight at a time.
+var errEditPending = errors.New("still saving the last change, try again")
+
+// editAppliedMsg is sent after an edit is applied, undone, or redone
+type editAppliedMsg struct {
+	Character db.Character
+	Edit      sheetEdit
+	Action    editAction
+	Err       error
+}
+
+type editAction int
+
+const (
+	editDo editAction = iota
+	editUndo
+	editRedo
+)
+
+// run applies a new edit
+func (u *undoStack) run(e sheetEdit) tea.Cmd {
+	if u.pending {
+		return func() tea.Msg { return editAppliedMsg{Edit: e, Action: editDo, Err: errEditPending} }
+	}
+	u.pending = true
+	return runEdit(e, e.apply, editDo)
+}
+
+// undo reverts the most recent edit, or returns nil if there is none
+func (u *undoStack) undo() tea.Cmd {
+	if u.pending || len(u.done) == 0 {
+		return nil
+	}
+	u.pending = true
+	e := u.done[len(u.done)-1]
+	return runEdit(e, e.revert, editUndo)
+}
+
+// redo re-applies the most recently undone edit, or returns nil if there
+// is none
+func (u *undoStack) redo() tea.Cmd {
+	if u.pending || len(u.undone) == 0 {
+		return nil
+	}
+	u.pending = true
+	e := u.undone[len(u.undone)-1]
+	return runEdit(e, e.apply, editRedo)
+}
+
+// finish moves the edit between the stacks once it has been written. Failed
+// writes leave the stacks as they were.
+func (u *undoStack) finish(msg editAppliedMsg) {
+	if errors.Is(msg.Err, errEditPending) {
+		// Refused without being written, so the write in flight is still
+		// pending
+		return
+	}
+	u.pending = false
+	if msg.Err != nil {
+		return
+	}
+	switch msg.Action {
+	case editDo:
+		u.done = append(u.done, msg.Edit)
+		if len(u.done) > undoLimit {
+			u.done = u.done[len(u.done)-undoLimit:]
+		}
+		u.undone = nil
+	case editUndo:
+		u.done = u.done[:len(u.done)-1]
+		u.undone = append(u.undone, msg.Edit)
+	case editRedo:
+		u.undone = u.undone[:len(u.undone)-1]
+		u.done = append(u.done, msg.Edit)
+	}
+}
+
+func runEdit(e sheetEdit, write func() (db.Character, error), action editAction) tea.Cmd {
+	return func() tea.Msg {
+		char, err := write()
+		return editAppliedMsg{Character: char, Edit: e, Action: action, Err: err}
+	}
+}
+
+// hpEdit changes current and temporary hit points, recording each write in
+// the HP history and change log. note, such as the damage type, is added
+// to the log entry.
+func hpEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, current, temp int32, note string) sheetEdit {
+	last := char
+	set := func(current, temp int32, suffix string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			updated, err := queries.UpdateCharacterHitPoints(ctx, db.UpdateCharacterHitPointsParams{
+				ID:                 char.ID,
+				CurrentHitPoints:   current,
+				TemporaryHitPoints: temp,
+			})
+			if err != nil {
+				return updated, err
+			}
+			_ = queries.AddHPHistory(ctx, db.AddHPHistoryParams{
+				CharacterID:        updated.ID,
+				CurrentHitPoints:   updated.CurrentHitPoints,
+				TemporaryHitPoints: updated.TemporaryHitPoints,
+				MaxHitPoints:       updated.MaxHitPoints,
+			})
+			audit.Record(ctx, queries, updated.ID, userID, audit.KindHP, audit.HPChange(last, updated)+suffix)
+			last = updated
+			return updated, nil
+		}
+	}
+	return sheetEdit{
+		label:   "HP change",
+		touches: partHPHistory,
+		apply:   set(current, temp, note),
+		revert:  set(char.CurrentHitPoints, char.TemporaryHitPoints, " (undo)"),
+	}
+}
+
+// notesEdit changes the notes and features & traits text. kind is the
+// audit kind and what names the edit in messages.
+func notesEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, kind, features, notes string) sheetEdit {
+	label := "notes edit"
+	if kind == audit.KindFeatures {
+		label = "features edit"
+	}
+	set := func(features, notes, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			updated, err := queries.UpdateCharacterNotes(ctx, db.UpdateCharacterNotesParams{
+				ID:             char.ID,
+				FeaturesTraits: features,
+				Notes:          notes,
+			})
+			if err != nil {
+				return updated, err
+			}
+			audit.Record(ctx, queries, updated.ID, userID, kind, description)
+			return updated, nil
+		}
+	}
+	return sheetEdit{
+		label:  label,
+		apply:  set(features, notes, "Edited "+strings.TrimSuffix(label, " edit")),
+		revert: set(char.FeaturesTraits, char.Notes, "Undid "+label),
+	}
+}
+
+// concentrationEdit changes the spell a character is concentrating on. An
+// empty spell ends concentration. description is recorded in the change log.
+func concentrationEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, spell, description string) sheetEdit {
+	set := func(spell, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			updated, err := queries.UpdateCharacterConcentration(ctx, db.UpdateCharacterConcentrationParams{
+				ID:              char.ID,
+				ConcentratingOn: spell,
+			})
+			if err != nil {
+				return updated, err
+			}
+			audit.Record(ctx, queries, updated.ID, userID, audit.KindConcentration, description)
+			return updated, nil
+		}
+	}
+	return sheetEdit{
+		label:  "concentration change",
+		apply:  set(spell, description),
+		revert: set(char.ConcentratingOn, "Undid concentration change"),
+	}
+}
+
+// exhaustionEdit sets a character's exhaustion level
+func exhaustionEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, level int32) sheetEdit {
+	set := func(from, to int32) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			updated, err := queries.UpdateCharacterExhaustion(ctx, db.UpdateCharacterExhaustionParams{
+				ID:         char.ID,
+				Exhaustion: to,
+			})
+			if err != nil {
+				return updated, err
+			}
+			description := fmt.Sprintf("Exhaustion %d → %d", from, to)
+			if to >= character.MaxExhaustion {
+				description += ", died of exhaustion"
+			}
+			audit.Record(ctx, queries, updated.ID, userID, audit.KindExhaustion, description)
+			return updated, nil
+		}
+	}
+	return sheetEdit{
+		label:  "exhaustion change",
+		apply:  set(char.Exhaustion, level),
+		revert: set(level, char.Exhaustion),
+	}
+}
+
+// inspirationEdit sets whether a character has inspiration and how many
+// luck points it holds. description is recorded in the change log.
+func inspirationEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, inspiration bool, luck int32, description string) sheetEdit {
+	set := func(inspiration bool, luck int32, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			updated, err := queries.UpdateCharacterInspiration(ctx, db.UpdateCharacterInspirationParams{
+				ID:          char.ID,
+				Inspiration: inspiration,
+				LuckPoints:  luck,
+			})
+			if err != nil {
+				return updated, err
+			}
+			audit.Record(ctx, queries, updated.ID, userID, audit.KindInspiration, description)
+			return updated, nil
+		}
+	}
+	label := "inspiration change"
+	if luck != char.LuckPoints {
+		label = "luck change"
+	}
+	return sheetEdit{
+		label:  label,
+		apply:  set(inspiration, luck, description),
+		revert: set(char.Inspiration, char.LuckPoints, "Undid "+label),
+	}
+}
+
+// proficiencyEdit sets a character's saving throw and skill proficiencies.
+// description is recorded in the change log.
+func proficiencyEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, saves, skills, expertise, half []string, description string) sheetEdit {
+	set := func(saves, skills, expertise, half []string, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			updated, err := queries.UpdateCharacterProficiencies(ctx, db.UpdateCharacterProficienciesParams{
+				ID:                       char.ID,
+				SavingThrowProficiencies: saves,
+				SkillProficiencies:       skills,
+				SkillExpertise:           expertise,
+				SkillHalfProficiencies:   half,
+			})
+			if err != nil {
+				return updated, err
+			}
+			audit.Record(ctx, queries, updated.ID, userID, audit.KindProficiency, description)
+			return updated, nil
+		}
+	}
+	return sheetEdit{
+		label:  "proficiency change",
+		apply:  set(saves, skills, expertise, half, description),
+		revert: set(char.SavingThrowProficiencies, char.SkillProficiencies, char.SkillExpertise, char.SkillHalfProficiencies, "Undid proficiency change"),
+	}
+}
+
+// longRestEdit restores hit points and spell slots, removes one level of
+// exhaustion, and grants any inspiration the ruleset gives for resting. sc
+// is nil for characters without spellcasting.
+func longRestEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, sc *db.CharacterSpellcasting, resources []db.CharacterResource) sheetEdit {
+	rested := character.RulesetFor(char.Ruleset).LongRest(character.RestState{
+		CurrentHP:   int(char.CurrentHitPoints),
+		TempHP:      int(char.TemporaryHitPoints),
+		MaxHP:       int(char.MaxHitPoints + char.MaxHitPointsBonus),
+		Exhaustion:  int(char.Exhaustion),
+		Inspiration: char.Inspiration,
+
+		Level:        int(char.Level),
+		Constitution: int(char.Constitution),
+		Race:         char.Race,
+	})
+	exhaustion := int32(rested.Exhaustion)
+	current := int32(rested.CurrentHP)
+
+	var oldSlots []int32
+	if sc != nil {
+		oldSlots = sc.SlotsUsed
+	}
+	// restore puts back the resources spent before the rest, for undo
+	set := func(current, temp, exhaustion int32, inspiration bool, slots []int32, restore bool, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			var updated db.Character
+			err := queries.InTx(ctx, func(q *db.Queries) error {
+				var err error
+				updated, err = q.UpdateCharacterRest(ctx, db.UpdateCharacterRestParams{
+					ID:                 char.ID,
+					CurrentHitPoints:   current,
+					TemporaryHitPoints: temp,
+					Exhaustion:         exhaustion,
+					Inspiration:        inspiration,
+				})
+				if err != nil {
+					return err
+				}
+				if slots != nil {
+					err := q.UpdateSpellSlotsUsed(ctx, db.UpdateSpellSlotsUsedParams{
+						CharacterID: char.ID,
+						SlotsUsed:   slots,
+					})
+					if err != nil {
+						return err
+					}
+				}
+				for _, r := range resources {
+					used := int32(0)
+					if restore {
+						used = r.Used
+					}
+					if err := q.SetResourceUsed(ctx, db.SetResourceUsedParams{CharacterID: char.ID, Name: r.Name, Used: used}); err != nil {
+						return err
+					}
+				}
+				return q.AddHPHistory(ctx, db.AddHPHistoryParams{
+					CharacterID:        updated.ID,
+					CurrentHitPoints:   updated.CurrentHitPoints,
+					TemporaryHitPoints: updated.TemporaryHitPoints,
+					MaxHitPoints:       updated.MaxHitPoints,
+				})
+			})
+			if err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, updated.ID, userID, audit.KindRest, description)
+			return updated, nil
+		}
+	}
+
+	var newSlots []int32
+	if oldSlots != nil {
+		newSlots = make([]int32, len(oldSlots))
+	}
+	description := fmt.Sprintf("Long rest: HP %d → %d", char.CurrentHitPoints, current)
+	if exhaustion != char.Exhaustion {
+		description += fmt.Sprintf(", exhaustion %d → %d", char.Exhaustion, exhaustion)
+	}
+	if newSlots != nil {
+		description += ", spell slots restored"
+	}
+	if spentResources(resources, character.RechargeLongRest) {
+		description += ", class resources restored"
+	}
+	if rested.Inspiration && !char.Inspiration {
+		description += ", gained Heroic Inspiration"
+	}
+	return sheetEdit{
+		label:   "long rest",
+		touches: partHPHistory | partSpells | partResources,
+		apply:   set(current, int32(rested.TempHP), exhaustion, rested.Inspiration, newSlots, false, description),
+		revert:  set(char.CurrentHitPoints, char.TemporaryHitPoints, char.Exhaustion, char.Inspiration, oldSlots, true, "Undid long rest"),
+	}
+}
+
+// shortRestEdit restores the class resources that come back on a short
+// rest, along with a warlock's Pact Magic slots
+func shortRestEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, sc *db.CharacterSpellcasting, resources []db.CharacterResource) sheetEdit {
+	var restored []db.CharacterResource
+	for _, r := range resources {
+		if r.Recharge == character.RechargeShortRest && r.Used > 0 {
+			restored = append(restored, r)
+		}
+	}
+	var oldSlots []int32
+	if sc != nil && sc.SpellcastingClass == "Warlock" {
+		oldSlots = sc.SlotsUsed
+	}
+
+	set := func(rest bool, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			for _, r := range restored {
+				used := r.Used
+				if rest {
+					used = 0
+				}
+				if err := queries.SetResourceUsed(ctx, db.SetResourceUsedParams{CharacterID: char.ID, Name: r.Name, Used: used}); err != nil {
+					return char, err
+				}
+			}
+			if oldSlots != nil {
+				slots := oldSlots
+				if rest {
+					slots = make([]int32, len(oldSlots))
+				}
+				if err := queries.UpdateSpellSlotsUsed(ctx, db.UpdateSpellSlotsUsedParams{CharacterID: char.ID, SlotsUsed: slots}); err != nil {
+					return char, err
+				}
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindRest, description)
+			return queries.GetCharacterByID(ctx, char.ID)
+		}
+	}
+
+	var names []string
+	for _, r := range restored {
+		names = append(names, r.Name)
+	}
+	if oldSlots != nil {
+		names = append(names, "Pact Magic slots")
+	}
+	description := "Short rest"
+	if len(names) > 0 {
+		description += ": restored " + strings.Join(names, ", ")
+	}
+	return sheetEdit{
+		label:   "short rest",
+		touches: partSpells | partResources,
+		apply:   set(true, description),
+		revert:  set(false, "Undid short rest"),
+	}
+}
+
+// spentResources reports whether any resource that a rest restores has
+// been used
+func spentResources(resources []db.CharacterResource, rest string) bool {
+	for _, r := range resources {
+		if r.Used > 0 && (rest == character.RechargeLongRest || r.Recharge == rest) {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceEdit spends or regains uses of a class resource
+func resourceEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, r db.CharacterResource, used int32) sheetEdit {
+	set := func(used int32, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			if err := queries.SetResourceUsed(ctx, db.SetResourceUsedParams{CharacterID: char.ID, Name: r.Name, Used: used}); err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindResource, description)
+			return queries.GetCharacterByID(ctx, char.ID)
+		}
+	}
+
+	verb := "Used"
+	if used < r.Used {
+		verb = "Regained"
+	}
+	left := r.MaxUses - used
+	return sheetEdit{
+		label:   r.Name,
+		touches: partResources,
+		apply:   set(used, fmt.Sprintf("%s %s (%d of %d left)", verb, r.Name, left, r.MaxUses)),
+		revert:  set(r.Used, fmt.Sprintf("Undid %s %s", strings.ToLower(verb), r.Name)),
+	}
+}
+
+// recalculateArmorClass stores the AC worked out from a character's
+// equipped items. It's called after every inventory change.
+func recalculateArmorClass(ctx context.Context, queries *db.Queries, characterID pgtype.UUID) (db.Character, error) {
+	char, err := queries.GetCharacterByID(ctx, characterID)
+	if err != nil {
+		return char, err
+	}
+	return storeArmorClass(ctx, queries, char, char.ArmorClassOverride)
+}
+
+// storeArmorClass calculates and saves a character's AC with an override,
+// which replaces the calculated value when valid
+func storeArmorClass(ctx context.Context, queries *db.Queries, char db.Character, override pgtype.Int4) (db.Character, error) {
+	items, err := queries.GetCharacterInventory(ctx, char.ID)
+	if err != nil {
+		return char, err
+	}
+	char.ArmorClassOverride = override
+	return queries.UpdateCharacterArmorClass(ctx, db.UpdateCharacterArmorClassParams{
+		ID:                 char.ID,
+		ArmorClass:         int32(armorClass(char, items).Total),
+		ArmorClassOverride: override,
+	})
+}
+
+// addItemEdit adds an item to a character's inventory
+func addItemEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, name string, equipped bool) sheetEdit {
+	// Undo deletes the row that was added, and redo adds a new one
+	var added pgtype.UUID
+	return sheetEdit{
+		label:   "new item",
+		touches: partInventory,
+		apply: func() (db.Character, error) {
+			item, err := queries.AddInventoryItem(ctx, db.AddInventoryItemParams{
+				CharacterID: char.ID,
+				Name:        name,
+				Quantity:    1,
+				Equipped:    equipped,
+			})
+			if err != nil {
+				return char, err
+			}
+			added = item.ID
+			audit.Record(ctx, queries, char.ID, userID, audit.KindInventory, "Added "+name)
+			return recalculateArmorClass(ctx, queries, char.ID)
+		},
+		revert: func() (db.Character, error) {
+			if err := queries.DeleteInventoryItem(ctx, added); err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindInventory, "Undid adding "+name)
+			return recalculateArmorClass(ctx, queries, char.ID)
+		},
+	}
+}
+
+// lootEdit adds rolled treasure to a character's inventory. Coins go onto
+// rows the character already has for them, and everything else gets new
+// rows, one per kind of item.
+func lootEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, inventory []db.CharacterInventory, loot character.Treasure) sheetEdit {
+	type lootRow struct {
+		name     string
+		quantity int32
+		existing pgtype.UUID // row the coins are added to, if any
+	}
+	var rows []lootRow
+	for i, amount := range loot.Coins {
+		if amount == 0 {
+			continue
+		}
+		row := lootRow{name: character.CoinNames[i], quantity: int32(amount)}
+		for _, item := range inventory {
+			if strings.EqualFold(item.Name, row.name) {
+				row.existing = item.ID
+				break
+			}
+		}
+		rows = append(rows, row)
+	}
+	counts := make(map[string]int32)
+	for _, name := range loot.Items {
+		if counts[name] == 0 {
+			rows = append(rows, lootRow{name: name})
+		}
+		counts[name]++
+	}
+	for i := range rows {
+		if n, ok := counts[rows[i].name]; ok {
+			rows[i].quantity = n
+		}
+	}
+
+	// Undo takes the coins back off and deletes the rows that were added
+	var added []pgtype.UUID
+	return sheetEdit{
+		label:   "loot",
+		touches: partInventory,
+		apply: func() (db.Character, error) {
+			added = nil
+			for _, row := range rows {
+				if row.existing.Valid {
+					if _, err := queries.AddInventoryQuantity(ctx, db.AddInventoryQuantityParams{ID: row.existing, Quantity: row.quantity}); err != nil {
+						return char, err
+					}
+					continue
+				}
+				item, err := queries.AddInventoryItem(ctx, db.AddInventoryItemParams{
+					CharacterID: char.ID,
+					Name:        row.name,
+					Quantity:    row.quantity,
+				})
+				if err != nil {
+					return char, err
+				}
+				added = append(added, item.ID)
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindInventory, "Added loot: "+loot.String())
+			return queries.GetCharacterByID(ctx, char.ID)
+		},
+		revert: func() (db.Character, error) {
+			for _, row := range rows {
+				if row.existing.Valid {
+					if _, err := queries.AddInventoryQuantity(ctx, db.AddInventoryQuantityParams{ID: row.existing, Quantity: -row.quantity}); err != nil {
+						return char, err
+					}
+				}
+			}
+			for _, id := range added {
+				if err := queries.DeleteInventoryItem(ctx, id); err != nil {
+					return char, err
+				}
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindInventory, "Undid adding loot: "+loot.String())
+			return queries.GetCharacterByID(ctx, char.ID)
+		},
+	}
+}
+
+// removeItemEdit deletes an item from a character's inventory
+func removeItemEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, item db.CharacterInventory) sheetEdit {
+	// Undo adds the item back as a new row, which redo then deletes
+	current := item.ID
+	return sheetEdit{
+		label:   "item removal",
+		touches: partInventory,
+		apply: func() (db.Character, error) {
+			if err := queries.DeleteInventoryItem(ctx, current); err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindInventory, "Removed "+item.Name)
+			return recalculateArmorClass(ctx, queries, char.ID)
+		},
+		revert: func() (db.Character, error) {
+			restored, err := queries.AddInventoryItem(ctx, db.AddInventoryItemParams{
+				CharacterID: char.ID,
+				Name:        item.Name,
+				Quantity:    item.Quantity,
+				Equipped:    item.Equipped,
+				Location:    item.Location,
+			})
+			if err != nil {
+				return char, err
+			}
+			current = restored.ID
+			audit.Record(ctx, queries, char.ID, userID, audit.KindInventory, "Undid removing "+item.Name)
+			return recalculateArmorClass(ctx, queries, char.ID)
+		},
+	}
+}
+
+// moveItemEdit stores an item in a container, or takes it out when
+// location is empty
+func moveItemEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, item db.CharacterInventory, location string) sheetEdit {
+	set := func(location, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			_, err := queries.SetInventoryItemLocation(ctx, db.SetInventoryItemLocationParams{
+				ID:       item.ID,
+				Location: location,
+			})
+			if err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindInventory, description)
+			return queries.GetCharacterByID(ctx, char.ID)
+		}
+	}
+	description := "Moved " + item.Name + " to " + location
+	if location == "" {
+		description = "Took " + item.Name + " out of " + item.Location
+	}
+	return sheetEdit{
+		label:   "item move",
+		touches: partInventory,
+		apply:   set(location, description),
+		revert:  set(item.Location, "Undid moving "+item.Name),
+	}
+}
+
+// equipEdit equips or unequips an item, recalculating AC
+func equipEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, item db.CharacterInventory, equipped bool) sheetEdit {
+	set := func(equipped bool, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			_, err := queries.SetInventoryItemEquipped(ctx, db.SetInventoryItemEquippedParams{
+				ID:       item.ID,
+				Equipped: equipped,
+			})
+			if err != nil {
+				return char, err
+			}
+			updated, err := recalculateArmorClass(ctx, queries, char.ID)
+			if err != nil {
+				return updated, err
+			}
+			logged := description
+			if updated.ArmorClass != char.ArmorClass {
+				logged += fmt.Sprintf(" (AC %d → %d)", char.ArmorClass, updated.ArmorClass)
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindInventory, logged)
+			return updated, nil
+		}
+	}
+	verb, undo := "Equipped ", "Undid equipping "
+	if !equipped {
+		verb, undo = "Unequipped ", "Undid unequipping "
+	}
+	return sheetEdit{
+		label:   "equipment change",
+		touches: partInventory,
+		apply:   set(equipped, verb+item.Name),
+		revert:  set(item.Equipped, undo+item.Name),
+	}
+}
+
+// armorClassOverrideEdit sets or clears a manual AC. An invalid override
+// goes back to the calculated AC.
+func armorClassOverrideEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, override pgtype.Int4) sheetEdit {
+	set := func(override pgtype.Int4, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			updated, err := storeArmorClass(ctx, queries, char, override)
+			if err != nil {
+				return updated, err
+			}
+			audit.Record(ctx, queries, updated.ID, userID, audit.KindArmorClass, description)
+			return updated, nil
+		}
+	}
+	description := "Cleared AC override"
+	if override.Valid {
+		description = fmt.Sprintf("Set AC to %d by hand", override.Int32)
+	}
+	return sheetEdit{
+		label:  "AC override",
+		apply:  set(override, description),
+		revert: set(char.ArmorClassOverride, "Undid AC override"),
+	}
+}
+
+// maxHPBonusEdit sets the increase to a character's hit point maximum from
+// effects such as Aid, along with the current hit points that go with it
+func maxHPBonusEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, bonus, current int32) sheetEdit {
+	set := func(bonus, current int32, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			updated, err := queries.UpdateCharacterMaxHitPointsBonus(ctx, db.UpdateCharacterMaxHitPointsBonusParams{
+				ID:                char.ID,
+				MaxHitPointsBonus: bonus,
+				CurrentHitPoints:  current,
+			})
+			if err != nil {
+				return updated, err
+			}
+			_ = queries.AddHPHistory(ctx, db.AddHPHistoryParams{
+				CharacterID:        updated.ID,
+				CurrentHitPoints:   updated.CurrentHitPoints,
+				TemporaryHitPoints: updated.TemporaryHitPoints,
+				MaxHitPoints:       updated.MaxHitPoints,
+			})
+			audit.Record(ctx, queries, updated.ID, userID, audit.KindHP, description)
+			return updated, nil
+		}
+	}
+	return sheetEdit{
+		label:   "max HP bonus",
+		touches: partHPHistory,
+		apply:   set(bonus, current, fmt.Sprintf("Max HP bonus %d → %d", char.MaxHitPointsBonus, bonus)),
+		revert:  set(char.MaxHitPointsBonus, char.CurrentHitPoints, "Undid max HP bonus"),
+	}
+}
+
+// renameEdit changes the character's name; undoing it restores the old one
+func renameEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, name string) sheetEdit {
+	return sheetEdit{
+		label: "rename",
+		apply: func() (db.Character, error) {
+			return renameCharacter(ctx, queries, userID, char, name)
+		},
+		revert: func() (db.Character, error) {
+			current, err := queries.GetCharacterByID(ctx, char.ID)
+			if err != nil {
+				return current, err
+			}
+			return renameCharacter(ctx, queries, userID, current, char.Name)
+		},
+	}
+}
+
+// xpEdit sets the character's experience points
+func xpEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, xp int32) sheetEdit {
+	set := func(xp int32, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			updated, err := queries.UpdateCharacterExperience(ctx, db.UpdateCharacterExperienceParams{
+				ID:               char.ID,
+				ExperiencePoints: xp,
+			})
+			if err != nil {
+				return updated, err
+			}
+			audit.Record(ctx, queries, updated.ID, userID, audit.KindXP, description)
+			return updated, nil
+		}
+	}
+	description := fmt.Sprintf("Gained %d XP (%d total)", xp-char.ExperiencePoints, xp)
+	if xp < char.ExperiencePoints {
+		description = fmt.Sprintf("Lost %d XP (%d total)", char.ExperiencePoints-xp, xp)
+	}
+	return sheetEdit{
+		label:  "XP",
+		apply:  set(xp, description),
+		revert: set(char.ExperiencePoints, "Undid XP change"),
+	}
+}
+
+// journalEdit saves a journal entry's title and text, adding the entry if
+// it's new
+func journalEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, entry db.CharacterJournal, title, body string) sheetEdit {
+	if !entry.ID.Valid {
+		// Undo deletes the entry that was added, and redo adds a new one
+		var added pgtype.UUID
+		return sheetEdit{
+			label:   "new journal entry",
+			touches: partJournal,
+			apply: func() (db.Character, error) {
+				created, err := queries.AddJournalEntry(ctx, db.AddJournalEntryParams{
+					CharacterID: char.ID,
+					Title:       title,
+					Body:        body,
+				})
+				if err != nil {
+					return char, err
+				}
+				added = created.ID
+				audit.Record(ctx, queries, char.ID, userID, audit.KindNotes, "Started journal entry "+title)
+				return queries.GetCharacterByID(ctx, char.ID)
+			},
+			revert: func() (db.Character, error) {
+				if err := queries.DeleteJournalEntry(ctx, added); err != nil {
+					return char, err
+				}
+				audit.Record(ctx, queries, char.ID, userID, audit.KindNotes, "Undid starting journal entry "+title)
+				return queries.GetCharacterByID(ctx, char.ID)
+			},
+		}
+	}
+
+	set := func(title, body, description string) func() (db.Character, error) {
+		return func() (db.Character, error) {
+			_, err := queries.UpdateJournalEntry(ctx, db.UpdateJournalEntryParams{
+				ID:    entry.ID,
+				Title: title,
+				Body:  body,
+			})
+			if err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindNotes, description)
+			return queries.GetCharacterByID(ctx, char.ID)
+		}
+	}
+	description := "Edited journal entry " + title
+	if title != entry.Title {
+		description = "Renamed journal entry " + entry.Title + " to " + title
+	}
+	return sheetEdit{
+		label:   "journal edit",
+		touches: partJournal,
+		apply:   set(title, body, description),
+		revert:  set(entry.Title, entry.Body, "Undid journal edit to "+entry.Title),
+	}
+}
+
+// deleteJournalEdit deletes a journal entry
+func deleteJournalEdit(ctx context.Context, queries *db.Queries, userID pgtype.UUID, char db.Character, entry db.CharacterJournal) sheetEdit {
+	// Undo adds the entry back as a new row, which redo then deletes
+	current := entry.ID
+	return sheetEdit{
+		label:   "journal entry deletion",
+		touches: partJournal,
+		apply: func() (db.Character, error) {
+			if err := queries.DeleteJournalEntry(ctx, current); err != nil {
+				return char, err
+			}
+			audit.Record(ctx, queries, char.ID, userID, audit.KindNotes, "Deleted journal entry "+entry.Title)
+			return queries.GetCharacterByID(ctx, char.ID)
+		},
+		revert: func() (db.Character, error) {
+			restored, err := queries.AddJournalEntry(ctx, db.AddJournalEntryParams{
+				CharacterID: char.ID,
+				Title:       entry.Title,
+				Body:        entry.Body,
+			})
+			if err != nil {
+				return char, err
+			}
+			current = restored.ID
+			audit.Record(ctx, queries, char.ID, userID, audit.KindNotes, "Undid deleting journal entry "+entry.Title)
+			return queries.GetCharacterByID(ctx, char.ID)
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package screens
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// usageStats is set when the server collects usage statistics from
+// players who opt in
+var usageStats bool
+
+// EnableUsageStats offers players the choice to send anonymous usage
+// statistics
+func EnableUsageStats() {
+	usageStats = true
+}
+
+// Usage names the feature a message shows was used, and whether it failed,
+// for usage statistics. Names are fixed strings, never anything the player
+// typed.
+func Usage(msg tea.Msg) (feature string, failed, ok bool) {
+	switch msg := msg.(type) {
+	case editAppliedMsg:
+		feature = "sheet." + strings.ReplaceAll(msg.Edit.label, " ", "_")
+		switch msg.Action {
+		case editUndo:
+			feature += ".undo"
+		case editRedo:
+			feature += ".redo"
+		}
+		return feature, msg.Err != nil, true
+	case shareLinkMsg:
+		return "sheet.share", false, true
+	case shareErrorMsg:
+		return "sheet.share", true, true
+	case characterCopiedMsg:
+		return "home.copy", false, true
+	case characterRenamedMsg:
+		return "home.rename", false, true
+	case homeErrorMsg:
+		return "home", true, true
+	case identityLinkedMsg:
+		return "links.link", false, true
+	case linkErrorMsg:
+		return "links", true, true
+	}
+	return "", false, false
+}
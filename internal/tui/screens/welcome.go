@@ -2,10 +2,13 @@ package screens
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/brady1408/dnd/internal/auth"
 	"github.com/brady1408/dnd/internal/db"
+	"github.com/brady1408/dnd/internal/tui/components"
 	"github.com/brady1408/dnd/internal/tui/styles"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -21,6 +24,10 @@ const (
 	ModeRegister
 	ModeRegisterSSH
 	ModeLoginSSH
+	ModeLinkCode
+	ModeLinkKey
+	ModeForgotPassword
+	ModeResetPassword
 )
 
 type WelcomeScreen struct {
@@ -29,12 +36,21 @@ type WelcomeScreen struct {
 	publicKey   ssh.PublicKey
 	styles      *styles.Styles
 
+	// keyRegistered is set when the session's SSH key belongs to an account,
+	// and otherwise the menu offers to link it to an email account
+	keyRegistered bool
+
 	mode        WelcomeMode
 	menuIndex   int
 	emailInput  textinput.Model
 	passInput   textinput.Model
+	inviteInput textinput.Model
+	linkInput   textinput.Model
+	resetInput  textinput.Model
 	focusIndex  int
 	err         string
+	notice      string
+	sending     bool // a reset code is being emailed
 	width       int
 	height      int
 }
@@ -43,6 +59,12 @@ type UserLoggedInMsg struct {
 	User *db.User
 }
 
+// resetRequestedMsg reports that a reset code was emailed, if the account
+// exists
+type resetRequestedMsg struct {
+	Err error
+}
+
 func NewWelcomeScreen(ctx context.Context, authService *auth.Service, publicKey ssh.PublicKey, s *styles.Styles) *WelcomeScreen {
 	emailInput := textinput.New()
 	emailInput.Placeholder = "Email"
@@ -56,19 +78,49 @@ func NewWelcomeScreen(ctx context.Context, authService *auth.Service, publicKey
 	passInput.CharLimit = 100
 	passInput.Width = 30
 
+	inviteInput := textinput.New()
+	inviteInput.Placeholder = "ABCD-EFGH"
+	inviteInput.CharLimit = 20
+	inviteInput.Width = 30
+
+	linkInput := textinput.New()
+	linkInput.Placeholder = "ABCD-EFGH"
+	linkInput.CharLimit = 20
+	linkInput.Width = 30
+
+	resetInput := textinput.New()
+	resetInput.Placeholder = "ABCD-EFGH"
+	resetInput.CharLimit = 20
+	resetInput.Width = 30
+
+	keyRegistered := false
+	if publicKey != nil {
+		_, err := authService.LoginWithPublicKey(ctx, publicKey)
+		keyRegistered = err == nil || errors.Is(err, auth.ErrAccountDisabled)
+	}
+
 	return &WelcomeScreen{
-		ctx:         ctx,
-		authService: authService,
-		publicKey:   publicKey,
-		styles:      s,
-		mode:        ModeMenu,
-		emailInput:  emailInput,
-		passInput:   passInput,
-		width:       80,
-		height:      24,
+		ctx:           ctx,
+		authService:   authService,
+		publicKey:     publicKey,
+		keyRegistered: keyRegistered,
+		styles:        s,
+		mode:          ModeMenu,
+		emailInput:    emailInput,
+		passInput:     passInput,
+		inviteInput:   inviteInput,
+		linkInput:     linkInput,
+		resetInput:    resetInput,
+		width:         80,
+		height:        24,
 	}
 }
 
+// SetError shows an error, such as why the player was signed out
+func (w *WelcomeScreen) SetError(err string) {
+	w.err = err
+}
+
 func (w *WelcomeScreen) Init() tea.Cmd {
 	return textinput.Blink
 }
@@ -79,18 +131,38 @@ func (w *WelcomeScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		w.width = msg.Width
 		w.height = msg.Height
 
+	case components.ClickMsg:
+		w.err = ""
+		return w.click(msg.Zone)
+
+	case resetRequestedMsg:
+		w.sending = false
+		if w.mode != ModeForgotPassword {
+			return w, nil
+		}
+		if msg.Err != nil {
+			w.err = msg.Err.Error()
+			return w, nil
+		}
+		w.notice = "If an account uses that email, a reset code is on its way."
+		return w, w.startReset()
+
 	case tea.KeyMsg:
 		w.err = ""
 
 		switch w.mode {
 		case ModeMenu:
 			return w.updateMenu(msg)
-		case ModeLogin, ModeRegister:
+		case ModeLogin, ModeRegister, ModeLinkKey, ModeResetPassword:
 			return w.updateForm(msg)
 		case ModeRegisterSSH:
 			return w.updateSSHRegister(msg)
 		case ModeLoginSSH:
 			return w.updateSSHLogin(msg)
+		case ModeLinkCode:
+			return w.updateLinkCode(msg)
+		case ModeForgotPassword:
+			return w.updateForgotPassword(msg)
 		}
 	}
 
@@ -98,16 +170,35 @@ func (w *WelcomeScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 	var cmd tea.Cmd
 
-	if w.mode == ModeLogin || w.mode == ModeRegister {
+	if w.mode == ModeLogin || w.mode == ModeRegister || w.mode == ModeLinkKey || w.mode == ModeResetPassword {
 		if w.focusIndex == 0 {
-			w.emailInput, cmd = w.emailInput.Update(msg)
+			first := w.firstInput()
+			*first, cmd = first.Update(msg)
 			cmds = append(cmds, cmd)
 		} else if w.focusIndex == 1 {
 			w.passInput, cmd = w.passInput.Update(msg)
 			cmds = append(cmds, cmd)
+		} else if w.focusIndex == 2 && w.needsInvite() {
+			w.inviteInput, cmd = w.inviteInput.Update(msg)
+			cmds = append(cmds, cmd)
 		}
 	}
 
+	if w.mode == ModeRegisterSSH && w.authService.InviteOnly() {
+		w.inviteInput, cmd = w.inviteInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	if w.mode == ModeLinkCode {
+		w.linkInput, cmd = w.linkInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	if w.mode == ModeForgotPassword {
+		w.emailInput, cmd = w.emailInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
 	return w, tea.Batch(cmds...)
 }
 
@@ -137,8 +228,27 @@ func (w *WelcomeScreen) updateMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			w.focusIndex = 0
 			w.emailInput.Focus()
 			return w, textinput.Blink
+		case "Link SSH Key to Email Account":
+			w.mode = ModeLinkKey
+			w.focusIndex = 0
+			w.emailInput.Focus()
+			return w, textinput.Blink
 		case "Register with SSH Key":
 			w.mode = ModeRegisterSSH
+			if w.authService.InviteOnly() {
+				w.inviteInput.SetValue("")
+				w.inviteInput.Focus()
+				return w, textinput.Blink
+			}
+		case "Login with Link Code":
+			w.mode = ModeLinkCode
+			w.linkInput.SetValue("")
+			w.linkInput.Focus()
+			return w, textinput.Blink
+		case "Forgot Password":
+			w.mode = ModeForgotPassword
+			w.emailInput.Focus()
+			return w, textinput.Blink
 		}
 	case "q", "ctrl+c":
 		return w, tea.Quit
@@ -151,7 +261,7 @@ func (w *WelcomeScreen) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "tab", "down":
 		w.focusIndex++
-		if w.focusIndex > 2 {
+		if w.focusIndex > w.buttonIndex() {
 			w.focusIndex = 0
 		}
 		w.updateFocus()
@@ -160,19 +270,19 @@ func (w *WelcomeScreen) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "shift+tab", "up":
 		w.focusIndex--
 		if w.focusIndex < 0 {
-			w.focusIndex = 2
+			w.focusIndex = w.buttonIndex()
 		}
 		w.updateFocus()
 		return w, nil
 
 	case "enter":
-		if w.focusIndex == 2 {
+		if w.focusIndex == w.buttonIndex() {
 			// Submit button
 			return w.submitForm()
 		}
 		// Move to next field
 		w.focusIndex++
-		if w.focusIndex > 2 {
+		if w.focusIndex > w.buttonIndex() {
 			w.focusIndex = 0
 		}
 		w.updateFocus()
@@ -180,26 +290,65 @@ func (w *WelcomeScreen) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "esc":
 		w.mode = ModeMenu
+		w.notice = ""
 		w.emailInput.SetValue("")
 		w.passInput.SetValue("")
+		w.inviteInput.SetValue("")
+		w.resetInput.SetValue("")
 		return w, nil
 	}
 
 	// Pass key to focused input
 	var cmd tea.Cmd
 	if w.focusIndex == 0 {
-		w.emailInput, cmd = w.emailInput.Update(msg)
+		first := w.firstInput()
+		*first, cmd = first.Update(msg)
 	} else if w.focusIndex == 1 {
 		w.passInput, cmd = w.passInput.Update(msg)
+	} else if w.focusIndex == 2 && w.needsInvite() {
+		w.inviteInput, cmd = w.inviteInput.Update(msg)
 	}
 	return w, cmd
 }
 
+// firstInput is the form's first field: the reset code when resetting a
+// password, and otherwise the email
+func (w *WelcomeScreen) firstInput() *textinput.Model {
+	if w.mode == ModeResetPassword {
+		return &w.resetInput
+	}
+	return &w.emailInput
+}
+
+// needsInvite reports whether the current form asks for an invite code
+func (w *WelcomeScreen) needsInvite() bool {
+	return w.mode == ModeRegister && w.authService.InviteOnly()
+}
+
+// buttonIndex is the focus index of the submit button
+func (w *WelcomeScreen) buttonIndex() int {
+	if w.needsInvite() {
+		return 3
+	}
+	return 2
+}
+
 func (w *WelcomeScreen) updateSSHRegister(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The invite code field takes all other keys, so only enter and esc apply
+	if w.authService.InviteOnly() {
+		switch msg.String() {
+		case "enter", "esc":
+		default:
+			var cmd tea.Cmd
+			w.inviteInput, cmd = w.inviteInput.Update(msg)
+			return w, cmd
+		}
+	}
+
 	switch msg.String() {
 	case "enter", "y":
 		if w.publicKey != nil {
-			user, err := w.authService.RegisterWithPublicKey(w.ctx, w.publicKey)
+			user, err := w.authService.RegisterWithPublicKey(w.ctx, w.publicKey, w.inviteInput.Value())
 			if err != nil {
 				w.err = err.Error()
 				return w, nil
@@ -211,6 +360,7 @@ func (w *WelcomeScreen) updateSSHRegister(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "esc", "n":
 		w.mode = ModeMenu
+		w.inviteInput.Blur()
 		return w, nil
 	}
 
@@ -222,8 +372,12 @@ func (w *WelcomeScreen) updateSSHLogin(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter", "y":
 		if w.publicKey != nil {
 			user, err := w.authService.LoginWithPublicKey(w.ctx, w.publicKey)
+			if errors.Is(err, auth.ErrAccountDisabled) {
+				w.err = err.Error()
+				return w, nil
+			}
 			if err != nil {
-				w.err = "SSH key not registered. Please register first."
+				w.err = "SSH key not registered. Register, or link it to your email account."
 				return w, nil
 			}
 			return w, func() tea.Msg { return UserLoggedInMsg{User: user} }
@@ -239,11 +393,84 @@ func (w *WelcomeScreen) updateSSHLogin(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return w, nil
 }
 
+func (w *WelcomeScreen) updateLinkCode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		user, err := w.authService.LoginWithLinkCode(w.ctx, w.linkInput.Value(), w.publicKey)
+		if err != nil {
+			w.err = err.Error()
+			return w, nil
+		}
+		w.linkInput.SetValue("")
+		return w, func() tea.Msg { return UserLoggedInMsg{User: user} }
+
+	case "esc":
+		w.mode = ModeMenu
+		w.linkInput.Blur()
+		return w, nil
+	}
+
+	var cmd tea.Cmd
+	w.linkInput, cmd = w.linkInput.Update(msg)
+	return w, cmd
+}
+
+// updateForgotPassword asks for the account's email and sends it a reset
+// code. Tab skips ahead for players who already have one.
+func (w *WelcomeScreen) updateForgotPassword(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		if w.sending {
+			return w, nil
+		}
+		email := strings.TrimSpace(w.emailInput.Value())
+		if email == "" {
+			w.err = "Email is required"
+			return w, nil
+		}
+		w.sending = true
+		return w, func() tea.Msg {
+			return resetRequestedMsg{Err: w.authService.RequestPasswordReset(w.ctx, email)}
+		}
+
+	case "tab":
+		w.notice = ""
+		return w, w.startReset()
+
+	case "esc":
+		w.mode = ModeMenu
+		w.emailInput.SetValue("")
+		w.emailInput.Blur()
+		return w, nil
+	}
+
+	var cmd tea.Cmd
+	w.emailInput, cmd = w.emailInput.Update(msg)
+	return w, cmd
+}
+
+// startReset shows the form for entering a reset code and new password
+func (w *WelcomeScreen) startReset() tea.Cmd {
+	w.mode = ModeResetPassword
+	w.emailInput.SetValue("")
+	w.passInput.SetValue("")
+	w.resetInput.SetValue("")
+	w.focusIndex = 0
+	w.updateFocus()
+	return textinput.Blink
+}
+
 func (w *WelcomeScreen) submitForm() (tea.Model, tea.Cmd) {
 	email := strings.TrimSpace(w.emailInput.Value())
 	pass := w.passInput.Value()
 
-	if email == "" {
+	if w.mode == ModeResetPassword {
+		email = w.resetInput.Value()
+		if strings.TrimSpace(email) == "" {
+			w.err = "Reset code is required"
+			return w, nil
+		}
+	} else if email == "" {
 		w.err = "Email is required"
 		return w, nil
 	}
@@ -259,10 +486,15 @@ func (w *WelcomeScreen) submitForm() (tea.Model, tea.Cmd) {
 	var user *db.User
 	var err error
 
-	if w.mode == ModeLogin {
+	switch w.mode {
+	case ModeLogin:
 		user, err = w.authService.LoginWithPassword(w.ctx, email, pass)
-	} else {
-		user, err = w.authService.RegisterWithPassword(w.ctx, email, pass)
+	case ModeLinkKey:
+		user, err = w.authService.LinkPublicKeyWithPassword(w.ctx, email, pass, w.publicKey)
+	case ModeResetPassword:
+		user, err = w.authService.ResetPassword(w.ctx, email, pass)
+	default:
+		user, err = w.authService.RegisterWithPassword(w.ctx, email, pass, w.inviteInput.Value())
 	}
 
 	if err != nil {
@@ -271,8 +503,11 @@ func (w *WelcomeScreen) submitForm() (tea.Model, tea.Cmd) {
 	}
 
 	// Clear inputs
+	w.notice = ""
 	w.emailInput.SetValue("")
 	w.passInput.SetValue("")
+	w.inviteInput.SetValue("")
+	w.resetInput.SetValue("")
 
 	return w, func() tea.Msg { return UserLoggedInMsg{User: user} }
 }
@@ -280,12 +515,18 @@ func (w *WelcomeScreen) submitForm() (tea.Model, tea.Cmd) {
 func (w *WelcomeScreen) updateFocus() {
 	w.emailInput.Blur()
 	w.passInput.Blur()
+	w.inviteInput.Blur()
+	w.resetInput.Blur()
 
 	switch w.focusIndex {
 	case 0:
-		w.emailInput.Focus()
+		w.firstInput().Focus()
 	case 1:
 		w.passInput.Focus()
+	case 2:
+		if w.needsInvite() {
+			w.inviteInput.Focus()
+		}
 	}
 }
 
@@ -294,6 +535,17 @@ func (w *WelcomeScreen) getMenuItems() []string {
 	if w.publicKey != nil {
 		// Insert SSH login option at the beginning since it's the easiest
 		items = []string{"Login with SSH Key", "Login with Email", "Register with Email", "Register with SSH Key"}
+		if !w.keyRegistered {
+			// Players who registered by email can use this key instead of
+			// making a second account with it
+			items = append(items, "Link SSH Key to Email Account")
+		}
+	}
+	if w.authService.IdentityLinking() {
+		items = append(items, "Login with Link Code")
+	}
+	if w.authService.PasswordResets() {
+		items = append(items, "Forgot Password")
 	}
 	return items
 }
@@ -316,6 +568,14 @@ func (w *WelcomeScreen) View() string {
 		b.WriteString(w.renderSSHRegister())
 	case ModeLoginSSH:
 		b.WriteString(w.renderSSHLogin())
+	case ModeLinkCode:
+		b.WriteString(w.renderLinkCode())
+	case ModeLinkKey:
+		b.WriteString(w.renderForm("Link Key"))
+	case ModeForgotPassword:
+		b.WriteString(w.renderForgotPassword())
+	case ModeResetPassword:
+		b.WriteString(w.renderForm("Reset Password"))
 	}
 
 	// Error message
@@ -329,6 +589,8 @@ func (w *WelcomeScreen) View() string {
 	switch w.mode {
 	case ModeMenu:
 		b.WriteString(w.styles.Help.Render("↑/↓: navigate • enter: select • q: quit"))
+	case ModeForgotPassword:
+		b.WriteString(w.styles.Help.Render("enter: email me a code • tab: I have a code • esc: back"))
 	default:
 		b.WriteString(w.styles.Help.Render("tab: next field • enter: submit • esc: back"))
 	}
@@ -352,8 +614,7 @@ func (w *WelcomeScreen) renderMenu() string {
 			cursor = "> "
 			style = w.styles.Selected
 		}
-		b.WriteString(w.styles.Cursor.Render(cursor))
-		b.WriteString(style.Render(item))
+		b.WriteString(components.Mark(components.Item("menu", i), w.styles.Cursor.Render(cursor)+style.Render(item)))
 		b.WriteString("\n")
 	}
 
@@ -370,14 +631,28 @@ func (w *WelcomeScreen) renderForm(title string) string {
 
 	b.WriteString(w.styles.Title.Render(title))
 	b.WriteString("\n\n")
+	if w.mode == ModeLinkKey {
+		b.WriteString(w.styles.Muted.Render("Log in to your email account to use this SSH key with it from now on."))
+		b.WriteString("\n\n")
+	}
+	if w.notice != "" {
+		b.WriteString(w.styles.SuccessText.Render(w.notice))
+		b.WriteString("\n\n")
+	}
 
-	// Email field
+	// Email field, or the reset code
 	emailStyle := w.styles.InputField
 	if w.focusIndex == 0 {
 		emailStyle = w.styles.FocusedInput
 	}
-	b.WriteString("Email:\n")
-	b.WriteString(emailStyle.Render(w.emailInput.View()))
+	passLabel := "Password:\n"
+	if w.mode == ModeResetPassword {
+		b.WriteString("Reset Code:\n")
+		passLabel = "New Password:\n"
+	} else {
+		b.WriteString("Email:\n")
+	}
+	b.WriteString(components.Mark(components.Item("field", 0), emailStyle.Render(w.firstInput().View())))
 	b.WriteString("\n\n")
 
 	// Password field
@@ -385,16 +660,27 @@ func (w *WelcomeScreen) renderForm(title string) string {
 	if w.focusIndex == 1 {
 		passStyle = w.styles.FocusedInput
 	}
-	b.WriteString("Password:\n")
-	b.WriteString(passStyle.Render(w.passInput.View()))
+	b.WriteString(passLabel)
+	b.WriteString(components.Mark(components.Item("field", 1), passStyle.Render(w.passInput.View())))
 	b.WriteString("\n\n")
 
+	// Invite code field
+	if w.needsInvite() {
+		inviteStyle := w.styles.InputField
+		if w.focusIndex == 2 {
+			inviteStyle = w.styles.FocusedInput
+		}
+		b.WriteString("Invite Code:\n")
+		b.WriteString(components.Mark(components.Item("field", 2), inviteStyle.Render(w.inviteInput.View())))
+		b.WriteString("\n\n")
+	}
+
 	// Submit button
 	btnStyle := w.styles.Button
-	if w.focusIndex == 2 {
+	if w.focusIndex == w.buttonIndex() {
 		btnStyle = w.styles.FocusedButton
 	}
-	b.WriteString(btnStyle.Render("[ " + title + " ]"))
+	b.WriteString(components.Mark(components.Item("field", w.buttonIndex()), btnStyle.Render("[ "+title+" ]")))
 
 	return b.String()
 }
@@ -414,7 +700,14 @@ func (w *WelcomeScreen) renderSSHRegister() string {
 		b.WriteString("Your SSH key:\n")
 		b.WriteString(w.styles.Box.Render(keyStr))
 		b.WriteString("\n\n")
-		b.WriteString("Register with this key? (y/n)")
+		if w.authService.InviteOnly() {
+			b.WriteString("Invite Code:\n")
+			b.WriteString(w.styles.FocusedInput.Render(w.inviteInput.View()))
+			b.WriteString("\n\n")
+			b.WriteString("Press enter to register with this key.")
+		} else {
+			b.WriteString("Register with this key? (y/n)")
+		}
 	} else {
 		b.WriteString(w.styles.ErrorText.Render("No SSH key detected."))
 		b.WriteString("\n")
@@ -448,3 +741,40 @@ func (w *WelcomeScreen) renderSSHLogin() string {
 
 	return b.String()
 }
+
+func (w *WelcomeScreen) renderForgotPassword() string {
+	var b strings.Builder
+
+	b.WriteString(w.styles.Title.Render("Forgot Password"))
+	b.WriteString("\n\n")
+	b.WriteString("We'll email a one-time code to reset your password.\n")
+	b.WriteString(w.styles.Muted.Render(fmt.Sprintf("Codes expire after %d minutes.", int(auth.PasswordResetTTL.Minutes()))))
+	b.WriteString("\n\n")
+	b.WriteString("Email:\n")
+	b.WriteString(w.styles.FocusedInput.Render(w.emailInput.View()))
+	if w.sending {
+		b.WriteString("\n\n")
+		b.WriteString(w.styles.Muted.Render("Sending..."))
+	}
+
+	return b.String()
+}
+
+func (w *WelcomeScreen) renderLinkCode() string {
+	var b strings.Builder
+
+	b.WriteString(w.styles.Title.Render("Login with Link Code"))
+	b.WriteString("\n\n")
+	b.WriteString("Sign in with Discord or Google to get a code:\n")
+	b.WriteString(w.styles.HighlightBox.Render(w.authService.SignInURL()))
+	b.WriteString("\n")
+	if w.publicKey != nil {
+		b.WriteString(w.styles.Muted.Render("New accounts are registered with your SSH key."))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString("Link Code:\n")
+	b.WriteString(w.styles.FocusedInput.Render(w.linkInput.View()))
+
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package styles
+
+import "github.com/charmbracelet/lipgloss"
+
+// Accent is a color a player picks for one of their characters, so it
+// stands out in lists
+type Accent struct {
+	Name  string         // stored per character
+	Label string         // shown when picking
+	Dark  lipgloss.Color // for dark themes
+	Light lipgloss.Color // for light themes
+}
+
+// Accents are the character colors, in the order they're offered. Each has
+// a lighter shade for dark themes and a darker one for light themes.
+var Accents = []Accent{
+	{Name: "red", Label: "Red", Dark: "#F87171", Light: "#B91C1C"},
+	{Name: "orange", Label: "Orange", Dark: "#FB923C", Light: "#C2410C"},
+	{Name: "gold", Label: "Gold", Dark: "#FACC15", Light: "#A16207"},
+	{Name: "green", Label: "Green", Dark: "#4ADE80", Light: "#15803D"},
+	{Name: "teal", Label: "Teal", Dark: "#2DD4BF", Light: "#0F766E"},
+	{Name: "blue", Label: "Blue", Dark: "#60A5FA", Light: "#1D4ED8"},
+	{Name: "purple", Label: "Purple", Dark: "#C084FC", Light: "#7E22CE"},
+	{Name: "pink", Label: "Pink", Dark: "#F472B6", Light: "#BE185D"},
+}
+
+// CharacterIcons are the glyphs a player can mark a character with. They're
+// all one cell wide, so lists stay lined up.
+var CharacterIcons = []string{"⚔", "✦", "★", "☽", "☀", "❄", "♠", "♣", "♥", "♦", "✚", "☠", "♪", "⚒", "⚘", "❖"}
+
+// FindAccent returns the accent with this name, or false for none
+func FindAccent(name string) (Accent, bool) {
+	for _, a := range Accents {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Accent{}, false
+}
+
+// AccentColor returns the shade of an accent for the current theme
+func (s *Styles) AccentColor(a Accent) lipgloss.Color {
+	if s.theme.Light {
+		return a.Light
+	}
+	return a.Dark
+}
+
+// CharacterMark returns a character's icon in its accent color, followed by
+// a space, or "" if it has no icon. A character with a color but no icon
+// gets a colored dot.
+func (s *Styles) CharacterMark(accent, icon string) string {
+	a, ok := FindAccent(accent)
+	if icon == "" {
+		if !ok {
+			return ""
+		}
+		icon = "●"
+	}
+	style := s.renderer.NewStyle()
+	if ok {
+		style = style.Foreground(s.AccentColor(a))
+	}
+	return style.Render(icon) + " "
+}
@@ -0,0 +1,57 @@
+package styles
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	glamourstyles "github.com/charmbracelet/glamour/styles"
+	"github.com/muesli/termenv"
+)
+
+// Markdown renders text as markdown wrapped to width, in colors suited to
+// the session's terminal. Line breaks are kept as typed, so plain notes look
+// the same as before. If rendering fails the text is returned unchanged.
+func (s *Styles) Markdown(text string, width int) string {
+	tr, ok := s.markdown[width]
+	if !ok {
+		var err error
+		tr, err = glamour.NewTermRenderer(
+			glamour.WithStyles(s.markdownStyle()),
+			glamour.WithColorProfile(s.renderer.ColorProfile()),
+			glamour.WithWordWrap(width),
+			glamour.WithPreservedNewLines(),
+		)
+		if err != nil {
+			return text
+		}
+		if s.markdown == nil {
+			s.markdown = map[int]*glamour.TermRenderer{}
+		}
+		s.markdown[width] = tr
+	}
+
+	out, err := tr.Render(text)
+	if err != nil {
+		return text
+	}
+	return strings.Trim(out, "\n")
+}
+
+// markdownStyle picks glamour's dark, light, or plain style, to suit the
+// theme and terminal, without the document margin, since the sheet lays out
+// its own
+func (s *Styles) markdownStyle() ansi.StyleConfig {
+	style := glamourstyles.DarkStyleConfig
+	switch {
+	case s.renderer.ColorProfile() == termenv.Ascii:
+		style = glamourstyles.ASCIIStyleConfig
+	case s.theme.Light || !s.renderer.HasDarkBackground():
+		style = glamourstyles.LightStyleConfig
+	}
+	var margin uint
+	style.Document.Margin = &margin
+	style.Document.BlockPrefix = ""
+	style.Document.BlockSuffix = ""
+	return style
+}
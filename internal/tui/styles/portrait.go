@@ -0,0 +1,44 @@
+package styles
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brady1408/dnd/internal/portrait"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// asciiShades run from dark to light, for terminals without color
+const asciiShades = " .:-=+*#%@"
+
+// Portrait draws a portrait two pixels to a cell, the upper one as an
+// upper half block and the lower one as its background. Terminals without
+// color get shading characters instead.
+func (s *Styles) Portrait(p portrait.Portrait) string {
+	if !p.Valid() {
+		return ""
+	}
+	color := func(x, y int) lipgloss.Color {
+		r, g, b := p.RGB(x, y)
+		return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", r, g, b))
+	}
+
+	var b strings.Builder
+	for y := 0; y+1 < p.Height; y += 2 {
+		for x := range p.Width {
+			if s.renderer.ColorProfile() == termenv.Ascii {
+				r1, g1, b1 := p.RGB(x, y)
+				r2, g2, b2 := p.RGB(x, y+1)
+				light := (int(r1) + int(g1) + int(b1) + int(r2) + int(g2) + int(b2)) / 6
+				b.WriteByte(asciiShades[light*len(asciiShades)/256])
+				continue
+			}
+			b.WriteString(s.renderer.NewStyle().Foreground(color(x, y)).Background(color(x, y+1)).Render("▀"))
+		}
+		if y+2 < p.Height {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -1,18 +1,9 @@
 package styles
 
-import "github.com/charmbracelet/lipgloss"
-
-// Colors
-var (
-	PrimaryColor    = lipgloss.Color("#7C3AED") // Purple
-	SecondaryColor  = lipgloss.Color("#EC4899") // Pink
-	SuccessColor    = lipgloss.Color("#10B981") // Green
-	WarningColor    = lipgloss.Color("#F59E0B") // Amber
-	ErrorColor      = lipgloss.Color("#EF4444") // Red
-	MutedColor      = lipgloss.Color("#6B7280") // Gray
-	BackgroundColor = lipgloss.Color("#1F2937") // Dark gray
-	ForegroundColor = lipgloss.Color("#F9FAFB") // Light gray
-	HighlightColor  = lipgloss.Color("#A78BFA") // Light purple
+import (
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 // Styles holds all lipgloss styles for the application, bound to a specific renderer
@@ -45,127 +36,179 @@ type Styles struct {
 	Proficient    lipgloss.Style
 	NotProficient lipgloss.Style
 	Logo          lipgloss.Style
+	QRCode        lipgloss.Style
+
+	renderer *lipgloss.Renderer
+	theme    Theme
+	markdown map[int]*glamour.TermRenderer // by wrap width
 }
 
-// NewStyles creates a new Styles instance bound to the given renderer
+// NewStyles creates a new Styles instance bound to the given renderer, in
+// the default theme
 func NewStyles(r *lipgloss.Renderer) *Styles {
-	return &Styles{
-		Muted: r.NewStyle().Foreground(MutedColor),
+	s := &Styles{renderer: r}
+	s.Apply(FindTheme(DefaultTheme))
+	return s
+}
+
+// Theme returns the color scheme in use
+func (s *Styles) Theme() Theme {
+	return s.theme
+}
+
+// ColorSupport describes the colors the session's terminal can show
+func (s *Styles) ColorSupport() string {
+	switch s.renderer.ColorProfile() {
+	case termenv.TrueColor:
+		return "full color"
+	case termenv.ANSI256:
+		return "256 colors"
+	case termenv.ANSI:
+		return "16 colors"
+	}
+	return "no color"
+}
+
+// Apply switches every style to a theme's colors, as the session's
+// terminal can show them. Screens share one Styles, so they all change at
+// once.
+func (s *Styles) Apply(theme Theme) {
+	r := s.renderer
+	t := theme.forProfile(r.ColorProfile())
+	*s = Styles{
+		renderer: r,
+		theme:    theme,
+
+		Muted: r.NewStyle().Foreground(t.Muted),
 
-		Base: r.NewStyle().Foreground(ForegroundColor),
+		Base: r.NewStyle().Foreground(t.Foreground),
 
 		Title: r.NewStyle().
 			Bold(true).
-			Foreground(PrimaryColor).
+			Foreground(t.Primary).
 			MarginBottom(1),
 
 		Subtitle: r.NewStyle().
-			Foreground(MutedColor).
+			Foreground(t.Muted).
 			Italic(true),
 
 		Header: r.NewStyle().
 			Bold(true).
-			Foreground(SecondaryColor).
+			Foreground(t.Secondary).
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderBottom(true).
-			BorderForeground(MutedColor).
+			BorderForeground(t.Muted).
 			MarginBottom(1).
 			PaddingBottom(0),
 
 		Box: r.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(MutedColor).
+			BorderForeground(t.Muted).
 			Padding(1, 2),
 
 		HighlightBox: r.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(PrimaryColor).
+			BorderForeground(t.Primary).
 			Padding(1, 2),
 
 		Selected: r.NewStyle().
 			Bold(true).
-			Foreground(HighlightColor).
-			Background(lipgloss.Color("#374151")),
+			Foreground(t.Highlight).
+			Background(t.Selection),
 
-		Unselected: r.NewStyle().Foreground(ForegroundColor),
+		Unselected: r.NewStyle().Foreground(t.Foreground),
 
 		Cursor: r.NewStyle().
-			Foreground(PrimaryColor).
+			Foreground(t.Primary).
 			Bold(true),
 
 		InputField: r.NewStyle().
 			Border(lipgloss.NormalBorder()).
-			BorderForeground(MutedColor).
+			BorderForeground(t.Muted).
 			Padding(0, 1),
 
 		FocusedInput: r.NewStyle().
 			Border(lipgloss.NormalBorder()).
-			BorderForeground(PrimaryColor).
+			BorderForeground(t.Primary).
 			Padding(0, 1),
 
 		Button: r.NewStyle().
-			Foreground(ForegroundColor).
-			Background(MutedColor).
+			Foreground(t.Foreground).
+			Background(t.Muted).
 			Padding(0, 2).
 			MarginRight(1),
 
 		FocusedButton: r.NewStyle().
-			Foreground(ForegroundColor).
-			Background(PrimaryColor).
+			Foreground(t.Foreground).
+			Background(t.Primary).
 			Padding(0, 2).
 			Bold(true).
 			MarginRight(1),
 
 		Help: r.NewStyle().
-			Foreground(MutedColor).
+			Foreground(t.Muted).
 			MarginTop(1),
 
 		ErrorText: r.NewStyle().
-			Foreground(ErrorColor).
+			Foreground(t.Error).
 			Bold(true),
 
 		SuccessText: r.NewStyle().
-			Foreground(SuccessColor).
+			Foreground(t.Success).
 			Bold(true),
 
-		WarningText: r.NewStyle().Foreground(WarningColor),
+		WarningText: r.NewStyle().Foreground(t.Warning),
 
 		StatValue: r.NewStyle().
 			Bold(true).
-			Foreground(PrimaryColor).
+			Foreground(t.Primary).
 			Width(3).
 			Align(lipgloss.Center),
 
 		StatMod: r.NewStyle().
-			Foreground(SecondaryColor).
+			Foreground(t.Secondary).
 			Width(4).
 			Align(lipgloss.Center),
 
 		StatLabel: r.NewStyle().
-			Foreground(MutedColor).
+			Foreground(t.Muted).
 			Width(12),
 
 		HPCurrent: r.NewStyle().
 			Bold(true).
-			Foreground(SuccessColor),
+			Foreground(t.Success),
 
-		HPMax: r.NewStyle().Foreground(MutedColor),
+		HPMax: r.NewStyle().Foreground(t.Muted),
 
 		HPLow: r.NewStyle().
 			Bold(true).
-			Foreground(WarningColor),
+			Foreground(t.Warning),
 
 		HPCritical: r.NewStyle().
 			Bold(true).
-			Foreground(ErrorColor),
+			Foreground(t.Error),
 
-		Proficient: r.NewStyle().Foreground(SuccessColor),
+		Proficient: r.NewStyle().Foreground(t.Success),
 
-		NotProficient: r.NewStyle().Foreground(MutedColor),
+		NotProficient: r.NewStyle().Foreground(t.Muted),
 
 		Logo: r.NewStyle().
-			Foreground(PrimaryColor).
+			Foreground(t.Primary).
 			Bold(true),
+
+		// Light modules are drawn as blocks, so the code needs a fixed
+		// black and white palette whatever the terminal's colors
+		QRCode: r.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("#000000")),
+	}
+
+	// Terminals without color drop bold and reverse video too, so the
+	// focused button or tab is bracketed instead
+	if r.ColorProfile() == termenv.Ascii {
+		s.FocusedButton = s.FocusedButton.
+			Padding(0, 1).
+			Transform(func(text string) string { return "[" + text + "]" })
 	}
 }
 
@@ -0,0 +1,155 @@
+package styles
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Theme is a color scheme for every screen
+type Theme struct {
+	Name  string // stored per user
+	Label string // shown in settings
+	Light bool   // made for light terminal backgrounds
+
+	Primary    lipgloss.Color
+	Secondary  lipgloss.Color
+	Success    lipgloss.Color
+	Warning    lipgloss.Color
+	Error      lipgloss.Color
+	Muted      lipgloss.Color
+	Foreground lipgloss.Color
+	Highlight  lipgloss.Color
+	Selection  lipgloss.Color // background of selected rows
+}
+
+// DefaultTheme is used before login and for players who haven't chosen one
+const DefaultTheme = "dark"
+
+// themes are the available color schemes, in the order settings lists them
+var themes = []Theme{
+	{
+		Name:       "dark",
+		Label:      "Dark",
+		Primary:    "#7C3AED", // Purple
+		Secondary:  "#EC4899", // Pink
+		Success:    "#10B981", // Green
+		Warning:    "#F59E0B", // Amber
+		Error:      "#EF4444", // Red
+		Muted:      "#6B7280", // Gray
+		Foreground: "#F9FAFB", // Light gray
+		Highlight:  "#A78BFA", // Light purple
+		Selection:  "#374151", // Dark gray
+	},
+	{
+		Name:       "light",
+		Label:      "Light",
+		Light:      true,
+		Primary:    "#6D28D9",
+		Secondary:  "#BE185D",
+		Success:    "#047857",
+		Warning:    "#B45309",
+		Error:      "#B91C1C",
+		Muted:      "#6B7280",
+		Foreground: "#111827",
+		Highlight:  "#5B21B6",
+		Selection:  "#E5E7EB",
+	},
+	{
+		// Pure, saturated colors that stay readable on any dark background
+		Name:       "high-contrast",
+		Label:      "High contrast",
+		Primary:    "#00FFFF",
+		Secondary:  "#FFFF00",
+		Success:    "#00FF00",
+		Warning:    "#FFFF00",
+		Error:      "#FF5555",
+		Muted:      "#D0D0D0",
+		Foreground: "#FFFFFF",
+		Highlight:  "#FFFFFF",
+		Selection:  "#0000AA",
+	},
+	{
+		// The Okabe-Ito palette: success and error differ in brightness and
+		// hue for red-green color blindness
+		Name:       "colorblind",
+		Label:      "Colorblind-safe",
+		Primary:    "#0072B2", // Blue
+		Secondary:  "#CC79A7", // Reddish purple
+		Success:    "#56B4E9", // Sky blue
+		Warning:    "#E69F00", // Orange
+		Error:      "#D55E00", // Vermillion
+		Muted:      "#999999",
+		Foreground: "#F9FAFB",
+		Highlight:  "#F0E442", // Yellow
+		Selection:  "#374151",
+	},
+	{
+		Name:       "red-dragon",
+		Label:      "Red dragon",
+		Primary:    "#DC2626", // Crimson
+		Secondary:  "#F59E0B", // Gold
+		Success:    "#84CC16", // Lime
+		Warning:    "#FBBF24", // Amber
+		Error:      "#F87171", // Pale red
+		Muted:      "#78716C", // Stone
+		Foreground: "#FAFAF9",
+		Highlight:  "#FCD34D", // Pale gold
+		Selection:  "#44403C", // Dark stone
+	},
+}
+
+// ansiThemes give each theme in the 16 standard terminal colors, which
+// terminals without 256-color support draw from their own palettes. Left to
+// pick the nearest, lipgloss turns most of the dark theme's grays to black.
+var ansiThemes = map[string]Theme{
+	"dark": {
+		Primary: "5", Secondary: "13", Success: "2", Warning: "3", Error: "1",
+		Muted: "8", Foreground: "15", Highlight: "13", Selection: "8",
+	},
+	"light": {
+		Primary: "5", Secondary: "4", Success: "2", Warning: "3", Error: "1",
+		Muted: "8", Foreground: "0", Highlight: "4", Selection: "7",
+	},
+	"high-contrast": {
+		Primary: "14", Secondary: "11", Success: "10", Warning: "11", Error: "9",
+		Muted: "7", Foreground: "15", Highlight: "15", Selection: "4",
+	},
+	"colorblind": {
+		Primary: "4", Secondary: "5", Success: "12", Warning: "3", Error: "1",
+		Muted: "8", Foreground: "15", Highlight: "11", Selection: "8",
+	},
+	"red-dragon": {
+		Primary: "1", Secondary: "3", Success: "10", Warning: "11", Error: "9",
+		Muted: "8", Foreground: "15", Highlight: "11", Selection: "8",
+	},
+}
+
+// forProfile returns the theme's colors as a terminal with the given
+// color support should draw them
+func (t Theme) forProfile(p termenv.Profile) Theme {
+	if p != termenv.ANSI {
+		return t
+	}
+	a, ok := ansiThemes[t.Name]
+	if !ok {
+		return t
+	}
+	a.Name, a.Label, a.Light = t.Name, t.Label, t.Light
+	return a
+}
+
+// Themes returns the available color schemes
+func Themes() []Theme {
+	return themes
+}
+
+// FindTheme looks up a theme by name, falling back to the default for
+// names it doesn't know, e.g. one that has since been removed
+func FindTheme(name string) Theme {
+	for _, t := range themes {
+		if t.Name == name {
+			return t
+		}
+	}
+	return themes[0]
+}